@@ -101,8 +101,11 @@ func (cfg *AKOCleanupConfig) Cleanup(ctx context.Context) error {
 		cleanupVSDatascripts,
 		cleanupHTTPPolicySets,
 		cleanupL4PolicySets,
+		cleanupNetworkSecurityPolicies,
 		cleanupPoolGroups,
 		cleanupPools,
+		cleanupHealthMonitors,
+		cleanupPersistenceProfiles,
 		func() error { return avirest.DeleteServiceEngines() },
 		avirest.DeleteServiceEngineGroup,
 	}
@@ -320,6 +323,19 @@ func cleanupL4PolicySets() error {
 	return deleteAviResource("/api/l4policyset", l4sets)
 }
 
+func cleanupNetworkSecurityPolicies() error {
+	aviObjCache := avicache.SharedAviObjCache()
+	nsps := make(map[string][]string)
+	for _, key := range aviObjCache.NSPCache.AviGetAllKeys() {
+		if _, ok := nsps[key.Namespace]; !ok {
+			nsps[key.Namespace] = []string{}
+		}
+		nspCache, _ := aviObjCache.NSPCache.AviCacheGet(key)
+		nsps[key.Namespace] = append(nsps[key.Namespace], nspCache.(*avicache.AviNetworkSecurityPolicyCache).Uuid)
+	}
+	return deleteAviResource("/api/networksecuritypolicy", nsps)
+}
+
 func cleanupPoolGroups() error {
 	aviObjCache := avicache.SharedAviObjCache()
 	pgroups := make(map[string][]string)
@@ -346,6 +362,32 @@ func cleanupPools() error {
 	return deleteAviResource("/api/pool", pools)
 }
 
+func cleanupHealthMonitors() error {
+	aviObjCache := avicache.SharedAviObjCache()
+	hms := make(map[string][]string)
+	for _, key := range aviObjCache.HMCache.AviGetAllKeys() {
+		if _, ok := hms[key.Namespace]; !ok {
+			hms[key.Namespace] = []string{}
+		}
+		hmCache, _ := aviObjCache.HMCache.AviCacheGet(key)
+		hms[key.Namespace] = append(hms[key.Namespace], hmCache.(*avicache.AviHealthMonitorCache).Uuid)
+	}
+	return deleteAviResource("/api/healthmonitor", hms)
+}
+
+func cleanupPersistenceProfiles() error {
+	aviObjCache := avicache.SharedAviObjCache()
+	persistenceProfiles := make(map[string][]string)
+	for _, key := range aviObjCache.AppPersistCache.AviGetAllKeys() {
+		if _, ok := persistenceProfiles[key.Namespace]; !ok {
+			persistenceProfiles[key.Namespace] = []string{}
+		}
+		persistenceCache, _ := aviObjCache.AppPersistCache.AviCacheGet(key)
+		persistenceProfiles[key.Namespace] = append(persistenceProfiles[key.Namespace], persistenceCache.(*avicache.AviPersistenceProfileCache).Uuid)
+	}
+	return deleteAviResource("/api/applicationpersistenceprofile", persistenceProfiles)
+}
+
 func convertPemToDer(cert string) string {
 	cert = strings.TrimPrefix(cert, "-----BEGIN CERTIFICATE-----")
 	cert = strings.TrimSuffix(cert, "-----END CERTIFICATE-----")
@@ -112,7 +112,7 @@ func (c *GatewayController) InitController(informers k8s.K8sinformers, registere
 	if err != nil {
 		utils.AviLog.Errorf("Cannot convert full sync interval value to integer, pls correct the value and restart AKO. Error: %s", err)
 	} else {
-		// First boot sync
+		// First boot sync, which records the state used by the periodic full sync
 		err = c.FullSyncK8s(false)
 		if err != nil {
 			// Something bad sync. We need to return and shutdown the API server
@@ -124,6 +124,7 @@ func (c *GatewayController) InitController(informers k8s.K8sinformers, registere
 			worker = utils.NewFullSyncThread(time.Duration(interval) * time.Second)
 			worker.SyncFunction = c.FullSync
 			worker.QuickSyncFunction = c.FullSyncK8s
+			worker.PeriodicSyncFunction = c.periodicFullSyncK8s
 			go worker.Run()
 		} else {
 			utils.AviLog.Warnf("Full sync interval set to 0, will not run full sync")
@@ -228,12 +229,47 @@ func (c *GatewayController) addIndexers() {
 	)
 }
 
+// periodicFullSyncK8s runs the full sync of the kubernetes objects after the Avi object cache is refreshed. Only the
+// changed objects are translated, and only the changed and diverged models are published to the rest layer.
+func (c *GatewayController) periodicFullSyncK8s() {
+	if err := c.FullSyncK8s(true); err != nil {
+		utils.AviLog.Warnf("Periodic full sync of the kubernetes objects failed: %v", err)
+	}
+}
+
+// fullSyncRoutes validates the routes of a kind, and dequeues the valid routes in the order of their creation.
+func fullSyncRoutes(fullSync *k8s.IncrementalFullSync, routes []gatewayRoute) {
+	var filteredRoutes []gatewayRoute
+	for _, route := range routes {
+		routeMeta := route.objectMeta()
+		key := route.kind() + "/" + routeMeta.Namespace + "/" + routeMeta.Name
+		objects.SharedResourceVerInstanceLister().Save(key, routeMeta.ResourceVersion)
+		if isRouteValid(key, route) {
+			filteredRoutes = append(filteredRoutes, route)
+		}
+	}
+	sort.Slice(filteredRoutes, func(i, j int) bool {
+		metaI, metaJ := filteredRoutes[i].objectMeta(), filteredRoutes[j].objectMeta()
+		if metaI.CreationTimestamp.Unix() == metaJ.CreationTimestamp.Unix() {
+			return metaI.Namespace+"/"+metaI.Name < metaJ.Namespace+"/"+metaJ.Name
+		}
+		return metaI.CreationTimestamp.Unix() < metaJ.CreationTimestamp.Unix()
+	})
+	for _, filteredRoute := range filteredRoutes {
+		routeMeta := filteredRoute.objectMeta()
+		key := filteredRoute.kind() + "/" + routeMeta.Namespace + "/" + routeMeta.Name
+		fullSync.DequeueIngestion(key, filteredRoute.object())
+	}
+}
+
 func (c *GatewayController) FullSyncK8s(sync bool) error {
 
 	if c.DisableSync {
 		utils.AviLog.Infof("Sync disabled, skipping full sync")
 		return nil
 	}
+	start := time.Now()
+	fullSync := k8s.NewIncrementalFullSync(sync, dequeueIngestion, dependencyVersions)
 
 	// GatewayClass Section
 	gwClassObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().List(labels.Set(nil).AsSelector())
@@ -256,7 +292,7 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 	}
 	for _, filteredGatewayClass := range filteredGatewayClasses {
 		key := lib.GatewayClass + "/" + utils.ObjKey(filteredGatewayClass)
-		akogatewayapinodes.DequeueIngestion(key, true)
+		fullSync.DequeueIngestion(key, filteredGatewayClass)
 	}
 
 	// Gateway Section
@@ -286,37 +322,17 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 	})
 	for _, filteredGateway := range filteredGateways {
 		key := lib.Gateway + "/" + utils.ObjKey(filteredGateway)
-		akogatewayapinodes.DequeueIngestion(key, true)
+		fullSync.DequeueIngestion(key, filteredGateway)
 	}
 
-	// HTTPRoute Section
-	var filteredHTTPRoutes []*gatewayv1.HTTPRoute
-	httpRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
-	if err != nil {
-		utils.AviLog.Errorf("Unable to retrieve the httproutes during full sync: %s", err)
-		return err
-	}
-
-	for _, httpRouteObj := range httpRouteObjs {
-		key := lib.HTTPRoute + "/" + utils.ObjKey(httpRouteObj)
-		meta, err := meta.Accessor(httpRouteObj)
-		if err == nil {
-			resVer := meta.GetResourceVersion()
-			objects.SharedResourceVerInstanceLister().Save(key, resVer)
-		}
-		if IsHTTPRouteValid(key, httpRouteObj) {
-			filteredHTTPRoutes = append(filteredHTTPRoutes, httpRouteObj)
-		}
-	}
-	sort.Slice(filteredHTTPRoutes, func(i, j int) bool {
-		if filteredHTTPRoutes[i].GetCreationTimestamp().Unix() == filteredHTTPRoutes[j].GetCreationTimestamp().Unix() {
-			return filteredHTTPRoutes[i].Namespace+"/"+filteredHTTPRoutes[i].Name < filteredHTTPRoutes[j].Namespace+"/"+filteredHTTPRoutes[j].Name
+	// Route Section
+	for _, listRoutes := range routeListers {
+		routes, err := listRoutes()
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the routes during full sync: %s", err)
+			return err
 		}
-		return filteredHTTPRoutes[i].GetCreationTimestamp().Unix() < filteredHTTPRoutes[j].GetCreationTimestamp().Unix()
-	})
-	for _, filteredHTTPRoute := range filteredHTTPRoutes {
-		key := lib.HTTPRoute + "/" + utils.ObjKey(filteredHTTPRoute)
-		akogatewayapinodes.DequeueIngestion(key, true)
+		fullSyncRoutes(fullSync, routes)
 	}
 
 	// Service Section
@@ -354,16 +370,21 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 				resVer := meta.GetResourceVersion()
 				objects.SharedResourceVerInstanceLister().Save(key, resVer)
 			}
-			akogatewayapinodes.DequeueIngestion(key, true)
+			fullSync.DequeueIngestion(key, podObj)
 		}
 	}
 
-	c.publishAllParentVSKeysToRestLayer()
+	c.publishParentVSKeysToRestLayer(fullSync)
+	fullSync.Report()
+	objects.SharedFullSyncStateLister().SetSynced()
 
+	lib.ObserveFullSync(lib.FullSyncKubernetes, start)
 	return nil
 }
 
-func (c *GatewayController) publishAllParentVSKeysToRestLayer() {
+// publishParentVSKeysToRestLayer publishes the models of the virtual services in the Avi object cache, and the newly
+// generated models, which have changed since the last full sync.
+func (c *GatewayController) publishParentVSKeysToRestLayer(fullSync *k8s.IncrementalFullSync) {
 	cache := avicache.SharedAviObjCache()
 	vsKeys := cache.VsCacheMeta.AviCacheGetAllParentVSKeys()
 	utils.AviLog.Debugf("Got the VS keys: %s", vsKeys)
@@ -382,18 +403,19 @@ func (c *GatewayController) publishAllParentVSKeysToRestLayer() {
 		modelName := vsCacheKey.Namespace + "/" + vsCacheKey.Name
 		delete(allModels, modelName)
 		utils.AviLog.Infof("Model published in full sync %s", modelName)
-		nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+		fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 
 	}
 	// Now also publish the newly generated models (if any)
 	// Publish all the models to REST layer.
 	utils.AviLog.Debugf("Newly generated models that do not exist in cache %s", utils.Stringify(allModels))
 	for modelName := range allModels {
-		nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+		fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 	}
 }
 
 func (c *GatewayController) FullSync() {
+	start := time.Now()
 	aviRestClientPool := avicache.SharedAVIClients(lib.GetTenant())
 	aviObjCache := avicache.SharedAviObjCache()
 
@@ -402,6 +424,7 @@ func (c *GatewayController) FullSync() {
 		aviObjCache.AviClusterStatusPopulate(aviRestClientPool.AviClient[0])
 
 		aviObjCache.AviCacheRefresh(aviRestClientPool.AviClient[0], utils.CloudName)
+		status.CheckCertificates(akogatewayapilib.AKOControlConfig().EventRecorder(), akogatewayapistatus.GetCertificateOwners)
 
 		allModelsMap := objects.SharedAviGraphLister().GetAll()
 		var allModels []string
@@ -421,10 +444,12 @@ func (c *GatewayController) FullSync() {
 			// Not publishing the model anymore to layer since we don't want to support full sync for now.
 			//nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
 		}
+		lib.ObserveFullSync(lib.FullSyncController, start)
 	}
 }
 
 func SyncFromNodesLayer(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.RestStage, time.Now())
 	keyStr, ok := key.(string)
 	if !ok {
 		utils.AviLog.Warnf("Unexpected object type: expected string, got %T", key)
@@ -441,6 +466,7 @@ func (c *GatewayController) RefreshAuthToken() {
 }
 
 func SyncFromIngestionLayer(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.GraphStage, time.Now())
 	// This method will do all necessary graph calculations on the Graph Layer
 	// Let's route the key to the graph layer.
 	// NOTE: There's no error propagation from the graph layer back to the workerqueue. We will evaluate
@@ -474,6 +500,7 @@ func SyncFromSlowRetryLayer(key interface{}, wg *sync.WaitGroup) error {
 	return nil
 }
 func SyncFromStatusQueue(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.StatusStage, time.Now())
 	akogatewayapistatus.DequeueStatus(key)
 	return nil
 }
@@ -648,6 +675,7 @@ func delConfigFromData(data map[string]string) bool {
 // The rest layer would pick up the model key and delete the objects in Avi
 func (c *GatewayController) DeleteModels() {
 	utils.AviLog.Infof("Deletion of all avi objects triggered")
+	objects.SharedFullSyncStateLister().Reset()
 	publisher := status.NewStatusPublisher()
 	publisher.AddStatefulSetAnnotation(status.GatewayObjectDeletionStatus, lib.ObjectDeletionStartStatus)
 	allModels := objects.SharedAviGraphLister().GetAll()
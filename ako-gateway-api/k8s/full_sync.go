@@ -0,0 +1,199 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+func dequeueIngestion(key string) {
+	akogatewayapinodes.DequeueIngestion(key, true)
+}
+
+// dependencyVersions returns the resource versions of the objects, which the translation of a gateway or a route
+// depends on. These are the secrets of a gateway, the parent gateways of a route, the backend services of a route with
+// their endpoints and BackendTLSPolicies, the ReferenceGrants permitting the references across namespaces, and the
+// AKO CRDs referred by the ExtensionRef filters of an HTTPRoute.
+func dependencyVersions(obj interface{}) ([]string, bool) {
+	var versions []string
+	switch o := obj.(type) {
+	case *gatewayv1.Gateway:
+		for _, listener := range o.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, certRef := range listener.TLS.CertificateRefs {
+				certNs := o.Namespace
+				if certRef.Namespace != nil {
+					certNs = string(*certRef.Namespace)
+				}
+				versions = append(versions, k8s.SecretVersion(certNs, string(certRef.Name)))
+				versions = append(versions, referenceGrantVersions(lib.Gateway, o.Namespace, certNs)...)
+			}
+		}
+	case *gatewayv1.HTTPRoute:
+		versions = append(versions, parentRefVersions(o.Namespace, o.Spec.ParentRefs)...)
+		for _, rule := range o.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				versions = append(versions, backendRefVersions(lib.HTTPRoute, o.Namespace, backendRef.BackendRef)...)
+			}
+			for _, filter := range rule.Filters {
+				if filter.ExtensionRef != nil {
+					versions = append(versions, extensionRefVersion(o.Namespace, filter.ExtensionRef))
+				}
+			}
+		}
+	case *gatewayv1alpha2.GRPCRoute:
+		versions = append(versions, parentRefVersions(o.Namespace, o.Spec.ParentRefs)...)
+		for _, rule := range o.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				versions = append(versions, backendRefVersions(lib.GRPCRoute, o.Namespace, backendRef.BackendRef)...)
+			}
+		}
+	case *gatewayv1alpha2.TLSRoute:
+		versions = append(versions, parentRefVersions(o.Namespace, o.Spec.ParentRefs)...)
+		for _, rule := range o.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				versions = append(versions, backendRefVersions(lib.TLSRoute, o.Namespace, backendRef)...)
+			}
+		}
+	case *gatewayv1alpha2.TCPRoute:
+		versions = append(versions, parentRefVersions(o.Namespace, o.Spec.ParentRefs)...)
+		for _, rule := range o.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				versions = append(versions, backendRefVersions(lib.TCPRoute, o.Namespace, backendRef)...)
+			}
+		}
+	case *gatewayv1alpha2.UDPRoute:
+		versions = append(versions, parentRefVersions(o.Namespace, o.Spec.ParentRefs)...)
+		for _, rule := range o.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				versions = append(versions, backendRefVersions(lib.UDPRoute, o.Namespace, backendRef)...)
+			}
+		}
+	default:
+		return nil, false
+	}
+	return versions, true
+}
+
+func parentRefVersions(namespace string, parentRefs []gatewayv1.ParentReference) []string {
+	var versions []string
+	for _, parentRef := range parentRefs {
+		if parentRef.Kind != nil && string(*parentRef.Kind) != lib.Gateway {
+			continue
+		}
+		gwNamespace := namespace
+		if parentRef.Namespace != nil {
+			gwNamespace = string(*parentRef.Namespace)
+		}
+		gwName := string(parentRef.Name)
+		gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(gwNamespace).Get(gwName)
+		versions = append(versions, k8s.DependencyVersion(lib.Gateway, gwNamespace+"/"+gwName, gateway, err))
+	}
+	return versions
+}
+
+func backendRefVersions(routeKind, namespace string, backendRef gatewayv1.BackendRef) []string {
+	if backendRef.Kind != nil && string(*backendRef.Kind) != utils.Service {
+		return nil
+	}
+	svcNamespace := namespace
+	if backendRef.Namespace != nil {
+		svcNamespace = string(*backendRef.Namespace)
+	}
+	svcName := string(backendRef.Name)
+	versions := k8s.ServiceVersions(svcNamespace, svcName)
+	versions = append(versions, backendTLSPolicyVersions(svcNamespace, svcName)...)
+	return append(versions, referenceGrantVersions(routeKind, namespace, svcNamespace)...)
+}
+
+// backendTLSPolicyVersions returns the resource versions of the BackendTLSPolicies targeting the service, and of their
+// CA certificate ConfigMaps.
+func backendTLSPolicyVersions(namespace, svcName string) []string {
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	if informers.BackendTLSPolicyInformer == nil {
+		return nil
+	}
+	backendTLSPolicies, err := informers.BackendTLSPolicyInformer.Lister().BackendTLSPolicies(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		return nil
+	}
+	sort.Slice(backendTLSPolicies, func(i, j int) bool {
+		return backendTLSPolicies[i].Name < backendTLSPolicies[j].Name
+	})
+	var versions []string
+	for _, backendTLSPolicy := range backendTLSPolicies {
+		targetRef := backendTLSPolicy.Spec.TargetRef
+		if string(targetRef.Kind) != utils.Service || string(targetRef.Name) != svcName {
+			continue
+		}
+		versions = append(versions, k8s.DependencyVersion(lib.BackendTLSPolicy, utils.ObjKey(backendTLSPolicy), backendTLSPolicy, nil))
+		for _, caCertRef := range backendTLSPolicy.Spec.TLS.CACertRefs {
+			if string(caCertRef.Kind) != akogatewayapilib.ConfigMap {
+				continue
+			}
+			cmObj, err := informers.ConfigMapInformer.Lister().ConfigMaps(namespace).Get(string(caCertRef.Name))
+			versions = append(versions, k8s.DependencyVersion(utils.ConfigMap, namespace+"/"+string(caCertRef.Name), cmObj, err))
+		}
+	}
+	return versions
+}
+
+// referenceGrantVersions returns the resource versions of the ReferenceGrants in toNamespace, which trust the objects
+// of kind fromKind in fromNamespace.
+func referenceGrantVersions(fromKind, fromNamespace, toNamespace string) []string {
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	if fromNamespace == toNamespace || informers.ReferenceGrantInformer == nil {
+		return nil
+	}
+	referenceGrants, err := informers.ReferenceGrantInformer.Lister().ReferenceGrants(toNamespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, referenceGrant := range referenceGrants {
+		if akogatewayapilib.IsReferenceGrantFromMatched(referenceGrant, fromKind, fromNamespace) {
+			versions = append(versions, k8s.DependencyVersion(lib.ReferenceGrant, utils.ObjKey(referenceGrant), referenceGrant, nil))
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// extensionRefVersion returns the resource version of the L7Rule or the RouteRuleExtension referred by an ExtensionRef
+// filter.
+func extensionRefVersion(namespace string, extensionRef *gatewayv1.LocalObjectReference) string {
+	name := string(extensionRef.Name)
+	switch string(extensionRef.Kind) {
+	case lib.L7Rule:
+		l7Rule, err := akogatewayapilib.GetL7Rule(namespace, name)
+		return k8s.DependencyVersion(lib.L7Rule, namespace+"/"+name, l7Rule, err)
+	case lib.RouteRuleExtension:
+		routeRuleExtension, err := akogatewayapilib.GetRouteRuleExtension(namespace, name)
+		return k8s.DependencyVersion(lib.RouteRuleExtension, namespace+"/"+name, routeRuleExtension, err)
+	}
+	return ""
+}
@@ -22,10 +22,13 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayexternalversions "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
@@ -33,6 +36,9 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	v1alpha2akoinformers "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/informers/externalversions"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -40,16 +46,14 @@ var controllerInstance *GatewayController
 var ctrlonce sync.Once
 
 type GatewayController struct {
-	worker_id   uint32
 	informers   *utils.Informers
-	workqueue   []workqueue.RateLimitingInterface
+	workqueue   []*utils.PriorityQueue
 	DisableSync bool
 }
 
 func SharedGatewayController() *GatewayController {
 	ctrlonce.Do(func() {
 		controllerInstance = &GatewayController{
-			worker_id:   (uint32(1) << utils.NumWorkersIngestion) - 1,
 			informers:   utils.GetInformers(),
 			DisableSync: true,
 		}
@@ -59,11 +63,51 @@ func SharedGatewayController() *GatewayController {
 
 func (c *GatewayController) InitGatewayAPIInformers(cs gatewayclientset.Interface) {
 	gatewayFactory := gatewayexternalversions.NewSharedInformerFactory(cs, time.Second*30)
-	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(&akogatewayapilib.GatewayAPIInformers{
-		GatewayInformer:      gatewayFactory.Gateway().V1().Gateways(),
-		GatewayClassInformer: gatewayFactory.Gateway().V1().GatewayClasses(),
-		HTTPRouteInformer:    gatewayFactory.Gateway().V1().HTTPRoutes(),
-	})
+	gatewayAPIInformers := &akogatewayapilib.GatewayAPIInformers{
+		GatewayInformer:        gatewayFactory.Gateway().V1().Gateways(),
+		GatewayClassInformer:   gatewayFactory.Gateway().V1().GatewayClasses(),
+		HTTPRouteInformer:      gatewayFactory.Gateway().V1().HTTPRoutes(),
+		ReferenceGrantInformer: gatewayFactory.Gateway().V1beta1().ReferenceGrants(),
+	}
+
+	// the v1alpha2 resources are part of the experimental channel, and their informers are left unset
+	// when the CRDs are not installed.
+	v1alpha2GroupVersion := gatewayv1alpha2.GroupVersion.String()
+	if akogatewayapilib.IsResourceServed(cs.Discovery(), v1alpha2GroupVersion, "grpcroutes") {
+		gatewayAPIInformers.GRPCRouteInformer = gatewayFactory.Gateway().V1alpha2().GRPCRoutes()
+	}
+	if akogatewayapilib.IsResourceServed(cs.Discovery(), v1alpha2GroupVersion, "tlsroutes") {
+		gatewayAPIInformers.TLSRouteInformer = gatewayFactory.Gateway().V1alpha2().TLSRoutes()
+	}
+	if akogatewayapilib.IsResourceServed(cs.Discovery(), v1alpha2GroupVersion, "tcproutes") {
+		gatewayAPIInformers.TCPRouteInformer = gatewayFactory.Gateway().V1alpha2().TCPRoutes()
+	}
+	if akogatewayapilib.IsResourceServed(cs.Discovery(), v1alpha2GroupVersion, "udproutes") {
+		gatewayAPIInformers.UDPRouteInformer = gatewayFactory.Gateway().V1alpha2().UDPRoutes()
+	}
+	if akogatewayapilib.IsResourceServed(cs.Discovery(), v1alpha2GroupVersion, "backendtlspolicies") {
+		gatewayAPIInformers.BackendTLSPolicyInformer = gatewayFactory.Gateway().V1alpha2().BackendTLSPolicies()
+		// the CA certificates of the BackendTLSPolicies are present in the ConfigMaps of the namespaces of the policies
+		kubeInformerFactory := kubeinformers.NewSharedInformerFactory(utils.GetInformers().ClientSet, time.Second*30)
+		gatewayAPIInformers.ConfigMapInformer = kubeInformerFactory.Core().V1().ConfigMaps()
+	}
+	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(gatewayAPIInformers)
+
+	// the AKO CRDs referred by the ExtensionRef filters of the HTTPRoutes are watched when the AKO CRD clientset is set.
+	// helm does not upgrade the CRDs of an existing release, hence each informer is set only when its CRD is installed.
+	if lib.AKOControlConfig().L7RuleEnabled() {
+		akoCRDDiscovery := lib.AKOControlConfig().V1alpha2CRDClientset().Discovery()
+		akoCRDGroupVersion := akov1alpha2.SchemeGroupVersion.String()
+		akoInformerFactory := v1alpha2akoinformers.NewSharedInformerFactoryWithOptions(lib.AKOControlConfig().V1alpha2CRDClientset(), time.Second*30)
+		crdInformers := &lib.AKOCrdInformers{}
+		if akogatewayapilib.IsResourceServed(akoCRDDiscovery, akoCRDGroupVersion, "l7rules") {
+			crdInformers.L7RuleInformer = akoInformerFactory.Ako().V1alpha2().L7Rules()
+		}
+		if akogatewayapilib.IsResourceServed(akoCRDDiscovery, akoCRDGroupVersion, "routeruleextensions") {
+			crdInformers.RouteRuleExtensionInformer = akoInformerFactory.Ako().V1alpha2().RouteRuleExtensions()
+		}
+		lib.AKOControlConfig().SetCRDInformers(crdInformers)
+	}
 }
 
 func (c *GatewayController) Start(stopCh <-chan struct{}) {
@@ -95,6 +139,45 @@ func (c *GatewayController) Start(stopCh <-chan struct{}) {
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Informer().HasSynced)
 	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().Run(stopCh)
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().HasSynced)
+	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().Run(stopCh)
+	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().HasSynced)
+
+	// the informers of the experimental channel resources are set only when their CRDs are installed.
+	gatewayAPIInformers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	if gatewayAPIInformers.GRPCRouteInformer != nil {
+		go gatewayAPIInformers.GRPCRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.GRPCRouteInformer.Informer().HasSynced)
+	}
+	if gatewayAPIInformers.TLSRouteInformer != nil {
+		go gatewayAPIInformers.TLSRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.TLSRouteInformer.Informer().HasSynced)
+	}
+	if gatewayAPIInformers.TCPRouteInformer != nil {
+		go gatewayAPIInformers.TCPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.TCPRouteInformer.Informer().HasSynced)
+	}
+	if gatewayAPIInformers.UDPRouteInformer != nil {
+		go gatewayAPIInformers.UDPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.UDPRouteInformer.Informer().HasSynced)
+	}
+	if gatewayAPIInformers.BackendTLSPolicyInformer != nil {
+		go gatewayAPIInformers.BackendTLSPolicyInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.BackendTLSPolicyInformer.Informer().HasSynced)
+		go gatewayAPIInformers.ConfigMapInformer.Informer().Run(stopCh)
+		informersList = append(informersList, gatewayAPIInformers.ConfigMapInformer.Informer().HasSynced)
+	}
+
+	if lib.AKOControlConfig().L7RuleEnabled() {
+		crdInformers := lib.AKOControlConfig().CRDInformers()
+		if crdInformers.L7RuleInformer != nil {
+			go crdInformers.L7RuleInformer.Informer().Run(stopCh)
+			informersList = append(informersList, crdInformers.L7RuleInformer.Informer().HasSynced)
+		}
+		if crdInformers.RouteRuleExtensionInformer != nil {
+			go crdInformers.RouteRuleExtensionInformer.Informer().Run(stopCh)
+			informersList = append(informersList, crdInformers.RouteRuleExtensionInformer.Informer().HasSynced)
+		}
+	}
 
 	if !cache.WaitForCacheSync(stopCh, informersList...) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
@@ -153,7 +236,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 				}
 				key := utils.Endpointslices + "/" + namespace + "/" + svcName
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			},
 			UpdateFunc: func(old, cur interface{}) {
@@ -236,7 +319,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 				}
 				bkt := utils.Bkt(namespace, numWorkers)
 				objects.SharedResourceVerInstanceLister().Delete(key)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			},
 			UpdateFunc: func(old, cur interface{}) {
@@ -315,7 +398,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 					return
 				}
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			},
 			UpdateFunc: func(old, cur interface{}) {
@@ -378,7 +461,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(svc))
 			key := utils.Service + "/" + utils.ObjKey(svc)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
@@ -441,7 +524,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 					return
 				}
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			}
 		},
@@ -529,7 +612,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(gw))
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
 		UpdateFunc: func(old, obj interface{}) {
@@ -598,7 +681,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(gwClass))
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
 		UpdateFunc: func(old, obj interface{}) {
@@ -663,7 +746,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(httpRoute))
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
 		UpdateFunc: func(old, obj interface{}) {
@@ -674,8 +757,9 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			newHTTPRoute := obj.(*gatewayv1.HTTPRoute)
 			if IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute) {
 				key := lib.HTTPRoute + "/" + utils.ObjKey(newHTTPRoute)
+				// an invalid route is queued as well, so that its existing configuration is removed
 				if !IsHTTPRouteValid(key, newHTTPRoute) {
-					return
+					utils.AviLog.Warnf("key: %s, msg: HTTPRoute is not valid, removing its configuration", key)
 				}
 				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newHTTPRoute))
 				bkt := utils.Bkt(namespace, numWorkers)
@@ -685,6 +769,751 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 		},
 	}
 	informer.HTTPRouteInformer.Informer().AddEventHandler(httpRouteEventHandler)
+
+	grpcRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute := obj.(*gatewayv1alpha2.GRPCRoute)
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == grpcRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsGRPCRouteValid(key, grpcRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute, ok := obj.(*gatewayv1alpha2.GRPCRoute)
+			if !ok {
+				// grpcRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				grpcRoute, ok = tombstone.Obj.(*gatewayv1alpha2.GRPCRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a GRPCRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldGRPCRoute := old.(*gatewayv1alpha2.GRPCRoute)
+			newGRPCRoute := obj.(*gatewayv1alpha2.GRPCRoute)
+			if IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute) {
+				key := lib.GRPCRoute + "/" + utils.ObjKey(newGRPCRoute)
+				// an invalid route is queued as well, so that its existing configuration is removed
+				if !IsGRPCRouteValid(key, newGRPCRoute) {
+					utils.AviLog.Warnf("key: %s, msg: GRPCRoute is not valid, removing its configuration", key)
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newGRPCRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.GRPCRouteInformer != nil {
+		informer.GRPCRouteInformer.Informer().AddEventHandler(grpcRouteEventHandler)
+	}
+
+	tlsRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tlsRoute := obj.(*gatewayv1alpha2.TLSRoute)
+			key := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == tlsRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsTLSRouteValid(key, tlsRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tlsRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tlsRoute, ok := obj.(*gatewayv1alpha2.TLSRoute)
+			if !ok {
+				// tlsRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				tlsRoute, ok = tombstone.Obj.(*gatewayv1alpha2.TLSRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a TLSRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tlsRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldTLSRoute := old.(*gatewayv1alpha2.TLSRoute)
+			newTLSRoute := obj.(*gatewayv1alpha2.TLSRoute)
+			if IsTLSRouteUpdated(oldTLSRoute, newTLSRoute) {
+				key := lib.TLSRoute + "/" + utils.ObjKey(newTLSRoute)
+				// an invalid route is queued as well, so that its existing configuration is removed
+				if !IsTLSRouteValid(key, newTLSRoute) {
+					utils.AviLog.Warnf("key: %s, msg: TLSRoute is not valid, removing its configuration", key)
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newTLSRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.TLSRouteInformer != nil {
+		informer.TLSRouteInformer.Informer().AddEventHandler(tlsRouteEventHandler)
+	}
+
+	tcpRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tcpRoute := obj.(*gatewayv1alpha2.TCPRoute)
+			key := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == tcpRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsTCPRouteValid(key, tcpRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tcpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tcpRoute, ok := obj.(*gatewayv1alpha2.TCPRoute)
+			if !ok {
+				// tcpRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				tcpRoute, ok = tombstone.Obj.(*gatewayv1alpha2.TCPRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a TCPRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tcpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldTCPRoute := old.(*gatewayv1alpha2.TCPRoute)
+			newTCPRoute := obj.(*gatewayv1alpha2.TCPRoute)
+			if IsTCPRouteUpdated(oldTCPRoute, newTCPRoute) {
+				key := lib.TCPRoute + "/" + utils.ObjKey(newTCPRoute)
+				// an invalid route is queued as well, so that its existing configuration is removed
+				if !IsTCPRouteValid(key, newTCPRoute) {
+					utils.AviLog.Warnf("key: %s, msg: TCPRoute is not valid, removing its configuration", key)
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newTCPRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.TCPRouteInformer != nil {
+		informer.TCPRouteInformer.Informer().AddEventHandler(tcpRouteEventHandler)
+	}
+
+	udpRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			udpRoute := obj.(*gatewayv1alpha2.UDPRoute)
+			key := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == udpRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsUDPRouteValid(key, udpRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(udpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			udpRoute, ok := obj.(*gatewayv1alpha2.UDPRoute)
+			if !ok {
+				// udpRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				udpRoute, ok = tombstone.Obj.(*gatewayv1alpha2.UDPRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a UDPRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(udpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldUDPRoute := old.(*gatewayv1alpha2.UDPRoute)
+			newUDPRoute := obj.(*gatewayv1alpha2.UDPRoute)
+			if IsUDPRouteUpdated(oldUDPRoute, newUDPRoute) {
+				key := lib.UDPRoute + "/" + utils.ObjKey(newUDPRoute)
+				// an invalid route is queued as well, so that its existing configuration is removed
+				if !IsUDPRouteValid(key, newUDPRoute) {
+					utils.AviLog.Warnf("key: %s, msg: UDPRoute is not valid, removing its configuration", key)
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newUDPRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.UDPRouteInformer != nil {
+		informer.UDPRouteInformer.Informer().AddEventHandler(udpRouteEventHandler)
+	}
+
+	referenceGrantEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.processReferenceGrant(key, referenceGrant.Namespace, referenceGrant.Spec.From, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant, ok := obj.(*gatewayv1beta1.ReferenceGrant)
+			if !ok {
+				// referenceGrant was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				referenceGrant, ok = tombstone.Obj.(*gatewayv1beta1.ReferenceGrant)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a ReferenceGrant: %#v", obj)
+					return
+				}
+			}
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.processReferenceGrant(key, referenceGrant.Namespace, referenceGrant.Spec.From, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldReferenceGrant := old.(*gatewayv1beta1.ReferenceGrant)
+			newReferenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			if !reflect.DeepEqual(oldReferenceGrant.Spec, newReferenceGrant.Spec) {
+				key := lib.ReferenceGrant + "/" + utils.ObjKey(newReferenceGrant)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				// objects trusted earlier as well as the ones trusted now are re-evaluated
+				referenceGrantFrom := make([]gatewayv1beta1.ReferenceGrantFrom, 0, len(oldReferenceGrant.Spec.From)+len(newReferenceGrant.Spec.From))
+				referenceGrantFrom = append(referenceGrantFrom, oldReferenceGrant.Spec.From...)
+				referenceGrantFrom = append(referenceGrantFrom, newReferenceGrant.Spec.From...)
+				c.processReferenceGrant(key, newReferenceGrant.Namespace, referenceGrantFrom, numWorkers)
+			}
+		},
+	}
+	informer.ReferenceGrantInformer.Informer().AddEventHandler(referenceGrantEventHandler)
+
+	backendTLSPolicyEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			backendTLSPolicy := obj.(*gatewayv1alpha2.BackendTLSPolicy)
+			key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicy)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.processBackendTLSPolicy(key, backendTLSPolicy, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			backendTLSPolicy, ok := obj.(*gatewayv1alpha2.BackendTLSPolicy)
+			if !ok {
+				// backendTLSPolicy was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				backendTLSPolicy, ok = tombstone.Obj.(*gatewayv1alpha2.BackendTLSPolicy)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a BackendTLSPolicy: %#v", obj)
+					return
+				}
+			}
+			key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicy)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.processBackendTLSPolicy(key, backendTLSPolicy, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldBackendTLSPolicy := old.(*gatewayv1alpha2.BackendTLSPolicy)
+			newBackendTLSPolicy := obj.(*gatewayv1alpha2.BackendTLSPolicy)
+			if !reflect.DeepEqual(oldBackendTLSPolicy.Spec, newBackendTLSPolicy.Spec) {
+				key := lib.BackendTLSPolicy + "/" + utils.ObjKey(newBackendTLSPolicy)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				// the service targeted earlier is processed as well, in case the target has changed
+				if oldBackendTLSPolicy.Spec.TargetRef.Name != newBackendTLSPolicy.Spec.TargetRef.Name {
+					c.processBackendTLSPolicy(key, oldBackendTLSPolicy, numWorkers)
+				}
+				c.processBackendTLSPolicy(key, newBackendTLSPolicy, numWorkers)
+			}
+		},
+	}
+	if informer.BackendTLSPolicyInformer != nil {
+		informer.BackendTLSPolicyInformer.Informer().AddEventHandler(backendTLSPolicyEventHandler)
+	}
+
+	caCertConfigMapEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			configMap := obj.(*corev1.ConfigMap)
+			key := akogatewayapilib.ConfigMap + "/" + utils.ObjKey(configMap)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.processCACertConfigMap(key, configMap, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			configMap, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				// configMap was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				configMap, ok = tombstone.Obj.(*corev1.ConfigMap)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a ConfigMap: %#v", obj)
+					return
+				}
+			}
+			key := akogatewayapilib.ConfigMap + "/" + utils.ObjKey(configMap)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.processCACertConfigMap(key, configMap, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldConfigMap := old.(*corev1.ConfigMap)
+			configMap := obj.(*corev1.ConfigMap)
+			if oldConfigMap.Data[akogatewayapilib.CACertKey] != configMap.Data[akogatewayapilib.CACertKey] {
+				key := akogatewayapilib.ConfigMap + "/" + utils.ObjKey(configMap)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				c.processCACertConfigMap(key, configMap, numWorkers)
+			}
+		},
+	}
+	if informer.ConfigMapInformer != nil {
+		informer.ConfigMapInformer.Informer().AddEventHandler(caCertConfigMapEventHandler)
+	}
+
+	if lib.AKOControlConfig().L7RuleEnabled() {
+		c.setupExtensionRefEventHandlers(numWorkers)
+	}
+}
+
+// setupExtensionRefEventHandlers sets up the event handlers of the AKO CRDs which can be referred by the
+// ExtensionRef filters of the HTTPRoutes.
+func (c *GatewayController) setupExtensionRefEventHandlers(numWorkers uint32) {
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+
+	l7RuleEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			l7Rule := obj.(*akov1alpha2.L7Rule)
+			key := lib.L7Rule + "/" + utils.ObjKey(l7Rule)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.processExtensionRef(key, l7Rule.Namespace, lib.L7Rule, l7Rule.Name, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			l7Rule, ok := obj.(*akov1alpha2.L7Rule)
+			if !ok {
+				// l7Rule was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				l7Rule, ok = tombstone.Obj.(*akov1alpha2.L7Rule)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not an L7Rule: %#v", obj)
+					return
+				}
+			}
+			key := lib.L7Rule + "/" + utils.ObjKey(l7Rule)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.processExtensionRef(key, l7Rule.Namespace, lib.L7Rule, l7Rule.Name, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldL7Rule := old.(*akov1alpha2.L7Rule)
+			newL7Rule := obj.(*akov1alpha2.L7Rule)
+			// the status is considered as well, as a rejected L7Rule is not applied
+			if !reflect.DeepEqual(oldL7Rule.Spec, newL7Rule.Spec) || oldL7Rule.Status.Status != newL7Rule.Status.Status {
+				key := lib.L7Rule + "/" + utils.ObjKey(newL7Rule)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				c.processExtensionRef(key, newL7Rule.Namespace, lib.L7Rule, newL7Rule.Name, numWorkers)
+			}
+		},
+	}
+	if crdInformers.L7RuleInformer != nil {
+		crdInformers.L7RuleInformer.Informer().AddEventHandler(l7RuleEventHandler)
+	}
+
+	routeRuleExtensionEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			routeRuleExtension := obj.(*akov1alpha2.RouteRuleExtension)
+			key := lib.RouteRuleExtension + "/" + utils.ObjKey(routeRuleExtension)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			updateRouteRuleExtensionStatus(key, routeRuleExtension)
+			c.processExtensionRef(key, routeRuleExtension.Namespace, lib.RouteRuleExtension, routeRuleExtension.Name, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			routeRuleExtension, ok := obj.(*akov1alpha2.RouteRuleExtension)
+			if !ok {
+				// routeRuleExtension was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				routeRuleExtension, ok = tombstone.Obj.(*akov1alpha2.RouteRuleExtension)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a RouteRuleExtension: %#v", obj)
+					return
+				}
+			}
+			key := lib.RouteRuleExtension + "/" + utils.ObjKey(routeRuleExtension)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.processExtensionRef(key, routeRuleExtension.Namespace, lib.RouteRuleExtension, routeRuleExtension.Name, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldRouteRuleExtension := old.(*akov1alpha2.RouteRuleExtension)
+			newRouteRuleExtension := obj.(*akov1alpha2.RouteRuleExtension)
+			if !reflect.DeepEqual(oldRouteRuleExtension.Spec, newRouteRuleExtension.Spec) {
+				key := lib.RouteRuleExtension + "/" + utils.ObjKey(newRouteRuleExtension)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				updateRouteRuleExtensionStatus(key, newRouteRuleExtension)
+				c.processExtensionRef(key, newRouteRuleExtension.Namespace, lib.RouteRuleExtension, newRouteRuleExtension.Name, numWorkers)
+			}
+		},
+	}
+	if crdInformers.RouteRuleExtensionInformer != nil {
+		crdInformers.RouteRuleExtensionInformer.Informer().AddEventHandler(routeRuleExtensionEventHandler)
+	}
+}
+
+// updateRouteRuleExtensionStatus sets the status of the RouteRuleExtension to Accepted or Rejected as per its validity.
+func updateRouteRuleExtensionStatus(key string, routeRuleExtension *akov1alpha2.RouteRuleExtension) {
+	updateStatus := status.UpdateCRDStatusOptions{Status: lib.StatusAccepted}
+	if err := akogatewayapilib.ValidateRouteRuleExtension(routeRuleExtension); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: RouteRuleExtension is not valid, err: %v", key, err)
+		updateStatus = status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()}
+	}
+	if routeRuleExtension.Status.Status == updateStatus.Status && routeRuleExtension.Status.Error == updateStatus.Error {
+		return
+	}
+	status.UpdateRouteRuleExtensionStatus(key, routeRuleExtension, updateStatus)
+}
+
+// processExtensionRef re-evaluates the HTTPRoutes in the namespace, whose ExtensionRef filters refer to the AKO CRD of
+// the given kind and name. Their statuses are updated and they are queued for the graph layer, where the settings of
+// the referred object are applied, or the configuration of the routes which are not valid anymore is removed.
+func (c *GatewayController) processExtensionRef(key, namespace, kind, name string, numWorkers uint32) {
+	httpRoutes, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: unable to retrieve the HTTPRoutes in namespace %s, err: %s", key, namespace, err)
+		return
+	}
+	bkt := utils.Bkt(namespace, numWorkers)
+	for _, httpRoute := range httpRoutes {
+		if !isHTTPRouteReferringExtension(httpRoute, kind, name) {
+			continue
+		}
+		routeKey := lib.HTTPRoute + "/" + utils.ObjKey(httpRoute)
+		// an invalid route is queued as well, so that its existing configuration is removed
+		IsHTTPRouteValid(routeKey, httpRoute)
+		c.workqueue[bkt].AddRateLimited(routeKey)
+		utils.AviLog.Debugf("key: %s, msg: %s is queued for re-evaluation", key, routeKey)
+	}
+}
+
+// processBackendTLSPolicy queues the Service targeted by the BackendTLSPolicy for the graph layer,
+// where the pools of the routes referring to the Service are built as per the BackendTLSPolicies present at that time.
+// The statuses of the routes are updated, as their backends are not programmed when the policy can not be applied.
+func (c *GatewayController) processBackendTLSPolicy(key string, backendTLSPolicy *gatewayv1alpha2.BackendTLSPolicy, numWorkers uint32) {
+	targetRef := backendTLSPolicy.Spec.TargetRef
+	if string(targetRef.Group) != "" || string(targetRef.Kind) != utils.Service {
+		utils.AviLog.Warnf("key: %s, msg: BackendTLSPolicy target %s/%s is not supported, must be a Service", key, targetRef.Group, targetRef.Kind)
+		return
+	}
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	svcName := string(targetRef.Name)
+	httpRoutes, err := informer.HTTPRouteInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: unable to retrieve the HTTPRoutes, err: %s", key, err)
+	}
+	for _, httpRoute := range httpRoutes {
+		if isRouteReferringService(httpRoute, httpRoute.Namespace, backendTLSPolicy.Namespace, svcName) {
+			IsHTTPRouteValid(lib.HTTPRoute+"/"+utils.ObjKey(httpRoute), httpRoute)
+		}
+	}
+	if informer.GRPCRouteInformer != nil {
+		grpcRoutes, err := informer.GRPCRouteInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("key: %s, msg: unable to retrieve the GRPCRoutes, err: %s", key, err)
+		}
+		for _, grpcRoute := range grpcRoutes {
+			if isRouteReferringService(grpcRoute, grpcRoute.Namespace, backendTLSPolicy.Namespace, svcName) {
+				IsGRPCRouteValid(lib.GRPCRoute+"/"+utils.ObjKey(grpcRoute), grpcRoute)
+			}
+		}
+	}
+	svcKey := utils.Service + "/" + backendTLSPolicy.Namespace + "/" + svcName
+	bkt := utils.Bkt(backendTLSPolicy.Namespace, numWorkers)
+	c.workqueue[bkt].AddRateLimited(svcKey)
+	utils.AviLog.Debugf("key: %s, msg: queued the target service %s", key, svcKey)
+}
+
+// processCACertConfigMap processes the BackendTLSPolicies which refer to the ConfigMap for their CA certificate,
+// so that the PKI profiles of the pools of their target Services are updated with the CA certificate present at that time.
+func (c *GatewayController) processCACertConfigMap(key string, configMap *corev1.ConfigMap, numWorkers uint32) {
+	backendTLSPolicies, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(configMap.Namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: unable to retrieve the BackendTLSPolicies in namespace %s, err: %s", key, configMap.Namespace, err)
+		return
+	}
+	for _, backendTLSPolicy := range backendTLSPolicies {
+		for _, caCertRef := range backendTLSPolicy.Spec.TLS.CACertRefs {
+			if string(caCertRef.Kind) == akogatewayapilib.ConfigMap && string(caCertRef.Name) == configMap.Name {
+				c.processBackendTLSPolicy(key, backendTLSPolicy, numWorkers)
+				break
+			}
+		}
+	}
+}
+
+// processReferenceGrant re-evaluates the Gateways and Routes, trusted by a ReferenceGrant in the given namespace,
+// which refer to the objects in that namespace. Their statuses are updated and they are queued for the graph layer
+// irrespective of their validity, where the references are permitted or denied as per the ReferenceGrants present
+// at that time, so that the routes losing the permission are removed.
+func (c *GatewayController) processReferenceGrant(key, namespace string, referenceGrantFrom []gatewayv1beta1.ReferenceGrantFrom, numWorkers uint32) {
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	for _, from := range referenceGrantFrom {
+		if string(from.Group) != gatewayv1.GroupName {
+			continue
+		}
+		fromNamespace := string(from.Namespace)
+		var objKeys []string
+		switch string(from.Kind) {
+		case lib.Gateway:
+			gateways, err := informer.GatewayInformer.Lister().Gateways(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the gateways in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, gateway := range gateways {
+				if !isGatewayReferringNamespace(gateway, namespace) {
+					continue
+				}
+				gwKey := lib.Gateway + "/" + utils.ObjKey(gateway)
+				// the gateway is processed irrespective of its validity, so that the secrets
+				// which are not permitted anymore are removed from the parent VS.
+				IsValidGateway(gwKey, gateway)
+				objKeys = append(objKeys, gwKey)
+			}
+		case lib.HTTPRoute:
+			httpRoutes, err := informer.HTTPRouteInformer.Lister().HTTPRoutes(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the HTTPRoutes in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, httpRoute := range httpRoutes {
+				routeKey := lib.HTTPRoute + "/" + utils.ObjKey(httpRoute)
+				if isRouteReferringNamespace(httpRoute, namespace) {
+					IsHTTPRouteValid(routeKey, httpRoute)
+					objKeys = append(objKeys, routeKey)
+				}
+			}
+		case lib.GRPCRoute:
+			if informer.GRPCRouteInformer == nil {
+				continue
+			}
+			grpcRoutes, err := informer.GRPCRouteInformer.Lister().GRPCRoutes(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the GRPCRoutes in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, grpcRoute := range grpcRoutes {
+				routeKey := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+				if isRouteReferringNamespace(grpcRoute, namespace) {
+					IsGRPCRouteValid(routeKey, grpcRoute)
+					objKeys = append(objKeys, routeKey)
+				}
+			}
+		case lib.TLSRoute:
+			if informer.TLSRouteInformer == nil {
+				continue
+			}
+			tlsRoutes, err := informer.TLSRouteInformer.Lister().TLSRoutes(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the TLSRoutes in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, tlsRoute := range tlsRoutes {
+				routeKey := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+				if isRouteReferringNamespace(tlsRoute, namespace) {
+					IsTLSRouteValid(routeKey, tlsRoute)
+					objKeys = append(objKeys, routeKey)
+				}
+			}
+		case lib.TCPRoute:
+			if informer.TCPRouteInformer == nil {
+				continue
+			}
+			tcpRoutes, err := informer.TCPRouteInformer.Lister().TCPRoutes(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the TCPRoutes in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, tcpRoute := range tcpRoutes {
+				routeKey := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+				if isRouteReferringNamespace(tcpRoute, namespace) {
+					IsTCPRouteValid(routeKey, tcpRoute)
+					objKeys = append(objKeys, routeKey)
+				}
+			}
+		case lib.UDPRoute:
+			if informer.UDPRouteInformer == nil {
+				continue
+			}
+			udpRoutes, err := informer.UDPRouteInformer.Lister().UDPRoutes(fromNamespace).List(labels.Set(nil).AsSelector())
+			if err != nil {
+				utils.AviLog.Errorf("key: %s, msg: unable to retrieve the UDPRoutes in namespace %s, err: %s", key, fromNamespace, err)
+				continue
+			}
+			for _, udpRoute := range udpRoutes {
+				routeKey := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+				if isRouteReferringNamespace(udpRoute, namespace) {
+					IsUDPRouteValid(routeKey, udpRoute)
+					objKeys = append(objKeys, routeKey)
+				}
+			}
+		default:
+			utils.AviLog.Debugf("key: %s, msg: references from %s are not processed", key, from.Kind)
+			continue
+		}
+
+		bkt := utils.Bkt(fromNamespace, numWorkers)
+		for _, objKey := range objKeys {
+			c.workqueue[bkt].AddRateLimited(objKey)
+			utils.AviLog.Debugf("key: %s, msg: %s is queued for re-evaluation", key, objKey)
+		}
+	}
 }
 
 func IsGatewayUpdated(oldGateway, newGateway *gatewayv1.Gateway) bool {
@@ -702,6 +1531,51 @@ func IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute *gatewayv1.HTTPRoute) bool {
 	}
 	oldHash := utils.Hash(utils.Stringify(oldHTTPRoute.Spec))
 	newHash := utils.Hash(utils.Stringify(newHTTPRoute.Spec))
+	if oldHash != newHash {
+		return true
+	}
+	// the retry policy of the route is set through annotations
+	for _, annotation := range []string{akogatewayapilib.RetryAttemptsAnnotation, akogatewayapilib.RetryCodesAnnotation} {
+		if oldHTTPRoute.GetAnnotations()[annotation] != newHTTPRoute.GetAnnotations()[annotation] {
+			return true
+		}
+	}
+	return false
+}
+
+func IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute *gatewayv1alpha2.GRPCRoute) bool {
+	if newGRPCRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldGRPCRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newGRPCRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsTLSRouteUpdated(oldTLSRoute, newTLSRoute *gatewayv1alpha2.TLSRoute) bool {
+	if newTLSRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldTLSRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newTLSRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsTCPRouteUpdated(oldTCPRoute, newTCPRoute *gatewayv1alpha2.TCPRoute) bool {
+	if newTCPRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldTCPRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newTCPRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsUDPRouteUpdated(oldUDPRoute, newUDPRoute *gatewayv1alpha2.UDPRoute) bool {
+	if newUDPRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldUDPRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newUDPRoute.Spec))
 	return oldHash != newHash
 }
 
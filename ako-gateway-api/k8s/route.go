@@ -0,0 +1,201 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+)
+
+// gatewayRoute is implemented for every route kind, for the validation and the full sync of the routes of the kind.
+type gatewayRoute interface {
+	kind() string
+	object() runtime.Object
+	objectMeta() *metav1.ObjectMeta
+	parentRefs() []gatewayv1.ParentReference
+	hostnames() []gatewayv1.Hostname
+	routeStatus() *gatewayv1.RouteStatus
+	// validateRules returns the rules dropped from the configuration of the route, or an error if the route can not
+	// be configured at all.
+	validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error)
+	recordStatus(key string, routeStatus *gatewayv1.RouteStatus)
+}
+
+type httpGatewayRoute struct {
+	*gatewayv1.HTTPRoute
+}
+
+func (r httpGatewayRoute) kind() string                            { return lib.HTTPRoute }
+func (r httpGatewayRoute) object() runtime.Object                  { return r.HTTPRoute }
+func (r httpGatewayRoute) objectMeta() *metav1.ObjectMeta          { return &r.ObjectMeta }
+func (r httpGatewayRoute) parentRefs() []gatewayv1.ParentReference { return r.Spec.ParentRefs }
+func (r httpGatewayRoute) hostnames() []gatewayv1.Hostname         { return r.Spec.Hostnames }
+func (r httpGatewayRoute) routeStatus() *gatewayv1.RouteStatus     { return &r.Status.RouteStatus }
+
+func (r httpGatewayRoute) validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error) {
+	validateHTTPRouteExtensionRefs(key, r.HTTPRoute, routeStatus)
+	if err := validateHTTPRouteMatches(r.HTTPRoute); err != nil {
+		return "", err
+	}
+	return validateHTTPRouteFilters(r.HTTPRoute)
+}
+
+func (r httpGatewayRoute) recordStatus(key string, routeStatus *gatewayv1.RouteStatus) {
+	akogatewayapistatus.Record(key, r.HTTPRoute, &akogatewayapistatus.Status{HTTPRouteStatus: &gatewayv1.HTTPRouteStatus{RouteStatus: *routeStatus}})
+}
+
+type grpcGatewayRoute struct {
+	*gatewayv1alpha2.GRPCRoute
+}
+
+func (r grpcGatewayRoute) kind() string                            { return lib.GRPCRoute }
+func (r grpcGatewayRoute) object() runtime.Object                  { return r.GRPCRoute }
+func (r grpcGatewayRoute) objectMeta() *metav1.ObjectMeta          { return &r.ObjectMeta }
+func (r grpcGatewayRoute) parentRefs() []gatewayv1.ParentReference { return r.Spec.ParentRefs }
+func (r grpcGatewayRoute) hostnames() []gatewayv1.Hostname         { return r.Spec.Hostnames }
+func (r grpcGatewayRoute) routeStatus() *gatewayv1.RouteStatus     { return &r.Status.RouteStatus }
+
+func (r grpcGatewayRoute) validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error) {
+	return validateGRPCRouteMatches(r.GRPCRoute)
+}
+
+func (r grpcGatewayRoute) recordStatus(key string, routeStatus *gatewayv1.RouteStatus) {
+	akogatewayapistatus.Record(key, r.GRPCRoute, &akogatewayapistatus.Status{GRPCRouteStatus: &gatewayv1alpha2.GRPCRouteStatus{RouteStatus: *routeStatus}})
+}
+
+type tlsGatewayRoute struct {
+	*gatewayv1alpha2.TLSRoute
+}
+
+func (r tlsGatewayRoute) kind() string                            { return lib.TLSRoute }
+func (r tlsGatewayRoute) object() runtime.Object                  { return r.TLSRoute }
+func (r tlsGatewayRoute) objectMeta() *metav1.ObjectMeta          { return &r.ObjectMeta }
+func (r tlsGatewayRoute) parentRefs() []gatewayv1.ParentReference { return r.Spec.ParentRefs }
+func (r tlsGatewayRoute) hostnames() []gatewayv1.Hostname         { return r.Spec.Hostnames }
+func (r tlsGatewayRoute) routeStatus() *gatewayv1.RouteStatus     { return &r.Status.RouteStatus }
+
+func (r tlsGatewayRoute) validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error) {
+	return "", nil
+}
+
+func (r tlsGatewayRoute) recordStatus(key string, routeStatus *gatewayv1.RouteStatus) {
+	akogatewayapistatus.Record(key, r.TLSRoute, &akogatewayapistatus.Status{TLSRouteStatus: &gatewayv1alpha2.TLSRouteStatus{RouteStatus: *routeStatus}})
+}
+
+type tcpGatewayRoute struct {
+	*gatewayv1alpha2.TCPRoute
+}
+
+func (r tcpGatewayRoute) kind() string                            { return lib.TCPRoute }
+func (r tcpGatewayRoute) object() runtime.Object                  { return r.TCPRoute }
+func (r tcpGatewayRoute) objectMeta() *metav1.ObjectMeta          { return &r.ObjectMeta }
+func (r tcpGatewayRoute) parentRefs() []gatewayv1.ParentReference { return r.Spec.ParentRefs }
+func (r tcpGatewayRoute) hostnames() []gatewayv1.Hostname         { return nil }
+func (r tcpGatewayRoute) routeStatus() *gatewayv1.RouteStatus     { return &r.Status.RouteStatus }
+
+func (r tcpGatewayRoute) validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error) {
+	return "", validateL4RouteRules(len(r.Spec.Rules))
+}
+
+func (r tcpGatewayRoute) recordStatus(key string, routeStatus *gatewayv1.RouteStatus) {
+	akogatewayapistatus.Record(key, r.TCPRoute, &akogatewayapistatus.Status{TCPRouteStatus: &gatewayv1alpha2.TCPRouteStatus{RouteStatus: *routeStatus}})
+}
+
+type udpGatewayRoute struct {
+	*gatewayv1alpha2.UDPRoute
+}
+
+func (r udpGatewayRoute) kind() string                            { return lib.UDPRoute }
+func (r udpGatewayRoute) object() runtime.Object                  { return r.UDPRoute }
+func (r udpGatewayRoute) objectMeta() *metav1.ObjectMeta          { return &r.ObjectMeta }
+func (r udpGatewayRoute) parentRefs() []gatewayv1.ParentReference { return r.Spec.ParentRefs }
+func (r udpGatewayRoute) hostnames() []gatewayv1.Hostname         { return nil }
+func (r udpGatewayRoute) routeStatus() *gatewayv1.RouteStatus     { return &r.Status.RouteStatus }
+
+func (r udpGatewayRoute) validateRules(key string, routeStatus *gatewayv1.RouteStatus) (string, error) {
+	return "", validateL4RouteRules(len(r.Spec.Rules))
+}
+
+func (r udpGatewayRoute) recordStatus(key string, routeStatus *gatewayv1.RouteStatus) {
+	akogatewayapistatus.Record(key, r.UDPRoute, &akogatewayapistatus.Status{UDPRouteStatus: &gatewayv1alpha2.UDPRouteStatus{RouteStatus: *routeStatus}})
+}
+
+// routeListers return the routes of each kind, in the order in which the kinds are synced. The routes of a kind are
+// not returned, when its CRD is not served in the cluster.
+var routeListers = []func() ([]gatewayRoute, error){
+	func() ([]gatewayRoute, error) {
+		httpRoutes, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().List(labels.Set(nil).AsSelector())
+		routes := make([]gatewayRoute, 0, len(httpRoutes))
+		for _, httpRoute := range httpRoutes {
+			routes = append(routes, httpGatewayRoute{httpRoute.DeepCopy()})
+		}
+		return routes, err
+	},
+	func() ([]gatewayRoute, error) {
+		informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer
+		if informer == nil {
+			return nil, nil
+		}
+		grpcRoutes, err := informer.Lister().List(labels.Set(nil).AsSelector())
+		routes := make([]gatewayRoute, 0, len(grpcRoutes))
+		for _, grpcRoute := range grpcRoutes {
+			routes = append(routes, grpcGatewayRoute{grpcRoute.DeepCopy()})
+		}
+		return routes, err
+	},
+	func() ([]gatewayRoute, error) {
+		informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer
+		if informer == nil {
+			return nil, nil
+		}
+		tlsRoutes, err := informer.Lister().List(labels.Set(nil).AsSelector())
+		routes := make([]gatewayRoute, 0, len(tlsRoutes))
+		for _, tlsRoute := range tlsRoutes {
+			routes = append(routes, tlsGatewayRoute{tlsRoute.DeepCopy()})
+		}
+		return routes, err
+	},
+	func() ([]gatewayRoute, error) {
+		informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer
+		if informer == nil {
+			return nil, nil
+		}
+		tcpRoutes, err := informer.Lister().List(labels.Set(nil).AsSelector())
+		routes := make([]gatewayRoute, 0, len(tcpRoutes))
+		for _, tcpRoute := range tcpRoutes {
+			routes = append(routes, tcpGatewayRoute{tcpRoute.DeepCopy()})
+		}
+		return routes, err
+	},
+	func() ([]gatewayRoute, error) {
+		informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer
+		if informer == nil {
+			return nil, nil
+		}
+		udpRoutes, err := informer.Lister().List(labels.Set(nil).AsSelector())
+		routes := make([]gatewayRoute, 0, len(udpRoutes))
+		for _, udpRoute := range udpRoutes {
+			routes = append(routes, udpGatewayRoute{udpRoute.DeepCopy()})
+		}
+		return routes, err
+	},
+}
@@ -20,8 +20,10 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -182,7 +184,10 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 
 	// protocol validation
 	if listener.Protocol != gatewayv1.HTTPProtocolType &&
-		listener.Protocol != gatewayv1.HTTPSProtocolType {
+		listener.Protocol != gatewayv1.HTTPSProtocolType &&
+		listener.Protocol != gatewayv1.TLSProtocolType &&
+		listener.Protocol != gatewayv1.TCPProtocolType &&
+		listener.Protocol != gatewayv1.UDPProtocolType {
 		utils.AviLog.Errorf("key: %s, msg: protocol is not supported for listener %s", key, listener.Name)
 		defaultCondition.
 			Reason(string(gatewayv1.ListenerReasonUnsupportedProtocol)).
@@ -192,8 +197,18 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		return false
 	}
 
-	// has valid TLS config
-	if listener.TLS != nil {
+	// TLS listeners are supported only in passthrough mode
+	if listener.Protocol == gatewayv1.TLSProtocolType {
+		if !akogatewayapilib.IsListenerPassthrough(listener) {
+			utils.AviLog.Errorf("key: %s, msg: tls mode not valid %+v/%+v, only Passthrough is supported for TLS protocol", key, gateway.Name, listener.Name)
+			defaultCondition.
+				Reason(string(gatewayv1.ListenerReasonUnsupportedProtocol)).
+				Message("TLS mode not valid. Only Passthrough is supported for TLS protocol").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+	} else if listener.TLS != nil {
+		// has valid TLS config
 		if (listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate) || len(listener.TLS.CertificateRefs) == 0 {
 			utils.AviLog.Errorf("key: %s, msg: tls mode/ref not valid %+v/%+v", key, gateway.Name, listener.Name)
 			defaultCondition.
@@ -213,7 +228,20 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 					SetIn(&gatewayStatus.Listeners[index].Conditions)
 				return false
 			}
-
+			// secret in another namespace can be referred only when a ReferenceGrant allows it
+			certRefNamespace := gateway.Namespace
+			if certRef.Namespace != nil && *certRef.Namespace != "" {
+				certRefNamespace = string(*certRef.Namespace)
+			}
+			if !akogatewayapilib.IsReferencePermitted(lib.Gateway, gateway.Namespace, utils.Secret, certRefNamespace, string(certRef.Name)) {
+				utils.AviLog.Errorf("key: %s, msg: CertificateRef %s/%s of %+v/%+v is not permitted by any ReferenceGrant", key, certRefNamespace, certRef.Name, gateway.Name, listener.Name)
+				defaultCondition.
+					Type(string(gatewayv1.ListenerConditionResolvedRefs)).
+					Reason(string(gatewayv1.ListenerReasonRefNotPermitted)).
+					Message(fmt.Sprintf("CertificateRef %s/%s is not permitted by any ReferenceGrant", certRefNamespace, certRef.Name)).
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				return false
+			}
 		}
 	}
 
@@ -221,12 +249,16 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 	if listener.AllowedRoutes != nil {
 		if listener.AllowedRoutes.Kinds != nil {
 			for _, kindInAllowedRoute := range listener.AllowedRoutes.Kinds {
-				if kindInAllowedRoute.Kind != "" && string(kindInAllowedRoute.Kind) != utils.HTTPRoute {
-					utils.AviLog.Errorf("key: %s, msg: AllowedRoute kind is invalid %+v/%+v. Supported AllowedRoute kind is HTTPRoute.", key, gateway.Name, listener.Name)
+				if kindInAllowedRoute.Kind != "" && !akogatewayapilib.IsRouteKindSupported(string(listener.Protocol), string(kindInAllowedRoute.Kind)) {
+					var supportedKinds []string
+					for _, supportedKind := range akogatewayapilib.SupportedKinds[listener.Protocol] {
+						supportedKinds = append(supportedKinds, string(supportedKind.Kind))
+					}
+					utils.AviLog.Errorf("key: %s, msg: AllowedRoute kind is invalid %+v/%+v. Supported AllowedRoute kinds are %v.", key, gateway.Name, listener.Name, supportedKinds)
 					defaultCondition.
 						Type(string(gatewayv1.ListenerConditionResolvedRefs)).
 						Reason(string(gatewayv1.ListenerReasonInvalidRouteKinds)).
-						Message("AllowedRoute kind is invalid. Only HTTPRoute is supported currently").
+						Message(fmt.Sprintf("AllowedRoute kind is invalid. Only %s are supported currently", strings.Join(akogatewayapilib.SupportedRouteKinds, ", "))).
 						SetIn(&gatewayStatus.Listeners[index].Conditions)
 					return false
 				}
@@ -254,44 +286,86 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 }
 
 func IsHTTPRouteValid(key string, obj *gatewayv1.HTTPRoute) bool {
+	return isRouteValid(key, httpGatewayRoute{obj.DeepCopy()})
+}
 
-	httpRoute := obj.DeepCopy()
-	if len(httpRoute.Spec.ParentRefs) == 0 {
-		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the HTTPRoute %s", key, httpRoute.Name)
+func IsGRPCRouteValid(key string, obj *gatewayv1alpha2.GRPCRoute) bool {
+	return isRouteValid(key, grpcGatewayRoute{obj.DeepCopy()})
+}
+
+func IsTLSRouteValid(key string, obj *gatewayv1alpha2.TLSRoute) bool {
+	return isRouteValid(key, tlsGatewayRoute{obj.DeepCopy()})
+}
+
+func IsTCPRouteValid(key string, obj *gatewayv1alpha2.TCPRoute) bool {
+	return isRouteValid(key, tcpGatewayRoute{obj.DeepCopy()})
+}
+
+func IsUDPRouteValid(key string, obj *gatewayv1alpha2.UDPRoute) bool {
+	return isRouteValid(key, udpGatewayRoute{obj.DeepCopy()})
+}
+
+// isRouteValid validates the parent references, the backend references and the rules of the route, and records the
+// status of the route.
+func isRouteValid(key string, route gatewayRoute) (valid bool) {
+	routeKind, routeMeta := route.kind(), route.objectMeta()
+	// the graph layer removes the configuration of the route, once it becomes invalid
+	defer func() {
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteValidity(routeKind+"/"+routeMeta.Namespace+"/"+routeMeta.Name, valid)
+	}()
+
+	parentRefs := route.parentRefs()
+	if len(parentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the %s %s", key, routeKind, routeMeta.Name)
 		return false
 	}
 
-	httpRouteStatus := obj.Status.DeepCopy()
-	httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(httpRoute.Spec.ParentRefs))
+	routeStatus := route.routeStatus().DeepCopy()
+	routeStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(parentRefs))
 	var invalidParentRefCount int
-	parentRefIndexInHttpRouteStatus := 0
-	for parentRefIndexFromSpec := range httpRoute.Spec.ParentRefs {
-		err := validateParentReference(key, httpRoute, httpRouteStatus, parentRefIndexFromSpec, &parentRefIndexInHttpRouteStatus)
+	parentRefIndexInRouteStatus := 0
+	for parentRefIndexFromSpec := range parentRefs {
+		err := validateParentReference(key, routeKind, routeMeta, parentRefs, route.hostnames(), routeStatus, parentRefIndexFromSpec, &parentRefIndexInRouteStatus)
 		if err != nil {
 			invalidParentRefCount++
-			parentRefName := httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Name
-			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of HTTPRoute object %s is not valid, err: %v", key, parentRefName, httpRoute.Name, err)
+			parentRefName := parentRefs[parentRefIndexFromSpec].Name
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of %s object %s is not valid, err: %v", key, parentRefName, routeKind, routeMeta.Name, err)
 		}
 	}
-	akogatewayapistatus.Record(key, httpRoute, &akogatewayapistatus.Status{HTTPRouteStatus: httpRouteStatus})
+	validateBackendReferences(key, routeKind, routeMeta, getRouteBackendRefs(route.object()), routeStatus)
+	droppedRules, err := route.validateRules(key, routeStatus)
+	if err != nil {
+		setRouteUnsupportedValueCondition(key, routeKind, routeMeta, err, routeStatus)
+	} else if droppedRules != "" {
+		setRoutePartiallyInvalidCondition(key, routeKind, routeMeta, droppedRules, routeStatus)
+	}
+	route.recordStatus(key, routeStatus)
 
-	// No valid attachment, we can't proceed with this HTTPRoute object.
-	if invalidParentRefCount == len(httpRoute.Spec.ParentRefs) {
-		utils.AviLog.Errorf("key: %s, msg: HTTPRoute object %s is not valid", key, httpRoute.Name)
-		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(httpRoute, corev1.EventTypeWarning,
-			lib.Detached, "HTTPRoute object %s is not valid", httpRoute.Name)
+	// Rules can't be ignored, we can't proceed with this route object.
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: %s object %s is not valid, err: %v", key, routeKind, routeMeta.Name, err)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(route.object(), corev1.EventTypeWarning,
+			lib.Detached, "%s object %s is not valid, %v", routeKind, routeMeta.Name, err)
+		return false
+	}
+
+	// No valid attachment, we can't proceed with this route object.
+	if invalidParentRefCount == len(parentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: %s object %s is not valid", key, routeKind, routeMeta.Name)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(route.object(), corev1.EventTypeWarning,
+			lib.Detached, "%s object %s is not valid", routeKind, routeMeta.Name)
 		return false
 	}
-	utils.AviLog.Infof("key: %s, msg: HTTPRoute object %s is valid", key, httpRoute.Name)
+	utils.AviLog.Infof("key: %s, msg: %s object %s is valid", key, routeKind, routeMeta.Name)
 	return true
 }
 
-func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRouteStatus *gatewayv1.HTTPRouteStatus, parentRefIndexFromSpec int, parentRefIndexInHttpRouteStatus *int) error {
+func validateParentReference(key, routeKind string, routeMeta *metav1.ObjectMeta, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, routeStatus *gatewayv1.RouteStatus, parentRefIndexFromSpec int, parentRefIndexInRouteStatus *int) error {
 
-	name := string(httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Name)
-	namespace := httpRoute.Namespace
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace != nil {
-		namespace = string(*httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace)
+	name := string(parentRefs[parentRefIndexFromSpec].Name)
+	namespace := routeMeta.Namespace
+	if parentRefs[parentRefIndexFromSpec].Namespace != nil {
+		namespace = string(*parentRefs[parentRefIndexFromSpec].Namespace)
 	}
 	gwNsName := namespace + "/" + name
 	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
@@ -304,23 +378,23 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	gwClass := string(gateway.Spec.GatewayClassName)
 	_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 	if !isAKOCtrl {
-		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of HTTPRoute object %s is not ako", key, name, httpRoute.Name)
-		return fmt.Errorf("controller for the parent reference %s of HTTPRoute object %s is not ako", name, httpRoute.Name)
+		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeKind, routeMeta.Name)
+		return fmt.Errorf("controller for the parent reference %s of %s object %s is not ako", name, routeKind, routeMeta.Name)
 	}
 	// creates the Parent status only when the AKO is the gateway controller
-	httpRouteStatus.Parents = append(httpRouteStatus.Parents, gatewayv1.RouteParentStatus{})
-	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ControllerName = akogatewayapilib.GatewayController
-	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.Name = gatewayv1.ObjectName(name)
-	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
-		httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.SectionName = httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName
+	routeStatus.Parents = append(routeStatus.Parents, gatewayv1.RouteParentStatus{})
+	routeStatus.Parents[*parentRefIndexInRouteStatus].ControllerName = akogatewayapilib.GatewayController
+	routeStatus.Parents[*parentRefIndexInRouteStatus].ParentRef.Name = gatewayv1.ObjectName(name)
+	routeStatus.Parents[*parentRefIndexInRouteStatus].ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
+	if parentRefs[parentRefIndexFromSpec].SectionName != nil {
+		routeStatus.Parents[*parentRefIndexInRouteStatus].ParentRef.SectionName = parentRefs[parentRefIndexFromSpec].SectionName
 	}
 
 	defaultCondition := akogatewayapistatus.NewCondition().
 		Type(string(gatewayv1.GatewayConditionAccepted)).
 		Reason(string(gatewayv1.GatewayReasonInvalid)).
 		Status(metav1.ConditionFalse).
-		ObservedGeneration(httpRoute.ObjectMeta.Generation)
+		ObservedGeneration(routeMeta.Generation)
 
 	if len(gateway.Status.Conditions) == 0 {
 		// Gateway processing by AKO has not started.
@@ -328,8 +402,8 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		err := fmt.Errorf("AKO is yet to process Gateway %s for parent reference %s", gateway.Name, name)
 		defaultCondition.
 			Message(err.Error()).
-			SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-		*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
+			SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+		*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
 		return err
 	}
 
@@ -341,15 +415,15 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		err := fmt.Errorf("Gateway %s is in Invalid State", gateway.Name)
 		defaultCondition.
 			Message(err.Error()).
-			SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-		*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
+			SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+		*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
 		return err
 	}
 
 	//section name is optional
 	var listenersForRoute []gatewayv1.Listener
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
-		listenerName := *httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName
+	if parentRefs[parentRefIndexFromSpec].SectionName != nil {
+		listenerName := *parentRefs[parentRefIndexFromSpec].SectionName
 		i := akogatewayapilib.FindListenerByName(string(listenerName), gateway.Spec.Listeners)
 		if i == -1 {
 			// listener is not present in gateway
@@ -357,8 +431,8 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 			err := fmt.Errorf("Invalid listener name provided")
 			defaultCondition.
 				Message(err.Error()).
-				SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-			*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
+				SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+			*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
 			return err
 		}
 		listenersForRoute = append(listenersForRoute, gateway.Spec.Listeners[i])
@@ -369,6 +443,11 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	// TODO: Validation for hostname (those being fqdns) need to validate as per the K8 gateway req.
 	var listenersMatchedToRoute []gatewayv1.Listener
 	for _, listenerObj := range listenersForRoute {
+		// route can attach only to the listeners serving its kind
+		if !akogatewayapilib.IsRouteKindSupported(string(listenerObj.Protocol), routeKind) {
+			utils.AviLog.Debugf("key: %s, msg: listener %s of Gateway %s does not support %s", key, listenerObj.Name, gateway.Name, routeKind)
+			continue
+		}
 		// check from store
 		hostInListener := listenerObj.Hostname
 		isListenerFqdnWildcard := false
@@ -384,7 +463,7 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 			if strings.HasPrefix(string(*hostInListener), utils.WILDCARD) {
 				isListenerFqdnWildcard = true
 			}
-			for _, host := range httpRoute.Spec.Hostnames {
+			for _, host := range hostnames {
 				// casese to consider:
 				// Case 1: hostname of gateway is wildcard(empty) and hostname from httproute is not wild card
 				// Case 2: hostname of gateway is not wild card and hostname from httproute is wildcard
@@ -422,27 +501,27 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 
 			}
 			// if there are no hostnames specified, all parent listneres should be matched.
-			if len(httpRoute.Spec.Hostnames) == 0 {
+			if len(hostnames) == 0 {
 				matched = true
 			}
 		}
 		if !matched {
-			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in HTTPRoute %s", key, gateway.Name, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in %s %s", key, gateway.Name, routeKind, routeMeta.Name)
 			continue
 		}
 		listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
 	}
 	if len(listenersMatchedToRoute) == 0 {
-		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in HTTPRoute")
+		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in %s", routeKind)
 		defaultCondition.
 			Message(err.Error()).
-			SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-		*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
-		gwRouteNsName := fmt.Sprintf("%s/%s/%s/%s", gwNsName, lib.HTTPRoute, httpRoute.Namespace, httpRoute.Name)
+			SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+		*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
+		gwRouteNsName := fmt.Sprintf("%s/%s/%s/%s", gwNsName, routeKind, routeMeta.Namespace, routeMeta.Name)
 		found, hosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
 		if found {
-			utils.AviLog.Warnf("key: %s, msg: Hostname in Gateway Listener doesn't match with any of the hostnames in HTTPRoute", key)
-			utils.AviLog.Debugf("key: %s, msg: %d hosts mapped to the route %s/%s/%s", key, len(hosts), "HTTPRoute", httpRoute.Namespace, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: Hostname in Gateway Listener doesn't match with any of the hostnames in %s", key, routeKind)
+			utils.AviLog.Debugf("key: %s, msg: %d hosts mapped to the route %s/%s/%s", key, len(hosts), routeKind, routeMeta.Namespace, routeMeta.Name)
 			return nil
 		}
 		return err
@@ -458,8 +537,8 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 			err := fmt.Errorf("Couldn't find the listener %s in the Gateway status", listenerName)
 			defaultCondition.
 				Message(err.Error()).
-				SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-			*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
+				SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+			*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
 			return err
 		}
 
@@ -471,8 +550,279 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		Reason(string(gatewayv1.GatewayReasonAccepted)).
 		Status(metav1.ConditionTrue).
 		Message("Parent reference is valid").
-		SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of HTTPRoute object %s is valid", key, name, httpRoute.Name)
-	*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
+		SetIn(&routeStatus.Parents[*parentRefIndexInRouteStatus].Conditions)
+	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of %s object %s is valid", key, name, routeKind, routeMeta.Name)
+	*parentRefIndexInRouteStatus = *parentRefIndexInRouteStatus + 1
+	return nil
+}
+
+// validateBackendReferences sets the ResolvedRefs condition in all the parent statuses of the route. The condition
+// is False with RefNotPermitted reason when a backend in another namespace is not allowed by any ReferenceGrant,
+// such backends are skipped while building the route configuration.
+func validateBackendReferences(key, routeKind string, routeMeta *metav1.ObjectMeta, backendRefs []gatewayv1.BackendRef, routeStatus *gatewayv1.RouteStatus) {
+	resolvedRefsCondition := akogatewayapistatus.NewCondition().
+		Type(string(gatewayv1.RouteConditionResolvedRefs)).
+		Reason(string(gatewayv1.RouteReasonResolvedRefs)).
+		Status(metav1.ConditionTrue).
+		ObservedGeneration(routeMeta.Generation).
+		Message("All the references are resolved")
+
+	for _, backendRef := range backendRefs {
+		namespace := routeMeta.Namespace
+		if backendRef.Namespace != nil {
+			namespace = string(*backendRef.Namespace)
+		}
+		if !akogatewayapilib.IsReferencePermitted(routeKind, routeMeta.Namespace, utils.Service, namespace, string(backendRef.Name)) {
+			utils.AviLog.Warnf("key: %s, msg: reference to the backend %s/%s from %s %s is not permitted by any ReferenceGrant", key, namespace, backendRef.Name, routeKind, routeMeta.Name)
+			resolvedRefsCondition.
+				Reason(string(gatewayv1.RouteReasonRefNotPermitted)).
+				Status(metav1.ConditionFalse).
+				Message(fmt.Sprintf("Reference to the backend %s/%s is not permitted by any ReferenceGrant", namespace, backendRef.Name))
+			break
+		}
+		if reason, err := validateBackendTLSPolicy(key, routeKind, namespace, backendRef); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: backend %s/%s of %s %s is not programmed, err: %v", key, namespace, backendRef.Name, routeKind, routeMeta.Name, err)
+			resolvedRefsCondition.
+				Reason(reason).
+				Status(metav1.ConditionFalse).
+				Message(err.Error())
+			break
+		}
+	}
+
+	for i := range routeStatus.Parents {
+		resolvedRefsCondition.SetIn(&routeStatus.Parents[i].Conditions)
+	}
+}
+
+// validateBackendTLSPolicy checks that the BackendTLSPolicy attached to the backend of an HTTPRoute or a GRPCRoute,
+// if any, can be applied. The pools of such backends are not programmed otherwise.
+func validateBackendTLSPolicy(key, routeKind, namespace string, backendRef gatewayv1.BackendRef) (string, error) {
+	if routeKind != lib.HTTPRoute && routeKind != lib.GRPCRoute || backendRef.Port == nil {
+		return "", nil
+	}
+	portName := akogatewayapilib.FindPortName(string(backendRef.Name), namespace, int32(*backendRef.Port), key)
+	backendTLSPolicy := akogatewayapilib.GetBackendTLSPolicy(namespace, string(backendRef.Name), portName)
+	if backendTLSPolicy == nil {
+		return "", nil
+	}
+	_, reason, err := akogatewayapilib.GetBackendTLSPolicyCACert(backendTLSPolicy)
+	return reason, err
+}
+
+// validateHTTPRouteExtensionRefs sets the ResolvedRefs condition to False in the parent statuses of the HTTPRoute,
+// where the backends are resolved, when an ExtensionRef filter refers to an unsupported kind, to a missing object or
+// to an invalid RouteRuleExtension. Such filters are skipped while building the route configuration.
+func validateHTTPRouteExtensionRefs(key string, httpRoute *gatewayv1.HTTPRoute, routeStatus *gatewayv1.RouteStatus) {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.ExtensionRef == nil {
+				continue
+			}
+			reason, err := akogatewayapilib.ValidateExtensionRef(httpRoute.Namespace, filter.ExtensionRef)
+			if err == nil {
+				continue
+			}
+			utils.AviLog.Warnf("key: %s, msg: ExtensionRef of HTTPRoute %s can not be resolved, err: %v", key, httpRoute.Name, err)
+			resolvedRefsCondition := akogatewayapistatus.NewCondition().
+				Type(string(gatewayv1.RouteConditionResolvedRefs)).
+				Reason(reason).
+				Status(metav1.ConditionFalse).
+				ObservedGeneration(httpRoute.Generation).
+				Message(err.Error())
+			for i := range routeStatus.Parents {
+				if apimeta.IsStatusConditionTrue(routeStatus.Parents[i].Conditions, string(gatewayv1.RouteConditionResolvedRefs)) {
+					resolvedRefsCondition.SetIn(&routeStatus.Parents[i].Conditions)
+				}
+			}
+			return
+		}
+	}
+}
+
+// validateHTTPRouteMatches checks that the matches of the HTTPRoute can be translated.
+// Only the exact query param matches are supported.
+func validateHTTPRouteMatches(httpRoute *gatewayv1.HTTPRoute) error {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			for _, queryParam := range match.QueryParams {
+				if queryParam.Type != nil && *queryParam.Type != gatewayv1.QueryParamMatchExact {
+					return fmt.Errorf("Query param match type %s is not supported", *queryParam.Type)
+				}
+			}
+		}
+	}
 	return nil
 }
+
+// validateHTTPRouteFilters checks that the filters of the HTTPRoute rules can be translated. The rules
+// with unsupported filters are dropped during the translation and are described by the returned message.
+// An error is returned only when all the rules of the HTTPRoute are dropped.
+func validateHTTPRouteFilters(httpRoute *gatewayv1.HTTPRoute) (string, error) {
+	var droppedRules []string
+	var firstErr error
+	for i := range httpRoute.Spec.Rules {
+		if err := akogatewayapilib.ValidateHTTPRouteRuleFilters(&httpRoute.Spec.Rules[i]); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			droppedRules = append(droppedRules, fmt.Sprintf("Rule %d is dropped, %v", i, err))
+		}
+	}
+	if firstErr != nil && len(droppedRules) == len(httpRoute.Spec.Rules) {
+		return "", firstErr
+	}
+	return strings.Join(droppedRules, "; "), nil
+}
+
+// validateGRPCRouteMatches checks that the method matches of the GRPCRoute rules can be translated. The rules with
+// RegularExpression method matches are dropped during the translation and are described by the returned message.
+// An error is returned only when all the rules of the GRPCRoute are dropped.
+func validateGRPCRouteMatches(grpcRoute *gatewayv1alpha2.GRPCRoute) (string, error) {
+	var droppedRules []string
+	var firstErr error
+	for i, rule := range grpcRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Method != nil && match.Method.Type != nil && *match.Method.Type == gatewayv1alpha2.GRPCMethodMatchRegularExpression {
+				err := fmt.Errorf("Method match type %s is not supported", *match.Method.Type)
+				if firstErr == nil {
+					firstErr = err
+				}
+				droppedRules = append(droppedRules, fmt.Sprintf("Rule %d is dropped, %v", i, err))
+				break
+			}
+		}
+	}
+	if firstErr != nil && len(droppedRules) == len(grpcRoute.Spec.Rules) {
+		return "", firstErr
+	}
+	return strings.Join(droppedRules, "; "), nil
+}
+
+// validateL4RouteRules checks that the TCPRoute/UDPRoute can be translated. The traffic of a
+// L4 listener can only be matched on its port, hence only one rule is supported per route.
+func validateL4RouteRules(rulesCount int) error {
+	if rulesCount > 1 {
+		return fmt.Errorf("Only one rule is supported per route")
+	}
+	return nil
+}
+
+// setRouteUnsupportedValueCondition sets the Accepted condition to false on all the
+// parents of the route.
+func setRouteUnsupportedValueCondition(key, routeKind string, routeMeta *metav1.ObjectMeta, err error, routeStatus *gatewayv1.RouteStatus) {
+	utils.AviLog.Warnf("key: %s, msg: unsupported value in %s %s, err: %v", key, routeKind, routeMeta.Name, err)
+	for i := range routeStatus.Parents {
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionAccepted)).
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Status(metav1.ConditionFalse).
+			ObservedGeneration(routeMeta.Generation).
+			Message(err.Error()).
+			SetIn(&routeStatus.Parents[i].Conditions)
+	}
+}
+
+// setRoutePartiallyInvalidCondition sets the PartiallyInvalid condition on all the accepted parents
+// of the route, when some of its rules are dropped.
+func setRoutePartiallyInvalidCondition(key, routeKind string, routeMeta *metav1.ObjectMeta, message string, routeStatus *gatewayv1.RouteStatus) {
+	utils.AviLog.Warnf("key: %s, msg: %s %s is partially invalid, %s", key, routeKind, routeMeta.Name, message)
+	for i := range routeStatus.Parents {
+		if !apimeta.IsStatusConditionTrue(routeStatus.Parents[i].Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			continue
+		}
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionPartiallyInvalid)).
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Status(metav1.ConditionTrue).
+			ObservedGeneration(routeMeta.Generation).
+			Message(message).
+			SetIn(&routeStatus.Parents[i].Conditions)
+	}
+}
+
+func getRouteBackendRefs(obj interface{}) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	switch route := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendRef)
+			}
+		}
+	case *gatewayv1alpha2.GRPCRoute:
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendRef)
+			}
+		}
+	case *gatewayv1alpha2.TLSRoute:
+		for _, rule := range route.Spec.Rules {
+			backendRefs = append(backendRefs, rule.BackendRefs...)
+		}
+	case *gatewayv1alpha2.TCPRoute:
+		for _, rule := range route.Spec.Rules {
+			backendRefs = append(backendRefs, rule.BackendRefs...)
+		}
+	case *gatewayv1alpha2.UDPRoute:
+		for _, rule := range route.Spec.Rules {
+			backendRefs = append(backendRefs, rule.BackendRefs...)
+		}
+	}
+	return backendRefs
+}
+
+// isHTTPRouteReferringExtension returns true if an ExtensionRef filter of the HTTPRoute refers to the AKO CRD
+// of the given kind and name.
+func isHTTPRouteReferringExtension(httpRoute *gatewayv1.HTTPRoute, kind, name string) bool {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.ExtensionRef != nil &&
+				string(filter.ExtensionRef.Group) == akogatewayapilib.AKOCRDGroup &&
+				string(filter.ExtensionRef.Kind) == kind &&
+				string(filter.ExtensionRef.Name) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGatewayReferringNamespace returns true if any listener of the gateway refers to a secret in the given namespace.
+func isGatewayReferringNamespace(gateway *gatewayv1.Gateway, namespace string) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		for _, certRef := range listener.TLS.CertificateRefs {
+			if certRef.Namespace != nil && string(*certRef.Namespace) == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRouteReferringNamespace returns true if any backend of the route is in the given namespace.
+// isRouteReferringService returns true if a backend of the route in routeNamespace is the Service namespace/name.
+func isRouteReferringService(obj interface{}, routeNamespace, namespace, name string) bool {
+	for _, backendRef := range getRouteBackendRefs(obj) {
+		backendNamespace := routeNamespace
+		if backendRef.Namespace != nil {
+			backendNamespace = string(*backendRef.Namespace)
+		}
+		if backendNamespace == namespace && string(backendRef.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isRouteReferringNamespace(obj interface{}, namespace string) bool {
+	for _, backendRef := range getRouteBackendRefs(obj) {
+		if backendRef.Namespace != nil && string(*backendRef.Namespace) == namespace {
+			return true
+		}
+	}
+	return false
+}
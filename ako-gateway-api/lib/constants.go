@@ -20,17 +20,53 @@ const (
 	CoreGroup         = "v1"
 	GatewayGroup      = "gateway.networking.k8s.io"
 	DefaultPSName     = "default-backend"
+	// DefaultApiServerPort is the port of the API server of the ako-gateway-api container, which serves the prometheus metrics.
+	DefaultApiServerPort = "8081"
 )
 
 const (
 	ZeroAttachedRoutes = 0
 )
 
+const (
+	// RetryAttemptsAnnotation sets the number of retries of a request on the backends of an HTTPRoute
+	RetryAttemptsAnnotation = "retry.ako.vmware.com/attempts"
+	// RetryCodesAnnotation sets the comma separated HTTP response codes on which a request is retried
+	RetryCodesAnnotation = "retry.ako.vmware.com/codes"
+)
+
 const (
 	GatewayClassGatewayControllerIndex = "GatewayClassGatewayController"
 )
 
+const (
+	// ConfigMap is the kind of the CA certificate references of a BackendTLSPolicy
+	ConfigMap = "ConfigMap"
+	// CACertKey is the key of the CA certificate in the ConfigMap referred by a BackendTLSPolicy
+	CACertKey = "ca.crt"
+	// RouteReasonInvalidCACertificateRef is the reason of the ResolvedRefs condition of a route, whose backend
+	// has a BackendTLSPolicy with a missing or invalid CA certificate reference
+	RouteReasonInvalidCACertificateRef = "InvalidCACertificateRef"
+)
+
+const (
+	// AKOCRDGroup is the group of the AKO CRDs referred by the ExtensionRef filters of an HTTPRoute
+	AKOCRDGroup = "ako.vmware.com"
+	// RouteReasonInvalidExtensionRef is the reason of the ResolvedRefs condition of a route
+	// referring to an invalid RouteRuleExtension
+	RouteReasonInvalidExtensionRef = "InvalidExtensionRef"
+)
+
 const (
 	AllowedRoutesNamespaceFromAll  = "All"
 	AllowedRoutesNamespaceFromSame = "Same"
 )
+
+const (
+	// URITokenEndIndex is the end index of the URI token referring to the end of the path
+	URITokenEndIndex = 65535
+	// MaxServerTimeout is the maximum server timeout of a pool in milliseconds
+	MaxServerTimeout = 21600000
+	// MaxRetryTimeout is the maximum timeout of a retry attempt in milliseconds
+	MaxRetryTimeout = 3600000
+)
@@ -19,18 +19,29 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayinformerv1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1"
+	gatewayinformerv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1alpha2"
+	gatewayinformerv1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 type GatewayAPIInformers struct {
-	GatewayInformer      gatewayinformerv1.GatewayInformer
-	GatewayClassInformer gatewayinformerv1.GatewayClassInformer
-	HTTPRouteInformer    gatewayinformerv1.HTTPRouteInformer
+	GatewayInformer          gatewayinformerv1.GatewayInformer
+	GatewayClassInformer     gatewayinformerv1.GatewayClassInformer
+	HTTPRouteInformer        gatewayinformerv1.HTTPRouteInformer
+	GRPCRouteInformer        gatewayinformerv1alpha2.GRPCRouteInformer
+	TLSRouteInformer         gatewayinformerv1alpha2.TLSRouteInformer
+	TCPRouteInformer         gatewayinformerv1alpha2.TCPRouteInformer
+	UDPRouteInformer         gatewayinformerv1alpha2.UDPRouteInformer
+	ReferenceGrantInformer   gatewayinformerv1beta1.ReferenceGrantInformer
+	BackendTLSPolicyInformer gatewayinformerv1alpha2.BackendTLSPolicyInformer
+	// ConfigMapInformer watches the ConfigMaps with the CA certificates referred by the BackendTLSPolicies
+	ConfigMapInformer coreinformers.ConfigMapInformer
 }
 
 // akoControlConfig struct is intended to store all AKO related global
@@ -18,12 +18,18 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -81,6 +87,22 @@ func GetPoolGroupName(parentNs, parentName, routeNs, routeName, matchName string
 	return lib.Encode(name, lib.PG)
 }
 
+// passthrough vs name format - clustername--parentNs-parentName-passthrough
+func GetGatewayPassthroughName(namespace, gwName string) string {
+	return lib.GetNamePrefix() + namespace + "-" + gwName + "-passthrough"
+}
+
+// passthrough PG name format - clustername--parentNs-parentName-hostname, selected by the passthrough datascript using the SNI
+func GetPassthroughPoolGroupName(parentNs, parentName, hostname string) string {
+	return lib.GetPassthroughPGName(hostname, parentNs+"-"+parentName)
+}
+
+// l4 policyset name format - ako-gw-clustername--encoded value of parentNs-parentName-routeNs-routeName-routeType
+func GetL4PolicySetName(parentNs, parentName, routeNs, routeName, routeType string) string {
+	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName + "-" + strings.ToLower(routeType)
+	return lib.Encode(name, lib.L4PS)
+}
+
 func GetHTTPRuleName(parentNs, parentName, routeNs, routeName, matchName string) string {
 	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName + "-" + utils.Stringify(utils.Hash(matchName))
 	return lib.Encode(name, lib.HPPMAP)
@@ -164,15 +186,275 @@ func VerifyHostnameSubdomainMatch(hostname string) bool {
 	return false
 }
 
-func ProtocolToRoute(proto string) string {
-	innerMap := map[string]string{
-		"HTTP":  lib.HTTPRoute,
-		"HTTPS": lib.HTTPRoute,
-		"TCP":   lib.TCPRoute,
-		"TLS":   lib.TLSRoute,
-		"UDP":   lib.UDPRoute,
+// IsRouteKindSupported returns true if routes of the given kind can be attached to a listener of the given protocol.
+func IsRouteKindSupported(protocol, routeKind string) bool {
+	for _, kind := range SupportedKinds[gatewayv1.ProtocolType(protocol)] {
+		if string(kind.Kind) == routeKind {
+			return true
+		}
+	}
+	return false
+}
+
+func IsListenerPassthrough(listener gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.TLSProtocolType &&
+		listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gatewayv1.TLSModePassthrough
+}
+
+// IsReferencePermitted returns true if an object of kind fromKind in fromNamespace is allowed to refer to the core
+// object of kind toKind named toName in toNamespace. References within a namespace are always permitted, while
+// cross namespace references need a ReferenceGrant in toNamespace allowing them.
+func IsReferencePermitted(fromKind, fromNamespace, toKind, toNamespace, toName string) bool {
+	if fromNamespace == toNamespace {
+		return true
+	}
+	referenceGrants, err := AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Lister().ReferenceGrants(toNamespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to retrieve the ReferenceGrants in namespace %s, err: %s", toNamespace, err)
+		return false
+	}
+	for _, referenceGrant := range referenceGrants {
+		if IsReferenceGrantFromMatched(referenceGrant, fromKind, fromNamespace) &&
+			isReferenceGrantToMatched(referenceGrant, toKind, toName) {
+			return true
+		}
 	}
+	return false
+}
 
-	return innerMap[proto]
+// IsReferenceGrantFromMatched returns true if the ReferenceGrant trusts the objects of kind fromKind in fromNamespace.
+func IsReferenceGrantFromMatched(referenceGrant *gatewayv1beta1.ReferenceGrant, fromKind, fromNamespace string) bool {
+	for _, from := range referenceGrant.Spec.From {
+		if string(from.Group) == gatewayv1.GroupName &&
+			string(from.Kind) == fromKind &&
+			string(from.Namespace) == fromNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+func isReferenceGrantToMatched(referenceGrant *gatewayv1beta1.ReferenceGrant, toKind, toName string) bool {
+	for _, to := range referenceGrant.Spec.To {
+		// only the core group objects, services and secrets, are referred from the gateway and routes.
+		if string(to.Group) == "" &&
+			string(to.Kind) == toKind &&
+			(to.Name == nil || string(*to.Name) == toName) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsResourceServed returns true if the API server serves the resource in groupVersion. The informers of the
+// experimental channel Gateway API CRDs, and of the AKO CRDs which are not upgraded along with the helm release,
+// are started only when their CRDs are installed, as their caches would never sync otherwise.
+func IsResourceServed(discoveryClient discovery.DiscoveryInterface, groupVersion, resource string) bool {
+	resourceList, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utils.AviLog.Warnf("%s is not served by the API server, %s are not processed", groupVersion, resource)
+		} else {
+			utils.AviLog.Errorf("Unable to discover the resources in %s, %s are not processed, err: %v", groupVersion, resource, err)
+		}
+		return false
+	}
+	for _, apiResource := range resourceList.APIResources {
+		if apiResource.Name == resource {
+			return true
+		}
+	}
+	utils.AviLog.Warnf("%s in %s is not served by the API server, the CRD is not installed", resource, groupVersion)
+	return false
+}
+
+// GetBackendTLSPolicy returns the BackendTLSPolicy attached to the port portName of the Service svcName in namespace.
+// A policy targeting the port takes precedence over a policy targeting the whole Service, and amongst the
+// conflicting policies, the oldest one is used.
+func GetBackendTLSPolicy(namespace, svcName, portName string) *gatewayv1alpha2.BackendTLSPolicy {
+	if AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer == nil {
+		return nil
+	}
+	backendTLSPolicies, err := AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to retrieve the BackendTLSPolicies in namespace %s, err: %s", namespace, err)
+		return nil
+	}
+	var attachedPolicy *gatewayv1alpha2.BackendTLSPolicy
+	for _, backendTLSPolicy := range backendTLSPolicies {
+		targetRef := backendTLSPolicy.Spec.TargetRef
+		if string(targetRef.Group) != "" || string(targetRef.Kind) != utils.Service || string(targetRef.Name) != svcName {
+			continue
+		}
+		if targetRef.Namespace != nil && string(*targetRef.Namespace) != namespace {
+			continue
+		}
+		if targetRef.SectionName != nil && string(*targetRef.SectionName) != portName {
+			continue
+		}
+		if attachedPolicy == nil || isBackendTLSPolicyPreferred(backendTLSPolicy, attachedPolicy) {
+			attachedPolicy = backendTLSPolicy
+		}
+	}
+	return attachedPolicy
+}
 
+// GetBackendTLSPolicyCACert returns the CA certificate referred by a BackendTLSPolicy. When the certificate can not
+// be retrieved, the reason for the ResolvedRefs condition of the routes of its Service is returned with the error.
+func GetBackendTLSPolicyCACert(backendTLSPolicy *gatewayv1alpha2.BackendTLSPolicy) (string, string, error) {
+	tlsConfig := backendTLSPolicy.Spec.TLS
+	if len(tlsConfig.CACertRefs) == 0 {
+		return "", RouteReasonInvalidCACertificateRef, fmt.Errorf("BackendTLSPolicy %s/%s without a CA certificate reference is not supported", backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	}
+	caCertRef := tlsConfig.CACertRefs[0]
+	if string(caCertRef.Group) != "" || string(caCertRef.Kind) != ConfigMap {
+		return "", string(gatewayv1.RouteReasonInvalidKind), fmt.Errorf("CA certificate reference %s/%s of BackendTLSPolicy %s/%s is not supported, must be a ConfigMap", caCertRef.Group, caCertRef.Kind, backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	}
+	cmObj, err := AKOControlConfig().GatewayApiInformers().ConfigMapInformer.Lister().ConfigMaps(backendTLSPolicy.Namespace).Get(string(caCertRef.Name))
+	if err != nil {
+		return "", RouteReasonInvalidCACertificateRef, fmt.Errorf("CA certificate ConfigMap %s/%s of BackendTLSPolicy %s/%s not found", backendTLSPolicy.Namespace, caCertRef.Name, backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	}
+	caCert, ok := cmObj.Data[CACertKey]
+	if !ok || caCert == "" {
+		return "", RouteReasonInvalidCACertificateRef, fmt.Errorf("CA certificate not found in ConfigMap %s/%s of BackendTLSPolicy %s/%s", backendTLSPolicy.Namespace, caCertRef.Name, backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	}
+	return caCert, "", nil
+}
+
+func isBackendTLSPolicyPreferred(policy, attachedPolicy *gatewayv1alpha2.BackendTLSPolicy) bool {
+	policySectionSet := policy.Spec.TargetRef.SectionName != nil
+	attachedPolicySectionSet := attachedPolicy.Spec.TargetRef.SectionName != nil
+	if policySectionSet != attachedPolicySectionSet {
+		return policySectionSet
+	}
+	if !policy.CreationTimestamp.Equal(&attachedPolicy.CreationTimestamp) {
+		return policy.CreationTimestamp.Before(&attachedPolicy.CreationTimestamp)
+	}
+	return policy.Name < attachedPolicy.Name
+}
+
+// GetL7Rule returns the L7Rule name in namespace, referred by an ExtensionRef filter of an HTTPRoute.
+func GetL7Rule(namespace, name string) (*akov1alpha2.L7Rule, error) {
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	if crdInformers == nil || crdInformers.L7RuleInformer == nil {
+		return nil, fmt.Errorf("L7Rule informer is not initialized")
+	}
+	return crdInformers.L7RuleInformer.Lister().L7Rules(namespace).Get(name)
+}
+
+// GetRouteRuleExtension returns the RouteRuleExtension name in namespace, referred by an ExtensionRef filter of an HTTPRoute.
+func GetRouteRuleExtension(namespace, name string) (*akov1alpha2.RouteRuleExtension, error) {
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	if crdInformers == nil || crdInformers.RouteRuleExtensionInformer == nil {
+		return nil, fmt.Errorf("RouteRuleExtension informer is not initialized")
+	}
+	return crdInformers.RouteRuleExtensionInformer.Lister().RouteRuleExtensions(namespace).Get(name)
+}
+
+// GetGRPCRoute returns the GRPCRoute name in namespace, which is reported as not found when the CRD is not served.
+func GetGRPCRoute(namespace, name string) (*gatewayv1alpha2.GRPCRoute, error) {
+	informer := AKOControlConfig().GatewayApiInformers().GRPCRouteInformer
+	if informer == nil {
+		return nil, errors.NewNotFound(gatewayv1alpha2.Resource("grpcroutes"), name)
+	}
+	return informer.Lister().GRPCRoutes(namespace).Get(name)
+}
+
+// GetTLSRoute returns the TLSRoute name in namespace, which is reported as not found when the CRD is not served.
+func GetTLSRoute(namespace, name string) (*gatewayv1alpha2.TLSRoute, error) {
+	informer := AKOControlConfig().GatewayApiInformers().TLSRouteInformer
+	if informer == nil {
+		return nil, errors.NewNotFound(gatewayv1alpha2.Resource("tlsroutes"), name)
+	}
+	return informer.Lister().TLSRoutes(namespace).Get(name)
+}
+
+// GetTCPRoute returns the TCPRoute name in namespace, which is reported as not found when the CRD is not served.
+func GetTCPRoute(namespace, name string) (*gatewayv1alpha2.TCPRoute, error) {
+	informer := AKOControlConfig().GatewayApiInformers().TCPRouteInformer
+	if informer == nil {
+		return nil, errors.NewNotFound(gatewayv1alpha2.Resource("tcproutes"), name)
+	}
+	return informer.Lister().TCPRoutes(namespace).Get(name)
+}
+
+// GetUDPRoute returns the UDPRoute name in namespace, which is reported as not found when the CRD is not served.
+func GetUDPRoute(namespace, name string) (*gatewayv1alpha2.UDPRoute, error) {
+	informer := AKOControlConfig().GatewayApiInformers().UDPRouteInformer
+	if informer == nil {
+		return nil, errors.NewNotFound(gatewayv1alpha2.Resource("udproutes"), name)
+	}
+	return informer.Lister().UDPRoutes(namespace).Get(name)
+}
+
+// ValidateRouteRuleExtension checks the load balancer policy of the RouteRuleExtension. The hash is allowed only with
+// the consistent hash algorithm, and the header only with the custom header hash.
+func ValidateRouteRuleExtension(routeRuleExtension *akov1alpha2.RouteRuleExtension) error {
+	lbPolicy := routeRuleExtension.Spec.LoadBalancerPolicy
+	if lbPolicy.Algorithm == lib.LB_ALGORITHM_CONSISTENT_HASH {
+		if lbPolicy.Hash == "" {
+			return fmt.Errorf("hash must be specified when algorithm is %s", lib.LB_ALGORITHM_CONSISTENT_HASH)
+		}
+	} else if lbPolicy.Hash != "" {
+		return fmt.Errorf("hash must not be specified when algorithm is not %s", lib.LB_ALGORITHM_CONSISTENT_HASH)
+	}
+	if lbPolicy.Hash == lib.LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER {
+		if lbPolicy.HostHeader == "" {
+			return fmt.Errorf("hostHeader must be specified when hash is %s", lib.LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER)
+		}
+	} else if lbPolicy.HostHeader != "" {
+		return fmt.Errorf("hostHeader must not be specified when hash is not %s", lib.LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER)
+	}
+	return nil
+}
+
+// ValidateExtensionRef checks the object referred by an ExtensionRef filter of an HTTPRoute in namespace, which must be
+// an L7Rule or a valid RouteRuleExtension. When the reference can't be resolved, the reason of the ResolvedRefs
+// condition of the route is returned along with the error.
+func ValidateExtensionRef(namespace string, extensionRef *gatewayv1.LocalObjectReference) (string, error) {
+	name := string(extensionRef.Name)
+	if string(extensionRef.Group) != AKOCRDGroup {
+		return string(gatewayv1.RouteReasonInvalidKind), fmt.Errorf("ExtensionRef %s of group %s is not supported", name, extensionRef.Group)
+	}
+	switch string(extensionRef.Kind) {
+	case lib.L7Rule:
+		if _, err := GetL7Rule(namespace, name); err != nil {
+			return string(gatewayv1.RouteReasonBackendNotFound), fmt.Errorf("L7Rule %s/%s not found", namespace, name)
+		}
+	case lib.RouteRuleExtension:
+		routeRuleExtension, err := GetRouteRuleExtension(namespace, name)
+		if err != nil {
+			return string(gatewayv1.RouteReasonBackendNotFound), fmt.Errorf("RouteRuleExtension %s/%s not found", namespace, name)
+		}
+		if err := ValidateRouteRuleExtension(routeRuleExtension); err != nil {
+			return RouteReasonInvalidExtensionRef, fmt.Errorf("RouteRuleExtension %s/%s is not valid, %v", namespace, name, err)
+		}
+	default:
+		return string(gatewayv1.RouteReasonInvalidKind), fmt.Errorf("ExtensionRef %s of kind %s is not supported", name, extensionRef.Kind)
+	}
+	return "", nil
+}
+
+// ValidateHTTPRouteRuleFilters checks that the filters of an HTTPRoute rule can be translated. The RequestMirror
+// filter is not supported, as the traffic of a virtual service can't be cloned to the endpoints of a Service.
+func ValidateHTTPRouteRuleFilters(rule *gatewayv1.HTTPRouteRule) error {
+	for _, filter := range rule.Filters {
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			gatewayv1.HTTPRouteFilterRequestRedirect,
+			gatewayv1.HTTPRouteFilterURLRewrite,
+			gatewayv1.HTTPRouteFilterExtensionRef:
+		case gatewayv1.HTTPRouteFilterRequestMirror:
+			return fmt.Errorf("Filter RequestMirror is not supported, the requests can not be mirrored to a backend")
+		default:
+			return fmt.Errorf("Filter %s is not supported", filter.Type)
+		}
+	}
+	for _, backendRef := range rule.BackendRefs {
+		if len(backendRef.Filters) != 0 {
+			return fmt.Errorf("Filters on the backend %s are not supported", backendRef.Name)
+		}
+	}
+	return nil
 }
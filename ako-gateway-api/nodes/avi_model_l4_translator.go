@@ -15,19 +15,177 @@
 package nodes
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/vmware/alb-sdk/go/models"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 func (o *AviObjectGraph) ProcessL4Routes(key string, routeModel RouteModel, parentNsName string) {
+	parentNode := o.GetAviEvhVS()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	gwRouteNsName := parentNsName + "/" + routeTypeNsName
+
+	// remove the avi objects created previously for this route
+	o.deleteL4RouteObjects(key, parentNode[0], routeModel, parentNsName)
+
+	// TCP/UDP traffic can only be matched on the listener port, hence the rule of the route (routes with
+	// more rules are rejected during validation) is translated to a L4 policyset, with the port of each
+	// listener the route is attached to.
 	for _, rule := range routeModel.ParseRouteConfig().Rules {
-		parentNode := o.GetAviEvhVS()
-		// create L4 policyset per rule
-		o.BuildL4PolicySet(key, parentNode[0], routeModel, rule)
-		// Logic to override the app profile per port also must be taken care here.
+		if len(rule.Backends) == 0 {
+			continue
+		}
+		o.BuildL4PolicySet(key, parentNode[0], routeModel, parentNsName, rule)
+	}
+	utils.AviLog.Infof("key: %s, msg: processing of l4 route %s attached to gateway %s completed", key, gwRouteNsName, parentNsName)
+}
+
+func (o *AviObjectGraph) BuildL4PolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, parentNsName string, rule *Rule) {
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	gwRouteNsName := parentNsName + "/" + routeTypeNsName
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+
+	protocol := utils.TCP
+	if routeModel.GetType() == lib.UDPRoute {
+		protocol = utils.UDP
+	}
+	var ports []int32
+	for _, listener := range akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName) {
+		if listener.Gateway == parentNsName && listener.Protocol == protocol && !utils.HasElem(ports, listener.Port) {
+			ports = append(ports, listener.Port)
+		}
+	}
+	if len(ports) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: No matching listener available for the route : %s", key, routeTypeNsName)
+		return
+	}
+
+	l4PGPool := akogatewayapiobjects.HTTPPSPGPool{
+		HTTPPS:    make([]string, 0),
+		PoolGroup: make([]string, 0),
+		Pool:      make([]string, 0),
 	}
+	PG := &nodes.AviPoolGroupNode{
+		Name:   akogatewayapilib.GetPoolGroupName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), ""),
+		Tenant: lib.GetTenant(),
+	}
+	for _, backend := range rule.Backends {
+		poolName := akogatewayapilib.GetPoolName(parentNs, parentName,
+			routeModel.GetNamespace(), routeModel.GetName(), "",
+			backend.Backend.Namespace, backend.Backend.Name, strconv.Itoa(int(backend.Backend.Port)))
+		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Backend.Namespace).Get(backend.Backend.Name)
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: there was an error in retrieving the service", key)
+			continue
+		}
+		poolNode := &nodes.AviPoolNode{
+			Name:       poolName,
+			Tenant:     lib.GetTenant(),
+			Protocol:   protocol,
+			PortName:   akogatewayapilib.FindPortName(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+			TargetPort: akogatewayapilib.FindTargetPort(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+			Port:       backend.Backend.Port,
+			ServiceMetadata: lib.ServiceMetadataObj{
+				NamespaceServiceName: []string{backend.Backend.Namespace + "/" + backend.Backend.Name},
+			},
+			VrfContext: lib.GetVrf(),
+		}
+		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
+		serviceType := lib.GetServiceType()
+		if serviceType == lib.NodePortLocal {
+			servers := nodes.PopulateServersForNPL(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+			if servers != nil {
+				poolNode.Servers = servers
+			}
+		} else if serviceType == lib.NodePort {
+			servers := nodes.PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+			if servers != nil {
+				poolNode.Servers = servers
+			}
+		} else {
+			servers := nodes.PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+			if servers != nil {
+				poolNode.Servers = servers
+			}
+		}
+		poolNode.CalculateCheckSum()
+		vsNode.PoolRefs = append(vsNode.PoolRefs, poolNode)
+		l4PGPool.Pool = append(l4PGPool.Pool, poolNode.Name)
+
+		poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		ratio := uint32(backend.Backend.Weight)
+		PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+	}
+	if len(PG.Members) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no valid backends found for the route %s", key, routeTypeNsName)
+		return
+	}
+	PG.CalculateCheckSum()
+	vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, PG)
+	l4PGPool.PoolGroup = append(l4PGPool.PoolGroup, PG.Name)
+
+	l4PolicyName := akogatewayapilib.GetL4PolicySetName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), routeModel.GetType())
+	l4PolicyNode := &nodes.AviL4PolicyNode{
+		Name:   l4PolicyName,
+		Tenant: lib.GetTenant(),
+	}
+	pgRef := fmt.Sprintf("/api/poolgroup?name=%s", PG.Name)
+	for _, port := range ports {
+		l4PolicyNode.PortPool = append(l4PolicyNode.PortPool, nodes.AviHostPathPortPoolPG{
+			Name:      l4PolicyName + "-" + strconv.Itoa(int(port)),
+			Port:      uint32(port),
+			Protocol:  protocol,
+			PoolGroup: pgRef,
+		})
+	}
+	l4PolicyNode.CalculateCheckSum()
+	vsNode.L4PolicyRefs = append(vsNode.L4PolicyRefs, l4PolicyNode)
+
+	l4PGPool.Pool = sets.NewString(l4PGPool.Pool...).List()
+	akogatewayapiobjects.GatewayApiLister().UpdateGatewayRouteToHTTPPSPGPool(gwRouteNsName, l4PGPool)
+	utils.AviLog.Infof("key: %s, msg: evaluated L4 policyset %s for the route %s", key, l4PolicyName, routeTypeNsName)
 }
 
-func (o *AviObjectGraph) BuildL4PolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+// deleteL4RouteObjects removes the l4 policyset, poolgroups and pools of a TCP/UDP route from the parent VS.
+func (o *AviObjectGraph) deleteL4RouteObjects(key string, parentNode *nodes.AviEvhVsNode, routeModel RouteModel, parentNsName string) {
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	l4PolicyName := akogatewayapilib.GetL4PolicySetName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), routeModel.GetType())
+	for i, l4Policy := range parentNode.L4PolicyRefs {
+		if l4Policy.Name == l4PolicyName {
+			parentNode.L4PolicyRefs = append(parentNode.L4PolicyRefs[:i], parentNode.L4PolicyRefs[i+1:]...)
+			break
+		}
+	}
 
-	// TODO: add the l4policset code here
+	gwRouteNsName := parentNsName + "/" + routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	found, l4PGPool := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHTTPSPGPool(gwRouteNsName)
+	if !found {
+		return
+	}
+	for _, pgName := range l4PGPool.PoolGroup {
+		for i, pg := range parentNode.PoolGroupRefs {
+			if pg.Name == pgName {
+				parentNode.PoolGroupRefs = append(parentNode.PoolGroupRefs[:i], parentNode.PoolGroupRefs[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, poolName := range l4PGPool.Pool {
+		for i, pool := range parentNode.PoolRefs {
+			if pool.Name == poolName {
+				parentNode.PoolRefs = append(parentNode.PoolRefs[:i], parentNode.PoolRefs[i+1:]...)
+				break
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: removed l4 objects %s from vs %s", key, utils.Stringify(l4PGPool), parentNode.Name)
+	akogatewayapiobjects.GatewayApiLister().DeleteGatewayRouteToHTTPSPGPool(gwRouteNsName)
 }
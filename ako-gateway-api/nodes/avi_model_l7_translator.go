@@ -16,6 +16,7 @@ package nodes
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/vmware/alb-sdk/go/models"
 	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -92,7 +94,7 @@ func (o *AviObjectGraph) ProcessL7Routes(key string, routeModel RouteModel, pare
 func (o *AviObjectGraph) BuildParentPGPoolHTTPPS(key string, routeModel RouteModel, parentNsName string, rules []*Rule, childVSes map[string]struct{}, fullsync bool) {
 
 	parentNode := o.GetAviEvhVS()
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 	// For each GW + httproute, one HTTPPSPGPool
 	var locaHTTTPPSPGPool objects.HTTPPSPGPool
@@ -125,13 +127,14 @@ func (o *AviObjectGraph) BuildParentPGPoolHTTPPS(key string, routeModel RouteMod
 		}
 
 	}
+	pruneQueryStringGroups(parentNode[0])
 	objects.GatewayApiLister().UpdateGatewayRouteToHTTPPSPGPool(gwHTTPRouteKey, locaHTTTPPSPGPool)
 }
 func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentNsName string, rule *Rule, childVSes map[string]struct{}, fullsync bool) {
 
 	parentNode := o.GetAviEvhVS()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 
 	gwRouteNsName := fmt.Sprintf("%s/%s", parentNsName, routeTypeNsName)
 	found, hosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
@@ -181,6 +184,9 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 	// create the httppolicyset if the filter is present
 	o.BuildHTTPPolicySet(key, childNode, routeModel, rule, 0, childVSName, &objects.HTTPPSPGPool{})
 
+	// apply the AKO CRDs referred by the extension ref filters
+	setChildVSExtensionRefs(key, routeModel.GetNamespace(), childNode, rule)
+
 	foundEvhModel := nodes.FindAndReplaceEvhInModel(childNode, parentNode, key)
 	if !foundEvhModel {
 		parentNode[0].EvhNodes = append(parentNode[0].EvhNodes, childNode)
@@ -222,7 +228,7 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 	childVsNode.DefaultPoolGroup = ""
 	childVsNode.PoolRefs = nil
 	// create the PG from backends
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 	allListeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName)
 	listeners := []akogatewayapiobjects.GatewayListenerStore{}
@@ -267,6 +273,14 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 			},
 			VrfContext: lib.GetVrf(),
 		}
+		// gRPC is served over HTTP/2, hence the backends of a GRPCRoute are reached over HTTP/2.
+		poolNode.EnableHTTP2 = routeModel.GetType() == lib.GRPCRoute
+		setPoolTimeoutAndRetry(poolNode, rule)
+		if !setPoolBackendTLS(key, poolNode, httpbackend.Backend) {
+			o.RemovePoolRefsFromPG(poolName, o.GetPoolGroupByName(PGName))
+			continue
+		}
+		setPoolExtensionRefs(key, routeModel.GetNamespace(), poolNode, rule)
 		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
@@ -300,13 +314,159 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 	}
 }
 
+// setPoolTimeoutAndRetry maps the timeouts and the retry policy of the rule to the
+// server timeout and the server reselect settings of the pool.
+func setPoolTimeoutAndRetry(poolNode *nodes.AviPoolNode, rule *Rule) {
+	var backendTimeout *uint32
+	if rule.Timeouts != nil {
+		backendTimeout = rule.Timeouts.BackendRequest
+		if backendTimeout == nil {
+			backendTimeout = rule.Timeouts.Request
+		}
+	}
+	if backendTimeout != nil {
+		// A zero timeout disables the timeout, whereas a zero server timeout on the pool
+		// falls back to the default, hence the maximum is used instead.
+		poolNode.ServerTimeout = *backendTimeout
+		if poolNode.ServerTimeout == 0 {
+			poolNode.ServerTimeout = akogatewayapilib.MaxServerTimeout
+		}
+	}
+
+	if rule.RetryPolicy == nil {
+		return
+	}
+	serverReselect := &models.HttpserverReselect{
+		Enabled:    proto.Bool(true),
+		NumRetries: proto.Uint32(rule.RetryPolicy.Attempts),
+		SvrRespCode: &models.HTTPReselectRespCode{
+			Codes: rule.RetryPolicy.Codes,
+		},
+	}
+	if len(rule.RetryPolicy.Codes) == 0 {
+		serverReselect.SvrRespCode.RespCodeBlock = []string{"HTTP_RSP_5XX"}
+	}
+	if rule.Timeouts != nil && rule.Timeouts.BackendRequest != nil && *rule.Timeouts.BackendRequest != 0 {
+		retryTimeout := min(*rule.Timeouts.BackendRequest, akogatewayapilib.MaxRetryTimeout)
+		serverReselect.RetryTimeout = &retryTimeout
+	}
+	poolNode.ServerReselect = serverReselect
+}
+
+// setPoolBackendTLS re-encrypts the traffic from the pool to the backend Service as per the BackendTLSPolicy
+// attached to it. The hostname of the policy is used as the SNI and is validated against the certificate of
+// the backend, which is verified by the PKI profile built from the CA certificate referred by the policy.
+// It returns false if the policy can not be applied, in which case the pool must not be programmed, so that
+// the traffic is not sent to the backend in plain text.
+func setPoolBackendTLS(key string, poolNode *nodes.AviPoolNode, backend *Backend) bool {
+	backendTLSPolicy := akogatewayapilib.GetBackendTLSPolicy(backend.Namespace, backend.Name, poolNode.PortName)
+	if backendTLSPolicy == nil {
+		return true
+	}
+	tlsConfig := backendTLSPolicy.Spec.TLS
+	if len(tlsConfig.CACertRefs) > 1 {
+		utils.AviLog.Warnf("key: %s, msg: only the first CA certificate reference of BackendTLSPolicy %s/%s is used", key, backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	}
+	caCert, _, err := akogatewayapilib.GetBackendTLSPolicyCACert(backendTLSPolicy)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: pool %s is not programmed, err: %v", key, poolNode.Name, err)
+		return false
+	}
+
+	poolNode.SniEnabled = true
+	poolNode.SslProfileRef = proto.String(fmt.Sprintf("/api/sslprofile?name=%s", lib.DefaultPoolSSLProfile))
+	poolNode.ServerName = string(tlsConfig.Hostname)
+	poolNode.PkiProfile = &nodes.AviPkiProfileNode{
+		Name:   lib.GetPoolPKIProfileName(poolNode.Name),
+		Tenant: poolNode.Tenant,
+		CACert: caCert,
+	}
+	utils.AviLog.Infof("key: %s, msg: added pki profile %s for pool %s as per BackendTLSPolicy %s/%s", key, poolNode.PkiProfile.Name, poolNode.Name, backendTLSPolicy.Namespace, backendTLSPolicy.Name)
+	return true
+}
+
+// setChildVSExtensionRefs applies the L7Rule and the WAF policy and datascripts of the RouteRuleExtensions, referred
+// by the ExtensionRef filters of the rule, on the child VS. The settings are reset when no such filter is present,
+// as the child VS node is retained across the updates of the route.
+func setChildVSExtensionRefs(key, namespace string, childNode *nodes.AviEvhVsNode, rule *Rule) {
+	var wafPolicyRef *string
+	var datascriptRefs []string
+	var l7RuleName string
+	for _, extensionRef := range rule.ExtensionRefs {
+		switch extensionRef.Kind {
+		case lib.L7Rule:
+			// only the first L7Rule is applied
+			if l7RuleName == "" {
+				l7RuleName = extensionRef.Name
+			}
+		case lib.RouteRuleExtension:
+			routeRuleExtension, err := akogatewayapilib.GetRouteRuleExtension(namespace, extensionRef.Name)
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to retrieve the RouteRuleExtension %s/%s, err: %s", key, namespace, extensionRef.Name, err)
+				continue
+			}
+			if routeRuleExtension.Spec.WAFPolicy != "" {
+				wafPolicyRef = proto.String(fmt.Sprintf("/api/wafpolicy?name=%s", routeRuleExtension.Spec.WAFPolicy))
+			}
+			for _, script := range routeRuleExtension.Spec.Datascripts {
+				if !utils.HasElem(datascriptRefs, fmt.Sprintf("/api/vsdatascriptset?name=%s", script)) {
+					datascriptRefs = append(datascriptRefs, fmt.Sprintf("/api/vsdatascriptset?name=%s", script))
+				}
+			}
+		}
+	}
+	childNode.SetWafPolicyRef(wafPolicyRef)
+	childNode.SetVsDatascriptRefs(datascriptRefs)
+	if l7RuleName != "" {
+		nodes.BuildL7Rule(childNode.Name, key, l7RuleName, namespace, childNode)
+	} else {
+		childNode.GetGeneratedFields().ConvertL7RuleFieldsToNil()
+	}
+}
+
+// setPoolExtensionRefs applies the health monitors, the application persistence profile and the load balancer policy
+// of the RouteRuleExtensions, referred by the ExtensionRef filters of the rule, on the pool.
+func setPoolExtensionRefs(key, namespace string, poolNode *nodes.AviPoolNode, rule *Rule) {
+	for _, extensionRef := range rule.ExtensionRefs {
+		if extensionRef.Kind != lib.RouteRuleExtension {
+			continue
+		}
+		routeRuleExtension, err := akogatewayapilib.GetRouteRuleExtension(namespace, extensionRef.Name)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: unable to retrieve the RouteRuleExtension %s/%s, err: %s", key, namespace, extensionRef.Name, err)
+			continue
+		}
+		for _, hm := range routeRuleExtension.Spec.HealthMonitors {
+			if !utils.HasElem(poolNode.HealthMonitorRefs, fmt.Sprintf("/api/healthmonitor?name=%s", hm)) {
+				poolNode.HealthMonitorRefs = append(poolNode.HealthMonitorRefs, fmt.Sprintf("/api/healthmonitor?name=%s", hm))
+			}
+		}
+		if routeRuleExtension.Spec.ApplicationPersistence != "" {
+			poolNode.ApplicationPersistenceProfileRef = proto.String(fmt.Sprintf("/api/applicationpersistenceprofile?name=%s", routeRuleExtension.Spec.ApplicationPersistence))
+		}
+		lbPolicy := routeRuleExtension.Spec.LoadBalancerPolicy
+		if lbPolicy.Algorithm != "" {
+			poolNode.LbAlgorithm = proto.String(lbPolicy.Algorithm)
+			poolNode.LbAlgorithmHash = nil
+			poolNode.LbAlgorithmConsistentHashHdr = nil
+			if lbPolicy.Algorithm == lib.LB_ALGORITHM_CONSISTENT_HASH {
+				poolNode.LbAlgorithmHash = proto.String(lbPolicy.Hash)
+				if lbPolicy.Hash == lib.LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER {
+					poolNode.LbAlgorithmConsistentHashHdr = proto.String(lbPolicy.HostHeader)
+				}
+			}
+		}
+		utils.AviLog.Infof("key: %s, msg: attached RouteRuleExtension %s/%s on pool %s", key, namespace, extensionRef.Name, poolNode.Name)
+	}
+}
+
 func (o *AviObjectGraph) BuildDefaultPGPoolForParentVS(key, parentNsName, matchName string, parentVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, httpPSPGPool *objects.HTTPPSPGPool) bool {
 	// create the PG from backends
 	pgAttachedToVS := false
 	httpRouteNamespace := routeModel.GetNamespace()
 	httpRouteName := routeModel.GetName()
 
-	routeTypeNsName := lib.HTTPRoute + "/" + httpRouteNamespace + "/" + httpRouteName
+	routeTypeNsName := routeModel.GetType() + "/" + httpRouteNamespace + "/" + httpRouteName
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 
 	PGName := akogatewayapilib.GetPoolGroupName(parentNs, parentName,
@@ -356,6 +516,14 @@ func (o *AviObjectGraph) BuildDefaultPGPoolForParentVS(key, parentNsName, matchN
 			},
 			VrfContext: lib.GetVrf(),
 		}
+		// gRPC is served over HTTP/2, hence the backends of a GRPCRoute are reached over HTTP/2.
+		poolNode.EnableHTTP2 = routeModel.GetType() == lib.GRPCRoute
+		setPoolTimeoutAndRetry(poolNode, rule)
+		if !setPoolBackendTLS(key, poolNode, backend.Backend) {
+			o.RemovePoolRefsFromPG(poolName, o.GetPoolGroupByName(PGName))
+			continue
+		}
+		setPoolExtensionRefs(key, routeModel.GetNamespace(), poolNode, rule)
 		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePort {
@@ -397,6 +565,8 @@ func (o *AviObjectGraph) BuildDefaultPGPoolForParentVS(key, parentNsName, matchN
 
 func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeTypeNsName string, vsNode *nodes.AviEvhVsNode, rule *Rule, hosts []string) {
 	var vhMatches []*models.VHMatch
+	// the string groups of the child vs are only referred by the query matches of the vh matches
+	vsNode.StringGroupRefs = nil
 
 	allListeners := objects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName)
 	listeners := []akogatewayapiobjects.GatewayListenerStore{}
@@ -429,6 +599,8 @@ func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeType
 					rule.Matches.Path.MatchCriteria = proto.String("EQUALS")
 				} else if match.PathMatch.Type == "PathPrefix" {
 					rule.Matches.Path.MatchCriteria = proto.String("BEGINS_WITH")
+				} else if match.PathMatch.Type == "PathSuffix" {
+					rule.Matches.Path.MatchCriteria = proto.String("ENDS_WITH")
 				}
 			}
 
@@ -445,6 +617,10 @@ func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeType
 				rule.Matches.Hdrs = append(rule.Matches.Hdrs, hdrMatch)
 			}
 
+			// query param and method match
+			rule.Matches.Query = buildQueryMatch(vsNode, match.QueryParamMatch)
+			rule.Matches.Method = buildMethodMatch(match.Method)
+
 			//port match from listener
 			matchCriteria := "IS_IN"
 			rule.Matches.VsPort = &models.PortMatch{
@@ -465,6 +641,85 @@ func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeType
 	utils.AviLog.Infof("key: %s, msg: Attached match criteria to vs %s", key, vsNode.Name)
 }
 
+// buildQueryMatch matches the query params exactly, with a regex on the query of the request, which is
+// added to the vs as a string group. The query params of a match are ANDed.
+func buildQueryMatch(vsNode *nodes.AviEvhVsNode, queryParamMatches []*QueryParamMatch) *models.QueryMatch {
+	if len(queryParamMatches) == 0 {
+		return nil
+	}
+	regex := getQueryParamRegex(queryParamMatches)
+	stringGroupName := lib.GetEncodedStringGroupName(vsNode.Name, regex)
+	found := false
+	for _, stringGroup := range vsNode.StringGroupRefs {
+		if *stringGroup.Name == stringGroupName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		tenant := vsNode.Tenant
+		stringGroupNode := &nodes.AviStringGroupNode{
+			StringGroup: &models.StringGroup{
+				TenantRef:    &tenant,
+				Type:         proto.String("SG_TYPE_KEYVAL"),
+				LongestMatch: proto.Bool(true),
+				Name:         &stringGroupName,
+				Kv:           []*models.KeyValue{{Key: &regex}},
+			},
+		}
+		stringGroupNode.CalculateCheckSum()
+		vsNode.StringGroupRefs = append(vsNode.StringGroupRefs, stringGroupNode)
+	}
+	return &models.QueryMatch{
+		MatchCase:       proto.String("SENSITIVE"),
+		MatchCriteria:   proto.String("QUERY_MATCH_REGEX_MATCH"),
+		StringGroupRefs: []string{"/api/stringgroup?name=" + stringGroupName},
+	}
+}
+
+// getQueryParamRegex returns the regex which matches the name=value pair of a query param anywhere in the
+// query. Multiple query params are ANDed with a lookahead for each of them.
+func getQueryParamRegex(queryParamMatches []*QueryParamMatch) string {
+	if len(queryParamMatches) == 1 {
+		return fmt.Sprintf("(^|&)%s=%s(&|$)", regexp.QuoteMeta(queryParamMatches[0].Name), regexp.QuoteMeta(queryParamMatches[0].Value))
+	}
+	regex := "^"
+	for _, queryParamMatch := range queryParamMatches {
+		regex += fmt.Sprintf("(?=.*(^|&)%s=%s(&|$))", regexp.QuoteMeta(queryParamMatch.Name), regexp.QuoteMeta(queryParamMatch.Value))
+	}
+	return regex
+}
+
+// pruneQueryStringGroups removes the string groups of the vs, which are no longer referred by the query
+// matches of its http policysets.
+func pruneQueryStringGroups(vsNode *nodes.AviEvhVsNode) {
+	stringGroupRefs := sets.NewString()
+	for _, httpPolicy := range vsNode.HttpPolicyRefs {
+		for _, requestRule := range httpPolicy.RequestRules {
+			if requestRule.Match != nil && requestRule.Match.Query != nil {
+				stringGroupRefs.Insert(requestRule.Match.Query.StringGroupRefs...)
+			}
+		}
+	}
+	var stringGroups []*nodes.AviStringGroupNode
+	for _, stringGroup := range vsNode.StringGroupRefs {
+		if stringGroupRefs.Has("/api/stringgroup?name=" + *stringGroup.Name) {
+			stringGroups = append(stringGroups, stringGroup)
+		}
+	}
+	vsNode.StringGroupRefs = stringGroups
+}
+
+func buildMethodMatch(method string) *models.MethodMatch {
+	if method == "" {
+		return nil
+	}
+	return &models.MethodMatch{
+		MatchCriteria: proto.String("IS_IN"),
+		Methods:       []string{"HTTP_METHOD_" + method},
+	}
+}
+
 func (o *AviObjectGraph) BuildParentHTTPPS(key, parentNsName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, index int, httpPSName string, httpPSPGPool *objects.HTTPPSPGPool) {
 	var policy *nodes.AviHttpPolicySetNode
 	var req_rule *models.HTTPRequestRule
@@ -504,7 +759,7 @@ func (o *AviObjectGraph) BuildParentHTTPPS(key, parentNsName string, vsNode *nod
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 
 	// Code to retrieve ports associated with given httproute name
-	routeTypeNsName := fmt.Sprintf("%s/%s/%s", lib.HTTPRoute, httpRouteNamespace, httpRouteName)
+	routeTypeNsName := fmt.Sprintf("%s/%s/%s", routeModel.GetType(), httpRouteNamespace, httpRouteName)
 	allListeners := objects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName)
 	listeners := []akogatewayapiobjects.GatewayListenerStore{}
 	for _, listener := range allListeners {
@@ -526,6 +781,8 @@ func (o *AviObjectGraph) BuildParentHTTPPS(key, parentNsName string, vsNode *nod
 				matchCriteria = "EQUALS"
 			} else if match.PathMatch.Type == "PathPrefix" {
 				matchCriteria = "BEGINS_WITH"
+			} else if match.PathMatch.Type == "PathSuffix" {
+				matchCriteria = "ENDS_WITH"
 			}
 			paths := []string{match.PathMatch.Path}
 			path_match := models.PathMatch{
@@ -547,6 +804,9 @@ func (o *AviObjectGraph) BuildParentHTTPPS(key, parentNsName string, vsNode *nod
 			}
 			match_target.Hdrs = append(match_target.Hdrs, hdrMatch)
 		}
+		// Query Param and Method Match
+		match_target.Query = buildQueryMatch(vsNode, match.QueryParamMatch)
+		match_target.Method = buildMethodMatch(match.Method)
 
 		// attaching ports
 		match_target.VsPort = &models.PortMatch{
@@ -593,22 +853,32 @@ func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNo
 		httpPSPGPool.HTTPPS = uniqueHTTPS.List()
 		index = len(vsNode.HttpPolicyRefs) - 1
 	}
-	isRedirectPresent := o.BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSName, vsNode, routeModel, rule.Filters, index)
+	isRedirectPresent := o.BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSName, vsNode, routeModel, rule, index)
 	if isRedirectPresent {
 		// When the RedirectAction is specified the Request and Response Modify Header Action
 		// won't have any effect, hence returning.
 		utils.AviLog.Infof("key: %s, msg: Attached HTTP redirect policy to vs %s", key, vsNode.Name)
 		return
 	}
-	o.BuildHTTPPolicySetHTTPRequestRules(key, httpPSName, vsNode, routeModel, rule.Filters, index)
+	o.BuildHTTPPolicySetHTTPRequestRules(key, httpPSName, vsNode, routeModel, rule, index)
 	o.BuildHTTPPolicySetHTTPResponseRules(key, vsNode, routeModel, rule.Filters, index)
 	utils.AviLog.Infof("key: %s, msg: Attached HTTP policies to vs %s", key, vsNode.Name)
 }
 
-func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRules(key, httpPSName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) {
+func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRules(key, httpPSName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, index int) {
 	requestRule := &models.HTTPRequestRule{Name: &httpPSName, Enable: proto.Bool(true), Index: proto.Int32(int32(index + 1))}
 	vsNode.HttpPolicyRefs[index].RequestRules = []*models.HTTPRequestRule{}
-	for _, filter := range filters {
+	for _, filter := range rule.Filters {
+		// considering only the first RewriteFilter
+		if filter.RewriteFilter != nil && requestRule.RewriteURLAction == nil {
+			requestRule.RewriteURLAction = &models.HTTPRewriteURLAction{}
+			if filter.RewriteFilter.Host != "" {
+				requestRule.RewriteURLAction.HostHdr = buildURIParamHost(filter.RewriteFilter.Host)
+			}
+			if filter.RewriteFilter.Path != nil {
+				requestRule.RewriteURLAction.Path = buildURIParamPath(filter.RewriteFilter.Path, rule.Matches)
+			}
+		}
 		if filter.RequestFilter != nil {
 			var j uint32 = 0
 			for i := range filter.RequestFilter.Add {
@@ -630,7 +900,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRules(key, httpPSName stri
 			}
 		}
 	}
-	if len(requestRule.HdrAction) != 0 {
+	if len(requestRule.HdrAction) != 0 || requestRule.RewriteURLAction != nil {
 		vsNode.HttpPolicyRefs[index].RequestRules = append(vsNode.HttpPolicyRefs[index].RequestRules, requestRule)
 		utils.AviLog.Debugf("key: %s, msg: Attached HTTP request policies %v to vs %s", key, utils.Stringify(vsNode.HttpPolicyRefs[index].RequestRules), vsNode.Name)
 	}
@@ -680,21 +950,31 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRuleHdrAction(key string, action
 	return hdrAction
 }
 
-func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) bool {
+func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, index int) bool {
 	redirectAction := &models.HTTPRedirectAction{}
 	isRedirectPresent := false
-	for _, filter := range filters {
+	for _, filter := range rule.Filters {
 		// considering only the first RedirectFilter
 		if filter.RedirectFilter != nil {
-			uriParamToken := &models.URIParamToken{
-				StrValue: &filter.RedirectFilter.Host,
-				Type:     proto.String("URI_TOKEN_TYPE_STRING"),
-			}
-			redirectAction.Host = &models.URIParam{
-				Tokens: []*models.URIParamToken{uriParamToken},
-				Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+			if filter.RedirectFilter.Host != "" {
+				redirectAction.Host = buildURIParamHost(filter.RedirectFilter.Host)
 			}
 			redirectAction.Protocol = proto.String("HTTP")
+			if filter.RedirectFilter.Scheme != "" {
+				redirectAction.Protocol = proto.String(strings.ToUpper(filter.RedirectFilter.Scheme))
+			}
+			// well known port of the scheme is used when only the scheme is specified
+			if filter.RedirectFilter.Port != 0 {
+				redirectAction.Port = proto.Uint32(uint32(filter.RedirectFilter.Port))
+			} else if filter.RedirectFilter.Scheme != "" {
+				redirectAction.Port = proto.Uint32(80)
+				if *redirectAction.Protocol == "HTTPS" {
+					redirectAction.Port = proto.Uint32(443)
+				}
+			}
+			if filter.RedirectFilter.Path != nil {
+				redirectAction.Path = buildURIParamPath(filter.RedirectFilter.Path, rule.Matches)
+			}
 			statusCode := "HTTP_REDIRECT_STATUS_CODE_302"
 			switch filter.RedirectFilter.StatusCode {
 			case 301, 302, 307:
@@ -702,7 +982,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSn
 			}
 			redirectAction.StatusCode = &statusCode
 			requestRule := &models.HTTPRequestRule{Name: &httpPSname, Enable: proto.Bool(true), RedirectAction: redirectAction, Index: proto.Int32(int32(index + 1))}
-			vsNode.HttpPolicyRefs[index].RequestRules = append(vsNode.HttpPolicyRefs[index].RequestRules, requestRule)
+			vsNode.HttpPolicyRefs[index].RequestRules = []*models.HTTPRequestRule{requestRule}
 			isRedirectPresent = true
 			utils.AviLog.Debugf("key: %s, msg: Attached HTTP request redirect policies %s to vs %s", key, utils.Stringify(vsNode.HttpPolicyRefs[index].RequestRules), vsNode.Name)
 			break
@@ -710,3 +990,43 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSn
 	}
 	return isRedirectPresent
 }
+
+func buildURIParamHost(host string) *models.URIParam {
+	uriParamToken := &models.URIParamToken{
+		StrValue: proto.String(host),
+		Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+	}
+	return &models.URIParam{
+		Tokens: []*models.URIParamToken{uriParamToken},
+		Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+	}
+}
+
+// buildURIParamPath translates the path modifier of the RequestRedirect and URLRewrite
+// filters. With ReplacePrefixMatch, the path segments of the matched prefix are replaced
+// and the remaining path segments of the request are retained.
+func buildURIParamPath(pathModifier *PathModifier, matches []*Match) *models.URIParam {
+	uriParam := &models.URIParam{Type: proto.String("URI_PARAM_TYPE_TOKENIZED")}
+	path := strings.Trim(pathModifier.Value, "/")
+	if path != "" || pathModifier.Type != string(gatewayv1.PrefixMatchHTTPPathModifier) {
+		uriParam.Tokens = append(uriParam.Tokens, &models.URIParamToken{
+			StrValue: proto.String(path),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		})
+	}
+	if pathModifier.Type == string(gatewayv1.PrefixMatchHTTPPathModifier) {
+		// ReplacePrefixMatch is allowed only with a single PathPrefix match
+		var startIndex uint32
+		if len(matches) == 1 && matches[0].PathMatch != nil {
+			if prefix := strings.Trim(matches[0].PathMatch.Path, "/"); prefix != "" {
+				startIndex = uint32(len(strings.Split(prefix, "/")))
+			}
+		}
+		uriParam.Tokens = append(uriParam.Tokens, &models.URIParamToken{
+			StartIndex: proto.Uint32(startIndex),
+			EndIndex:   proto.Uint32(akogatewayapilib.URITokenEndIndex),
+			Type:       proto.String("URI_TOKEN_TYPE_PATH"),
+		})
+	}
+	return uriParam
+}
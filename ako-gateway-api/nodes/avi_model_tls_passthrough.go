@@ -0,0 +1,245 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// BuildGatewayPassthroughVs creates the L4 VS for the TLS listeners in Passthrough mode. The VS shares
+// the VSVIP of the parent VS and selects the poolgroup based on the SNI using the passthrough datascript.
+func (o *AviObjectGraph) BuildGatewayPassthroughVs(gateway *gatewayv1.Gateway, parentVsNode *nodes.AviEvhVsNode, key string) *nodes.AviVsNode {
+	var portProtocols []nodes.AviPortHostProtocol
+	for _, listener := range gateway.Spec.Listeners {
+		if !akogatewayapilib.IsListenerPassthrough(listener) {
+			continue
+		}
+		pp := nodes.AviPortHostProtocol{Port: int32(listener.Port), Protocol: utils.TCP}
+		if !utils.HasElem(portProtocols, pp) {
+			portProtocols = append(portProtocols, pp)
+		}
+	}
+	if len(portProtocols) == 0 {
+		return nil
+	}
+
+	vsName := akogatewayapilib.GetGatewayPassthroughName(gateway.Namespace, gateway.Name)
+	passthroughNode := &nodes.AviVsNode{
+		Name:               vsName,
+		Tenant:             lib.GetTenant(),
+		ServiceEngineGroup: lib.GetSEGName(),
+		ApplicationProfile: utils.DEFAULT_L4_APP_PROFILE,
+		NetworkProfile:     utils.DEFAULT_TCP_NW_PROFILE,
+		SharedVS:           true,
+		VrfContext:         lib.GetVrf(),
+		PortProto:          portProtocols,
+		ServiceMetadata: lib.ServiceMetadataObj{
+			PassthroughParentRef: parentVsNode.Name,
+		},
+	}
+	passthroughNode.VSVIPRefs = parentVsNode.VSVIPRefs
+
+	dsNode := o.ConstructL4DataScript(vsName, key, passthroughNode)
+	// poolgroups selected by the datascript are scoped to the gateway
+	dsNode.Script = strings.Replace(dsNode.Script, "AVIINFRA", gateway.Namespace+"-"+gateway.Name+"-", 1)
+
+	parentVsNode.PassthroughChildNodes = []*nodes.AviVsNode{passthroughNode}
+	parentVsNode.ServiceMetadata.PassthroughChildRef = passthroughNode.Name
+	utils.AviLog.Infof("key: %s, msg: added passthrough vs %s to the parent vs %s", key, vsName, parentVsNode.Name)
+	return passthroughNode
+}
+
+func (o *AviObjectGraph) ProcessPassthroughRoutes(key string, routeModel RouteModel, parentNsName string) {
+	parentNode := o.GetAviEvhVS()
+	if len(parentNode[0].PassthroughChildNodes) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no passthrough VS found for gateway %s", key, parentNsName)
+		return
+	}
+	passthroughNode := parentNode[0].PassthroughChildNodes[0]
+	if len(passthroughNode.HTTPDSrefs) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no datascript found for passthrough VS %s", key, passthroughNode.Name)
+		return
+	}
+	dsNode := passthroughNode.HTTPDSrefs[0]
+
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	gwRouteNsName := fmt.Sprintf("%s/%s", parentNsName, routeTypeNsName)
+
+	// passthrough traffic is switched on the SNI, hence only the non wildcard hostnames can be used.
+	_, routeHosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
+	var hosts []string
+	for _, host := range routeHosts {
+		if strings.Contains(host, utils.WILDCARD) {
+			utils.AviLog.Warnf("key: %s, msg: wildcard hostname %s is not supported for passthrough route %s", key, host, routeTypeNsName)
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	// remove the avi objects created previously for this route
+	o.deletePassthroughObjects(key, parentNode[0], gwRouteNsName)
+	if len(hosts) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: No hosts mapped to the route %s", key, routeTypeNsName)
+		return
+	}
+
+	listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName)
+	listenerFound := false
+	for _, listener := range listeners {
+		if listener.Gateway == parentNsName {
+			listenerFound = true
+			break
+		}
+	}
+	if !listenerFound {
+		utils.AviLog.Warnf("key: %s, msg: No matching listener available for the route : %s", key, routeTypeNsName)
+		return
+	}
+
+	passthroughPGPool := akogatewayapiobjects.HTTPPSPGPool{
+		HTTPPS:    make([]string, 0),
+		PoolGroup: make([]string, 0),
+		Pool:      make([]string, 0),
+	}
+	for _, host := range hosts {
+		pgName := akogatewayapilib.GetPassthroughPoolGroupName(parentNs, parentName, host)
+		if isPGPresentInVS(passthroughNode, pgName) {
+			utils.AviLog.Warnf("key: %s, msg: hostname %s is already served by another route on gateway %s, skipping it for route %s", key, host, parentNsName, routeTypeNsName)
+			continue
+		}
+		PG := &nodes.AviPoolGroupNode{
+			Name:   pgName,
+			Tenant: lib.GetTenant(),
+		}
+		for _, rule := range routeModel.ParseRouteConfig().Rules {
+			for _, backend := range rule.Backends {
+				poolName := akogatewayapilib.GetPoolName(parentNs, parentName,
+					routeModel.GetNamespace(), routeModel.GetName(), host,
+					backend.Backend.Namespace, backend.Backend.Name, strconv.Itoa(int(backend.Backend.Port)))
+				svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Backend.Namespace).Get(backend.Backend.Name)
+				if err != nil {
+					utils.AviLog.Debugf("key: %s, msg: there was an error in retrieving the service", key)
+					continue
+				}
+				poolNode := &nodes.AviPoolNode{
+					Name:       poolName,
+					Tenant:     lib.GetTenant(),
+					PortName:   akogatewayapilib.FindPortName(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+					TargetPort: akogatewayapilib.FindTargetPort(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+					Port:       backend.Backend.Port,
+					ServiceMetadata: lib.ServiceMetadataObj{
+						NamespaceServiceName: []string{backend.Backend.Namespace + "/" + backend.Backend.Name},
+					},
+					VrfContext: lib.GetVrf(),
+				}
+				poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
+				serviceType := lib.GetServiceType()
+				if serviceType == lib.NodePortLocal {
+					servers := nodes.PopulateServersForNPL(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+					if servers != nil {
+						poolNode.Servers = servers
+					}
+				} else if serviceType == lib.NodePort {
+					servers := nodes.PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+					if servers != nil {
+						poolNode.Servers = servers
+					}
+				} else {
+					servers := nodes.PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+					if servers != nil {
+						poolNode.Servers = servers
+					}
+				}
+				poolNode.CalculateCheckSum()
+				passthroughNode.PoolRefs = append(passthroughNode.PoolRefs, poolNode)
+				passthroughPGPool.Pool = append(passthroughPGPool.Pool, poolNode.Name)
+
+				poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+				ratio := uint32(backend.Backend.Weight)
+				PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+			}
+		}
+		if len(PG.Members) == 0 {
+			continue
+		}
+		PG.CalculateCheckSum()
+		passthroughNode.PoolGroupRefs = append(passthroughNode.PoolGroupRefs, PG)
+		passthroughPGPool.PoolGroup = append(passthroughPGPool.PoolGroup, PG.Name)
+		if !utils.HasElem(dsNode.PoolGroupRefs, PG.Name) {
+			dsNode.PoolGroupRefs = append(dsNode.PoolGroupRefs, PG.Name)
+		}
+	}
+	passthroughPGPool.Pool = sets.NewString(passthroughPGPool.Pool...).List()
+	akogatewayapiobjects.GatewayApiLister().UpdateGatewayRouteToHTTPPSPGPool(gwRouteNsName, passthroughPGPool)
+	updateHostname(key, parentNsName, parentNode[0])
+	utils.AviLog.Infof("key: %s, msg: processing of passthrough route %s attached to passthrough vs %s completed", key, routeTypeNsName, passthroughNode.Name)
+}
+
+func isPGPresentInVS(vsNode *nodes.AviVsNode, pgName string) bool {
+	for _, pg := range vsNode.PoolGroupRefs {
+		if pg.Name == pgName {
+			return true
+		}
+	}
+	return false
+}
+
+// deletePassthroughObjects removes the poolgroups and pools of a route from the passthrough VS of the gateway.
+func (o *AviObjectGraph) deletePassthroughObjects(key string, parentNode *nodes.AviEvhVsNode, gwRouteNsName string) {
+	found, passthroughPGPool := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHTTPSPGPool(gwRouteNsName)
+	if !found || len(parentNode.PassthroughChildNodes) == 0 {
+		return
+	}
+	passthroughNode := parentNode.PassthroughChildNodes[0]
+	for _, pgName := range passthroughPGPool.PoolGroup {
+		for i, pg := range passthroughNode.PoolGroupRefs {
+			if pg.Name == pgName {
+				passthroughNode.PoolGroupRefs = append(passthroughNode.PoolGroupRefs[:i], passthroughNode.PoolGroupRefs[i+1:]...)
+				break
+			}
+		}
+		for _, dsNode := range passthroughNode.HTTPDSrefs {
+			for i, pgRef := range dsNode.PoolGroupRefs {
+				if pgRef == pgName {
+					dsNode.PoolGroupRefs = append(dsNode.PoolGroupRefs[:i], dsNode.PoolGroupRefs[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	for _, poolName := range passthroughPGPool.Pool {
+		for i, pool := range passthroughNode.PoolRefs {
+			if pool.Name == poolName {
+				passthroughNode.PoolRefs = append(passthroughNode.PoolRefs[:i], passthroughNode.PoolRefs[i+1:]...)
+				break
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: removed passthrough objects %s from vs %s", key, utils.Stringify(passthroughPGPool), passthroughNode.Name)
+	akogatewayapiobjects.GatewayApiLister().DeleteGatewayRouteToHTTPSPGPool(gwRouteNsName)
+}
@@ -82,12 +82,21 @@ func DequeueIngestion(key string, fullsync bool) {
 				}
 				continue
 			}
+			if !akogatewayapiobjects.GatewayApiLister().IsRouteValid(routeTypeNsName) {
+				utils.AviLog.Infof("key: %s, msg: deleting configurations corresponding to invalid route %s", key, routeTypeNsName)
+				model.ProcessRouteDeletion(key, gatewayNsName, routeModel, fullsync)
+				continue
+			}
 
 			childVSes := make(map[string]struct{}, 0)
 
 			switch objType {
-			case lib.HTTPRoute:
+			case lib.HTTPRoute, lib.GRPCRoute:
 				model.ProcessL7Routes(key, routeModel, gatewayNsName, childVSes, fullsync)
+			case lib.TLSRoute:
+				model.ProcessPassthroughRoutes(key, routeModel, gatewayNsName)
+			case lib.TCPRoute, lib.UDPRoute:
+				model.ProcessL4Routes(key, routeModel, gatewayNsName)
 			default:
 				utils.AviLog.Warnf("key: %s, msg: route of type %s not supported", key, objType)
 				continue
@@ -229,6 +238,10 @@ func (o *AviObjectGraph) ProcessRouteDeletion(key, parentNsName string, routeMod
 				akogatewayapiobjects.GatewayApiLister().DeleteRouteChildVSMappings(routeTypeNsName, childVSName)
 			}
 		}
+	} else if routeModel.GetType() == lib.TLSRoute {
+		o.deletePassthroughObjects(key, parentNode[0], parentNsName+"/"+routeTypeNsName)
+	} else if routeModel.GetType() == lib.TCPRoute || routeModel.GetType() == lib.UDPRoute {
+		o.deleteL4RouteObjects(key, parentNode[0], routeModel, parentNsName)
 	} else {
 		// check parent association
 		found, localHTTPPSPGPools := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHTTPSPGPool(parentNsName + "/" + routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName())
@@ -266,6 +279,7 @@ func (o *AviObjectGraph) ProcessRouteDeletion(key, parentNsName string, routeMod
 				}
 			}
 		}
+		pruneQueryStringGroups(parentNode[0])
 		akogatewayapiobjects.GatewayApiLister().DeleteGatewayRouteToHTTPSPGPool(parentNsName + "/" + routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName())
 	}
 	updateHostname(key, parentNsName, parentNode[0])
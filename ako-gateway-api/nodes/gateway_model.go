@@ -72,26 +72,55 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key stri
 	vsvipNode := BuildVsVipNodeForGateway(gateway, parentVsNode.Name)
 	parentVsNode.VSVIPRefs = []*nodes.AviVSVIPNode{vsvipNode}
 
+	o.BuildGatewayPassthroughVs(gateway, parentVsNode, key)
+
 	return parentVsNode
 }
 
 func BuildPortProtocols(gateway *gatewayv1.Gateway, key string) []nodes.AviPortHostProtocol {
 	var portProtocols []nodes.AviPortHostProtocol
 	for _, listener := range gateway.Spec.Listeners {
-
+		// TLS passthrough listeners are served by the passthrough VS
+		if akogatewayapilib.IsListenerPassthrough(listener) {
+			continue
+		}
 		pp := nodes.AviPortHostProtocol{Port: int32(listener.Port), Protocol: string(listener.Protocol)}
 		//TLS config on listener is present
 		if listener.TLS != nil && len(listener.TLS.CertificateRefs) > 0 {
 			pp.EnableSSL = true
 		}
-		if !utils.HasElem(portProtocols, pp) {
+		// gRPC clients need HTTP/2 on the port of the listener
+		enableHTTP2 := isGRPCRouteAllowed(listener)
+		found := false
+		for i := range portProtocols {
+			if portProtocols[i].Port == pp.Port && portProtocols[i].Protocol == pp.Protocol && portProtocols[i].EnableSSL == pp.EnableSSL {
+				portProtocols[i].EnableHTTP2 = portProtocols[i].EnableHTTP2 || enableHTTP2
+				found = true
+				break
+			}
+		}
+		if !found {
+			pp.EnableHTTP2 = enableHTTP2
 			portProtocols = append(portProtocols, pp)
 		}
-
 	}
 	return portProtocols
 }
 
+// isGRPCRouteAllowed returns true if GRPCRoutes can be attached to the listener, either as one of the kinds of its
+// allowed routes, or as one of the kinds supported for its protocol when the allowed kinds are not set.
+func isGRPCRouteAllowed(listener gatewayv1.Listener) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return akogatewayapilib.IsRouteKindSupported(string(listener.Protocol), lib.GRPCRoute)
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if string(kind.Kind) == lib.GRPCRoute && (kind.Group == nil || *kind.Group == gatewayv1.GroupName) {
+			return true
+		}
+	}
+	return false
+}
+
 func BuildTLSNodesForGateway(gateway *gatewayv1.Gateway, key string) []*nodes.AviTLSKeyCertNode {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	var ns, name string
@@ -106,6 +135,10 @@ func BuildTLSNodesForGateway(gateway *gatewayv1.Gateway, key string) []*nodes.Av
 					ns = string(*certRef.Namespace)
 				}
 				name = string(certRef.Name)
+				if !akogatewayapilib.IsReferencePermitted(lib.Gateway, gateway.Namespace, utils.Secret, ns, name) {
+					utils.AviLog.Warnf("key: %s, msg: skipping the secret %s/%s, reference is not permitted by any ReferenceGrant", key, ns, name)
+					continue
+				}
 				secretObj, err := cs.CoreV1().Secrets(ns).Get(context.TODO(), name, metav1.GetOptions{})
 				if err != nil || secretObj == nil {
 					utils.AviLog.Warnf("key: %s, msg: secret %s has been deleted, err: %s", key, name, err)
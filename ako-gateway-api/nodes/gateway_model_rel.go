@@ -20,6 +20,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -85,6 +86,26 @@ var (
 		GetGateways: HTTPRouteToGateway,
 		GetRoutes:   HTTPRouteChanges,
 	}
+	GRPCRoute = GraphSchema{
+		Type:        lib.GRPCRoute,
+		GetGateways: GRPCRouteToGateway,
+		GetRoutes:   GRPCRouteChanges,
+	}
+	TLSRoute = GraphSchema{
+		Type:        lib.TLSRoute,
+		GetGateways: TLSRouteToGateway,
+		GetRoutes:   TLSRouteChanges,
+	}
+	TCPRoute = GraphSchema{
+		Type:        lib.TCPRoute,
+		GetGateways: TCPRouteToGateway,
+		GetRoutes:   TCPRouteChanges,
+	}
+	UDPRoute = GraphSchema{
+		Type:        lib.UDPRoute,
+		GetGateways: UDPRouteToGateway,
+		GetRoutes:   UDPRouteChanges,
+	}
 	Pod = GraphSchema{
 		Type:        "Pod",
 		GetGateways: PodToGateway,
@@ -98,6 +119,10 @@ var (
 		Endpoint,
 		EndpointSlices,
 		HTTPRoute,
+		GRPCRoute,
+		TLSRoute,
+		TCPRoute,
+		UDPRoute,
 		Pod,
 	}
 )
@@ -136,8 +161,8 @@ func GatewayGetGw(namespace, name, key string) ([]string, bool) {
 
 		if listenerObj.AllowedRoutes == nil {
 			gwListener.AllowedRouteNs = gwObj.Namespace
-			gwListener.AllowedRouteTypes = []akogatewayapiobjects.GatewayRouteKind{
-				{Group: akogatewayapilib.GatewayGroup, Kind: akogatewayapilib.ProtocolToRoute(gwListener.Protocol)},
+			for _, routeKind := range akogatewayapilib.SupportedKinds[listenerObj.Protocol] {
+				gwListener.AllowedRouteTypes = append(gwListener.AllowedRouteTypes, akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: string(routeKind.Kind)})
 			}
 		} else {
 			if listenerObj.AllowedRoutes.Namespaces != nil {
@@ -254,16 +279,65 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 		}
 		return gwNsNameList, true
 	}
+	return routeToGateway(key, routeTypeNsName, httpGroupKind, hrObj.Namespace, hrObj.Spec.ParentRefs, hrObj.Spec.Hostnames), true
+}
+
+func GRPCRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grpcGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: lib.GRPCRoute}
+	grObj, err := akogatewayapilib.GetGRPCRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting GRPCRoute: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, grpcGroupKind, grObj.Namespace, grObj.Spec.ParentRefs, grObj.Spec.Hostnames), true
+}
+
+func TLSRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.TLSRoute + "/" + namespace + "/" + name
+	tlsGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: lib.TLSRoute}
+	trObj, err := akogatewayapilib.GetTLSRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting TLSRoute: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, tlsGroupKind, trObj.Namespace, trObj.Spec.ParentRefs, trObj.Spec.Hostnames), true
+}
+
+// routeToGateway updates the gateway, listener and hostname mappings of a route and returns the parent gateways.
+func routeToGateway(key, routeTypeNsName string, routeGroupKind akogatewayapiobjects.GatewayRouteKind, routeNamespace string,
+	parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname) []string {
+	// an invalid route is processed only to remove its configuration from the gateways it is mapped to
+	if !akogatewayapiobjects.GatewayApiLister().IsRouteValid(routeTypeNsName) {
+		_, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		return gwNsNameList
+	}
 	var listenerList []akogatewayapiobjects.GatewayListenerStore
 	var gatewayList []string
 	var gwNsNameList []string
 	parentNameToHostnameMap := make(map[string][]string)
-	for _, parentRef := range hrObj.Spec.ParentRefs {
+	for _, parentRef := range parentRefs {
 		hostnameIntersection, _ := parentNameToHostnameMap[string(parentRef.Name)]
-		ns := namespace
+		ns := routeNamespace
 		if parentRef.Namespace != nil {
 			ns = string(*parentRef.Namespace)
-			// if *parentRef.Namespace != gatewayv1beta1.Namespace(hrObj.Namespace) {
+			// if *parentRef.Namespace != gatewayv1beta1.Namespace(routeNamespace) {
 			// 	//check reference grant
 			// }
 		}
@@ -286,8 +360,8 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 		for _, listener := range listeners {
 			//check if namespace is allowed
 			// TODO: akshay: add selector condition here.
-			if (len(listener.AllowedRouteTypes) == 0 || utils.HasElem(listener.AllowedRouteTypes, httpGroupKind)) &&
-				(listener.AllowedRouteNs == akogatewayapilib.AllowedRoutesNamespaceFromAll || listener.AllowedRouteNs == hrObj.Namespace) {
+			if (len(listener.AllowedRouteTypes) == 0 || utils.HasElem(listener.AllowedRouteTypes, routeGroupKind)) &&
+				(listener.AllowedRouteNs == akogatewayapilib.AllowedRoutesNamespaceFromAll || listener.AllowedRouteNs == routeNamespace) {
 				//if provided, check if section name and port matches
 				if (parentRef.SectionName == nil || string(*parentRef.SectionName) == listener.Name) &&
 					(parentRef.Port == nil || int32(*parentRef.Port) == listener.Port) {
@@ -296,7 +370,7 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 					listenerHostname := akogatewayapiobjects.GatewayApiLister().GetGatewayListenerToHostname(gwListenerNsName)
 
 					hostnameMatched := false
-					for _, routeHostname := range hrObj.Spec.Hostnames {
+					for _, routeHostname := range hostnames {
 						// When Gateway hostname is empty, then just check validity of hostname and append it.
 						// When hostname in HTTProute has wildcard
 						// When there is exact match
@@ -307,7 +381,7 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 							}
 						}
 					}
-					if (hostnameMatched && !utils.HasElem(gatewayListenerList, listener)) || len(hrObj.Spec.Hostnames) == 0 {
+					if (hostnameMatched && !utils.HasElem(gatewayListenerList, listener)) || len(hostnames) == 0 {
 						gatewayListenerList = append(gatewayListenerList, listener)
 					}
 				}
@@ -335,7 +409,7 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 	}
 
 	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
-	return gwNsNameList, true
+	return gwNsNameList
 }
 
 func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
@@ -375,6 +449,211 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 		}
 	}
 
+	updateRouteMappings(routeTypeNsName, gwNsNameList, svcNsNameList)
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func GRPCRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grObj, err := akogatewayapilib.GetGRPCRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting GRPCRoute: %v", key, err)
+			return []string{}, false
+		}
+		// grpcroute must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var gwNsNameList []string
+	for _, parentRef := range grObj.Spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
+
+	var svcNsNameList []string
+	for _, rule := range grObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+
+	updateRouteMappings(routeTypeNsName, gwNsNameList, svcNsNameList)
+	utils.AviLog.Debugf("key: %s, msg: GRPCRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func TLSRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.TLSRoute + "/" + namespace + "/" + name
+	trObj, err := akogatewayapilib.GetTLSRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting TLSRoute: %v", key, err)
+			return []string{}, false
+		}
+		// tlsroute must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var gwNsNameList []string
+	for _, parentRef := range trObj.Spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
+
+	var svcNsNameList []string
+	for _, rule := range trObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+
+	updateRouteMappings(routeTypeNsName, gwNsNameList, svcNsNameList)
+	utils.AviLog.Debugf("key: %s, msg: TLSRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func TCPRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.TCPRoute + "/" + namespace + "/" + name
+	tcpGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: lib.TCPRoute}
+	trObj, err := akogatewayapilib.GetTCPRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting TCPRoute: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, tcpGroupKind, trObj.Namespace, trObj.Spec.ParentRefs, nil), true
+}
+
+func TCPRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.TCPRoute + "/" + namespace + "/" + name
+	trObj, err := akogatewayapilib.GetTCPRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting TCPRoute: %v", key, err)
+			return []string{}, false
+		}
+		// tcproute must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var gwNsNameList []string
+	for _, parentRef := range trObj.Spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
+
+	var svcNsNameList []string
+	for _, rule := range trObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+
+	updateRouteMappings(routeTypeNsName, gwNsNameList, svcNsNameList)
+	utils.AviLog.Debugf("key: %s, msg: TCPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func UDPRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.UDPRoute + "/" + namespace + "/" + name
+	udpGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: lib.UDPRoute}
+	urObj, err := akogatewayapilib.GetUDPRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting UDPRoute: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, udpGroupKind, urObj.Namespace, urObj.Spec.ParentRefs, nil), true
+}
+
+func UDPRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.UDPRoute + "/" + namespace + "/" + name
+	urObj, err := akogatewayapilib.GetUDPRoute(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting UDPRoute: %v", key, err)
+			return []string{}, false
+		}
+		// udproute must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var gwNsNameList []string
+	for _, parentRef := range urObj.Spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
+
+	var svcNsNameList []string
+	for _, rule := range urObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+
+	updateRouteMappings(routeTypeNsName, gwNsNameList, svcNsNameList)
+	utils.AviLog.Debugf("key: %s, msg: UDPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+// updateRouteMappings syncs the route <-> gateway and route <-> service mappings with the current parents and backends of the route.
+func updateRouteMappings(routeTypeNsName string, gwNsNameList, svcNsNameList []string) {
 	// deletes the services, which are removed, from the gateway <-> service and route <-> service mappings
 	found, oldSvcs := akogatewayapiobjects.GatewayApiLister().GetRouteToService(routeTypeNsName)
 	if found {
@@ -417,9 +696,6 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 			akogatewayapiobjects.GatewayApiLister().DeleteGatewayServiceMappings(gwNsName, svcNsName)
 		}
 	}
-
-	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved %s", key, []string{routeTypeNsName})
-	return []string{routeTypeNsName}, true
 }
 
 func ServiceToGateways(namespace, name, key string) ([]string, bool) {
@@ -16,13 +16,19 @@ package nodes
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 type RouteModel interface {
@@ -39,6 +45,14 @@ func NewRouteModel(key, objType, name, namespace string) (RouteModel, error) {
 	switch objType {
 	case lib.HTTPRoute:
 		return GetHTTPRouteModel(key, name, namespace)
+	case lib.GRPCRoute:
+		return GetGRPCRouteModel(key, name, namespace)
+	case lib.TLSRoute:
+		return GetTLSRouteModel(key, name, namespace)
+	case lib.TCPRoute:
+		return GetTCPRouteModel(key, name, namespace)
+	case lib.UDPRoute:
+		return GetUDPRouteModel(key, name, namespace)
 	}
 	return nil, fmt.Errorf("object of type %s not supported", objType)
 }
@@ -55,9 +69,25 @@ type PathMatch struct {
 	Type string
 }
 
+type QueryParamMatch struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+type QueryParamMatches []*QueryParamMatch
+
+func (q QueryParamMatches) Len() int           { return len(q) }
+func (q QueryParamMatches) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q QueryParamMatches) Less(i, j int) bool { return q[i].Name < q[j].Name }
+
+// QueryParamMatch and Method are omitted when empty, to keep the object names
+// derived from the stringified matches unchanged for the existing routes.
 type Match struct {
-	PathMatch   *PathMatch
-	HeaderMatch []*HeaderMatch
+	PathMatch       *PathMatch
+	HeaderMatch     []*HeaderMatch
+	QueryParamMatch []*QueryParamMatch `json:",omitempty"`
+	Method          string             `json:",omitempty"`
 }
 
 type Matches []*Match
@@ -65,10 +95,22 @@ type Matches []*Match
 func (m Matches) Len() int      { return len(m) }
 func (m Matches) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
 func (m Matches) Less(i, j int) bool {
-	if m[i].PathMatch != nil && m[j].PathMatch != nil {
-		return m[i].PathMatch.Path < m[j].PathMatch.Path // TODO: need to check this logic
+	var pathI, pathJ string
+	if m[i].PathMatch != nil {
+		pathI = m[i].PathMatch.Path
+	}
+	if m[j].PathMatch != nil {
+		pathJ = m[j].PathMatch.Path
+	}
+	if pathI != pathJ {
+		return pathI < pathJ
 	}
-	return false
+	// matches with the same path are ordered by the rest of the match criteria
+	if m[i].Method != m[j].Method {
+		return m[i].Method < m[j].Method
+	}
+	return utils.Stringify(m[i].HeaderMatch)+utils.Stringify(m[i].QueryParamMatch) <
+		utils.Stringify(m[j].HeaderMatch)+utils.Stringify(m[j].QueryParamMatch)
 }
 
 type Header struct {
@@ -88,9 +130,24 @@ type HeaderFilter struct {
 	Remove []string
 }
 
+type PathModifier struct {
+	Type  string
+	Value string
+}
+
+// Fields added to the filters are omitted when empty, to keep the object names
+// derived from the stringified rule unchanged for the existing routes.
 type RedirectFilter struct {
 	Host       string
 	StatusCode int32
+	Scheme     string        `json:",omitempty"`
+	Port       int32         `json:",omitempty"`
+	Path       *PathModifier `json:",omitempty"`
+}
+
+type RewriteFilter struct {
+	Host string
+	Path *PathModifier
 }
 
 type Filter struct {
@@ -98,6 +155,7 @@ type Filter struct {
 	RequestFilter  *HeaderFilter
 	ResponseFilter *HeaderFilter
 	RedirectFilter *RedirectFilter
+	RewriteFilter  *RewriteFilter `json:",omitempty"`
 }
 
 type Backend struct {
@@ -112,10 +170,35 @@ type HTTPBackend struct {
 	Filters []*Filter
 }
 
+// Timeouts holds the request and backend request timeouts of a rule in milliseconds,
+// a zero value disables the timeout.
+type Timeouts struct {
+	Request        *uint32
+	BackendRequest *uint32
+}
+
+// RetryPolicy holds the number of retries of a request and the response codes
+// on which the request is retried.
+type RetryPolicy struct {
+	Attempts uint32
+	Codes    []int64
+}
+
+// ExtensionRef holds the kind and name of the AKO CRD referred by an ExtensionRef filter of a rule.
+type ExtensionRef struct {
+	Kind string
+	Name string
+}
+
+// ExtensionRefs are kept apart from the filters, as the AKO CRDs referred by them are applied on the
+// child VS and the pools of the rule, whereas the filters are translated to the HTTP policy sets.
 type Rule struct {
-	Matches  []*Match
-	Filters  []*Filter
-	Backends []*HTTPBackend
+	Matches       []*Match
+	Filters       []*Filter
+	Backends      []*HTTPBackend
+	Timeouts      *Timeouts
+	RetryPolicy   *RetryPolicy
+	ExtensionRefs []*ExtensionRef
 }
 
 type RouteConfig struct {
@@ -129,6 +212,7 @@ type httpRoute struct {
 	namespace   string
 	routeConfig *RouteConfig
 	spec        *gatewayv1.HTTPRouteSpec
+	annotations map[string]string
 }
 
 func GetHTTPRouteModel(key string, name, namespace string) (RouteModel, error) {
@@ -143,6 +227,7 @@ func GetHTTPRouteModel(key string, name, namespace string) (RouteModel, error) {
 		return hr, err
 	}
 	hr.spec = hrObj.Spec.DeepCopy()
+	hr.annotations = hrObj.GetAnnotations()
 	return hr, nil
 }
 
@@ -173,9 +258,20 @@ func (hr *httpRoute) ParseRouteConfig() *RouteConfig {
 		routeConfig.Hosts[i] = string(hr.spec.Hostnames[i])
 	}
 
+	retryPolicy := parseRetryPolicy(hr.key, hr.annotations)
+
 	routeConfig.Rules = make([]*Rule, 0, len(hr.spec.Rules))
-	for _, rule := range hr.spec.Rules {
+	for i, rule := range hr.spec.Rules {
+		// rules with unsupported filters are dropped, the route is marked partially invalid
+		if err := akogatewayapilib.ValidateHTTPRouteRuleFilters(&hr.spec.Rules[i]); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: skipping the rule %d, err: %v", hr.key, i, err)
+			continue
+		}
 		routeConfigRule := &Rule{}
+		routeConfigRule.RetryPolicy = retryPolicy
+		if rule.Timeouts != nil {
+			routeConfigRule.Timeouts = parseTimeouts(hr.key, rule.Timeouts)
+		}
 		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
 		for _, ruleMatch := range rule.Matches {
 			match := &Match{}
@@ -207,67 +303,53 @@ func (hr *httpRoute) ParseRouteConfig() *RouteConfig {
 				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
 			}
 
+			// query param match
+			for _, queryParam := range ruleMatch.QueryParams {
+				queryParamMatch := &QueryParamMatch{}
+				if queryParam.Type != nil {
+					queryParamMatch.Type = string(*queryParam.Type)
+				}
+				queryParamMatch.Name = string(queryParam.Name)
+				queryParamMatch.Value = queryParam.Value
+				match.QueryParamMatch = append(match.QueryParamMatch, queryParamMatch)
+			}
+			sort.Sort((QueryParamMatches)(match.QueryParamMatch))
+
+			// method match
+			if ruleMatch.Method != nil {
+				match.Method = string(*ruleMatch.Method)
+			}
+
 			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
 		}
 		sort.Sort((Matches)(routeConfigRule.Matches))
 
 		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
 		for _, ruleFilter := range rule.Filters {
+			// extension ref filter, the references which can't be resolved are skipped
+			if ruleFilter.ExtensionRef != nil {
+				if _, err := akogatewayapilib.ValidateExtensionRef(hr.namespace, ruleFilter.ExtensionRef); err != nil {
+					utils.AviLog.Warnf("key: %s, msg: skipping the ExtensionRef filter, err: %v", hr.key, err)
+					continue
+				}
+				routeConfigRule.ExtensionRefs = append(routeConfigRule.ExtensionRefs, &ExtensionRef{
+					Kind: string(ruleFilter.ExtensionRef.Kind),
+					Name: string(ruleFilter.ExtensionRef.Name),
+				})
+				continue
+			}
+
 			filter := &Filter{}
 			filter.Type = string(ruleFilter.Type)
 
 			// request header filter
 			if ruleFilter.RequestHeaderModifier != nil {
-				filter.RequestFilter = &HeaderFilter{}
-				filter.RequestFilter.Add = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.RequestHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.RequestFilter.Add = append(filter.RequestFilter.Add, addHeader)
-				}
-				filter.RequestFilter.Set = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.RequestHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.RequestFilter.Set = append(filter.RequestFilter.Set, setHeader)
-				}
-				filter.RequestFilter.Remove = make([]string, len(ruleFilter.RequestHeaderModifier.Remove))
-				copy(filter.RequestFilter.Remove, ruleFilter.RequestHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.RequestFilter.Add))
-				sort.Sort((Headers)(filter.RequestFilter.Set))
-				sort.Strings(filter.RequestFilter.Remove)
+				filter.RequestFilter = parseHeaderFilter(ruleFilter.RequestHeaderModifier)
 			}
 
 			// response header filter
 			if ruleFilter.ResponseHeaderModifier != nil {
-				filter.ResponseFilter = &HeaderFilter{}
-				filter.ResponseFilter.Add = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.ResponseHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.ResponseFilter.Add = append(filter.ResponseFilter.Add, addHeader)
-				}
-				filter.ResponseFilter.Set = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.ResponseHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.ResponseFilter.Set = append(filter.ResponseFilter.Set, setHeader)
-				}
-				filter.ResponseFilter.Remove = make([]string, len(ruleFilter.ResponseHeaderModifier.Remove))
-				copy(filter.ResponseFilter.Remove, ruleFilter.ResponseHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.ResponseFilter.Add))
-				sort.Sort((Headers)(filter.ResponseFilter.Set))
-				sort.Strings(filter.ResponseFilter.Remove)
+				filter.ResponseFilter = parseHeaderFilter(ruleFilter.ResponseHeaderModifier)
 			}
 
 			// request redirect filter
@@ -279,6 +361,26 @@ func (hr *httpRoute) ParseRouteConfig() *RouteConfig {
 				if ruleFilter.RequestRedirect.StatusCode != nil {
 					filter.RedirectFilter.StatusCode = int32(*ruleFilter.RequestRedirect.StatusCode)
 				}
+				if ruleFilter.RequestRedirect.Scheme != nil {
+					filter.RedirectFilter.Scheme = *ruleFilter.RequestRedirect.Scheme
+				}
+				if ruleFilter.RequestRedirect.Port != nil {
+					filter.RedirectFilter.Port = int32(*ruleFilter.RequestRedirect.Port)
+				}
+				if ruleFilter.RequestRedirect.Path != nil {
+					filter.RedirectFilter.Path = parsePathModifier(ruleFilter.RequestRedirect.Path)
+				}
+			}
+
+			// url rewrite filter
+			if ruleFilter.URLRewrite != nil {
+				filter.RewriteFilter = &RewriteFilter{}
+				if ruleFilter.URLRewrite.Hostname != nil {
+					filter.RewriteFilter.Host = string(*ruleFilter.URLRewrite.Hostname)
+				}
+				if ruleFilter.URLRewrite.Path != nil {
+					filter.RewriteFilter.Path = parsePathModifier(ruleFilter.URLRewrite.Path)
+				}
 			}
 			routeConfigRule.Filters = append(routeConfigRule.Filters, filter)
 		}
@@ -291,6 +393,10 @@ func (hr *httpRoute) ParseRouteConfig() *RouteConfig {
 			} else {
 				backend.Namespace = hr.namespace
 			}
+			if !akogatewayapilib.IsReferencePermitted(lib.HTTPRoute, hr.namespace, utils.Service, backend.Namespace, backend.Name) {
+				utils.AviLog.Warnf("key: %s, msg: skipping the backend %s/%s, reference is not permitted by any ReferenceGrant", hr.key, backend.Namespace, backend.Name)
+				continue
+			}
 			if ruleBackend.BackendRef.Port != nil {
 				//Default 0
 				backend.Port = int32(*ruleBackend.Port)
@@ -323,3 +429,483 @@ func (hr *httpRoute) GetParents() sets.Set[string] {
 	}
 	return parents
 }
+
+type grpcRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.GRPCRouteSpec
+}
+
+func GetGRPCRouteModel(key string, name, namespace string) (RouteModel, error) {
+	gr := &grpcRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	grObj, err := akogatewayapilib.GetGRPCRoute(namespace, name)
+	if err != nil {
+		return gr, err
+	}
+	gr.spec = grObj.Spec.DeepCopy()
+	return gr, nil
+}
+
+func (gr *grpcRoute) GetName() string {
+	return gr.name
+}
+
+func (gr *grpcRoute) GetNamespace() string {
+	return gr.namespace
+}
+
+func (gr *grpcRoute) GetType() string {
+	return lib.GRPCRoute
+}
+
+func (gr *grpcRoute) GetSpec() interface{} {
+	return gr.spec
+}
+
+func (gr *grpcRoute) ParseRouteConfig() *RouteConfig {
+	if gr.routeConfig != nil {
+		return gr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	routeConfig.Hosts = make([]string, len(gr.spec.Hostnames))
+	for i := range gr.spec.Hostnames {
+		routeConfig.Hosts[i] = string(gr.spec.Hostnames[i])
+	}
+
+	routeConfig.Rules = make([]*Rule, 0, len(gr.spec.Rules))
+	for _, rule := range gr.spec.Rules {
+		routeConfigRule := &Rule{}
+		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
+		isRuleValid := true
+		for _, ruleMatch := range rule.Matches {
+			match := &Match{}
+
+			// method match
+			match.PathMatch = grpcMethodToPathMatch(ruleMatch.Method)
+			if match.PathMatch == nil {
+				isRuleValid = false
+				break
+			}
+
+			// header match
+			match.HeaderMatch = make([]*HeaderMatch, 0, len(ruleMatch.Headers))
+			for _, header := range ruleMatch.Headers {
+				headerMatch := &HeaderMatch{}
+				if header.Type != nil {
+					headerMatch.Type = string(*header.Type)
+				}
+				headerMatch.Name = string(header.Name)
+				headerMatch.Value = header.Value
+				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
+			}
+
+			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
+		}
+		if !isRuleValid {
+			utils.AviLog.Warnf("key: %s, msg: RegularExpression method match is not supported, skipping the rule of GRPCRoute %s/%s", gr.key, gr.namespace, gr.name)
+			continue
+		}
+		// rule without matches matches all the gRPC requests
+		if len(routeConfigRule.Matches) == 0 {
+			routeConfigRule.Matches = append(routeConfigRule.Matches, &Match{
+				PathMatch:   grpcMethodToPathMatch(nil),
+				HeaderMatch: make([]*HeaderMatch, 0),
+			})
+		}
+		sort.Sort((Matches)(routeConfigRule.Matches))
+
+		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
+		for _, ruleFilter := range rule.Filters {
+			filter := &Filter{}
+			filter.Type = string(ruleFilter.Type)
+
+			// request header filter
+			if ruleFilter.RequestHeaderModifier != nil {
+				filter.RequestFilter = parseHeaderFilter(ruleFilter.RequestHeaderModifier)
+			}
+
+			// response header filter
+			if ruleFilter.ResponseHeaderModifier != nil {
+				filter.ResponseFilter = parseHeaderFilter(ruleFilter.ResponseHeaderModifier)
+			}
+			routeConfigRule.Filters = append(routeConfigRule.Filters, filter)
+		}
+
+		backendRefs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+		for _, ruleBackend := range rule.BackendRefs {
+			backendRefs = append(backendRefs, ruleBackend.BackendRef)
+		}
+		routeConfigRule.Backends = parseBackendRefs(gr.key, lib.GRPCRoute, backendRefs, gr.namespace).Backends
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	gr.routeConfig = routeConfig
+	return gr.routeConfig
+}
+
+func (gr *grpcRoute) Exists() bool {
+	return gr != nil
+}
+
+func (gr *grpcRoute) GetParents() sets.Set[string] {
+	return getParents(gr.spec.ParentRefs, gr.namespace)
+}
+
+type tlsRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.TLSRouteSpec
+}
+
+func GetTLSRouteModel(key string, name, namespace string) (RouteModel, error) {
+	tr := &tlsRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	trObj, err := akogatewayapilib.GetTLSRoute(namespace, name)
+	if err != nil {
+		return tr, err
+	}
+	tr.spec = trObj.Spec.DeepCopy()
+	return tr, nil
+}
+
+func (tr *tlsRoute) GetName() string {
+	return tr.name
+}
+
+func (tr *tlsRoute) GetNamespace() string {
+	return tr.namespace
+}
+
+func (tr *tlsRoute) GetType() string {
+	return lib.TLSRoute
+}
+
+func (tr *tlsRoute) GetSpec() interface{} {
+	return tr.spec
+}
+
+func (tr *tlsRoute) ParseRouteConfig() *RouteConfig {
+	if tr.routeConfig != nil {
+		return tr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	routeConfig.Hosts = make([]string, len(tr.spec.Hostnames))
+	for i := range tr.spec.Hostnames {
+		routeConfig.Hosts[i] = string(tr.spec.Hostnames[i])
+	}
+
+	// TLSRoute rules have no matches and filters, only the backends are used.
+	routeConfig.Rules = make([]*Rule, 0, len(tr.spec.Rules))
+	for _, rule := range tr.spec.Rules {
+		routeConfig.Rules = append(routeConfig.Rules, parseBackendRefs(tr.key, lib.TLSRoute, rule.BackendRefs, tr.namespace))
+	}
+	tr.routeConfig = routeConfig
+	return tr.routeConfig
+}
+
+func (tr *tlsRoute) Exists() bool {
+	return tr != nil
+}
+
+func (tr *tlsRoute) GetParents() sets.Set[string] {
+	return getParents(tr.spec.ParentRefs, tr.namespace)
+}
+
+type tcpRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.TCPRouteSpec
+}
+
+func GetTCPRouteModel(key string, name, namespace string) (RouteModel, error) {
+	tr := &tcpRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	trObj, err := akogatewayapilib.GetTCPRoute(namespace, name)
+	if err != nil {
+		return tr, err
+	}
+	tr.spec = trObj.Spec.DeepCopy()
+	return tr, nil
+}
+
+func (tr *tcpRoute) GetName() string {
+	return tr.name
+}
+
+func (tr *tcpRoute) GetNamespace() string {
+	return tr.namespace
+}
+
+func (tr *tcpRoute) GetType() string {
+	return lib.TCPRoute
+}
+
+func (tr *tcpRoute) GetSpec() interface{} {
+	return tr.spec
+}
+
+func (tr *tcpRoute) ParseRouteConfig() *RouteConfig {
+	if tr.routeConfig != nil {
+		return tr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+	routeConfig.Rules = make([]*Rule, 0, len(tr.spec.Rules))
+	for _, rule := range tr.spec.Rules {
+		routeConfig.Rules = append(routeConfig.Rules, parseBackendRefs(tr.key, lib.TCPRoute, rule.BackendRefs, tr.namespace))
+	}
+	tr.routeConfig = routeConfig
+	return tr.routeConfig
+}
+
+func (tr *tcpRoute) Exists() bool {
+	return tr != nil
+}
+
+func (tr *tcpRoute) GetParents() sets.Set[string] {
+	return getParents(tr.spec.ParentRefs, tr.namespace)
+}
+
+type udpRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.UDPRouteSpec
+}
+
+func GetUDPRouteModel(key string, name, namespace string) (RouteModel, error) {
+	ur := &udpRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	urObj, err := akogatewayapilib.GetUDPRoute(namespace, name)
+	if err != nil {
+		return ur, err
+	}
+	ur.spec = urObj.Spec.DeepCopy()
+	return ur, nil
+}
+
+func (ur *udpRoute) GetName() string {
+	return ur.name
+}
+
+func (ur *udpRoute) GetNamespace() string {
+	return ur.namespace
+}
+
+func (ur *udpRoute) GetType() string {
+	return lib.UDPRoute
+}
+
+func (ur *udpRoute) GetSpec() interface{} {
+	return ur.spec
+}
+
+func (ur *udpRoute) ParseRouteConfig() *RouteConfig {
+	if ur.routeConfig != nil {
+		return ur.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+	routeConfig.Rules = make([]*Rule, 0, len(ur.spec.Rules))
+	for _, rule := range ur.spec.Rules {
+		routeConfig.Rules = append(routeConfig.Rules, parseBackendRefs(ur.key, lib.UDPRoute, rule.BackendRefs, ur.namespace))
+	}
+	ur.routeConfig = routeConfig
+	return ur.routeConfig
+}
+
+func (ur *udpRoute) Exists() bool {
+	return ur != nil
+}
+
+func (ur *udpRoute) GetParents() sets.Set[string] {
+	return getParents(ur.spec.ParentRefs, ur.namespace)
+}
+
+// parseBackendRefs builds the rule for the routes which only carry backends, without any matches and filters.
+// Backends in other namespaces are skipped unless a ReferenceGrant allows the reference.
+func parseBackendRefs(key, routeKind string, backendRefs []gatewayv1.BackendRef, namespace string) *Rule {
+	routeConfigRule := &Rule{}
+	for _, ruleBackend := range backendRefs {
+		backend := &Backend{}
+		backend.Name = string(ruleBackend.Name)
+		if ruleBackend.Namespace != nil {
+			backend.Namespace = string(*ruleBackend.Namespace)
+		} else {
+			backend.Namespace = namespace
+		}
+		if !akogatewayapilib.IsReferencePermitted(routeKind, namespace, utils.Service, backend.Namespace, backend.Name) {
+			utils.AviLog.Warnf("key: %s, msg: skipping the backend %s/%s, reference is not permitted by any ReferenceGrant", key, backend.Namespace, backend.Name)
+			continue
+		}
+		if ruleBackend.Port != nil {
+			//Default 0
+			backend.Port = int32(*ruleBackend.Port)
+		}
+		backend.Weight = 1
+		if ruleBackend.Weight != nil {
+			backend.Weight = *ruleBackend.Weight
+		}
+		routeConfigRule.Backends = append(routeConfigRule.Backends, &HTTPBackend{Backend: backend})
+	}
+	return routeConfigRule
+}
+
+func getParents(parentRefs []gatewayv1.ParentReference, routeNamespace string) sets.Set[string] {
+	parents := sets.New[string]()
+	for _, ref := range parentRefs {
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+// grpcMethodToPathMatch translates the gRPC method match to a path match, as a gRPC request is sent
+// to the path /<service>/<method>. nil is returned for the RegularExpression match, which is not supported.
+func grpcMethodToPathMatch(methodMatch *gatewayv1alpha2.GRPCMethodMatch) *PathMatch {
+	if methodMatch == nil {
+		return &PathMatch{Path: "/", Type: "PathPrefix"}
+	}
+	if methodMatch.Type != nil && *methodMatch.Type == gatewayv1alpha2.GRPCMethodMatchRegularExpression {
+		return nil
+	}
+	var service, method string
+	if methodMatch.Service != nil {
+		service = *methodMatch.Service
+	}
+	if methodMatch.Method != nil {
+		method = *methodMatch.Method
+	}
+	switch {
+	case service != "" && method != "":
+		return &PathMatch{Path: "/" + service + "/" + method, Type: "Exact"}
+	case service != "":
+		return &PathMatch{Path: "/" + service + "/", Type: "PathPrefix"}
+	case method != "":
+		return &PathMatch{Path: "/" + method, Type: "PathSuffix"}
+	}
+	return &PathMatch{Path: "/", Type: "PathPrefix"}
+}
+
+func parsePathModifier(pathModifier *gatewayv1.HTTPPathModifier) *PathModifier {
+	path := &PathModifier{Type: string(pathModifier.Type)}
+	switch pathModifier.Type {
+	case gatewayv1.FullPathHTTPPathModifier:
+		if pathModifier.ReplaceFullPath != nil {
+			path.Value = *pathModifier.ReplaceFullPath
+		}
+	case gatewayv1.PrefixMatchHTTPPathModifier:
+		if pathModifier.ReplacePrefixMatch != nil {
+			path.Value = *pathModifier.ReplacePrefixMatch
+		}
+	}
+	return path
+}
+
+func parseTimeouts(key string, ruleTimeouts *gatewayv1.HTTPRouteTimeouts) *Timeouts {
+	timeouts := &Timeouts{}
+	if ruleTimeouts.Request != nil {
+		timeouts.Request = parseDurationInMilliseconds(key, *ruleTimeouts.Request)
+	}
+	if ruleTimeouts.BackendRequest != nil {
+		timeouts.BackendRequest = parseDurationInMilliseconds(key, *ruleTimeouts.BackendRequest)
+	}
+	if timeouts.Request == nil && timeouts.BackendRequest == nil {
+		return nil
+	}
+	return timeouts
+}
+
+func parseDurationInMilliseconds(key string, duration gatewayv1.Duration) *uint32 {
+	parsedDuration, err := time.ParseDuration(string(duration))
+	if err != nil || parsedDuration < 0 {
+		utils.AviLog.Warnf("key: %s, msg: ignoring invalid timeout %s", key, duration)
+		return nil
+	}
+	milliseconds := uint32(akogatewayapilib.MaxServerTimeout)
+	if parsedDuration.Milliseconds() < akogatewayapilib.MaxServerTimeout {
+		milliseconds = uint32(parsedDuration.Milliseconds())
+	}
+	return &milliseconds
+}
+
+func parseRetryPolicy(key string, annotations map[string]string) *RetryPolicy {
+	attemptsValue, ok := annotations[akogatewayapilib.RetryAttemptsAnnotation]
+	if !ok {
+		return nil
+	}
+	attempts, err := strconv.ParseUint(strings.TrimSpace(attemptsValue), 10, 32)
+	if err != nil || attempts == 0 {
+		utils.AviLog.Warnf("key: %s, msg: ignoring invalid value %s of annotation %s", key, attemptsValue, akogatewayapilib.RetryAttemptsAnnotation)
+		return nil
+	}
+	retryPolicy := &RetryPolicy{Attempts: uint32(attempts)}
+
+	codesValue, ok := annotations[akogatewayapilib.RetryCodesAnnotation]
+	if !ok {
+		return retryPolicy
+	}
+	for _, codeValue := range strings.Split(codesValue, ",") {
+		code, err := strconv.ParseInt(strings.TrimSpace(codeValue), 10, 64)
+		if err != nil || code < 400 || code > 599 {
+			utils.AviLog.Warnf("key: %s, msg: ignoring invalid code %s in annotation %s", key, codeValue, akogatewayapilib.RetryCodesAnnotation)
+			continue
+		}
+		retryPolicy.Codes = append(retryPolicy.Codes, code)
+	}
+	slices.Sort(retryPolicy.Codes)
+	return retryPolicy
+}
+
+func parseHeaderFilter(headerModifier *gatewayv1.HTTPHeaderFilter) *HeaderFilter {
+	headerFilter := &HeaderFilter{}
+	headerFilter.Add = make([]*Header, 0, len(headerModifier.Add))
+	for _, addFilter := range headerModifier.Add {
+		addHeader := &Header{
+			Name:  string(addFilter.Name),
+			Value: addFilter.Value,
+		}
+		headerFilter.Add = append(headerFilter.Add, addHeader)
+	}
+	headerFilter.Set = make([]*Header, 0, len(headerModifier.Set))
+	for _, setFilter := range headerModifier.Set {
+		setHeader := &Header{
+			Name:  string(setFilter.Name),
+			Value: setFilter.Value,
+		}
+		headerFilter.Set = append(headerFilter.Set, setHeader)
+	}
+	headerFilter.Remove = make([]string, len(headerModifier.Remove))
+	copy(headerFilter.Remove, headerModifier.Remove)
+
+	sort.Sort((Headers)(headerFilter.Add))
+	sort.Sort((Headers)(headerFilter.Set))
+	sort.Strings(headerFilter.Remove)
+	return headerFilter
+}
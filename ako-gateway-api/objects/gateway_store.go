@@ -47,6 +47,7 @@ func GatewayApiLister() *GWLister {
 			gatewayRouteToHostnameStore:    objects.NewObjectMapStore(),
 			gatewayRouteToHTTPSPGPoolStore: objects.NewObjectMapStore(),
 			podToServiceStore:              objects.NewObjectMapStore(),
+			invalidRouteStore:              objects.NewObjectMapStore(),
 		}
 	})
 	return gwLister
@@ -113,6 +114,10 @@ type GWLister struct {
 	//Pods -> Service Mapping for NPL
 	//podNs/podName -> [svcNs/svcName, ...]
 	podToServiceStore *objects.ObjectMapStore
+
+	// Routes which failed the validation
+	// routeType/routeNs/routeName -> struct{}
+	invalidRouteStore *objects.ObjectMapStore
 }
 
 type GatewayRouteKind struct {
@@ -680,6 +685,8 @@ func (g *GWLister) DeleteRouteFromStore(routeTypeNsName string) {
 	//delete route to gateway listener
 	g.routeToGatewayListener.Delete(routeTypeNsName)
 
+	g.invalidRouteStore.Delete(routeTypeNsName)
+
 	//delete route to gateway
 	found, gatewayList := g.routeToGateway.Get(routeTypeNsName)
 	var gatewayListObj, svcListObj []string
@@ -889,3 +896,24 @@ func (g *GWLister) DeletePodsToService(podNsName string) {
 
 	g.podToServiceStore.Delete(podNsName)
 }
+
+//Invalid routes
+
+func (g *GWLister) IsRouteValid(routeTypeNsName string) bool {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, _ := g.invalidRouteStore.Get(routeTypeNsName)
+	return !found
+}
+
+func (g *GWLister) UpdateRouteValidity(routeTypeNsName string, valid bool) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	if valid {
+		g.invalidRouteStore.Delete(routeTypeNsName)
+		return
+	}
+	g.invalidRouteStore.AddOrUpdate(routeTypeNsName, struct{}{})
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+)
+
+// GetCertificateOwners returns the Gateway, for which a virtual service is built.
+func GetCertificateOwners(svcMetadata lib.ServiceMetadataObj) []runtime.Object {
+	namespace, name, found := strings.Cut(svcMetadata.Gateway, "/")
+	if !found {
+		return nil
+	}
+	gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	return []runtime.Object{gateway}
+}
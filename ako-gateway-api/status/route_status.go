@@ -0,0 +1,199 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// routeKind retrieves the routes of a kind, and reads and patches their status.
+type routeKind interface {
+	get(namespace, name string) (runtime.Object, error)
+	// routeStatus returns the status of the route, and the status to be set on the route.
+	routeStatus(obj runtime.Object, status *Status) (*gatewayv1.RouteStatus, *gatewayv1.RouteStatus)
+	patch(namespace, name string, patchPayload []byte) error
+}
+
+// route publishes the status of the routes of the kinds other than HTTPRoute, which share the RouteStatus.
+type route struct {
+	kind string
+	routeKind
+}
+
+func (o *route) Get(key string, name string, namespace string) runtime.Object {
+
+	obj, err := o.get(namespace, name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the %s object. err: %s", key, o.kind, err)
+		return nil
+	}
+	utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the %s object %s", key, o.kind, name)
+	return obj
+}
+
+func (o *route) Delete(key string, option status.StatusOptions) {
+	// TODO: Add this code when we publish the status from the rest layer
+}
+
+func (o *route) Update(key string, option status.StatusOptions) {
+	// TODO: Add this code when we publish the status from the rest layer
+}
+
+func (o *route) BulkUpdate(key string, options []status.StatusOptions) {
+	// TODO: Add this code when we publish the status from the rest layer
+}
+
+func (o *route) Patch(key string, obj runtime.Object, status *Status, retryNum ...int) {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: Patch retried 5 times, aborting", key)
+			return
+		}
+	}
+
+	routeObj, err := meta.Accessor(obj)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to read the %s object. err: %s", key, o.kind, err)
+		return
+	}
+	oldStatus, newStatus := o.routeStatus(obj, status)
+	if o.isStatusEqual(oldStatus, newStatus) {
+		return
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": newStatus,
+	})
+	err = o.patch(routeObj.GetNamespace(), routeObj.GetName(), patchPayload)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the %s status. err: %+v, retry: %d", key, o.kind, err, retry)
+		updatedObj, err := o.get(routeObj.GetNamespace(), routeObj.GetName())
+		if err != nil {
+			utils.AviLog.Warnf("%s not found %v", o.kind, err)
+			return
+		}
+		o.Patch(key, updatedObj, status, retry+1)
+		return
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the %s %s/%s status %+v", key, o.kind, routeObj.GetNamespace(), routeObj.GetName(), utils.Stringify(status))
+}
+
+func (o *route) isStatusEqual(old, new *gatewayv1.RouteStatus) bool {
+	oldStatus, newStatus := old.DeepCopy(), new.DeepCopy()
+	currentTime := metav1.Now()
+	for i := range oldStatus.Parents {
+		for j := range oldStatus.Parents[i].Conditions {
+			oldStatus.Parents[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	for i := range newStatus.Parents {
+		for j := range newStatus.Parents[i].Conditions {
+			newStatus.Parents[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	return reflect.DeepEqual(oldStatus, newStatus)
+}
+
+type grpcRouteKind struct{}
+
+func (grpcRouteKind) get(namespace, name string) (runtime.Object, error) {
+	obj, err := akogatewayapilib.GetGRPCRoute(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (grpcRouteKind) routeStatus(obj runtime.Object, status *Status) (*gatewayv1.RouteStatus, *gatewayv1.RouteStatus) {
+	return &obj.(*gatewayv1alpha2.GRPCRoute).Status.RouteStatus, &status.GRPCRouteStatus.RouteStatus
+}
+
+func (grpcRouteKind) patch(namespace, name string, patchPayload []byte) error {
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().GRPCRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	return err
+}
+
+type tlsRouteKind struct{}
+
+func (tlsRouteKind) get(namespace, name string) (runtime.Object, error) {
+	obj, err := akogatewayapilib.GetTLSRoute(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (tlsRouteKind) routeStatus(obj runtime.Object, status *Status) (*gatewayv1.RouteStatus, *gatewayv1.RouteStatus) {
+	return &obj.(*gatewayv1alpha2.TLSRoute).Status.RouteStatus, &status.TLSRouteStatus.RouteStatus
+}
+
+func (tlsRouteKind) patch(namespace, name string, patchPayload []byte) error {
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().TLSRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	return err
+}
+
+type tcpRouteKind struct{}
+
+func (tcpRouteKind) get(namespace, name string) (runtime.Object, error) {
+	obj, err := akogatewayapilib.GetTCPRoute(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (tcpRouteKind) routeStatus(obj runtime.Object, status *Status) (*gatewayv1.RouteStatus, *gatewayv1.RouteStatus) {
+	return &obj.(*gatewayv1alpha2.TCPRoute).Status.RouteStatus, &status.TCPRouteStatus.RouteStatus
+}
+
+func (tcpRouteKind) patch(namespace, name string, patchPayload []byte) error {
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().TCPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	return err
+}
+
+type udpRouteKind struct{}
+
+func (udpRouteKind) get(namespace, name string) (runtime.Object, error) {
+	obj, err := akogatewayapilib.GetUDPRoute(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (udpRouteKind) routeStatus(obj runtime.Object, status *Status) (*gatewayv1.RouteStatus, *gatewayv1.RouteStatus) {
+	return &obj.(*gatewayv1alpha2.UDPRoute).Status.RouteStatus, &status.UDPRouteStatus.RouteStatus
+}
+
+func (udpRouteKind) patch(namespace, name string, patchPayload []byte) error {
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().UDPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	return err
+}
@@ -17,6 +17,7 @@ package status
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
@@ -34,6 +35,10 @@ type Status struct {
 	*gatewayv1.GatewayClassStatus
 	*gatewayv1.GatewayStatus
 	*gatewayv1.HTTPRouteStatus
+	*gatewayv1alpha2.GRPCRouteStatus
+	*gatewayv1alpha2.TLSRouteStatus
+	*gatewayv1alpha2.TCPRouteStatus
+	*gatewayv1alpha2.UDPRouteStatus
 }
 
 func New(ObjectType string) StatusUpdater {
@@ -44,6 +49,14 @@ func New(ObjectType string) StatusUpdater {
 		return &gateway{}
 	case lib.HTTPRoute:
 		return &httproute{}
+	case lib.GRPCRoute:
+		return &route{kind: ObjectType, routeKind: grpcRouteKind{}}
+	case lib.TLSRoute:
+		return &route{kind: ObjectType, routeKind: tlsRouteKind{}}
+	case lib.TCPRoute:
+		return &route{kind: ObjectType, routeKind: tcpRouteKind{}}
+	case lib.UDPRoute:
+		return &route{kind: ObjectType, routeKind: udpRouteKind{}}
 	}
 	return nil
 }
@@ -89,6 +102,14 @@ func Record(key string, obj runtime.Object, status *Status) {
 		objectType = lib.Gateway
 	case *gatewayv1.HTTPRoute:
 		objectType = lib.HTTPRoute
+	case *gatewayv1alpha2.GRPCRoute:
+		objectType = lib.GRPCRoute
+	case *gatewayv1alpha2.TLSRoute:
+		objectType = lib.TLSRoute
+	case *gatewayv1alpha2.TCPRoute:
+		objectType = lib.TCPRoute
+	case *gatewayv1alpha2.UDPRoute:
+		objectType = lib.UDPRoute
 	default:
 		utils.AviLog.Warnf("key %s, msg: Unsupported object received at the status layer, %T", key, obj)
 		return
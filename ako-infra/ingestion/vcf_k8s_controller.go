@@ -44,7 +44,6 @@ var WorkloadNamespaceCount int = 0
 var countLock sync.RWMutex
 
 type VCFK8sController struct {
-	worker_id        uint32
 	informers        *utils.Informers
 	dynamicInformers *lib.DynamicInformers
 	//workqueue        []workqueue.RateLimitingInterface
@@ -55,7 +54,6 @@ type VCFK8sController struct {
 func SharedVCFK8sController() *VCFK8sController {
 	ctrlonce.Do(func() {
 		controllerInstance = &VCFK8sController{
-			worker_id:        (uint32(1) << utils.NumWorkersIngestion) - 1,
 			informers:        utils.GetInformers(),
 			dynamicInformers: lib.GetDynamicInformers(),
 			DisableSync:      true,
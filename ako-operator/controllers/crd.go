@@ -29,11 +29,13 @@ const (
 	aviinfrasettingCRDLocation = "/var/crds/ako.vmware.com_aviinfrasettings.yaml"
 	l4ruleCRDLocation          = "/var/crds/ako.vmware.com_l4rules.yaml"
 	ssoruleCRDLocation         = "/var/crds/ako.vmware.com_ssorules.yaml"
+	routeRuleExtCRDLocation    = "/var/crds/ako.vmware.com_routeruleextensions.yaml"
 	hostRuleFullCRDName        = "hostrules.ako.vmware.com"
 	httpRuleFullCRDName        = "httprules.ako.vmware.com"
 	aviInfraSettingFullCRDName = "aviinfrasettings.ako.vmware.com"
 	l4RuleFullCRDName          = "l4rules.ako.vmware.com"
 	ssoRuleFullCRDName         = "ssorules.ako.vmware.com"
+	routeRuleExtFullCRDName    = "routeruleextensions.ako.vmware.com"
 )
 
 var (
@@ -42,11 +44,13 @@ var (
 	aviinfrasettingCRD  *apiextensionv1.CustomResourceDefinition
 	l4ruleCRD           *apiextensionv1.CustomResourceDefinition
 	ssoruleCRD          *apiextensionv1.CustomResourceDefinition
+	routeRuleExtCRD     *apiextensionv1.CustomResourceDefinition
 	hostruleOnce        sync.Once
 	httpruleOnce        sync.Once
 	aviinfrasettingOnce sync.Once
 	l4ruleOnce          sync.Once
 	ssoruleOnce         sync.Once
+	routeRuleExtOnce    sync.Once
 )
 
 func readCRDFromManifest(crdLocation string, log logr.Logger) (*apiextensionv1.CustomResourceDefinition, error) {
@@ -269,6 +273,45 @@ func createSSORuleCRD(clientset *apiextension.ApiextensionsV1Client, log logr.Lo
 	return err
 }
 
+func createRouteRuleExtensionCRD(clientset *apiextension.ApiextensionsV1Client, log logr.Logger) error {
+	var err error
+	routeRuleExtOnce.Do(func() {
+		routeRuleExtCRD, err = readCRDFromManifest(routeRuleExtCRDLocation, log)
+	})
+	if err != nil {
+		return err
+	} else if routeRuleExtCRD == nil {
+		return errors.New(fmt.Sprintf("Failure while reading %s CRD manifest", routeRuleExtFullCRDName))
+	}
+
+	existingRouteRuleExt, err := clientset.CustomResourceDefinitions().Get(context.TODO(), routeRuleExtFullCRDName, v1.GetOptions{})
+	if err == nil && existingRouteRuleExt != nil {
+		if routeRuleExtCRD.GetResourceVersion() == existingRouteRuleExt.GetResourceVersion() {
+			log.Info(fmt.Sprintf("no updates required for %s CRD", routeRuleExtFullCRDName))
+		} else {
+			routeRuleExtCRD.SetResourceVersion(existingRouteRuleExt.GetResourceVersion())
+			_, err = clientset.CustomResourceDefinitions().Update(context.TODO(), routeRuleExtCRD, v1.UpdateOptions{})
+			if err != nil {
+				log.Error(err, fmt.Sprintf("Error while updating %s CRD", routeRuleExtFullCRDName))
+				return err
+			} else {
+				log.Info(fmt.Sprintf("successfully updated %s CRD", routeRuleExtFullCRDName))
+			}
+		}
+		return nil
+	}
+
+	_, err = clientset.CustomResourceDefinitions().Create(context.TODO(), routeRuleExtCRD, v1.CreateOptions{})
+	if err == nil {
+		log.Info(fmt.Sprintf("%s CRD created", routeRuleExtFullCRDName))
+		return nil
+	} else if apierrors.IsAlreadyExists(err) {
+		log.Info(fmt.Sprintf("%s CRD already exists", routeRuleExtFullCRDName))
+		return nil
+	}
+	return err
+}
+
 func createCRDs(cfg *rest.Config, log logr.Logger) error {
 	kubeClient, _ := apiextension.NewForConfig(cfg)
 
@@ -292,6 +335,10 @@ func createCRDs(cfg *rest.Config, log logr.Logger) error {
 	if err != nil {
 		return err
 	}
+	err = createRouteRuleExtensionCRD(kubeClient, log)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -306,7 +353,7 @@ func deleteCRDs(cfg *rest.Config, log logr.Logger) error {
 			}
 			log.Info(fmt.Sprintf("%s crd deleted successfully", crdFullName))
 		}
-	}(hostRuleFullCRDName, httpRuleFullCRDName, aviInfraSettingFullCRDName, l4RuleFullCRDName, ssoRuleFullCRDName)
+	}(hostRuleFullCRDName, httpRuleFullCRDName, aviInfraSettingFullCRDName, l4RuleFullCRDName, ssoRuleFullCRDName, routeRuleExtFullCRDName)
 	if err != nil {
 		return err
 	}
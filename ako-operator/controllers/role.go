@@ -139,7 +139,7 @@ func BuildClusterrole(ako akov1alpha1.AKOConfig, r *AKOConfigReconciler, log log
 			},
 			{
 				APIGroups: []string{"ako.vmware.com"},
-				Resources: []string{"hostrules", "hostrules/status", "httprules", "httprules/status", "aviinfrasettings", "aviinfrasettings/status", "l4rules", "l4rules/status", "ssorules", "ssorules/status"},
+				Resources: []string{"hostrules", "hostrules/status", "httprules", "httprules/status", "aviinfrasettings", "aviinfrasettings/status", "l4rules", "l4rules/status", "ssorules", "ssorules/status", "l7rules", "l7rules/status", "routeruleextensions", "routeruleextensions/status"},
 				Verbs:     []string{"get", "watch", "list", "patch", "update"},
 			},
 			{
@@ -159,7 +159,7 @@ func BuildClusterrole(ako akov1alpha1.AKOConfig, r *AKOConfigReconciler, log log
 			},
 			{
 				APIGroups: []string{"gateway.networking.k8s.io"},
-				Resources: []string{"gatewayclasses", "gatewayclasses/status", "gateways", "gateways/status", "httproutes", "httproutes/status"},
+				Resources: []string{"gatewayclasses", "gatewayclasses/status", "gateways", "gateways/status", "httproutes", "httproutes/status", "grpcroutes", "grpcroutes/status", "tlsroutes", "tlsroutes/status", "tcproutes", "tcproutes/status", "udproutes", "udproutes/status", "referencegrants", "backendtlspolicies"},
 				Verbs:     []string{"get", "watch", "list", "patch", "update"},
 			},
 		},
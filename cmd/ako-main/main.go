@@ -28,6 +28,7 @@ import (
 	"github.com/go-logr/logr"
 
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/introspect"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api"
@@ -69,7 +70,16 @@ func InitializeAKOApi() {
 	if lib.IsPrometheusEnabled() {
 		lib.SetPrometheusRegistry()
 	}
-	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{}, lib.IsPrometheusEnabled(), lib.GetPrometheusRegistry())
+	apiModels := []models.ApiModel{}
+	if lib.IsIntrospectionEnabled() {
+		// Exposes the internal state of AKO, which is useful for troubleshooting.
+		apiModels = append(apiModels, &introspect.IntrospectModel{})
+	}
+	if lib.IsDryRunEnabled() {
+		// Exposes the pending changes of the virtualservices, which are not applied to the controller.
+		apiModels = append(apiModels, models.DryRun)
+	}
+	akoApi := api.NewServer(lib.GetAkoApiServerPort(), apiModels, lib.IsPrometheusEnabled(), lib.GetPrometheusRegistry())
 	akoApi.InitApi()
 	lib.SetApiServerInstance(akoApi)
 }
@@ -281,6 +291,7 @@ func InitializeAKC() {
 
 	if akoControlConfig.GetAKOAKOPrometheusFlag() {
 		lib.RegisterPromMetrics()
+		avicache.RegisterCachePromMetrics()
 	}
 
 	if aviRestClientPool != nil && !avicache.IsAviClusterActive(aviRestClientPool.AviClient[0]) {
@@ -334,6 +345,10 @@ func InitializeAKC() {
 		<-istioUpdateCh
 	}
 
+	if lib.IsValidatingWebhookEnabled() {
+		go k8s.StartValidatingWebhook(stopCh)
+	}
+
 	go c.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
 	<-stopCh
 	close(ctrlCh)
@@ -33,6 +33,9 @@ import (
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	v1alpha2crd "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -52,6 +55,15 @@ func main() {
 	Initialize()
 }
 
+func InitializeGatewayApi() {
+	if lib.IsPrometheusEnabled() {
+		lib.SetPrometheusRegistry()
+	}
+	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{}, lib.IsPrometheusEnabled(), lib.GetPrometheusRegistry())
+	akoApi.InitApi()
+	lib.SetApiServerInstance(akoApi)
+}
+
 func Initialize() {
 
 	os.Setenv(lib.ENABLE_EVH, "true")
@@ -60,12 +72,17 @@ func Initialize() {
 	os.Setenv("SHARD_VS_SIZE", "LARGE")
 	os.Setenv("PASSTHROUGH_SHARD_SIZE", "LARGE")
 	os.Setenv(lib.DISABLE_STATIC_ROUTE_SYNC, "false")
-	os.Setenv("PROMETHEUS_ENABLED", "false")
 	os.Setenv("PRIMARY_AKO_FLAG", "false")
+	if os.Getenv("AKO_API_PORT") == "" {
+		// The API server of the AKO container runs on the default port in the same pod.
+		os.Setenv("AKO_API_PORT", akogatewaylib.DefaultApiServerPort)
+	}
 
 	utils.AviLog.SetLevel("DEBUG") // TODO: integrate the configmap to this pod and remove this hardcoding.
 	utils.AviLog.Infof("AKO is running with version: %s", version)
 
+	InitializeGatewayApi()
+
 	kubeCluster := false
 	// Check if we are running inside kubernetes. Hence try authenticating with service token
 	cfg, err := rest.InClusterConfig()
@@ -92,6 +109,7 @@ func Initialize() {
 	//TODO handle leader logic, must not be used with HA
 	lib.AKOControlConfig().SetIsLeaderFlag(true)
 	lib.AKOControlConfig().SetEndpointSlicesEnabled(lib.GetEndpointSliceEnabled())
+	lib.AKOControlConfig().SetAKOPrometheusFlag(lib.IsPrometheusEnabled())
 
 	gwApiClient, err := gatewayclientset.NewForConfig(cfg)
 	if err != nil {
@@ -99,6 +117,13 @@ func Initialize() {
 	}
 	akoControlConfig.SetGatewayAPIClientset(gwApiClient)
 
+	// AKO CRDs can be referred by the ExtensionRef filters of the HTTPRoutes
+	v1alpha2crdClient, err := v1alpha2crd.NewForConfig(cfg)
+	if err != nil {
+		utils.AviLog.Fatalf("Error building AKO CRD v1alpha2 clientset: %s", err.Error())
+	}
+	lib.AKOControlConfig().Setv1alpha2CRDClientset(v1alpha2crdClient)
+
 	kubeClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		utils.AviLog.Fatalf("Error building kubernetes clientset: %s", err.Error())
@@ -140,6 +165,11 @@ func Initialize() {
 		utils.AviLog.Fatalf("Avi client not initialized")
 	}
 
+	if lib.AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		lib.RegisterPromMetrics()
+		avicache.RegisterCachePromMetrics()
+	}
+
 	if aviRestClientPool != nil && !avicache.IsAviClusterActive(aviRestClientPool.AviClient[0]) {
 		akoControlConfig.PodEventf(corev1.EventTypeWarning, lib.AKOShutdown, "Avi Controller Cluster state is not Active")
 		utils.AviLog.Fatalf("Avi Controller Cluster state is not Active, shutting down AKO")
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+)
+
+// RegisterCachePromMetrics registers the number of objects in each of the Avi object caches, and the validity of the
+// certificates in the cache, which are read when the metrics are scraped.
+// This is to be called after lib.RegisterPromMetrics.
+func RegisterCachePromMetrics() {
+	aviObjCache := SharedAviObjCache()
+	objCaches := map[string]*AviCache{
+		"VirtualService":                aviObjCache.VsCacheMeta,
+		"Pool":                          aviObjCache.PoolCache,
+		"PoolGroup":                     aviObjCache.PgCache,
+		"VsVip":                         aviObjCache.VSVIPCache,
+		"HTTPPolicySet":                 aviObjCache.HTTPPolicyCache,
+		"L4PolicySet":                   aviObjCache.L4PolicyCache,
+		"SSLKeyAndCertificate":          aviObjCache.SSLKeyCache,
+		"PKIProfile":                    aviObjCache.PKIProfileCache,
+		"VSDataScriptSet":               aviObjCache.DSCache,
+		"StringGroup":                   aviObjCache.StringGroupCache,
+		"NetworkSecurityPolicy":         aviObjCache.NSPCache,
+		"HealthMonitor":                 aviObjCache.HMCache,
+		"ApplicationPersistenceProfile": aviObjCache.AppPersistCache,
+		"ApplicationProfile":            aviObjCache.AppProfileCache,
+		"VrfContext":                    aviObjCache.VrfCache,
+	}
+	for objectType, objCache := range objCaches {
+		objCache := objCache
+		lib.GetPrometheusRegistry().MustRegister(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace:   "ako",
+				Subsystem:   lib.GetPromSubsystem(),
+				Name:        "cache_objects",
+				Help:        "Number of objects in the Avi object cache of AKO, per object type.",
+				ConstLabels: prometheus.Labels{"object_type": objectType},
+			},
+			func() float64 {
+				return float64(objCache.Size())
+			},
+		))
+	}
+	lib.GetPrometheusRegistry().MustRegister(newCertificateCollector())
+}
@@ -41,6 +41,9 @@ type AviPoolCache struct {
 	LastModified         string
 	InvalidData          bool
 	HasReference         bool
+
+	HealthMonitorCollection      NamespaceName
+	PersistenceProfileCollection NamespaceName
 }
 
 type AviDSCache struct {
@@ -88,6 +91,12 @@ type AviVsCache struct {
 	InvalidData              bool
 	VSCacheLock              sync.RWMutex
 	StringGroupKeyCollection []NamespaceName
+
+	NetworkSecurityPolicyCollection []NamespaceName
+	// The health monitors and the persistence profiles are referred by the pools, these are collected
+	// only for the stale objects which are not referred by any pool.
+	HealthMonitorCollection      []NamespaceName
+	PersistenceProfileCollection []NamespaceName
 }
 
 func (c *AviCache) AviCacheAddVS(k NamespaceName) *AviVsCache {
@@ -264,6 +273,22 @@ func (v *AviVsCache) RemoveFromL4PolicyCollection(k NamespaceName) {
 	v.L4PolicyCollection = RemoveNamespaceName(v.L4PolicyCollection, k)
 }
 
+func (v *AviVsCache) AddToNetworkSecurityPolicyCollection(k NamespaceName) {
+	if v.NetworkSecurityPolicyCollection == nil {
+		v.NetworkSecurityPolicyCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.NetworkSecurityPolicyCollection, k) {
+		v.NetworkSecurityPolicyCollection = append(v.NetworkSecurityPolicyCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromNetworkSecurityPolicyCollection(k NamespaceName) {
+	if v.NetworkSecurityPolicyCollection == nil {
+		return
+	}
+	v.NetworkSecurityPolicyCollection = RemoveNamespaceName(v.NetworkSecurityPolicyCollection, k)
+}
+
 func (v *AviVsCache) AddToSNIChildCollection(k string) {
 	if v.SNIChildCollection == nil {
 		v.SNIChildCollection = []string{k}
@@ -361,6 +386,41 @@ type AviL4PolicyCache struct {
 	HasReference     bool
 }
 
+type AviNetworkSecurityPolicyCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	HasReference     bool
+}
+
+type AviHealthMonitorCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	HasReference     bool
+}
+
+type AviPersistenceProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	HasReference     bool
+}
+
+type AviAppProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+}
+
 type AviVrfCache struct {
 	Name             string
 	Uuid             string
@@ -512,6 +572,30 @@ func (c *AviCache) AviCacheGetNameByUuid(uuid string) (interface{}, bool) {
 			} else if value.(*AviL4PolicyCache).Uuid == uuid {
 				return value.(*AviL4PolicyCache).Name, true
 			}
+		case *AviNetworkSecurityPolicyCache:
+			if value.(*AviNetworkSecurityPolicyCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for network security policy key %v", reflect.ValueOf(key))
+			} else if value.(*AviNetworkSecurityPolicyCache).Uuid == uuid {
+				return value.(*AviNetworkSecurityPolicyCache).Name, true
+			}
+		case *AviHealthMonitorCache:
+			if value.(*AviHealthMonitorCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for health monitor key %v", reflect.ValueOf(key))
+			} else if value.(*AviHealthMonitorCache).Uuid == uuid {
+				return value.(*AviHealthMonitorCache).Name, true
+			}
+		case *AviPersistenceProfileCache:
+			if value.(*AviPersistenceProfileCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for persistence profile key %v", reflect.ValueOf(key))
+			} else if value.(*AviPersistenceProfileCache).Uuid == uuid {
+				return value.(*AviPersistenceProfileCache).Name, true
+			}
+		case *AviAppProfileCache:
+			if value.(*AviAppProfileCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for application profile key %v", reflect.ValueOf(key))
+			} else if value.(*AviAppProfileCache).Uuid == uuid {
+				return value.(*AviAppProfileCache).Name, true
+			}
 		case *AviHTTPPolicyCache:
 			if value.(*AviHTTPPolicyCache) == nil {
 				utils.AviLog.Warnf("Got nil value in cache for http policy key %v", reflect.ValueOf(key))
@@ -553,6 +637,12 @@ func (c *AviCache) AviCacheDelete(k interface{}) {
 	delete(c.cache, k)
 }
 
+func (c *AviCache) Size() int {
+	c.cache_lock.RLock()
+	defer c.cache_lock.RUnlock()
+	return len(c.cache)
+}
+
 func (c *AviCache) ShallowCopy() map[interface{}]interface{} {
 	// Shallow copy, does not dereference the pointers.
 	c.cache_lock.Lock()
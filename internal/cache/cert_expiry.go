@@ -0,0 +1,161 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// VSCertificate is a certificate, which is programmed on a virtual service.
+type VSCertificate struct {
+	VS          *AviVsCache
+	Key         NamespaceName
+	Certificate *x509.Certificate
+	// MismatchedHosts are the hostnames of the virtual service, which are not covered by the certificate.
+	MismatchedHosts []string
+}
+
+// ParseCertificate returns the first certificate in the PEM data of the SSL key and certificate.
+func (s *AviSSLCache) ParseCertificate() (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s.Cert))
+	if block == nil {
+		return nil, errors.New("certificate is not PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// GetVSCertificates returns the certificates, which are programmed on the virtual services in the cache.
+func GetVSCertificates() []VSCertificate {
+	var vsCertificates []VSCertificate
+	aviObjCache := SharedAviObjCache()
+	for _, vsKey := range aviObjCache.VsCacheMeta.AviGetAllKeys() {
+		obj, ok := aviObjCache.VsCacheMeta.AviCacheGet(vsKey)
+		if !ok {
+			continue
+		}
+		vsCacheObj, ok := obj.(*AviVsCache)
+		if !ok {
+			continue
+		}
+		for _, sslKey := range vsCacheObj.SSLKeyCertCollection {
+			certificate := getCertificate(sslKey)
+			// The CA certificates are referred by the server certificates, and are not served for the hostnames.
+			if certificate == nil || certificate.IsCA {
+				continue
+			}
+			vsCertificate := VSCertificate{
+				VS:          vsCacheObj,
+				Key:         sslKey,
+				Certificate: certificate,
+			}
+			// The certificates of a parent virtual service are selected per listener or SNI, hence these are not
+			// matched against all the hostnames of the virtual service.
+			if len(vsCacheObj.SNIChildCollection) == 0 {
+				vsCertificate.MismatchedHosts = getMismatchedHosts(certificate, vsCacheObj.ServiceMetadataObj.HostNames)
+			}
+			vsCertificates = append(vsCertificates, vsCertificate)
+		}
+	}
+	return vsCertificates
+}
+
+func getCertificate(sslKey NamespaceName) *x509.Certificate {
+	obj, ok := SharedAviObjCache().SSLKeyCache.AviCacheGet(sslKey)
+	if !ok {
+		return nil
+	}
+	sslCacheObj, ok := obj.(*AviSSLCache)
+	if !ok || sslCacheObj.Cert == "" {
+		return nil
+	}
+	certificate, err := sslCacheObj.ParseCertificate()
+	if err != nil {
+		utils.AviLog.Debugf("Unable to parse the certificate of sslkeyandcertificate %s/%s: %v", sslKey.Namespace, sslKey.Name, err)
+		return nil
+	}
+	return certificate
+}
+
+func getMismatchedHosts(certificate *x509.Certificate, hosts []string) []string {
+	var mismatchedHosts []string
+	for _, host := range hosts {
+		// Wildcard hosts are matched only by the same wildcard name in the certificate.
+		if strings.HasPrefix(host, "*") {
+			if !utils.HasElem(certificate.DNSNames, host) {
+				mismatchedHosts = append(mismatchedHosts, host)
+			}
+			continue
+		}
+		if err := certificate.VerifyHostname(host); err != nil {
+			mismatchedHosts = append(mismatchedHosts, host)
+		}
+	}
+	return mismatchedHosts
+}
+
+// certificateCollector reports the validity of the certificates, which are programmed by AKO on the Avi controller.
+// The certificates are read from the cache, when the metrics are scraped.
+type certificateCollector struct {
+	expiry       *prometheus.Desc
+	hostMismatch *prometheus.Desc
+}
+
+func newCertificateCollector() *certificateCollector {
+	return &certificateCollector{
+		expiry: prometheus.NewDesc(
+			prometheus.BuildFQName("ako", lib.GetPromSubsystem(), "certificate_expiry_timestamp_seconds"),
+			"Unix time at which a certificate, programmed on the Avi controller, expires.",
+			[]string{"tenant", "certificate", "common_name"},
+			nil,
+		),
+		hostMismatch: prometheus.NewDesc(
+			prometheus.BuildFQName("ako", lib.GetPromSubsystem(), "certificate_host_mismatch"),
+			"1 if the certificate of a virtual service does not match a hostname of the virtual service.",
+			[]string{"tenant", "virtualservice", "certificate", "host"},
+			nil,
+		),
+	}
+}
+
+func (c *certificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.expiry
+	ch <- c.hostMismatch
+}
+
+func (c *certificateCollector) Collect(ch chan<- prometheus.Metric) {
+	sslKeyCache := SharedAviObjCache().SSLKeyCache
+	for _, sslKey := range sslKeyCache.AviGetAllKeys() {
+		certificate := getCertificate(sslKey)
+		if certificate == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.expiry, prometheus.GaugeValue, float64(certificate.NotAfter.Unix()),
+			sslKey.Namespace, sslKey.Name, certificate.Subject.CommonName)
+	}
+	for _, vsCertificate := range GetVSCertificates() {
+		for _, host := range vsCertificate.MismatchedHosts {
+			ch <- prometheus.MustNewConstMetric(c.hostMismatch, prometheus.GaugeValue, 1,
+				vsCertificate.VS.Tenant, vsCertificate.VS.Name, vsCertificate.Key.Name, host)
+		}
+	}
+}
@@ -48,6 +48,10 @@ type AviObjCache struct {
 	CloudKeyCache      *AviCache
 	HTTPPolicyCache    *AviCache
 	L4PolicyCache      *AviCache
+	NSPCache           *AviCache
+	HMCache            *AviCache
+	AppPersistCache    *AviCache
+	AppProfileCache    *AviCache
 	SSLKeyCache        *AviCache
 	PKIProfileCache    *AviCache
 	VSVIPCache         *AviCache
@@ -69,6 +73,10 @@ func NewAviObjCache() *AviObjCache {
 	c.CloudKeyCache = NewAviCache()
 	c.HTTPPolicyCache = NewAviCache()
 	c.L4PolicyCache = NewAviCache()
+	c.NSPCache = NewAviCache()
+	c.HMCache = NewAviCache()
+	c.AppPersistCache = NewAviCache()
+	c.AppProfileCache = NewAviCache()
 	c.VSVIPCache = NewAviCache()
 	c.VrfCache = NewAviCache()
 	c.PKIProfileCache = NewAviCache()
@@ -99,6 +107,9 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 		c.PopulateVsVipDataToCache(client[7], cloud, tenant)
 	}()
 	c.PopulatePkiProfilesToCache(client[0], cloud, tenant)
+	c.PopulateHealthMonitorsToCache(client[0], cloud, tenant)
+	c.PopulatePersistenceProfilesToCache(client[0], cloud, tenant)
+	c.PopulateAppProfilesToCache(client[0], cloud, tenant)
 	c.PopulatePoolsToCache(client[1], cloud, tenant)
 	c.PopulatePgDataToCache(client[2], cloud, tenant)
 	c.PopulateStringGroupDataToCache(client[8], cloud, tenant)
@@ -114,6 +125,7 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 	go func() {
 		defer wg.Done()
 		c.PopulateL4PolicySetToCache(client[6], cloud, tenant)
+		c.PopulateNetworkSecurityPolicyToCache(client[6], cloud, tenant)
 	}()
 
 	wg.Wait()
@@ -291,6 +303,14 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 		}
 	}
 
+	for _, objKey := range vsCacheObj.NetworkSecurityPolicyCollection {
+		if intf, found := c.NSPCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviNetworkSecurityPolicyCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
+
 	for _, objKey := range vsCacheObj.PGKeyCollection {
 		if intf, found := c.PgCache.AviCacheGet(objKey); found {
 			if obj, ok := intf.(*AviPGCache); ok {
@@ -303,6 +323,7 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 		if intf, found := c.PoolCache.AviCacheGet(objKey); found {
 			if obj, ok := intf.(*AviPoolCache); ok {
 				obj.HasReference = true
+				c.markPoolReference(obj)
 			}
 		}
 	}
@@ -324,12 +345,27 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 	}
 }
 
+// markPoolReference marks the health monitor and the persistence profile referred by a pool.
+func (c *AviObjCache) markPoolReference(poolCacheObj *AviPoolCache) {
+	if intf, found := c.HMCache.AviCacheGet(poolCacheObj.HealthMonitorCollection); found {
+		if obj, ok := intf.(*AviHealthMonitorCache); ok {
+			obj.HasReference = true
+		}
+	}
+	if intf, found := c.AppPersistCache.AviCacheGet(poolCacheObj.PersistenceProfileCollection); found {
+		if obj, ok := intf.(*AviPersistenceProfileCache); ok {
+			obj.HasReference = true
+		}
+	}
+}
+
 // DeleteUnmarked : Adds non referenced cached objects to a Dummy VS, which
 // would be used later to delete these objects from AVI Controller
 func (c *AviObjCache) DeleteUnmarked(childCollection []string, tenant string) {
 
 	var dsKeys, vsVipKeys, httpKeys, sslKeys []NamespaceName
-	var pgKeys, poolKeys, l4Keys []NamespaceName
+	var pgKeys, poolKeys, l4Keys, nspKeys []NamespaceName
+	var hmKeys, persistenceKeys []NamespaceName
 	for _, objkey := range c.DSCache.AviGetAllKeys() {
 		if objkey.Namespace != tenant {
 			continue
@@ -369,6 +405,19 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string, tenant string) {
 		}
 	}
 
+	for _, objkey := range c.NSPCache.AviGetAllKeys() {
+		if objkey.Namespace != tenant {
+			continue
+		}
+		intf, _ := c.NSPCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviNetworkSecurityPolicyCache); ok {
+			if !obj.HasReference {
+				utils.AviLog.Infof("Reference Not found for network security policy: %s", objkey)
+				nspKeys = append(nspKeys, objkey)
+			}
+		}
+	}
+
 	for _, objkey := range c.PgCache.AviGetAllKeys() {
 		if objkey.Namespace != tenant {
 			continue
@@ -392,6 +441,34 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string, tenant string) {
 			if !obj.HasReference {
 				utils.AviLog.Infof("Reference Not found for pool: %s", objkey)
 				poolKeys = append(poolKeys, objkey)
+				// The health monitor and the persistence profile of the pool are deleted along with the pool.
+				c.markPoolReference(obj)
+			}
+		}
+	}
+
+	for _, objkey := range c.HMCache.AviGetAllKeys() {
+		if objkey.Namespace != tenant {
+			continue
+		}
+		intf, _ := c.HMCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviHealthMonitorCache); ok {
+			if !obj.HasReference {
+				utils.AviLog.Infof("Reference Not found for health monitor: %s", objkey)
+				hmKeys = append(hmKeys, objkey)
+			}
+		}
+	}
+
+	for _, objkey := range c.AppPersistCache.AviGetAllKeys() {
+		if objkey.Namespace != tenant {
+			continue
+		}
+		intf, _ := c.AppPersistCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviPersistenceProfileCache); ok {
+			if !obj.HasReference {
+				utils.AviLog.Infof("Reference Not found for persistence profile: %s", objkey)
+				persistenceKeys = append(persistenceKeys, objkey)
 			}
 		}
 	}
@@ -442,6 +519,10 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string, tenant string) {
 		PoolKeyCollection:    poolKeys,
 		L4PolicyCollection:   l4Keys,
 		SNIChildCollection:   childCollection,
+
+		NetworkSecurityPolicyCollection: nspKeys,
+		HealthMonitorCollection:         hmKeys,
+		PersistenceProfileCollection:    persistenceKeys,
 	}
 	vsKey := NamespaceName{
 		Namespace: tenant,
@@ -673,6 +754,9 @@ func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud strin
 			PkiProfileCollection: pkiKey,
 			ServiceMetadataObj:   svc_mdata_obj,
 			LastModified:         *pool.LastModified,
+
+			HealthMonitorCollection:      c.getPoolHealthMonitorKey(pool.HealthMonitorRefs, tenant),
+			PersistenceProfileCollection: c.getPoolPersistenceProfileKey(pool.ApplicationPersistenceProfileRef, tenant),
 		}
 		*poolData = append(*poolData, poolCacheObj)
 	}
@@ -1241,6 +1325,9 @@ func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 			PkiProfileCollection: pkiKey,
 			ServiceMetadataObj:   svc_mdata_obj,
 			LastModified:         *pool.LastModified,
+
+			HealthMonitorCollection:      c.getPoolHealthMonitorKey(pool.HealthMonitorRefs, tenant),
+			PersistenceProfileCollection: c.getPoolPersistenceProfileKey(pool.ApplicationPersistenceProfileRef, tenant),
 		}
 		k := NamespaceName{Namespace: tenant, Name: *pool.Name}
 		c.PoolCache.AviCacheAdd(k, &poolCacheObj)
@@ -1585,7 +1672,7 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 		if l4pol.L4ConnectionPolicy != nil {
 			for _, rule := range l4pol.L4ConnectionPolicy.Rules {
 				protocols = append(protocols, *rule.Match.Protocol.Protocol)
-				if rule.Action != nil {
+				if rule.Action != nil && rule.Action.SelectPool.PoolRef != nil {
 					poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
 					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
 					if found {
@@ -1883,10 +1970,12 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 						protocol = utils.UDP
 					}
 					protocols = append(protocols, protocol)
-					poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
+					if rule.Action.SelectPool.PoolRef != nil {
+						poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
+						poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
+						if found {
+							pools = append(pools, poolName.(string))
+						}
 					}
 				}
 				if rule.Match != nil {
@@ -1948,6 +2037,549 @@ func (c *AviObjCache) PopulateL4PolicySetToCache(client *clients.AviClient, clou
 	}
 }
 
+func (c *AviObjCache) AviPopulateAllNetworkSecurityPolicies(client *clients.AviClient, cloud string, nspData *[]AviNetworkSecurityPolicyCache, nextPage ...NextPage) (*[]AviNetworkSecurityPolicyCache, int, error) {
+	var uri string
+	akoUser := lib.AKOUser
+
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		uri = "/api/networksecuritypolicy/?" + "&include_name=true" + "&created_by=" + akoUser + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for networksecuritypolicy %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		nsp := models.NetworkSecurityPolicy{}
+		err = json.Unmarshal(elems[i], &nsp)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+			continue
+		}
+		if nsp.Name == nil || nsp.UUID == nil {
+			utils.AviLog.Warnf("Incomplete network security policy data unmarshalled, %s", utils.Stringify(nsp))
+			continue
+		}
+
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		sourceRanges := lib.GetNetworkSecurityPolicySourceRanges(nsp.Rules)
+		cksum := lib.NetworkSecurityPolicyChecksum(sourceRanges, emptyIngestionMarkers, nsp.Markers, true)
+		nspCacheObj := AviNetworkSecurityPolicyCache{
+			Name:             *nsp.Name,
+			Tenant:           getTenantFromTenantRef(*nsp.TenantRef),
+			Uuid:             *nsp.UUID,
+			LastModified:     *nsp.LastModified,
+			CloudConfigCksum: cksum,
+		}
+
+		*nspData = append(*nspData, nspCacheObj)
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/networksecuritypolicy")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/networksecuritypolicy" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllNetworkSecurityPolicies(client, cloud, nspData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return nspData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateNetworkSecurityPolicyToCache(client *clients.AviClient, cloud, tenant string, overrideUri ...NextPage) {
+	var nspData []AviNetworkSecurityPolicyCache
+	_, count, err := c.AviPopulateAllNetworkSecurityPolicies(client, cloud, &nspData)
+	if err != nil || len(nspData) != count {
+		return
+	}
+	nspCacheData := c.NSPCache.ShallowCopy()
+	for i, nspCacheObj := range nspData {
+		k := NamespaceName{Namespace: nspCacheObj.Tenant, Name: nspCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to network security policy cache :%s", utils.Stringify(nspCacheObj))
+		c.NSPCache.AviCacheAdd(k, &nspData[i])
+		delete(nspCacheData, k)
+	}
+	// The data that is left in nspCacheData should be explicitly removed
+	for key := range nspCacheData {
+		namespaceKey, ok := key.(NamespaceName)
+		if !ok || namespaceKey.Namespace != tenant {
+			continue
+		}
+		utils.AviLog.Debugf("Deleting key from network security policy cache :%s", key)
+		c.NSPCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateOneNetworkSecurityPolicyCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+	akoUser := lib.AKOUser
+
+	uri = "/api/networksecuritypolicy?name=" + objName + "&include_name=true" + "&created_by=" + akoUser
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for networksecuritypolicy %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		nsp := models.NetworkSecurityPolicy{}
+		err = json.Unmarshal(elems[i], &nsp)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+			continue
+		}
+		if nsp.Name == nil || nsp.UUID == nil {
+			utils.AviLog.Warnf("Incomplete network security policy data unmarshalled, %s", utils.Stringify(nsp))
+			continue
+		}
+		//Only cache a network security policy that belongs to this AKO.
+		if !strings.HasPrefix(*nsp.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		sourceRanges := lib.GetNetworkSecurityPolicySourceRanges(nsp.Rules)
+		cksum := lib.NetworkSecurityPolicyChecksum(sourceRanges, emptyIngestionMarkers, nsp.Markers, true)
+		tenant := getTenantFromTenantRef(*nsp.TenantRef)
+		nspCacheObj := AviNetworkSecurityPolicyCache{
+			Name:             *nsp.Name,
+			Tenant:           tenant,
+			Uuid:             *nsp.UUID,
+			LastModified:     *nsp.LastModified,
+			CloudConfigCksum: cksum,
+		}
+		k := NamespaceName{Namespace: tenant, Name: *nsp.Name}
+		c.NSPCache.AviCacheAdd(k, &nspCacheObj)
+		utils.AviLog.Debugf("Adding network security policy to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateAllHealthMonitors(client *clients.AviClient, cloud string, hmData *[]AviHealthMonitorCache, nextPage ...NextPage) (*[]AviHealthMonitorCache, int, error) {
+	var uri string
+
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		// Health monitors do not have a created_by field, the AKO created ones carry a created_by label.
+		uri = "/api/healthmonitor/?" + "&include_name=true" + "&label_key=" + lib.CreatedByLabelKey + "&label_value=" + lib.GetAKOUser() + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		hm := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &hm)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if hm.Name == nil || hm.UUID == nil {
+			utils.AviLog.Warnf("Incomplete health monitor data unmarshalled, %s", utils.Stringify(hm))
+			continue
+		}
+
+		*hmData = append(*hmData, getHealthMonitorCacheObj(hm))
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/healthmonitor")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/healthmonitor" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllHealthMonitors(client, cloud, hmData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return hmData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateHealthMonitorsToCache(client *clients.AviClient, cloud, tenant string, overrideUri ...NextPage) {
+	var hmData []AviHealthMonitorCache
+	_, count, err := c.AviPopulateAllHealthMonitors(client, cloud, &hmData)
+	if err != nil || len(hmData) != count {
+		return
+	}
+	hmCacheData := c.HMCache.ShallowCopy()
+	for i, hmCacheObj := range hmData {
+		k := NamespaceName{Namespace: hmCacheObj.Tenant, Name: hmCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to health monitor cache :%s", utils.Stringify(hmCacheObj))
+		c.HMCache.AviCacheAdd(k, &hmData[i])
+		delete(hmCacheData, k)
+	}
+	// The data that is left in hmCacheData should be explicitly removed
+	for key := range hmCacheData {
+		namespaceKey, ok := key.(NamespaceName)
+		if !ok || namespaceKey.Namespace != tenant {
+			continue
+		}
+		utils.AviLog.Debugf("Deleting key from health monitor cache :%s", key)
+		c.HMCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateOneHealthMonitorCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/healthmonitor?name=" + objName + "&include_name=true" + "&label_key=" + lib.CreatedByLabelKey + "&label_value=" + lib.GetAKOUser()
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		hm := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &hm)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if hm.Name == nil || hm.UUID == nil {
+			utils.AviLog.Warnf("Incomplete health monitor data unmarshalled, %s", utils.Stringify(hm))
+			continue
+		}
+		hmCacheObj := getHealthMonitorCacheObj(hm)
+		k := NamespaceName{Namespace: hmCacheObj.Tenant, Name: hmCacheObj.Name}
+		c.HMCache.AviCacheAdd(k, &hmCacheObj)
+		utils.AviLog.Debugf("Adding health monitor to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func getHealthMonitorCacheObj(hm models.HealthMonitor) AviHealthMonitorCache {
+	var hmType, httpRequest, lastModified string
+	var monitorPort int32
+	if hm.Type != nil {
+		hmType = *hm.Type
+	}
+	if hm.MonitorPort != nil {
+		monitorPort = *hm.MonitorPort
+	}
+	if hm.HTTPMonitor != nil && hm.HTTPMonitor.HTTPRequest != nil {
+		httpRequest = *hm.HTTPMonitor.HTTPRequest
+	}
+	if hm.LastModified != nil {
+		lastModified = *hm.LastModified
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return AviHealthMonitorCache{
+		Name:             *hm.Name,
+		Tenant:           getTenantFromTenantRef(*hm.TenantRef),
+		Uuid:             *hm.UUID,
+		LastModified:     lastModified,
+		CloudConfigCksum: lib.HealthMonitorChecksum(hmType, monitorPort, httpRequest, emptyIngestionMarkers, hm.Markers, true),
+	}
+}
+
+// getPoolHealthMonitorKey returns the AKO created health monitor among the health monitors of a pool.
+func (c *AviObjCache) getPoolHealthMonitorKey(healthMonitorRefs []string, tenant string) NamespaceName {
+	for _, hmRef := range healthMonitorRefs {
+		hmUuid := ExtractUUID(hmRef, "healthmonitor-.*.#")
+		if hmName, found := c.HMCache.AviCacheGetNameByUuid(hmUuid); found {
+			return NamespaceName{Namespace: tenant, Name: hmName.(string)}
+		}
+	}
+	return NamespaceName{}
+}
+
+func (c *AviObjCache) AviPopulateAllPersistenceProfiles(client *clients.AviClient, cloud string, persistenceData *[]AviPersistenceProfileCache, nextPage ...NextPage) (*[]AviPersistenceProfileCache, int, error) {
+	var uri string
+
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		// Persistence profiles do not have a created_by field, the AKO created ones carry a created_by label.
+		uri = "/api/applicationpersistenceprofile/?" + "&include_name=true" + "&label_key=" + lib.CreatedByLabelKey + "&label_value=" + lib.GetAKOUser() + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete persistence profile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+
+		*persistenceData = append(*persistenceData, getPersistenceProfileCacheObj(persistenceProfile))
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/applicationpersistenceprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationpersistenceprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllPersistenceProfiles(client, cloud, persistenceData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return persistenceData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulatePersistenceProfilesToCache(client *clients.AviClient, cloud, tenant string, overrideUri ...NextPage) {
+	var persistenceData []AviPersistenceProfileCache
+	_, count, err := c.AviPopulateAllPersistenceProfiles(client, cloud, &persistenceData)
+	if err != nil || len(persistenceData) != count {
+		return
+	}
+	persistenceCacheData := c.AppPersistCache.ShallowCopy()
+	for i, persistenceCacheObj := range persistenceData {
+		k := NamespaceName{Namespace: persistenceCacheObj.Tenant, Name: persistenceCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to persistence profile cache :%s", utils.Stringify(persistenceCacheObj))
+		c.AppPersistCache.AviCacheAdd(k, &persistenceData[i])
+		delete(persistenceCacheData, k)
+	}
+	// The data that is left in persistenceCacheData should be explicitly removed
+	for key := range persistenceCacheData {
+		namespaceKey, ok := key.(NamespaceName)
+		if !ok || namespaceKey.Namespace != tenant {
+			continue
+		}
+		utils.AviLog.Debugf("Deleting key from persistence profile cache :%s", key)
+		c.AppPersistCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateOnePersistenceProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/applicationpersistenceprofile?name=" + objName + "&include_name=true" + "&label_key=" + lib.CreatedByLabelKey + "&label_value=" + lib.GetAKOUser()
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete persistence profile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+		persistenceCacheObj := getPersistenceProfileCacheObj(persistenceProfile)
+		k := NamespaceName{Namespace: persistenceCacheObj.Tenant, Name: persistenceCacheObj.Name}
+		c.AppPersistCache.AviCacheAdd(k, &persistenceCacheObj)
+		utils.AviLog.Debugf("Adding persistence profile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func getPersistenceProfileCacheObj(persistenceProfile models.ApplicationPersistenceProfile) AviPersistenceProfileCache {
+	var lastModified string
+	persistenceType, timeout, cookieName := lib.GetPersistenceProfileSettings(persistenceProfile)
+	if persistenceProfile.LastModified != nil {
+		lastModified = *persistenceProfile.LastModified
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return AviPersistenceProfileCache{
+		Name:             *persistenceProfile.Name,
+		Tenant:           getTenantFromTenantRef(*persistenceProfile.TenantRef),
+		Uuid:             *persistenceProfile.UUID,
+		LastModified:     lastModified,
+		CloudConfigCksum: lib.PersistenceProfileChecksum(persistenceType, timeout, cookieName, emptyIngestionMarkers, persistenceProfile.Markers, true),
+	}
+}
+
+// getPoolPersistenceProfileKey returns the persistence profile of a pool, if it is created by AKO.
+func (c *AviObjCache) getPoolPersistenceProfileKey(persistenceProfileRef *string, tenant string) NamespaceName {
+	if persistenceProfileRef == nil {
+		return NamespaceName{}
+	}
+	persistenceUuid := ExtractUUID(*persistenceProfileRef, "applicationpersistenceprofile-.*.#")
+	if persistenceName, found := c.AppPersistCache.AviCacheGetNameByUuid(persistenceUuid); found {
+		return NamespaceName{Namespace: tenant, Name: persistenceName.(string)}
+	}
+	return NamespaceName{}
+}
+
+func (c *AviObjCache) AviPopulateAllAppProfiles(client *clients.AviClient, cloud string, appProfileData *[]AviAppProfileCache, nextPage ...NextPage) (*[]AviAppProfileCache, int, error) {
+	var uri string
+
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		uri = "/api/applicationprofile/?" + "&include_name=true" + "&created_by=" + lib.GetAKOUser() + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete application profile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+
+		*appProfileData = append(*appProfileData, getAppProfileCacheObj(appProfile))
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/applicationprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllAppProfiles(client, cloud, appProfileData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return appProfileData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateAppProfilesToCache(client *clients.AviClient, cloud, tenant string, overrideUri ...NextPage) {
+	var appProfileData []AviAppProfileCache
+	_, count, err := c.AviPopulateAllAppProfiles(client, cloud, &appProfileData)
+	if err != nil || len(appProfileData) != count {
+		return
+	}
+	appProfileCacheData := c.AppProfileCache.ShallowCopy()
+	for i, appProfileCacheObj := range appProfileData {
+		k := NamespaceName{Namespace: appProfileCacheObj.Tenant, Name: appProfileCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to application profile cache :%s", utils.Stringify(appProfileCacheObj))
+		c.AppProfileCache.AviCacheAdd(k, &appProfileData[i])
+		delete(appProfileCacheData, k)
+	}
+	// The data that is left in appProfileCacheData should be explicitly removed
+	for key := range appProfileCacheData {
+		namespaceKey, ok := key.(NamespaceName)
+		if !ok || namespaceKey.Namespace != tenant {
+			continue
+		}
+		utils.AviLog.Debugf("Deleting key from application profile cache :%s", key)
+		c.AppProfileCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateOneAppProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/applicationprofile?name=" + objName + "&include_name=true" + "&created_by=" + lib.GetAKOUser()
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete application profile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		appProfileCacheObj := getAppProfileCacheObj(appProfile)
+		k := NamespaceName{Namespace: appProfileCacheObj.Tenant, Name: appProfileCacheObj.Name}
+		c.AppProfileCache.AviCacheAdd(k, &appProfileCacheObj)
+		utils.AviLog.Debugf("Adding application profile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func getAppProfileCacheObj(appProfile models.ApplicationProfile) AviAppProfileCache {
+	var lastModified string
+	baseProfile, pkiProfileName, clientCertificateMode, forwardClientIdentity, clientMaxBodySize := lib.GetApplicationProfileSettings(appProfile)
+	if appProfile.LastModified != nil {
+		lastModified = *appProfile.LastModified
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return AviAppProfileCache{
+		Name:             *appProfile.Name,
+		Tenant:           getTenantFromTenantRef(*appProfile.TenantRef),
+		Uuid:             *appProfile.UUID,
+		LastModified:     lastModified,
+		CloudConfigCksum: lib.ApplicationProfileChecksum(baseProfile, pkiProfileName, clientCertificateMode, forwardClientIdentity, clientMaxBodySize, emptyIngestionMarkers, appProfile.Markers, true),
+	}
+}
+
 func (c *AviObjCache) AviPopulateAllStringGroups(client *clients.AviClient, cloud string, StringGroupData *[]AviStringGroupCache, nextPage ...NextPage) (*[]AviStringGroupCache, int, error) {
 	var uri string
 
@@ -2218,6 +2850,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 				var dsKeys []NamespaceName
 				var httpKeys []NamespaceName
 				var l4Keys []NamespaceName
+				var nspKeys []NamespaceName
 				var poolgroupKeys []NamespaceName
 				var poolKeys []NamespaceName
 				var sharedVsOrL4 bool
@@ -2325,6 +2958,14 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 						}
 					}
 				}
+				if vs["network_security_policy_ref"] != nil {
+					nspUuid := ExtractUUID(vs["network_security_policy_ref"].(string), "networksecuritypolicy-.*.#")
+					nspName, foundNsp := c.NSPCache.AviCacheGetNameByUuid(nspUuid)
+					if foundNsp {
+						nspKey := NamespaceName{Namespace: tenant, Name: nspName.(string)}
+						nspKeys = append(nspKeys, nspKey)
+					}
+				}
 				if vs["http_policies"] != nil {
 					for _, http_intf := range vs["http_policies"].([]interface{}) {
 						httpmap, ok := http_intf.(map[string]interface{})
@@ -2372,6 +3013,19 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 						}
 					}
 				}
+				if vs["pool_group_ref"] != nil {
+					pgRef, ok := vs["pool_group_ref"].(string)
+					if ok {
+						pgUuid := ExtractUUID(pgRef, "poolgroup-.*.#")
+						pgName, foundpg := c.PgCache.AviCacheGetNameByUuid(pgUuid)
+						if foundpg {
+							pgKey := NamespaceName{Namespace: tenant, Name: pgName.(string)}
+							poolgroupKeys = append(poolgroupKeys, pgKey)
+							pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName.(string), tenant)
+							poolKeys = append(poolKeys, pgpoolKeys...)
+						}
+					}
+				}
 
 				// Populate the vscache meta object here.
 				vsMetaObj := AviVsCache{
@@ -2391,6 +3045,8 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 					L4PolicyCollection:       l4Keys,
 					LastModified:             vs["_last_modified"].(string),
 					StringGroupKeyCollection: stringgroupKeys,
+
+					NetworkSecurityPolicyCollection: nspKeys,
 				}
 				if val, ok := vs["enable_rhi"]; ok {
 					vsMetaObj.EnableRhi = val.(bool)
@@ -2490,6 +3146,7 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 				var poolgroupKeys []NamespaceName
 				var poolKeys []NamespaceName
 				var l4Keys []NamespaceName
+				var nspKeys []NamespaceName
 				var stringgroupKeys []NamespaceName
 
 				// Populate the VSVIP cache
@@ -2586,6 +3243,14 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 						}
 					}
 				}
+				if vs["network_security_policy_ref"] != nil {
+					nspUuid := ExtractUUID(vs["network_security_policy_ref"].(string), "networksecuritypolicy-.*.#")
+					nspName, foundNsp := c.NSPCache.AviCacheGetNameByUuid(nspUuid)
+					if foundNsp {
+						nspKey := NamespaceName{Namespace: tenant, Name: nspName.(string)}
+						nspKeys = append(nspKeys, nspKey)
+					}
+				}
 				if vs["http_policies"] != nil {
 					for _, http_intf := range vs["http_policies"].([]interface{}) {
 						// find the sslkey name from the ssl key cache
@@ -2643,6 +3308,8 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 					L4PolicyCollection:       l4Keys,
 					ServiceMetadataObj:       svc_mdata_obj,
 					StringGroupKeyCollection: stringgroupKeys,
+
+					NetworkSecurityPolicyCollection: nspKeys,
 				}
 				if val, ok := vs["enable_rhi"]; ok {
 					vsMetaObj.EnableRhi = val.(bool)
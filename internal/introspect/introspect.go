@@ -0,0 +1,258 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package introspect exposes read-only APIs on AKO's API server, which dump the models built by the graph layer,
+// the Avi object caches, and the mapping between the kubernetes objects and the Avi objects built from them.
+package introspect
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// Avi object types, as used in the routes of the introspection APIs.
+const (
+	VirtualService                = "virtualservice"
+	Pool                          = "pool"
+	PoolGroup                     = "poolgroup"
+	VsVip                         = "vsvip"
+	HTTPPolicySet                 = "httppolicyset"
+	L4PolicySet                   = "l4policyset"
+	SSLKeyAndCertificate          = "sslkeyandcertificate"
+	PKIProfile                    = "pkiprofile"
+	VSDataScriptSet               = "vsdatascriptset"
+	StringGroup                   = "stringgroup"
+	NetworkSecurityPolicy         = "networksecuritypolicy"
+	HealthMonitor                 = "healthmonitor"
+	ApplicationPersistenceProfile = "applicationpersistenceprofile"
+	ApplicationProfile            = "applicationprofile"
+	VrfContext                    = "vrfcontext"
+)
+
+// ModelDump is the serializable form of a model in the AviGraphLister.
+type ModelDump struct {
+	Name          string          `json:"name"`
+	GraphChecksum uint32          `json:"graph_checksum"`
+	RetryCount    int             `json:"retry_count"`
+	Nodes         []ModelNodeDump `json:"nodes"`
+}
+
+type ModelNodeDump struct {
+	Type string             `json:"type"`
+	Node nodes.AviModelNode `json:"node"`
+}
+
+// IntrospectModel implements ApiModel
+type IntrospectModel struct{}
+
+func (a *IntrospectModel) InitModel() {}
+
+func (a *IntrospectModel) ApiOperationMap(prometheusEnavbled bool, reg *prometheus.Registry) []models.OperationMap {
+	var operationMapList []models.OperationMap
+
+	listModels := models.OperationMap{
+		Route:   "/api/models",
+		Method:  "GET",
+		Handler: listModelsHandler,
+	}
+	operationMapList = append(operationMapList, listModels)
+
+	getModel := models.OperationMap{
+		Route:   "/api/models/{tenant}/{name}",
+		Method:  "GET",
+		Handler: getModelHandler,
+	}
+	operationMapList = append(operationMapList, getModel)
+
+	getCache := models.OperationMap{
+		Route:   "/api/cache/{type}/{tenant}/{name}",
+		Method:  "GET",
+		Handler: getCacheHandler,
+	}
+	operationMapList = append(operationMapList, getCache)
+
+	getAviObjects := models.OperationMap{
+		Route:   "/api/provenance/k8s/{kind}/{namespace}/{name}",
+		Method:  "GET",
+		Handler: getAviObjectsHandler,
+	}
+	operationMapList = append(operationMapList, getAviObjects)
+
+	getK8sObjects := models.OperationMap{
+		Route:   "/api/provenance/avi/{type}/{tenant}/{name}",
+		Method:  "GET",
+		Handler: getK8sObjectsHandler,
+	}
+	operationMapList = append(operationMapList, getK8sObjects)
+	return operationMapList
+}
+
+func listModelsHandler(w http.ResponseWriter, r *http.Request) {
+	modelNames := []string{}
+	if allModels, ok := objects.SharedAviGraphLister().GetAll().(map[string]interface{}); ok {
+		for modelName := range allModels {
+			modelNames = append(modelNames, modelName)
+		}
+	}
+	sort.Strings(modelNames)
+	utils.Respond(w, modelNames)
+}
+
+func getModelHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	modelName := vars["tenant"] + "/" + vars["name"]
+	found, aviModelIntf := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModelIntf == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	aviModel, ok := aviModelIntf.(*nodes.AviObjectGraph)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	// The model is serialized under its lock, as the graph layer may update it in place.
+	aviModel.Lock.RLock()
+	defer aviModel.Lock.RUnlock()
+	dump := ModelDump{
+		Name:          aviModel.Name,
+		GraphChecksum: aviModel.GraphChecksum,
+		RetryCount:    aviModel.RetryCount,
+		Nodes:         []ModelNodeDump{},
+	}
+	for _, node := range aviModel.GetOrderedNodes() {
+		dump.Nodes = append(dump.Nodes, ModelNodeDump{Type: node.GetNodeType(), Node: node})
+	}
+	redactedDump, err := redactPrivateKeys(dump)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to serialize the model %s, err: %v", modelName, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	utils.Respond(w, redactedDump)
+}
+
+// redactPrivateKeys returns the serializable form of a model dump, without the private keys of the
+// sslkeyandcertificate nodes, which are nested at any depth under the virtualservice nodes.
+func redactPrivateKeys(dump ModelDump) (interface{}, error) {
+	bytes, err := json.Marshal(dump)
+	if err != nil {
+		return nil, err
+	}
+	var redactedDump interface{}
+	if err := json.Unmarshal(bytes, &redactedDump); err != nil {
+		return nil, err
+	}
+	redactKeys(redactedDump)
+	return redactedDump, nil
+}
+
+func redactKeys(obj interface{}) {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		// AviTLSKeyCertNode carries the private key in the Key field, next to the certificate.
+		if _, isKeyCert := value["Cert"]; isKeyCert {
+			if _, hasKey := value["Key"]; hasKey {
+				value["Key"] = nil
+			}
+		}
+		for _, field := range value {
+			redactKeys(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			redactKeys(item)
+		}
+	}
+}
+
+func getCacheHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	objCache := getObjCache(vars["type"])
+	if objCache == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cacheKey := avicache.NamespaceName{Namespace: vars["tenant"], Name: vars["name"]}
+	cacheObj, found := objCache.AviCacheGet(cacheKey)
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if vsCache, ok := cacheObj.(*avicache.AviVsCache); ok {
+		// The virtualservice cache entries are updated in place by the rest layer.
+		if vsCacheCopy, done := vsCache.GetVSCopy(); done {
+			cacheObj = vsCacheCopy
+		}
+	}
+	utils.Respond(w, cacheObj)
+}
+
+func getAviObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	utils.Respond(w, GetAviObjectsForK8sObject(vars["kind"], vars["namespace"], vars["name"]))
+}
+
+func getK8sObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	utils.Respond(w, GetK8sObjectsForAviObject(vars["type"], vars["tenant"], vars["name"]))
+}
+
+// getObjCache returns the Avi object cache of an object type, nil if the type is not cached by AKO.
+func getObjCache(objType string) *avicache.AviCache {
+	aviObjCache := avicache.SharedAviObjCache()
+	switch objType {
+	case VirtualService:
+		return aviObjCache.VsCacheMeta
+	case Pool:
+		return aviObjCache.PoolCache
+	case PoolGroup:
+		return aviObjCache.PgCache
+	case VsVip:
+		return aviObjCache.VSVIPCache
+	case HTTPPolicySet:
+		return aviObjCache.HTTPPolicyCache
+	case L4PolicySet:
+		return aviObjCache.L4PolicyCache
+	case SSLKeyAndCertificate:
+		return aviObjCache.SSLKeyCache
+	case PKIProfile:
+		return aviObjCache.PKIProfileCache
+	case VSDataScriptSet:
+		return aviObjCache.DSCache
+	case StringGroup:
+		return aviObjCache.StringGroupCache
+	case NetworkSecurityPolicy:
+		return aviObjCache.NSPCache
+	case HealthMonitor:
+		return aviObjCache.HMCache
+	case ApplicationPersistenceProfile:
+		return aviObjCache.AppPersistCache
+	case ApplicationProfile:
+		return aviObjCache.AppProfileCache
+	case VrfContext:
+		return aviObjCache.VrfCache
+	}
+	return nil
+}
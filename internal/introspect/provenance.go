@@ -0,0 +1,253 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package introspect
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// AviObjectRef identifies an Avi object in a model of the AviGraphLister.
+type AviObjectRef struct {
+	Model  string `json:"model"`
+	Type   string `json:"type"`
+	Tenant string `json:"tenant"`
+	Name   string `json:"name"`
+}
+
+// K8sObjectRef identifies a kubernetes object, for which AKO builds Avi objects.
+type K8sObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// aviObject is an Avi object in a model, along with the metadata which refers to the kubernetes objects it is built from.
+type aviObject struct {
+	objType         string
+	name            string
+	markers         utils.AviObjectMarkers
+	serviceMetadata lib.ServiceMetadataObj
+}
+
+// GetAviObjectsForK8sObject returns the Avi objects, across all the models, that are built from a kubernetes object.
+// The kind is matched case insensitively, e.g. Ingress, Route, Service, Gateway, HTTPRoute, HostRule, HTTPRule or SSORule.
+func GetAviObjectsForK8sObject(kind, namespace, name string) []AviObjectRef {
+	aviObjects := []AviObjectRef{}
+	for _, modelName := range getModelNames() {
+		tenant, _ := utils.ExtractNamespaceObjectName(modelName)
+		for _, obj := range getModelObjects(modelName) {
+			for _, k8sObj := range obj.k8sObjects() {
+				if strings.EqualFold(k8sObj.Kind, kind) && k8sObj.Namespace == namespace && k8sObj.Name == name {
+					aviObjects = append(aviObjects, AviObjectRef{Model: modelName, Type: obj.objType, Tenant: tenant, Name: obj.name})
+					break
+				}
+			}
+		}
+	}
+	return aviObjects
+}
+
+// GetK8sObjectsForAviObject returns the kubernetes objects that an Avi object is built from.
+func GetK8sObjectsForAviObject(objType, tenant, name string) []K8sObjectRef {
+	k8sObjects := []K8sObjectRef{}
+	for _, modelName := range getModelNames() {
+		if modelTenant, _ := utils.ExtractNamespaceObjectName(modelName); modelTenant != tenant {
+			continue
+		}
+		for _, obj := range getModelObjects(modelName) {
+			if obj.objType != objType || obj.name != name {
+				continue
+			}
+			for _, k8sObj := range obj.k8sObjects() {
+				if !containsK8sObject(k8sObjects, k8sObj) {
+					k8sObjects = append(k8sObjects, k8sObj)
+				}
+			}
+		}
+	}
+	return k8sObjects
+}
+
+func getModelNames() []string {
+	var modelNames []string
+	if allModels, ok := objects.SharedAviGraphLister().GetAll().(map[string]interface{}); ok {
+		for modelName := range allModels {
+			modelNames = append(modelNames, modelName)
+		}
+	}
+	sort.Strings(modelNames)
+	return modelNames
+}
+
+// getModelObjects returns the Avi objects of a model, which carry references to the kubernetes objects.
+func getModelObjects(modelName string) []aviObject {
+	found, aviModelIntf := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModelIntf == nil {
+		return nil
+	}
+	aviModel, ok := aviModelIntf.(*nodes.AviObjectGraph)
+	if !ok {
+		return nil
+	}
+	aviModel.Lock.RLock()
+	defer aviModel.Lock.RUnlock()
+	var aviObjects []aviObject
+	for _, vsNode := range aviModel.GetAviVS() {
+		aviObjects = append(aviObjects, getVsObjects(vsNode)...)
+	}
+	for _, evhNode := range aviModel.GetAviEvhVS() {
+		aviObjects = append(aviObjects, getEvhObjects(evhNode)...)
+	}
+	return aviObjects
+}
+
+func getVsObjects(vsNode *nodes.AviVsNode) []aviObject {
+	aviObjects := []aviObject{{objType: VirtualService, name: vsNode.Name, markers: vsNode.AviMarkers, serviceMetadata: vsNode.ServiceMetadata}}
+	aviObjects = append(aviObjects, getChildObjects(vsNode)...)
+	for _, vsvip := range vsNode.VSVIPRefs {
+		aviObjects = append(aviObjects, aviObject{objType: VsVip, name: vsvip.Name})
+	}
+	for _, l4Policy := range vsNode.L4PolicyRefs {
+		aviObjects = append(aviObjects, aviObject{objType: L4PolicySet, name: l4Policy.Name, markers: l4Policy.AviMarkers})
+	}
+	for _, caCert := range vsNode.CACertRefs {
+		aviObjects = append(aviObjects, aviObject{objType: SSLKeyAndCertificate, name: caCert.Name, markers: caCert.AviMarkers})
+	}
+	for _, dataScript := range vsNode.HTTPDSrefs {
+		aviObjects = append(aviObjects, aviObject{objType: VSDataScriptSet, name: dataScript.Name})
+	}
+	for _, sniNode := range vsNode.SniNodes {
+		aviObjects = append(aviObjects, getVsObjects(sniNode)...)
+	}
+	for _, passthroughNode := range vsNode.PassthroughChildNodes {
+		aviObjects = append(aviObjects, getVsObjects(passthroughNode)...)
+	}
+	return aviObjects
+}
+
+func getEvhObjects(evhNode *nodes.AviEvhVsNode) []aviObject {
+	aviObjects := []aviObject{{objType: VirtualService, name: evhNode.Name, markers: evhNode.AviMarkers, serviceMetadata: evhNode.ServiceMetadata}}
+	aviObjects = append(aviObjects, getChildObjects(evhNode)...)
+	for _, vsvip := range evhNode.VSVIPRefs {
+		aviObjects = append(aviObjects, aviObject{objType: VsVip, name: vsvip.Name})
+	}
+	for _, l4Policy := range evhNode.L4PolicyRefs {
+		aviObjects = append(aviObjects, aviObject{objType: L4PolicySet, name: l4Policy.Name, markers: l4Policy.AviMarkers})
+	}
+	for _, caCert := range evhNode.CACertRefs {
+		aviObjects = append(aviObjects, aviObject{objType: SSLKeyAndCertificate, name: caCert.Name, markers: caCert.AviMarkers})
+	}
+	for _, dataScript := range evhNode.HTTPDSrefs {
+		aviObjects = append(aviObjects, aviObject{objType: VSDataScriptSet, name: dataScript.Name})
+	}
+	for _, childNode := range evhNode.EvhNodes {
+		aviObjects = append(aviObjects, getEvhObjects(childNode)...)
+	}
+	for _, passthroughNode := range evhNode.PassthroughChildNodes {
+		aviObjects = append(aviObjects, getVsObjects(passthroughNode)...)
+	}
+	return aviObjects
+}
+
+// getChildObjects returns the child objects common to the normal and the EVH virtualservices.
+func getChildObjects(vsNode nodes.AviVsEvhSniModel) []aviObject {
+	var aviObjects []aviObject
+	for _, pool := range vsNode.GetPoolRefs() {
+		aviObjects = append(aviObjects, aviObject{objType: Pool, name: pool.Name, markers: pool.AviMarkers, serviceMetadata: pool.ServiceMetadata})
+		if pool.HealthMonitor != nil {
+			aviObjects = append(aviObjects, aviObject{objType: HealthMonitor, name: pool.HealthMonitor.Name, markers: pool.HealthMonitor.AviMarkers})
+		}
+		if pool.PersistenceProfile != nil {
+			aviObjects = append(aviObjects, aviObject{objType: ApplicationPersistenceProfile, name: pool.PersistenceProfile.Name, markers: pool.PersistenceProfile.AviMarkers})
+		}
+	}
+	for _, pg := range vsNode.GetPoolGroupRefs() {
+		aviObjects = append(aviObjects, aviObject{objType: PoolGroup, name: pg.Name, markers: pg.AviMarkers})
+	}
+	for _, httpPolicy := range vsNode.GetHttpPolicyRefs() {
+		aviObjects = append(aviObjects, aviObject{objType: HTTPPolicySet, name: httpPolicy.Name, markers: httpPolicy.AviMarkers})
+	}
+	for _, sslKeyCert := range vsNode.GetSSLKeyCertRefs() {
+		aviObjects = append(aviObjects, aviObject{objType: SSLKeyAndCertificate, name: sslKeyCert.Name, markers: sslKeyCert.AviMarkers})
+	}
+	for _, stringGroup := range vsNode.GetStringGroupRefs() {
+		aviObjects = append(aviObjects, aviObject{objType: StringGroup, name: utils.String(stringGroup.Name)})
+	}
+	if appProfile := vsNode.GetClientCertAppProfile(); appProfile != nil {
+		aviObjects = append(aviObjects, aviObject{objType: ApplicationProfile, name: appProfile.Name, markers: appProfile.AviMarkers})
+		if appProfile.PkiProfile != nil {
+			aviObjects = append(aviObjects, aviObject{objType: PKIProfile, name: appProfile.PkiProfile.Name, markers: appProfile.PkiProfile.AviMarkers})
+		}
+	}
+	return aviObjects
+}
+
+// k8sObjects returns the kubernetes objects referred by the markers and the service metadata of the Avi object.
+func (o aviObject) k8sObjects() []K8sObjectRef {
+	var k8sObjects []K8sObjectRef
+	add := func(kind, namespace, name string) {
+		if namespace == "" || name == "" {
+			return
+		}
+		k8sObj := K8sObjectRef{Kind: kind, Namespace: namespace, Name: name}
+		if !containsK8sObject(k8sObjects, k8sObj) {
+			k8sObjects = append(k8sObjects, k8sObj)
+		}
+	}
+	addNamespacedName := func(kind, nsName string) {
+		if namespace, name, found := strings.Cut(nsName, "/"); found {
+			add(kind, namespace, name)
+		}
+	}
+	ingressKind := utils.Ingress
+	if utils.GetInformers().RouteInformer != nil {
+		ingressKind = "Route"
+	}
+
+	add(utils.Service, o.markers.Namespace, o.markers.ServiceName)
+	for _, ingressName := range o.markers.IngressName {
+		add(ingressKind, o.markers.Namespace, ingressName)
+	}
+	add(lib.Gateway, o.markers.Namespace, o.markers.GatewayName)
+
+	for _, svcNSName := range o.serviceMetadata.NamespaceServiceName {
+		addNamespacedName(utils.Service, svcNSName)
+	}
+	for _, ingNSName := range o.serviceMetadata.NamespaceIngressName {
+		addNamespacedName(ingressKind, ingNSName)
+	}
+	add(ingressKind, o.serviceMetadata.Namespace, o.serviceMetadata.IngressName)
+	addNamespacedName(lib.Gateway, o.serviceMetadata.Gateway)
+	addNamespacedName(lib.HTTPRoute, o.serviceMetadata.HTTPRoute)
+	if o.serviceMetadata.CRDStatus.Type != "" {
+		addNamespacedName(o.serviceMetadata.CRDStatus.Type, o.serviceMetadata.CRDStatus.Value)
+	}
+	return k8sObjects
+}
+
+func containsK8sObject(k8sObjects []K8sObjectRef, k8sObj K8sObjectRef) bool {
+	for _, obj := range k8sObjects {
+		if obj == k8sObj {
+			return true
+		}
+	}
+	return false
+}
@@ -121,7 +121,7 @@ func (c *AviController) SetupAdvL4EventHandlers(numWorkers uint32) {
 			}
 			utils.AviLog.Infof("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 		},
 	}
 
@@ -172,7 +172,7 @@ func (c *AviController) SetupAdvL4EventHandlers(numWorkers uint32) {
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(gwclass))
 			utils.AviLog.Infof("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 		},
 	}
 
@@ -227,7 +227,7 @@ func (c *AviController) SetupNamespaceEventHandler(numWorkers uint32) {
 			key := lib.Namespace + "/" + objKey
 			utils.AviLog.Infof("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(ns.Name, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 		},
 	}
 	c.informers.NSInformer.Informer().AddEventHandler(nsHandler)
@@ -373,7 +373,7 @@ func AddGatewaysFromNSToIngestionQueueWCP(numWorkers uint32, c *AviController, n
 	for _, gw := range gateways {
 		key := lib.Gateway + "/" + utils.ObjKey(gw)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
 }
@@ -290,6 +290,8 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 		return err
 	}
 
+	// The settings of the configmap, with which AKO has booted up.
+	var bootupConfig map[string]string
 	configMapEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			cm, ok := validateAviConfigMap(obj)
@@ -297,6 +299,10 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 				return
 			}
 			utils.AviLog.Infof("avi k8s configmap created")
+			bootupConfig = make(map[string]string, len(cm.Data))
+			for key, value := range cm.Data {
+				bootupConfig[key] = value
+			}
 			utils.AviLog.SetLevel(cm.Data[lib.LOG_LEVEL])
 			lib.AKOControlConfig().EventsSetEnabled(cm.Data[lib.EnableEvents])
 			// Check if AKO is configured to only use Ingress. This value can be only set during bootup and can't be edited dynamically.
@@ -334,6 +340,9 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 				lib.AKOControlConfig().EventsSetEnabled(cm.Data[lib.EnableEvents])
 			}
 
+			c.applyLiveConfig(oldcm.Data, cm.Data)
+			updateRestartRequiredStatus(bootupConfig, cm.Data)
+
 			if oldcm.Data[lib.DeleteConfig] == cm.Data[lib.DeleteConfig] {
 				return
 			}
@@ -539,6 +548,9 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 
 	c.addIndexers()
 	c.Start(stopCh)
+	// The CRDs applied to the virtual services are indexed after the informers are synced, as the L4Rules are
+	// referred by the annotations of the services.
+	status.RebuildCRDAttachmentIndex()
 
 	fullSyncInterval := os.Getenv(utils.FULL_SYNC_INTERVAL)
 	interval, err := strconv.ParseInt(fullSyncInterval, 10, 64)
@@ -552,7 +564,7 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	if err != nil {
 		utils.AviLog.Errorf("Cannot convert full sync interval value to integer, pls correct the value and restart AKO. Error: %s", err)
 	} else {
-		// First boot sync
+		// First boot sync, which records the state used by the periodic full sync
 		err = c.FullSyncK8s(false)
 		if err != nil {
 			// Something bad sync. We need to return and shutdown the API server
@@ -564,6 +576,7 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 			worker = utils.NewFullSyncThread(time.Duration(interval) * time.Second)
 			worker.SyncFunction = c.FullSync
 			worker.QuickSyncFunction = c.FullSyncK8s
+			worker.PeriodicSyncFunction = c.periodicFullSyncK8s
 			go worker.Run()
 		} else {
 			utils.AviLog.Warnf("Full sync interval set to 0, will not run full sync")
@@ -804,6 +817,7 @@ func (c *AviController) addIndexers() {
 }
 
 func (c *AviController) FullSync() {
+	start := time.Now()
 	aviRestClientPool := avicache.SharedAVIClients(lib.GetTenant())
 	aviObjCache := avicache.SharedAviObjCache()
 
@@ -817,6 +831,9 @@ func (c *AviController) FullSync() {
 			restlayer := rest.NewRestOperations(aviObjCache)
 			restlayer.SyncObjectStatuses()
 		}
+		if lib.AKOControlConfig().IsLeader() {
+			status.CheckCertificates(lib.AKOControlConfig().EventRecorder(), status.GetCertificateOwners)
+		}
 		allModelsMap := objects.SharedAviGraphLister().GetAll()
 		var allModels []string
 		for modelName := range allModelsMap.(map[string]interface{}) {
@@ -835,6 +852,19 @@ func (c *AviController) FullSync() {
 			// Not publishing the model anymore to layer since we don't want to support full sync for now.
 			//nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
 		}
+		lib.ObserveFullSync(lib.FullSyncController, start)
+	}
+}
+
+// periodicFullSyncK8s runs the full sync of the kubernetes objects on the leader, after the Avi object cache is refreshed.
+// It uses the state of the boot or the last full sync, so that only the changed objects are translated, and only the
+// changed models and the ones diverged from the refreshed cache are published to the rest layer.
+func (c *AviController) periodicFullSyncK8s() {
+	if !lib.AKOControlConfig().IsLeader() {
+		return
+	}
+	if err := c.FullSyncK8s(true); err != nil {
+		utils.AviLog.Warnf("Periodic full sync of the kubernetes objects failed: %v", err)
 	}
 }
 
@@ -843,7 +873,10 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 		utils.AviLog.Infof("Sync disabled, skipping full sync")
 		return nil
 	}
+	start := time.Now()
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	// The objects are translated, and the models are published, only if these have changed since the last full sync.
+	fullSync := NewIncrementalFullSync(sync, dequeueIngestion, dependencyVersions)
 	var vrfModelName string
 	if lib.GetDisableStaticRoute() && !lib.IsNodePortMode() {
 		utils.AviLog.Infof("Static route sync disabled, skipping node informers")
@@ -876,13 +909,12 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 					resVer := meta.GetResourceVersion()
 					objects.SharedResourceVerInstanceLister().Save(key, resVer)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, node)
 			}
 			// Publish vrfcontext model now, this has to be processed first
 			vrfModelName = lib.GetModelName(lib.GetTenant(), lib.GetVrf())
 			utils.AviLog.Infof("Processing model for vrf context in full sync: %s", vrfModelName)
-			nodes.PublishKeyToRestLayer(vrfModelName, "fullsync", sharedQueue)
+			nodes.PublishKeyToRestLayerWithPriority(vrfModelName, "fullsync", sharedQueue, utils.PriorityBulk)
 			utils.AviLog.Infof("Processing done for VRF")
 		} else {
 			utils.AviLog.Warnf("AKO is not primary instance, skipping vrf context publish in full sync.")
@@ -935,8 +967,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateHostRuleObj(key, hostRuleObj); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of HostRule: %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, hostRuleObj)
 			}
 		}
 
@@ -954,8 +985,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateHTTPRuleObj(key, httpRuleObj); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of HTTPRule: %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, httpRuleObj)
 			}
 		}
 
@@ -973,8 +1003,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateAviInfraSetting(key, aviInfraObj); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of AviInfraSetting: %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, aviInfraObj)
 			}
 		}
 
@@ -992,8 +1021,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateSSORuleObj(key, ssoRuleObj); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of SSORule : %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, ssoRuleObj)
 			}
 		}
 
@@ -1011,8 +1039,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateL4RuleObj(key, l4Rule); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of L4Rule: %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, l4Rule)
 			}
 		}
 
@@ -1046,8 +1073,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				resVer := meta.GetResourceVersion()
 				objects.SharedResourceVerInstanceLister().Save(key, resVer)
 			}
-			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-			nodes.DequeueIngestion(key, true)
+			fullSync.DequeueIngestion(key, svcObj)
 		}
 	}
 
@@ -1073,8 +1099,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				resVer := meta.GetResourceVersion()
 				objects.SharedResourceVerInstanceLister().Save(key, resVer)
 			}
-			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-			nodes.DequeueIngestion(key, true)
+			fullSync.DequeueIngestion(key, podObj)
 		}
 	}
 
@@ -1093,8 +1118,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 						objects.SharedResourceVerInstanceLister().Save(key, resVer)
 					}
 					utils.AviLog.Debugf("Dequeue for ingressClass key: %v", key)
-					lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-					nodes.DequeueIngestion(key, true)
+					fullSync.DequeueIngestion(key, ingClass)
 				}
 			}
 		}
@@ -1113,8 +1137,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 							objects.SharedResourceVerInstanceLister().Save(key, resVer)
 						}
 						utils.AviLog.Debugf("Dequeue for ingress key: %v", key)
-						lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-						nodes.DequeueIngestion(key, true)
+						fullSync.DequeueIngestion(key, ingObj)
 					}
 				}
 			}
@@ -1136,8 +1159,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 						objects.SharedResourceVerInstanceLister().Save(key, resVer)
 					}
 					utils.AviLog.Debugf("Dequeue for route key: %v", key)
-					lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-					nodes.DequeueIngestion(key, true)
+					fullSync.DequeueIngestion(key, routeObj)
 				}
 			}
 		}
@@ -1158,8 +1180,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 						objects.SharedResourceVerInstanceLister().Save(key, resVer)
 					}
 					InformerStatusUpdatesForSvcApiGateway(key, gatewayObj)
-					lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-					nodes.DequeueIngestion(key, true)
+					fullSync.DequeueIngestion(key, gatewayObj)
 				}
 			}
 
@@ -1175,8 +1196,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 					resVer := meta.GetResourceVersion()
 					objects.SharedResourceVerInstanceLister().Save(key, resVer)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, gwClassObj)
 			}
 		}
 		if utils.IsMultiClusterIngressEnabled() {
@@ -1195,8 +1215,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 						resVer := meta.GetResourceVersion()
 						objects.SharedResourceVerInstanceLister().Save(key, resVer)
 					}
-					lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-					nodes.DequeueIngestion(key, true)
+					fullSync.DequeueIngestion(key, mciObj)
 				}
 			}
 			siObjs, err := utils.GetInformers().ServiceImportInformer.Lister().ServiceImports(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
@@ -1214,8 +1233,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 						resVer := meta.GetResourceVersion()
 						objects.SharedResourceVerInstanceLister().Save(key, resVer)
 					}
-					lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-					nodes.DequeueIngestion(key, true)
+					fullSync.DequeueIngestion(key, siObj)
 				}
 			}
 		}
@@ -1234,8 +1252,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				if err := c.GetValidator().ValidateAviInfraSetting(key, aviInfraObj); err != nil {
 					utils.AviLog.Warnf("key: %s, Error retrieved during validation of AviInfraSetting: %v", key, err)
 				}
-				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-				nodes.DequeueIngestion(key, true)
+				fullSync.DequeueIngestion(key, aviInfraObj)
 			}
 		}
 
@@ -1254,8 +1271,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 							objects.SharedResourceVerInstanceLister().Save(key, resVer)
 						}
 						utils.AviLog.Debugf("Dequeue for ingress key: %v", key)
-						lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-						nodes.DequeueIngestion(key, true)
+						fullSync.DequeueIngestion(key, ingObj)
 					}
 				}
 			}
@@ -1274,8 +1290,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 			}
 			key := lib.Gateway + "/" + utils.ObjKey(gatewayObj)
 			InformerStatusUpdatesForGateway(key, gatewayObj)
-			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-			nodes.DequeueIngestion(key, true)
+			fullSync.DequeueIngestion(key, gatewayObj)
 		}
 
 		gwClassObjs, err := lib.AKOControlConfig().AdvL4Informers().GatewayClassInformer.Lister().List(labels.Set(nil).AsSelector())
@@ -1285,17 +1300,25 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 		}
 		for _, gwClassObj := range gwClassObjs {
 			key := lib.GatewayClass + "/" + utils.ObjKey(gwClassObj)
-			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
-			nodes.DequeueIngestion(key, true)
+			fullSync.DequeueIngestion(key, gwClassObj)
 		}
 	}
 	if sync {
-		c.publishAllParentVSKeysToRestLayer()
+		c.publishParentVSKeysToRestLayer(fullSync)
 	}
+	fullSync.Report()
+	objects.SharedFullSyncStateLister().SetSynced()
+	lib.ObserveFullSync(lib.FullSyncKubernetes, start)
 	return nil
 }
 
 func (c *AviController) publishAllParentVSKeysToRestLayer() {
+	c.publishParentVSKeysToRestLayer(nil)
+}
+
+// publishParentVSKeysToRestLayer publishes the models of the virtual services in the Avi object cache, and the newly
+// generated models. All the models are published if fullSync is nil.
+func (c *AviController) publishParentVSKeysToRestLayer(fullSync *IncrementalFullSync) {
 	cache := avicache.SharedAviObjCache()
 	vsKeys := cache.VsCacheMeta.AviCacheGetAllParentVSKeys()
 	utils.AviLog.Debugf("Got the VS keys: %s", vsKeys)
@@ -1319,26 +1342,26 @@ func (c *AviController) publishAllParentVSKeysToRestLayer() {
 				if strings.HasPrefix(vsCacheKey.Name, shardVsPrefix) {
 					delete(allModels, modelName)
 					utils.AviLog.Infof("Model published L7 VS during namespace based sync: %s", modelName)
-					nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+					fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 				}
 			}
 			// For namespace based syncs, the L4 VSes would be named: clusterName + "--" + namespace
 			if strings.HasPrefix(vsCacheKey.Name, lib.GetNamePrefix()+syncNamespace) {
 				delete(allModels, modelName)
 				utils.AviLog.Infof("Model published L4 VS during namespace based sync: %s", modelName)
-				nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+				fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 			}
 		} else {
 			delete(allModels, modelName)
 			utils.AviLog.Infof("Model published in full sync %s", modelName)
-			nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+			fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 		}
 	}
 	// Now also publish the newly generated models (if any)
 	// Publish all the models to REST layer.
 	utils.AviLog.Debugf("Newly generated models that do not exist in cache %s", utils.Stringify(allModels))
 	for modelName := range allModels {
-		nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+		fullSync.PublishKeyToRestLayer(modelName, sharedQueue)
 	}
 }
 
@@ -1346,6 +1369,7 @@ func (c *AviController) publishAllParentVSKeysToRestLayer() {
 // The rest layer would pick up the model key and delete the objects in Avi
 func (c *AviController) DeleteModels() {
 	utils.AviLog.Infof("Deletion of all avi objects triggered")
+	objects.SharedFullSyncStateLister().Reset()
 	publisher := status.NewStatusPublisher()
 	publisher.AddStatefulSetAnnotation(status.ObjectDeletionStatus, lib.ObjectDeletionStartStatus)
 	allModels := objects.SharedAviGraphLister().GetAll()
@@ -1431,6 +1455,7 @@ func DeleteNPLAnnotations() {
 }
 
 func SyncFromIngestionLayer(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.GraphStage, time.Now())
 	// This method will do all necessary graph calculations on the Graph Layer
 	// Let's route the key to the graph layer.
 	// NOTE: There's no error propagation from the graph layer back to the workerqueue. We will evaluate
@@ -1470,6 +1495,7 @@ func SyncFromSlowRetryLayer(key interface{}, wg *sync.WaitGroup) error {
 }
 
 func SyncFromNodesLayer(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.RestStage, time.Now())
 	keyStr, ok := key.(string)
 	if !ok {
 		utils.AviLog.Warnf("Unexpected object type: expected string, got %T", key)
@@ -1483,6 +1509,7 @@ func SyncFromNodesLayer(key interface{}, wg *sync.WaitGroup) error {
 }
 
 func SyncFromStatusQueue(key interface{}, wg *sync.WaitGroup) error {
+	defer lib.ObserveStageDuration(lib.StatusStage, time.Now())
 	publisher := status.NewStatusPublisher()
 	publisher.DequeueStatus(key)
 	return nil
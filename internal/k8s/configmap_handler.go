@@ -0,0 +1,171 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// liveConfigKeys are the settings of the avi-k8s-config configmap, which are applied without restarting AKO.
+// A change in any other setting is applied only after AKO restarts.
+var liveConfigKeys = map[string]struct{}{
+	lib.LOG_LEVEL:           {},
+	lib.EnableEvents:        {},
+	lib.DeleteConfig:        {},
+	lib.DefaultDomainConfig: {},
+	lib.AutoFQDNConfig:      {},
+	lib.BlockedNSListConfig: {},
+}
+
+// applyLiveConfig applies the changes in the defaultDomain, autoFQDN and blockedNamespaceList settings,
+// and adds the objects affected by the changes to the ingestion queue. The changes in logLevel, enableEvents
+// and deleteConfig are handled by the configmap event handler.
+func (c *AviController) applyLiveConfig(oldData, data map[string]string) {
+	var updatedKeys []string
+	if oldData[lib.DefaultDomainConfig] != data[lib.DefaultDomainConfig] || oldData[lib.AutoFQDNConfig] != data[lib.AutoFQDNConfig] {
+		// The L4 FQDN settings are read from the environment when the L4 models are built.
+		os.Setenv(lib.DEFAULT_DOMAIN, data[lib.DefaultDomainConfig])
+		os.Setenv(lib.AUTO_L4_FQDN, data[lib.AutoFQDNConfig])
+		utils.AviLog.Infof("Default domain set to %s and autoFQDN set to %s", data[lib.DefaultDomainConfig], data[lib.AutoFQDNConfig])
+		c.addL4ServicesToIngestionQueue()
+		updatedKeys = append(updatedKeys, lib.DefaultDomainConfig, lib.AutoFQDNConfig)
+	}
+
+	if oldData[lib.BlockedNSListConfig] != data[lib.BlockedNSListConfig] {
+		os.Setenv(lib.BLOCKED_NS_LIST, data[lib.BlockedNSListConfig])
+		oldBlockedNS := lib.AKOControlConfig().GetAKOBlockedNSList()
+		lib.AKOControlConfig().SetAKOBlockedNSList(lib.GetGlobalBlockedNSList())
+		newBlockedNS := lib.AKOControlConfig().GetAKOBlockedNSList()
+		utils.AviLog.Infof("Blocked namespace list set to %s", utils.Stringify(lib.GetGlobalBlockedNSList()))
+		for namespace := range oldBlockedNS {
+			if _, ok := newBlockedNS[namespace]; !ok {
+				c.addNamespaceObjectsToIngestionQueue(namespace, lib.NsFilterAdd)
+			}
+		}
+		for namespace := range newBlockedNS {
+			if _, ok := oldBlockedNS[namespace]; !ok {
+				c.addNamespaceObjectsToIngestionQueue(namespace, lib.NsFilterDelete)
+			}
+		}
+		updatedKeys = append(updatedKeys, lib.BlockedNSListConfig)
+	}
+
+	if len(updatedKeys) > 0 {
+		// The objects skipped by the incremental full sync are translated with the earlier settings, so the next
+		// full sync translates all the objects.
+		objects.SharedFullSyncStateLister().Reset()
+		lib.AKOControlConfig().PodEventf(corev1.EventTypeNormal, lib.AKOConfigUpdated, "Applied the changes in configmap for %s", strings.Join(updatedKeys, ", "))
+	}
+}
+
+// getRestartRequiredConfigKeys returns the settings, which differ from the ones AKO booted up with and are
+// applied only after AKO restarts.
+func getRestartRequiredConfigKeys(bootupData, data map[string]string) []string {
+	var restartRequiredKeys []string
+	for key, value := range data {
+		if _, ok := liveConfigKeys[key]; ok {
+			continue
+		}
+		if bootupValue, ok := bootupData[key]; !ok || bootupValue != value {
+			restartRequiredKeys = append(restartRequiredKeys, key)
+		}
+	}
+	for key := range bootupData {
+		if _, ok := liveConfigKeys[key]; ok {
+			continue
+		}
+		if _, ok := data[key]; !ok {
+			restartRequiredKeys = append(restartRequiredKeys, key)
+		}
+	}
+	sort.Strings(restartRequiredKeys)
+	return restartRequiredKeys
+}
+
+// updateRestartRequiredStatus raises an event and annotates the AKO statefulset with the changes in the configmap,
+// which are not applied until AKO restarts. The annotation is removed when the changes are reverted.
+func updateRestartRequiredStatus(bootupData, data map[string]string) {
+	restartRequiredKeys := getRestartRequiredConfigKeys(bootupData, data)
+	if len(restartRequiredKeys) == 0 {
+		status.NewStatusPublisher().ResetStatefulSetAnnotation(status.ConfigRestartRequiredStatus)
+		return
+	}
+	keys := strings.Join(restartRequiredKeys, ",")
+	utils.AviLog.Warnf("Changes in configmap for %s would be applied after AKO restarts", keys)
+	lib.AKOControlConfig().PodEventf(corev1.EventTypeWarning, lib.AKORestartRequired, "Restart AKO to apply the changes in configmap for %s", keys)
+	status.NewStatusPublisher().AddStatefulSetAnnotation(status.ConfigRestartRequiredStatus, keys)
+}
+
+// addL4ServicesToIngestionQueue adds the services of type LoadBalancer in all the synced namespaces to the ingestion queue.
+func (c *AviController) addL4ServicesToIngestionQueue() {
+	if c.DisableSync || c.workqueue == nil || utils.GetInformers().ServiceInformer == nil || lib.GetLayer7Only() {
+		return
+	}
+	svcObjs, err := utils.GetInformers().ServiceInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("Unable to retrieve the services: %s", err)
+		return
+	}
+	numWorkers := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer).NumWorkers
+	for _, svcObj := range svcObjs {
+		if !isServiceLBType(svcObj) || !lib.IsNamespaceSynced(svcObj.Namespace) {
+			continue
+		}
+		key := utils.L4LBService + "/" + utils.ObjKey(svcObj)
+		AddKeyFromNSToIngstionQueue(numWorkers, c, svcObj.Namespace, key, "configmap updated")
+	}
+}
+
+// addNamespaceObjectsToIngestionQueue adds the objects of a namespace to the ingestion queue, when the namespace is
+// added to or removed from the blocked namespace list.
+func (c *AviController) addNamespaceObjectsToIngestionQueue(namespace, msg string) {
+	if c.DisableSync || c.workqueue == nil || !utils.CheckIfNamespaceAccepted(namespace) {
+		return
+	}
+	numWorkers := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer).NumWorkers
+	if utils.GetInformers().IngressInformer != nil {
+		utils.AviLog.Debugf("Adding ingresses for namespace: %s", namespace)
+		AddIngressFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	} else if utils.GetInformers().RouteInformer != nil {
+		utils.AviLog.Debugf("Adding routes for namespace: %s", namespace)
+		AddRoutesFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().MultiClusterIngressInformer != nil {
+		utils.AviLog.Debugf("Adding multi-cluster ingresses for namespace: %s", namespace)
+		AddMultiClusterIngressFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().ServiceImportInformer != nil {
+		utils.AviLog.Debugf("Adding service imports for namespace: %s", namespace)
+		AddServiceImportsFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().ServiceInformer != nil {
+		utils.AviLog.Debugf("Adding services for namespace: %s", namespace)
+		AddServicesFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if lib.UseServicesAPI() {
+		utils.AviLog.Debugf("Adding gateways for namespace: %s", namespace)
+		AddGatewaysFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+}
@@ -35,7 +35,6 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
 var controllerInstance *AviController
@@ -60,11 +59,10 @@ var ctrlonce sync.Once
 // +kubebuilder:rbac:groups=ako.vmware.com,resources=aviinfrasettings;aviinfrasettings/status,verbs=get;list;watch;create;update;patch;delete
 
 type AviController struct {
-	worker_id uint32
 	//recorder        record.EventRecorder
 	informers        *utils.Informers
 	dynamicInformers *lib.DynamicInformers
-	workqueue        []workqueue.RateLimitingInterface
+	workqueue        []*utils.PriorityQueue
 	DisableSync      bool
 	State            *State
 }
@@ -78,7 +76,6 @@ type K8sinformers struct {
 func SharedAviController() *AviController {
 	ctrlonce.Do(func() {
 		controllerInstance = &AviController{
-			worker_id: (uint32(1) << utils.NumWorkersIngestion) - 1,
 			//recorder:  recorder,
 			informers:        utils.GetInformers(),
 			dynamicInformers: lib.GetDynamicInformers(),
@@ -198,7 +195,7 @@ func AddIngressFromNSToIngestionQueue(numWorkers uint32, c *AviController, names
 	for _, ingObj := range ingObjs {
 		key := utils.Ingress + "/" + utils.ObjKey(ingObj)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -213,7 +210,7 @@ func AddRoutesFromNSToIngestionQueue(numWorkers uint32, c *AviController, namesp
 	for _, routeObj := range routeObjs {
 		key := utils.OshiftRoute + "/" + utils.ObjKey(routeObj)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -238,7 +235,7 @@ func AddServicesFromNSToIngestionQueue(numWorkers uint32, c *AviController, name
 			key = utils.Service + "/" + utils.ObjKey(svcObj)
 		}
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -255,7 +252,7 @@ func AddGatewaysFromNSToIngestionQueue(numWorkers uint32, c *AviController, name
 		key := lib.Gateway + "/" + utils.ObjKey(gatewayObj)
 		InformerStatusUpdatesForSvcApiGateway(key, gatewayObj)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -270,7 +267,7 @@ func AddMultiClusterIngressFromNSToIngestionQueue(numWorkers uint32, c *AviContr
 	for _, mciObj := range mciObjs {
 		key := lib.MultiClusterIngress + "/" + utils.ObjKey(mciObj)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -285,7 +282,7 @@ func AddServiceImportsFromNSToIngestionQueue(numWorkers uint32, c *AviController
 	for _, siObj := range siObjs {
 		key := lib.MultiClusterIngress + "/" + utils.ObjKey(siObj)
 		bkt := utils.Bkt(namespace, numWorkers)
-		c.workqueue[bkt].AddRateLimited(key)
+		c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityBulk)
 		lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, msg, namespace)
 	}
@@ -552,7 +549,7 @@ func AddRouteEventHandler(numWorkers uint32, c *AviController) cache.ResourceEve
 				return
 			}
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
@@ -639,7 +636,7 @@ func AddPodEventHandler(numWorkers uint32, c *AviController) cache.ResourceEvent
 			}
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
@@ -728,7 +725,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 					return
 				}
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			},
@@ -806,7 +803,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				return
 			}
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
@@ -932,7 +929,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				key = utils.Service + "/" + utils.ObjKey(svc)
 			}
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
@@ -1031,7 +1028,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				}
 				key := utils.NodeObj + "/" + specJSON["node"]
 				bkt := utils.Bkt(lib.GetTenant(), numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -1071,7 +1068,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				}
 				key := utils.NodeObj + "/" + host
 				bkt := utils.Bkt(lib.GetTenant(), numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -1114,7 +1111,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				nodename := crd.GetName()
 				key := utils.NodeObj + "/" + nodename
 				bkt := utils.Bkt(lib.GetTenant(), numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -1171,7 +1168,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 					return
 				}
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			}
@@ -1208,6 +1205,78 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		c.informers.SecretInformer.Informer().AddEventHandler(secretEventHandler)
 	}
 
+	// The ConfigMaps of the custom-headers annotation requeue the Ingresses referring to them.
+	nginxConfigMapEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			configMap := obj.(*corev1.ConfigMap)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(configMap))
+			key := utils.ConfigMap + "/" + utils.ObjKey(configMap)
+			if lib.IsNamespaceBlocked(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: configmap add event. namespace: %s didn't qualify filter", key, namespace)
+				return
+			}
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			configMap, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				configMap, ok = tombstone.Obj.(*corev1.ConfigMap)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a ConfigMap: %#v", obj)
+					return
+				}
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(configMap))
+			key := utils.ConfigMap + "/" + utils.ObjKey(configMap)
+			if lib.IsNamespaceBlocked(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: configmap delete event. namespace: %s didn't qualify filter", key, namespace)
+				return
+			}
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
+			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldobj := old.(*corev1.ConfigMap)
+			configMap := cur.(*corev1.ConfigMap)
+			if oldobj.ResourceVersion == configMap.ResourceVersion || reflect.DeepEqual(configMap.Data, oldobj.Data) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(configMap))
+			key := utils.ConfigMap + "/" + utils.ObjKey(configMap)
+			if lib.IsNamespaceBlocked(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: configmap update event. namespace: %s didn't qualify filter", key, namespace)
+				return
+			}
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+		},
+	}
+
+	if c.informers.NginxConfigMapInformer != nil {
+		c.informers.NginxConfigMapInformer.Informer().AddEventHandler(nginxConfigMapEventHandler)
+	}
+
 	// Add CRD handlers HostRule/HTTPRule/AviInfraSettings/SSORule
 	c.SetupAKOCRDEventHandlers(numWorkers)
 
@@ -1287,7 +1356,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 			}
 			objects.SharedResourceVerInstanceLister().Delete(key)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
@@ -1362,7 +1431,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 			}
 			bkt := utils.Bkt(lib.GetTenant(), numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
@@ -1434,7 +1503,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 				key := utils.IngressClass + "/" + utils.ObjKey(ingClass)
 				bkt := utils.Bkt(namespace, numWorkers)
 				objects.SharedResourceVerInstanceLister().Delete(key)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			},
@@ -1597,6 +1666,11 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 			informersList = append(informersList, c.informers.RouteInformer.Informer().HasSynced)
 		}
 
+		if c.informers.NginxConfigMapInformer != nil {
+			go c.informers.NginxConfigMapInformer.Informer().Run(stopCh)
+			informersList = append(informersList, c.informers.NginxConfigMapInformer.Informer().HasSynced)
+		}
+
 		go c.informers.NodeInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.informers.NodeInformer.Informer().HasSynced)
 
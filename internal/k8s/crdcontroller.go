@@ -225,7 +225,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 				objects.SharedResourceVerInstanceLister().Delete(key)
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -293,7 +293,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 				// no need to validate for delete handler
 				bkt := utils.Bkt(namespace, numWorkers)
 				objects.SharedResourceVerInstanceLister().Delete(key)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -359,7 +359,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 				objects.SharedResourceVerInstanceLister().Delete(key)
 				// no need to validate for delete handler
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -423,7 +423,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 				objects.SharedResourceVerInstanceLister().Delete(key)
 				bkt := utils.Bkt(namespace, numWorkers)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -487,7 +487,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 				bkt := utils.Bkt(namespace, numWorkers)
 				objects.SharedResourceVerInstanceLister().Delete(key)
-				c.workqueue[bkt].AddRateLimited(key)
+				c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 				lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 			},
 		}
@@ -587,7 +587,7 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 						key := lib.HostRule + "/" + utils.ObjKey(hostrule)
 						utils.AviLog.Debugf("key: %s, msg: Update", key)
 						bkt := utils.Bkt(namespace, numWorkers)
-						c.workqueue[bkt].AddRateLimited(key)
+						c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 						lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 					}
 				}
@@ -658,7 +658,7 @@ func (c *AviController) SetupIstioCRDEventHandlers(numWorkers uint32) {
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		},
 	}
@@ -720,7 +720,7 @@ func (c *AviController) SetupIstioCRDEventHandlers(numWorkers uint32) {
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		},
 	}
@@ -782,7 +782,7 @@ func (c *AviController) SetupIstioCRDEventHandlers(numWorkers uint32) {
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		},
 	}
@@ -865,7 +865,7 @@ func (c *AviController) SetupMultiClusterIngressEventHandlers(numWorkers uint32)
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		},
 	}
@@ -946,7 +946,7 @@ func (c *AviController) SetupServiceImportEventHandlers(numWorkers uint32) {
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
 			objects.SharedResourceVerInstanceLister().Delete(key)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 			lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
 		},
 	}
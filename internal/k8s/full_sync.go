@@ -0,0 +1,386 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// IncrementalFullSync tracks the kubernetes objects and the models, which are rebuilt or skipped in a full sync.
+// An object is translated only if it has changed since the last full sync, or if a virtual service built for it
+// has diverged from the Avi object cache. A model is published to the rest layer only if its graph checksum has changed
+// since it was last published, or if its virtual services have diverged from the Avi object cache.
+type IncrementalFullSync struct {
+	// incremental is false for the first full sync, which translates all the objects and publishes all the models.
+	incremental bool
+	// dequeue translates the object of a key.
+	dequeue func(key string)
+	// dependencyVersions returns the resource versions of the objects, which the translation of an object depends on.
+	dependencyVersions func(obj interface{}) ([]string, bool)
+	// divergedModels are the models, whose virtual services are not in the Avi object cache with the checksum of the model.
+	divergedModels map[string]struct{}
+	// divergedObjects are the keys of the ingresses, routes and services, for which the diverged virtual services are built.
+	divergedObjects map[string]struct{}
+	objectsRebuilt  int
+	objectsSkipped  int
+	modelsRebuilt   int
+	modelsSkipped   int
+}
+
+// aviGraph is implemented by the models of the ingresses, routes and services, and the models of the gateways.
+type aviGraph interface {
+	GetAviVS() []*nodes.AviVsNode
+	GetAviEvhVS() []*nodes.AviEvhVsNode
+	GetCheckSum() uint32
+}
+
+func dequeueIngestion(key string) {
+	lib.IncrementQueueCounter(utils.ObjectIngestionLayer)
+	nodes.DequeueIngestion(key, true)
+}
+
+// NewIncrementalFullSync returns the tracker of a full sync, which translates the objects with dequeue. An object is
+// skipped only if neither it nor the dependencies returned by dependencyVersions have changed. The full sync is
+// incremental if requested and if a full sync has completed since the state was last reset.
+func NewIncrementalFullSync(incremental bool, dequeue func(key string), dependencyVersions func(obj interface{}) ([]string, bool)) *IncrementalFullSync {
+	fullSync := &IncrementalFullSync{
+		incremental:        incremental && objects.SharedFullSyncStateLister().IsSynced(),
+		dequeue:            dequeue,
+		dependencyVersions: dependencyVersions,
+		divergedModels:     make(map[string]struct{}),
+		divergedObjects:    make(map[string]struct{}),
+	}
+	if fullSync.incremental {
+		fullSync.findDivergedModels()
+	}
+	return fullSync
+}
+
+// findDivergedModels compares the virtual services of the models with the Avi object cache.
+func (f *IncrementalFullSync) findDivergedModels() {
+	allModels, ok := objects.SharedAviGraphLister().GetAll().(map[string]interface{})
+	if !ok {
+		return
+	}
+	for modelName, aviModelIntf := range allModels {
+		aviModel, ok := aviModelIntf.(aviGraph)
+		if !ok || aviModelIntf == nil {
+			continue
+		}
+		tenant := strings.Split(modelName, "/")[0]
+		diverged := false
+		for _, vsNode := range aviModel.GetAviVS() {
+			vsNodes := append([]*nodes.AviVsNode{vsNode}, vsNode.SniNodes...)
+			for _, node := range vsNodes {
+				if isVSDiverged(tenant, node.Name, node.GetCheckSum()) {
+					diverged = true
+					f.addDivergedObjects(node.ServiceMetadata)
+				}
+			}
+		}
+		for _, evhNode := range aviModel.GetAviEvhVS() {
+			evhNodes := append([]*nodes.AviEvhVsNode{evhNode}, evhNode.EvhNodes...)
+			for _, node := range evhNodes {
+				if isVSDiverged(tenant, node.Name, node.GetCheckSum()) {
+					diverged = true
+					f.addDivergedObjects(node.ServiceMetadata)
+				}
+			}
+		}
+		if diverged {
+			f.divergedModels[modelName] = struct{}{}
+		}
+	}
+}
+
+func isVSDiverged(tenant, vsName string, checksum uint32) bool {
+	vsKey := avicache.NamespaceName{Namespace: tenant, Name: vsName}
+	vsCache, found := avicache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+	if !found {
+		return true
+	}
+	vsCacheObj, ok := vsCache.(*avicache.AviVsCache)
+	return !ok || vsCacheObj.CloudConfigCksum != strconv.Itoa(int(checksum))
+}
+
+func (f *IncrementalFullSync) addDivergedObjects(svcMetadata lib.ServiceMetadataObj) {
+	ingressTypes := []string{utils.Ingress, utils.OshiftRoute}
+	for _, ingNSName := range svcMetadata.NamespaceIngressName {
+		for _, objType := range ingressTypes {
+			f.divergedObjects[objType+"/"+ingNSName] = struct{}{}
+		}
+	}
+	if svcMetadata.Gateway != "" {
+		f.divergedObjects[lib.Gateway+"/"+svcMetadata.Gateway] = struct{}{}
+	}
+	if svcMetadata.HTTPRoute != "" {
+		f.divergedObjects[lib.HTTPRoute+"/"+svcMetadata.HTTPRoute] = struct{}{}
+	}
+	if svcMetadata.Namespace != "" && svcMetadata.IngressName != "" {
+		for _, objType := range ingressTypes {
+			f.divergedObjects[objType+"/"+svcMetadata.Namespace+"/"+svcMetadata.IngressName] = struct{}{}
+		}
+	}
+	for _, svcNSName := range svcMetadata.NamespaceServiceName {
+		for _, objType := range []string{utils.Service, utils.L4LBService, lib.SharedVipServiceKey} {
+			f.divergedObjects[objType+"/"+svcNSName] = struct{}{}
+		}
+	}
+}
+
+// DequeueIngestion translates the object, unless it and the objects it depends on are unchanged since the last full sync.
+func (f *IncrementalFullSync) DequeueIngestion(key string, obj interface{}) {
+	resVer := f.syncedResourceVersion(obj)
+	fullSyncState := objects.SharedFullSyncStateLister()
+	if f.incremental && fullSyncState.IsResourceVersionSynced(key, resVer) {
+		if _, diverged := f.divergedObjects[key]; !diverged {
+			utils.AviLog.Debugf("key: %s, msg: object unchanged since the last full sync, skipping", key)
+			f.objectsSkipped++
+			return
+		}
+	}
+	f.dequeue(key)
+	fullSyncState.SaveResourceVersion(key, resVer)
+	f.objectsRebuilt++
+}
+
+// syncedResourceVersion returns the version of the object, which is recorded in the full sync state. It includes the
+// resource versions of the namespace and the dependencies of the object, as a change in them does not change the
+// resource version of the object itself.
+func (f *IncrementalFullSync) syncedResourceVersion(obj interface{}) string {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil || objMeta.GetResourceVersion() == "" {
+		return ""
+	}
+	dependencies, ok := f.dependencyVersions(obj)
+	if !ok {
+		return objMeta.GetResourceVersion()
+	}
+	dependencies = append(dependencies, namespaceVersion(objMeta.GetNamespace()))
+	return objMeta.GetResourceVersion() + "/" + strings.Join(dependencies, ",")
+}
+
+// dependencyVersions returns the resource versions of the secrets, services, endpoints, HostRules, HTTPRules,
+// IngressClass and AviInfraSetting, which the translation of an ingress, a route or a service depends on.
+func dependencyVersions(obj interface{}) ([]string, bool) {
+	switch o := obj.(type) {
+	case *networkingv1.Ingress:
+		return ingressDependencyVersions(o), true
+	case *routev1.Route:
+		return routeDependencyVersions(o), true
+	case *corev1.Service:
+		return EndpointsVersions(o.Namespace, o.Name), true
+	}
+	return nil, false
+}
+
+// DependencyVersion formats the resource version of a dependency, which is empty if the dependency is not found.
+func DependencyVersion(kind, name string, obj metav1.Object, err error) string {
+	if err != nil {
+		return kind + "/" + name + "="
+	}
+	return kind + "/" + name + "=" + obj.GetResourceVersion()
+}
+
+func namespaceVersion(namespace string) string {
+	if utils.GetInformers().NSInformer == nil {
+		return ""
+	}
+	nsObj, err := utils.GetInformers().NSInformer.Lister().Get(namespace)
+	return DependencyVersion(utils.Namespace, namespace, nsObj, err)
+}
+
+// ServiceVersions returns the resource versions of the service and its endpoints.
+func ServiceVersions(namespace, name string) []string {
+	var versions []string
+	if utils.GetInformers().ServiceInformer != nil {
+		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(name)
+		versions = append(versions, DependencyVersion(utils.Service, namespace+"/"+name, svcObj, err))
+	}
+	return append(versions, EndpointsVersions(namespace, name)...)
+}
+
+// EndpointsVersions returns the resource versions of the endpoints, or the endpointslices, of the service.
+func EndpointsVersions(namespace, name string) []string {
+	if lib.AKOControlConfig().GetEndpointSlicesEnabled() {
+		if utils.GetInformers().EpSlicesInformer == nil {
+			return nil
+		}
+		epSliceIntList, err := utils.GetInformers().EpSlicesInformer.Informer().GetIndexer().ByIndex(discovery.LabelServiceName, namespace+"/"+name)
+		if err != nil {
+			return nil
+		}
+		var versions []string
+		for _, epSliceInt := range epSliceIntList {
+			if epSlice, ok := epSliceInt.(*discovery.EndpointSlice); ok {
+				versions = append(versions, DependencyVersion(utils.Endpointslices, namespace+"/"+epSlice.Name, epSlice, nil))
+			}
+		}
+		sort.Strings(versions)
+		return versions
+	}
+	if utils.GetInformers().EpInformer == nil {
+		return nil
+	}
+	epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(namespace).Get(name)
+	return []string{DependencyVersion(utils.Endpoints, namespace+"/"+name, epObj, err)}
+}
+
+// SecretVersion returns the resource version of the secret.
+func SecretVersion(namespace, name string) string {
+	if utils.GetInformers().SecretInformer == nil {
+		return ""
+	}
+	secretObj, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(name)
+	return DependencyVersion(utils.Secret, namespace+"/"+name, secretObj, err)
+}
+
+// hostRuleVersions returns the resource versions of the HostRule, its CA Secret and the HTTPRules of the host.
+func hostRuleVersions(host string) []string {
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	if crdInformers == nil || host == "" {
+		return nil
+	}
+	var versions []string
+	if found, hostRule := objects.SharedCRDLister().GetFQDNToHostruleMapping(host); found && crdInformers.HostRuleInformer != nil {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(hostRule); err == nil {
+			hostRuleObj, err := crdInformers.HostRuleInformer.Lister().HostRules(namespace).Get(name)
+			versions = append(versions, DependencyVersion(lib.HostRule, hostRule, hostRuleObj, err))
+			if err == nil && hostRuleObj.Spec.VirtualHost.TLS.ClientCertificate != nil &&
+				hostRuleObj.Spec.VirtualHost.TLS.ClientCertificate.CACertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
+				versions = append(versions, SecretVersion(namespace, hostRuleObj.Spec.VirtualHost.TLS.ClientCertificate.CACertificate.Name))
+			}
+		}
+	}
+	if found, httpRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host); found && crdInformers.HTTPRuleInformer != nil {
+		for _, httpRule := range httpRules {
+			if namespace, name, err := cache.SplitMetaNamespaceKey(httpRule); err == nil {
+				httpRuleObj, err := crdInformers.HTTPRuleInformer.Lister().HTTPRules(namespace).Get(name)
+				versions = append(versions, DependencyVersion(lib.HTTPRule, httpRule, httpRuleObj, err))
+			}
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func ingressDependencyVersions(ingress *networkingv1.Ingress) []string {
+	var versions []string
+	if ingress.Spec.IngressClassName != nil && utils.GetInformers().IngressClassInformer != nil {
+		ingClass, err := utils.GetInformers().IngressClassInformer.Lister().Get(*ingress.Spec.IngressClassName)
+		versions = append(versions, DependencyVersion(utils.IngressClass, *ingress.Spec.IngressClassName, ingClass, err))
+		crdInformers := lib.AKOControlConfig().CRDInformers()
+		if err == nil && ingClass.Spec.Parameters != nil && ingClass.Spec.Parameters.Kind == lib.AviInfraSetting &&
+			crdInformers != nil && crdInformers.AviInfraSettingInformer != nil {
+			infraSetting, err := crdInformers.AviInfraSettingInformer.Lister().Get(ingClass.Spec.Parameters.Name)
+			versions = append(versions, DependencyVersion(lib.AviInfraSetting, ingClass.Spec.Parameters.Name, infraSetting, err))
+		}
+	}
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName != "" {
+			versions = append(versions, SecretVersion(ingress.Namespace, tls.SecretName))
+		}
+	}
+	if configMapRef := ingress.Annotations[lib.NginxCustomHeaders]; configMapRef != "" && lib.IsNginxAnnotationsEnabled() &&
+		utils.GetInformers().NginxConfigMapInformer != nil {
+		name := strings.TrimPrefix(strings.TrimSpace(configMapRef), ingress.Namespace+"/")
+		configMap, err := utils.GetInformers().NginxConfigMapInformer.Lister().ConfigMaps(ingress.Namespace).Get(name)
+		versions = append(versions, DependencyVersion(utils.ConfigMap, ingress.Namespace+"/"+name, configMap, err))
+	}
+	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
+		versions = append(versions, ServiceVersions(ingress.Namespace, ingress.Spec.DefaultBackend.Service.Name)...)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		versions = append(versions, hostRuleVersions(rule.Host)...)
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				versions = append(versions, ServiceVersions(ingress.Namespace, path.Backend.Service.Name)...)
+			}
+		}
+	}
+	return versions
+}
+
+func routeDependencyVersions(route *routev1.Route) []string {
+	versions := hostRuleVersions(route.Spec.Host)
+	versions = append(versions, ServiceVersions(route.Namespace, route.Spec.To.Name)...)
+	for _, backend := range route.Spec.AlternateBackends {
+		versions = append(versions, ServiceVersions(route.Namespace, backend.Name)...)
+	}
+	return versions
+}
+
+// needsPublish returns true if the model is to be published to the rest layer.
+func (f *IncrementalFullSync) needsPublish(modelName string) bool {
+	if f == nil || !f.incremental {
+		return true
+	}
+	if _, diverged := f.divergedModels[modelName]; diverged {
+		return true
+	}
+	found, aviModelIntf := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModelIntf == nil {
+		// The virtual services of a deleted model are removed from the Avi controller by the rest layer.
+		return true
+	}
+	aviModel, ok := aviModelIntf.(aviGraph)
+	return !ok || !objects.SharedFullSyncStateLister().IsGraphChecksumSynced(modelName, aviModel.GetCheckSum())
+}
+
+// PublishKeyToRestLayer publishes the model to the rest layer, unless it is unchanged since it was last published.
+func (f *IncrementalFullSync) PublishKeyToRestLayer(modelName string, sharedQueue *utils.WorkerQueue) {
+	if !f.needsPublish(modelName) {
+		utils.AviLog.Debugf("Model unchanged since the last full sync, skipping: %s", modelName)
+		f.modelsSkipped++
+		return
+	}
+	found, aviModelIntf := objects.SharedAviGraphLister().Get(modelName)
+	if found && aviModelIntf != nil {
+		if aviModel, ok := aviModelIntf.(aviGraph); ok {
+			objects.SharedFullSyncStateLister().SaveGraphChecksum(modelName, aviModel.GetCheckSum())
+		}
+	}
+	nodes.PublishKeyToRestLayerWithPriority(modelName, "fullsync", sharedQueue, utils.PriorityBulk)
+	if f != nil {
+		f.modelsRebuilt++
+	}
+}
+
+// Report logs and records the number of the objects and the models, which are rebuilt and skipped in the full sync.
+func (f *IncrementalFullSync) Report() {
+	utils.AviLog.Infof("Full sync of the kubernetes objects rebuilt %d and skipped %d objects, published %d and skipped %d models",
+		f.objectsRebuilt, f.objectsSkipped, f.modelsRebuilt, f.modelsSkipped)
+	lib.ObserveFullSyncModels(f.modelsRebuilt, f.modelsSkipped)
+}
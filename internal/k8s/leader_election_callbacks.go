@@ -22,6 +22,7 @@ import (
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/rest"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -57,6 +58,8 @@ func (c *AviController) OnStoppedLeading() {
 func (c *AviController) OnStartedLeadingDuringBootup() {
 	c.publishAllParentVSKeysToRestLayer()
 	c.CleanupStaleVSes()
+	// The changes in the configmap, made before the restart, are applied during the bootup.
+	status.NewStatusPublisher().ResetStatefulSetAnnotation(status.ConfigRestartRequiredStatus)
 	// once the l3 cache is populated, we can call the updatestatus functions from here
 	restlayer := rest.NewRestOperations(avicache.SharedAviObjCache())
 	restlayer.SyncObjectStatuses()
@@ -255,7 +255,7 @@ func (c *AviController) SetupSvcApiEventHandlers(numWorkers uint32) {
 			}
 			utils.AviLog.Infof("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 		},
 	}
 
@@ -306,7 +306,7 @@ func (c *AviController) SetupSvcApiEventHandlers(numWorkers uint32) {
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(gwclass))
 			utils.AviLog.Infof("key: %s, msg: DELETE", key)
 			bkt := utils.Bkt(namespace, numWorkers)
-			c.workqueue[bkt].AddRateLimited(key)
+			c.workqueue[bkt].AddRateLimitedWithPriority(key, utils.PriorityDelete)
 		},
 	}
 
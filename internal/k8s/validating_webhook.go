@@ -0,0 +1,241 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vmware/alb-sdk/go/session"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// maxAdmissionReviewSize is the limit on the size of an AdmissionReview request body.
+const maxAdmissionReviewSize = 3 * 1024 * 1024
+
+// StartValidatingWebhook serves the validation of the AKO CRDs as a ValidatingAdmissionWebhook, so that the invalid
+// CRDs are rejected when they are created or updated, instead of being rejected in their status later.
+// The certificate and the key of the webhook server are read from the ValidatingWebhookCertDir.
+func StartValidatingWebhook(stopCh <-chan struct{}) {
+	cert := NewWebhookCertificate(filepath.Join(lib.ValidatingWebhookCertDir, "tls.crt"), filepath.Join(lib.ValidatingWebhookCertDir, "tls.key"))
+	if _, err := cert.GetCertificate(nil); err != nil {
+		utils.AviLog.Errorf("Unable to load the certificate of the validating webhook, not starting the webhook server: %v", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(lib.ValidatingWebhookPath, ServeValidatingWebhook)
+	server := &http.Server{
+		Addr:         ":" + lib.GetValidatingWebhookPort(),
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		TLSConfig: &tls.Config{
+			GetCertificate: cert.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		},
+	}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			utils.AviLog.Warnf("Error shutting down the validating webhook server: %v", err)
+		}
+	}()
+
+	utils.AviLog.Infof("Starting validating webhook server at %s", server.Addr)
+	if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		utils.AviLog.Errorf("Validating webhook server stopped: %v", err)
+	}
+}
+
+// WebhookCertificate is the certificate of the webhook server, which is reloaded when the certificate file is
+// modified, as the mounted secret is updated without restarting AKO.
+type WebhookCertificate struct {
+	certFile string
+	keyFile  string
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	modTime  time.Time
+}
+
+func NewWebhookCertificate(certFile, keyFile string) *WebhookCertificate {
+	return &WebhookCertificate{certFile: certFile, keyFile: keyFile}
+}
+
+// GetCertificate returns the certificate loaded from the files, or the last loaded certificate if the files can not
+// be loaded.
+func (c *WebhookCertificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	info, err := os.Stat(c.certFile)
+	if err == nil && c.cert != nil && info.ModTime().Equal(c.modTime) {
+		return c.cert, nil
+	}
+	if err == nil {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(c.certFile, c.keyFile); err == nil {
+			if c.cert != nil {
+				utils.AviLog.Infof("Reloaded the certificate of the validating webhook")
+			}
+			c.cert = &cert
+			c.modTime = info.ModTime()
+			return c.cert, nil
+		}
+	}
+	if c.cert == nil {
+		return nil, err
+	}
+	utils.AviLog.Warnf("Unable to reload the certificate of the validating webhook, using the earlier certificate: %v", err)
+	return c.cert, nil
+}
+
+// ServeValidatingWebhook handles an AdmissionReview request for an AKO CRD.
+func ServeValidatingWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAdmissionReviewSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to read the request: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, "invalid AdmissionReview request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = reviewCRD(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		utils.AviLog.Warnf("Unable to write the AdmissionReview response: %v", err)
+	}
+}
+
+// reviewCRD validates the CRD in an AdmissionRequest. The references to the Avi objects are not validated when
+// the Avi controller is unreachable, unless the webhook is configured to fail closed.
+func reviewCRD(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	key := request.Kind.Kind + "/" + request.Namespace + "/" + request.Name
+	if request.Namespace == "" {
+		key = request.Kind.Kind + "/" + request.Name
+	}
+	options := crdValidationOptions{checkRefs: true}
+	var warnings []string
+	if !isAviControllerReachable(key) {
+		if !lib.IsValidatingWebhookFailOpen() {
+			return deniedAdmissionResponse(fmt.Sprintf("%s can not be validated, Avi controller is unreachable", request.Kind.Kind))
+		}
+		options.checkRefs = false
+		warnings = append(warnings, "Avi controller is unreachable, the references to the Avi objects are not validated")
+	}
+
+	var err error
+	switch request.Kind.Kind {
+	case lib.HostRule:
+		hostrule := &akov1beta1.HostRule{}
+		if err = json.Unmarshal(request.Object.Raw, hostrule); err == nil {
+			err = validateHostRule(key, hostrule, options)
+		}
+	case lib.HTTPRule:
+		httprule := &akov1beta1.HTTPRule{}
+		if err = json.Unmarshal(request.Object.Raw, httprule); err == nil {
+			err = validateHTTPRule(key, httprule, options)
+		}
+	case lib.AviInfraSetting:
+		infraSetting := &akov1beta1.AviInfraSetting{}
+		if err = json.Unmarshal(request.Object.Raw, infraSetting); err == nil {
+			err = validateAviInfraSetting(key, infraSetting, options)
+		}
+	case lib.L4Rule:
+		l4Rule := &akov1alpha2.L4Rule{}
+		if err = json.Unmarshal(request.Object.Raw, l4Rule); err == nil {
+			err = validateL4Rule(key, l4Rule, options)
+		}
+	case lib.L7Rule:
+		l7Rule := &akov1alpha2.L7Rule{}
+		if err = json.Unmarshal(request.Object.Raw, l7Rule); err == nil {
+			err = validateL7Rule(key, l7Rule, options)
+		}
+	case lib.SSORule:
+		ssoRule := &akov1alpha2.SSORule{}
+		if err = json.Unmarshal(request.Object.Raw, ssoRule); err == nil {
+			err = validateSSORule(key, ssoRule, options)
+		}
+	default:
+		utils.AviLog.Debugf("key: %s, msg: kind %s is not validated by the webhook", key, request.Kind.Kind)
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: rejected by the validating webhook: %v", key, err)
+		return deniedAdmissionResponse(err.Error())
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true, Warnings: warnings}
+}
+
+func deniedAdmissionResponse(message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
+
+// isAviControllerReachable returns false if the Avi controller does not respond to a request, in which case
+// the references to the Avi objects can not be validated.
+func isAviControllerReachable(key string) bool {
+	clients := avicache.SharedAVIClients(lib.GetTenant())
+	if clients == nil || len(clients.AviClient) == 0 {
+		return false
+	}
+	uri := fmt.Sprintf("/api/cloud?name=%s&fields=name", utils.CloudName)
+	_, err := clients.AviClient[len(clients.AviClient)-1].AviSession.GetRaw(utils.GetUriEncoded(uri))
+	if err != nil {
+		if _, ok := err.(session.AviError); ok {
+			// The controller responded with an error.
+			return true
+		}
+		utils.AviLog.Warnf("key: %s, msg: Avi controller is unreachable: %v", key, err)
+		return false
+	}
+	return true
+}
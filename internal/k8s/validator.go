@@ -16,6 +16,7 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"regexp"
@@ -49,6 +50,16 @@ type (
 	leader   struct{}
 )
 
+// crdValidationOptions controls the validation checks of the CRDs, which depend on the objects outside the CRD.
+type crdValidationOptions struct {
+	// checkRefs checks the references to the objects on the Avi controller.
+	checkRefs bool
+	// checkK8sRefs checks the references to the Secrets and L7Rules, which may be created after the CRD.
+	checkK8sRefs bool
+}
+
+var leaderValidationOptions = crdValidationOptions{checkRefs: true, checkK8sRefs: true}
+
 func NewValidator() Validator {
 	if lib.AKOControlConfig().IsLeader() {
 		return &leader{}
@@ -59,13 +70,34 @@ func NewValidator() Validator {
 // validateHostRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule) error {
+	if hostrule.Spec.VirtualHost.L7Rule != "" {
+		objects.SharedCRDLister().UpdateL7RuleToHostRuleMapping(hostrule.Namespace+"/"+hostrule.Spec.VirtualHost.L7Rule, hostrule.Name)
+	}
+	if err := validateHostRule(key, hostrule, leaderValidationOptions); err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateHostRule(key, hostrule, crdValidationOptions{}) == nil,
+		})
+		return err
+	}
+
+	// No need to update status of hostrule object as accepted since it was accepted before.
+	if hostrule.Status.Status == lib.StatusAccepted && hostrule.Status.ObservedGeneration == hostrule.Generation {
+		return nil
+	}
+
+	status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	return nil
+}
 
+// validateHostRule does the validation checks of a HostRule, without updating its status.
+func validateHostRule(key string, hostrule *akov1beta1.HostRule, options crdValidationOptions) error {
 	var err error
 	fqdn := hostrule.Spec.VirtualHost.Fqdn
 	foundHost, foundHR := objects.SharedCRDLister().GetFQDNToHostruleMapping(fqdn)
 	if foundHost && foundHR != hostrule.Namespace+"/"+hostrule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundHR)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -82,7 +114,6 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		re := regexp.MustCompile(lib.IPRegex)
 		if !re.MatchString(hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP) {
 			err = fmt.Errorf("loadBalancerIP %s is not a valid IP", hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP)
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -90,7 +121,6 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" {
 		if fqdn == hostrule.Spec.VirtualHost.Gslb.Fqdn {
 			err = fmt.Errorf("GSLB FQDN and local FQDN are same")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -105,7 +135,6 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		}
 		if !sslEnabled {
 			err = fmt.Errorf("Hosting parent virtualservice must have SSL enabled")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -113,26 +142,22 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Aliases != nil {
 		if hostrule.Spec.VirtualHost.FqdnType != akov1beta1.Exact {
 			err = fmt.Errorf("Aliases is supported only when FQDN type is set as Exact")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.HasElem(hostrule.Spec.VirtualHost.Aliases, fqdn) {
 			err = fmt.Errorf("Duplicate entry found. Aliases field has same entry as the FQDN field")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.ContainsDuplicate(hostrule.Spec.VirtualHost.Aliases) {
 			err = fmt.Errorf("Aliases must be unique")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" &&
 			utils.HasElem(hostrule.Spec.VirtualHost.Aliases, hostrule.Spec.VirtualHost.Gslb.Fqdn) {
 			err = fmt.Errorf("Aliases must not contain GSLB FQDN")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
@@ -144,7 +169,6 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 			for _, alias := range hostrule.Spec.VirtualHost.Aliases {
 				if utils.HasElem(aliases, alias) {
 					err = fmt.Errorf("%s is already in use by hostrule %s", alias, cachedFQDN)
-					status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 					return err
 				}
 			}
@@ -162,11 +186,10 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		refData[hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name] = "SslKeyCert"
 	}
 
-	if hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
+	if options.checkK8sRefs && hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -174,16 +197,33 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		refData[hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Name] = "SslKeyCert"
 	}
 
-	if hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
+	if options.checkK8sRefs && hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
+
+	if clientCert := hostrule.Spec.VirtualHost.TLS.ClientCertificate; clientCert != nil {
+		if clientCert.CACertificate.Name == "" {
+			return fmt.Errorf("CA certificate is required for client certificate authentication")
+		}
+		if clientCert.Mode != "" &&
+			clientCert.Mode != akov1beta1.HostRuleClientCertificateModeRequire &&
+			clientCert.Mode != akov1beta1.HostRuleClientCertificateModeRequest {
+			return fmt.Errorf("client certificate mode %s is not supported", clientCert.Mode)
+		}
+		if clientCert.CACertificate.Type == akov1beta1.HostRuleSecretTypeAviReference {
+			refData[clientCert.CACertificate.Name] = "PKIProfile"
+		} else if options.checkK8sRefs && clientCert.CACertificate.Type == akov1beta1.HostRuleSecretTypeSecretReference {
+			if err := validateCASecretReferenceInHostrule(hostrule.Namespace, clientCert.CACertificate.Name); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(hostrule.Spec.VirtualHost.ICAPProfile) > 1 {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: "Can only have 1 ICAP profile associated with VS"})
 		return fmt.Errorf("Can only have 1 ICAP profile associated with VS")
 	} else {
 		for _, icapprofile := range hostrule.Spec.VirtualHost.ICAPProfile {
@@ -206,38 +246,28 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	}
 	tenant := lib.GetTenantInNamespace(hostrule.Namespace)
 
-	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
-		return err
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, tenant); err != nil {
+			return err
+		}
 	}
 
-	if hostrule.Spec.VirtualHost.L7Rule != "" {
-		objects.SharedCRDLister().UpdateL7RuleToHostRuleMapping(hostrule.Namespace+"/"+hostrule.Spec.VirtualHost.L7Rule, hostrule.Name)
+	if options.checkK8sRefs && hostrule.Spec.VirtualHost.L7Rule != "" {
 		_, err := lib.AKOControlConfig().CRDInformers().L7RuleInformer.Lister().L7Rules(hostrule.Namespace).Get(hostrule.Spec.VirtualHost.L7Rule)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
 
 	if strings.Contains(fqdn, lib.ShardVSSubstring) && hostrule.Spec.VirtualHost.UseRegex {
 		err = fmt.Errorf("hostrule useRegex with fqdn %s cannot be applied to shared virtualservices", fqdn)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
 	if strings.Contains(fqdn, lib.ShardVSSubstring) && hostrule.Spec.VirtualHost.ApplicationRootPath != "" {
 		err = fmt.Errorf("hostrule applicationRootPath with fqdn %s cannot be applied to shared virtualservices", fqdn)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
-
-	// No need to update status of hostrule object as accepted since it was accepted before.
-	if hostrule.Status.Status == lib.StatusAccepted {
-		return nil
-	}
-
-	status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -256,6 +286,22 @@ func validateSecretReferenceInHostrule(namespace, secretName string) error {
 	return err
 }
 
+// validateCASecretReferenceInHostrule checks that the secret, used for the client certificate
+// authentication, carries the CA certificate.
+func validateCASecretReferenceInHostrule(namespace, secretName string) error {
+	if err := validateSecretReferenceInHostrule(namespace, secretName); err != nil {
+		return err
+	}
+	secretObj, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(secretName)
+	if err != nil {
+		return err
+	}
+	if len(secretObj.Data[utils.K8S_TLS_SECRET_CA_CERT]) == 0 {
+		return fmt.Errorf("%s not found in secret %s/%s", utils.K8S_TLS_SECRET_CA_CERT, namespace, secretName)
+	}
+	return nil
+}
+
 func validateSecretReferenceInSSORule(namespace, secretName string) (*v1.Secret, error) {
 
 	// reject the SSORule if the secret handling is restricted to the namespace where
@@ -274,16 +320,34 @@ func validateSecretReferenceInSSORule(namespace, secretName string) (*v1.Secret,
 // validateHTTPRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule) error {
+	if err := validateHTTPRule(key, httprule, leaderValidationOptions); err != nil {
+		status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateHTTPRule(key, httprule, crdValidationOptions{}) == nil,
+		})
+		return err
+	}
+
+	// No need to update status of httprule object as accepted since it was accepted before.
+	if httprule.Status.Status == lib.StatusAccepted && httprule.Status.ObservedGeneration == httprule.Generation {
+		return nil
+	}
+
+	status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+		Status: lib.StatusAccepted,
+		Error:  "",
+	})
+	return nil
+}
 
+// validateHTTPRule does the validation checks of an HTTPRule, without updating its status.
+func validateHTTPRule(key string, httprule *akov1beta1.HTTPRule, options crdValidationOptions) error {
 	refData := make(map[string]string)
 	for _, path := range httprule.Spec.Paths {
 		if path.TLS.PKIProfile != "" && path.TLS.DestinationCA != "" {
 			//if both pkiProfile and destCA set, reject httprule
-			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
-				Status: lib.StatusRejected,
-				Error:  lib.HttpRulePkiAndDestCASetErr,
-			})
-			return fmt.Errorf("key: %s, msg: %s", key, lib.HttpRulePkiAndDestCASetErr)
+			return errors.New(lib.HttpRulePkiAndDestCASetErr)
 		}
 		refData[path.TLS.SSLProfile] = "SslProfile"
 		refData[path.ApplicationPersistence] = "ApplicationPersistence"
@@ -297,37 +361,71 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 	}
 	tenant := lib.GetTenantInNamespace(httprule.Namespace)
 
-	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAviInfraSetting would do validaion checks on the
+// ingested AviInfraSetting objects
+func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.AviInfraSetting) error {
+	if err := validateAviInfraSetting(key, infraSetting, leaderValidationOptions); err != nil {
+		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateAviInfraSetting(key, infraSetting, crdValidationOptions{}) == nil,
 		})
 		return err
 	}
 
-	// No need to update status of httprule object as accepted since it was accepted before.
-	if httprule.Status.Status == lib.StatusAccepted {
+	// This would add SEG labels only if they are not configured yet. In case there is a label mismatch
+	// to any pre-existing SEG labels, the AviInfraSettig CR will get Rejected from the checkRefsOnController
+	// step before this.
+	segMgmtNetworK := ""
+	if infraSetting.Spec.SeGroup.Name != "" {
+		addSeGroupLabel(key, infraSetting.Spec.SeGroup.Name)
+		// Not required for NO access cloud
+		if lib.GetCloudType() == lib.CLOUD_VCENTER {
+			segMgmtNetworK = GetSEGManagementNetwork(infraSetting.Spec.SeGroup.Name)
+		}
+	}
+
+	if len(infraSetting.Spec.Network.VipNetworks) > 0 {
+		SetAviInfrasettingVIPNetworks(infraSetting.Name, segMgmtNetworK, infraSetting.Spec.SeGroup.Name, infraSetting.Spec.Network.VipNetworks)
+	}
+
+	if len(infraSetting.Spec.Network.NodeNetworks) > 0 {
+		SetAviInfrasettingNodeNetworks(infraSetting.Name, segMgmtNetworK, infraSetting.Spec.SeGroup.Name, infraSetting.Spec.Network.NodeNetworks)
+	}
+
+	namespaces, err := utils.GetInformers().NSInformer.Informer().GetIndexer().ByIndex(lib.AviSettingNamespaceIndex, infraSetting.GetName())
+	if err == nil && len(namespaces) > 0 {
+		objects.InfraSettingL7Lister().UpdateInfraSettingToNamespaceMapping(infraSetting.GetName(), namespaces)
+	} else {
+		// This handles the case where an NS scoped infrasetting was deleted and later recreated without NS scope.
+		objects.InfraSettingL7Lister().DeleteInfraSettingToNamespaceMapping(infraSetting.GetName())
+	}
+
+	// No need to update status of infra setting object as accepted since it was accepted before.
+	if infraSetting.Status.Status == lib.StatusAccepted && infraSetting.Status.ObservedGeneration == infraSetting.Generation {
 		return nil
 	}
 
-	status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+	status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
 	return nil
 }
 
-// validateAviInfraSetting would do validaion checks on the
-// ingested AviInfraSetting objects
-func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.AviInfraSetting) error {
-
+// validateAviInfraSetting does the validation checks of an AviInfraSetting, without updating its status.
+func validateAviInfraSetting(key string, infraSetting *akov1beta1.AviInfraSetting, options crdValidationOptions) error {
 	if ((infraSetting.Spec.Network.EnableRhi != nil && !*infraSetting.Spec.Network.EnableRhi) || infraSetting.Spec.Network.EnableRhi == nil) &&
 		len(infraSetting.Spec.Network.BgpPeerLabels) > 0 {
 		err := fmt.Errorf("BGPPeerLabels cannot be set if EnableRhi is false.")
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
-		})
 		return err
 	}
 
@@ -337,10 +435,6 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			re := regexp.MustCompile(lib.IPCIDRRegex)
 			if !re.MatchString(vipNetwork.Cidr) {
 				err := fmt.Errorf("invalid CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-					Status: lib.StatusRejected,
-					Error:  err.Error(),
-				})
 				return err
 			}
 		}
@@ -348,10 +442,6 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			re := regexp.MustCompile(lib.IPV6CIDRRegex)
 			if !re.MatchString(vipNetwork.V6Cidr) {
 				err := fmt.Errorf("invalid IPv6 CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.V6Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-					Status: lib.StatusRejected,
-					Error:  err.Error(),
-				})
 				return err
 			}
 		}
@@ -384,58 +474,14 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		}
 		if !sslEnabled {
 			err := fmt.Errorf("One of the port in aviInfraSetting must have SSL enabled")
-			status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-				Status: lib.StatusRejected,
-				Error:  err.Error(),
-			})
 			return err
 		}
 	}
-	if err := checkRefsOnController(key, refData, lib.GetTenant()); err != nil {
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
-		})
-		return err
-	}
-
-	// This would add SEG labels only if they are not configured yet. In case there is a label mismatch
-	// to any pre-existing SEG labels, the AviInfraSettig CR will get Rejected from the checkRefsOnController
-	// step before this.
-	segMgmtNetworK := ""
-	if infraSetting.Spec.SeGroup.Name != "" {
-		addSeGroupLabel(key, infraSetting.Spec.SeGroup.Name)
-		// Not required for NO access cloud
-		if lib.GetCloudType() == lib.CLOUD_VCENTER {
-			segMgmtNetworK = GetSEGManagementNetwork(infraSetting.Spec.SeGroup.Name)
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, lib.GetTenant()); err != nil {
+			return err
 		}
 	}
-
-	if len(infraSetting.Spec.Network.VipNetworks) > 0 {
-		SetAviInfrasettingVIPNetworks(infraSetting.Name, segMgmtNetworK, infraSetting.Spec.SeGroup.Name, infraSetting.Spec.Network.VipNetworks)
-	}
-
-	if len(infraSetting.Spec.Network.NodeNetworks) > 0 {
-		SetAviInfrasettingNodeNetworks(infraSetting.Name, segMgmtNetworK, infraSetting.Spec.SeGroup.Name, infraSetting.Spec.Network.NodeNetworks)
-	}
-
-	namespaces, err := utils.GetInformers().NSInformer.Informer().GetIndexer().ByIndex(lib.AviSettingNamespaceIndex, infraSetting.GetName())
-	if err == nil && len(namespaces) > 0 {
-		objects.InfraSettingL7Lister().UpdateInfraSettingToNamespaceMapping(infraSetting.GetName(), namespaces)
-	} else {
-		// This handles the case where an NS scoped infrasetting was deleted and later recreated without NS scope.
-		objects.InfraSettingL7Lister().DeleteInfraSettingToNamespaceMapping(infraSetting.GetName())
-	}
-
-	// No need to update status of infra setting object as accepted since it was accepted before.
-	if infraSetting.Status.Status == lib.StatusAccepted {
-		return nil
-	}
-
-	status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
-		Status: lib.StatusAccepted,
-		Error:  "",
-	})
 	return nil
 }
 
@@ -487,12 +533,31 @@ func (l *leader) ValidateServiceImportObj(key string, serviceImport *akov1alpha1
 // ValidateSSORuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) error {
+	if err := validateSSORule(key, ssoRule, leaderValidationOptions); err != nil {
+		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateSSORule(key, ssoRule, crdValidationOptions{}) == nil,
+		})
+		return err
+	}
+
+	// No need to update status of ssoRule object as accepted since it was accepted before.
+	if ssoRule.Status.Status == lib.StatusAccepted && ssoRule.Status.ObservedGeneration == ssoRule.Generation {
+		return nil
+	}
+
+	status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	return nil
+}
+
+// validateSSORule does the validation checks of an SSORule, without updating its status.
+func validateSSORule(key string, ssoRule *akov1alpha2.SSORule, options crdValidationOptions) error {
 	var err error
 	fqdn := *ssoRule.Spec.Fqdn
 	foundHost, foundSR := objects.SharedCRDLister().GetFQDNToSSORuleMapping(fqdn)
 	if foundHost && foundSR != ssoRule.Namespace+"/"+ssoRule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundSR)
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -500,7 +565,6 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 
 	if ssoRule.Spec.SsoPolicyRef == nil {
 		err = fmt.Errorf("SsoPolicyRef is not specified")
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 	refData[*ssoRule.Spec.SsoPolicyRef] = "SSOPolicy"
@@ -512,23 +576,20 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 			for _, profile := range oauthConfigObj.OauthSettings {
 				refData[*profile.AuthProfileRef] = "AuthProfile"
 
-				if profile.AppSettings != nil {
+				if options.checkK8sRefs && profile.AppSettings != nil {
 					clientSecret := *profile.AppSettings.ClientSecret
 					clientSecretObj, err := validateSecretReferenceInSSORule(ssoRule.Namespace, clientSecret)
 					if err != nil {
 						err = fmt.Errorf("Got error while fetching %s secret : %s", clientSecret, err.Error())
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if clientSecretObj == nil {
 						err = fmt.Errorf("specified client secret is empty : %s", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					clientSecretString := string(clientSecretObj.Data["clientSecret"])
 					if clientSecretString == "" {
 						err = fmt.Errorf("clientSecret field not found in %s secret", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 				}
@@ -536,32 +597,27 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 				if profile.ResourceServer != nil {
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_JWT && profile.ResourceServer.JwtParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Jwt Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_OPAQUE && profile.ResourceServer.OpaqueTokenParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Opaque Token Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 
-					if profile.ResourceServer.OpaqueTokenParams != nil {
+					if options.checkK8sRefs && profile.ResourceServer.OpaqueTokenParams != nil {
 						serverSecret := *profile.ResourceServer.OpaqueTokenParams.ServerSecret
 						serverSecretObj, err := utils.GetInformers().ClientSet.CoreV1().Secrets(ssoRule.Namespace).Get(context.TODO(), serverSecret, metav1.GetOptions{})
 						if err != nil {
 							err = fmt.Errorf("Got error while fetching %s secret : %s", serverSecret, err.Error())
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						if serverSecretObj == nil {
 							err = fmt.Errorf("specified server secret is empty : %s", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						serverSecretString := string(serverSecretObj.Data["serverSecret"])
 						if serverSecretString == "" {
 							err = fmt.Errorf("serverSecret field not found in %s secret", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 					}
@@ -578,33 +634,46 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 	}
 	tenant := lib.GetTenantInNamespace(ssoRule.Namespace)
 
-	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateL4RuleObj would do validation checks and updates the status before
+// pushing to ingestion
+func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error {
+	if err := validateL4Rule(key, l4Rule, leaderValidationOptions); err != nil {
+		status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateL4Rule(key, l4Rule, crdValidationOptions{}) == nil,
+		})
 		return err
 	}
 
-	// No need to update status of ssoRule object as accepted since it was accepted before.
-	if ssoRule.Status.Status == lib.StatusAccepted {
+	// No need to update status of l4rule object as accepted since it was accepted before.
+	if l4Rule.Status.Status == lib.StatusAccepted && l4Rule.Status.ObservedGeneration == l4Rule.Generation {
 		return nil
 	}
 
-	status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+		Status: lib.StatusAccepted,
+		Error:  "",
+	})
+
 	return nil
 }
 
-// ValidateL4RuleObj would do validation checks and updates the status before
-// pushing to ingestion
-func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error {
-
+// validateL4Rule does the validation checks of an L4Rule, without updating its status.
+func validateL4Rule(key string, l4Rule *akov1alpha2.L4Rule, options crdValidationOptions) error {
 	l4RuleSpec := l4Rule.Spec
 
 	if l4RuleSpec.LoadBalancerIP != nil &&
 		net.ParseIP(*l4RuleSpec.LoadBalancerIP) == nil {
 		err := fmt.Errorf("loadBalancerIP %s is not valid", *l4RuleSpec.LoadBalancerIP)
-		status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
-		})
 		return err
 	}
 
@@ -615,7 +684,8 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 	}
 
 	var isNetworkProfileTypeTCP bool
-	if l4RuleSpec.ApplicationProfileRef != nil {
+	// The type of the application profile is known only from the controller.
+	if options.checkRefs && l4RuleSpec.ApplicationProfileRef != nil {
 		isSSLEnabled := false
 		for _, svc := range l4RuleSpec.Services {
 			if *svc.EnableSsl {
@@ -624,19 +694,11 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 		isL4SSL, err := checkForL4SSLAppProfile(key, *l4RuleSpec.ApplicationProfileRef)
 		if err != nil {
-			status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-				Status: lib.StatusRejected,
-				Error:  err.Error(),
-			})
 			return err
 		}
 		if isL4SSL {
 			if !isSSLEnabled {
 				sslErr := fmt.Errorf("SSL is not enabled in l4rule listener Spec but App Profile %s is of type SSL", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-					Status: lib.StatusRejected,
-					Error:  sslErr.Error(),
-				})
 				return sslErr
 			}
 			if l4RuleSpec.SslProfileRef != nil {
@@ -648,10 +710,6 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if l4RuleSpec.NetworkProfileRef != nil {
 				isNetworkProfileTypeTCP, err = checkForNetworkProfileTypeTCP(key, *l4RuleSpec.NetworkProfileRef)
 				if err != nil {
-					status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-						Status: lib.StatusRejected,
-						Error:  err.Error(),
-					})
 					return err
 				}
 			}
@@ -659,27 +717,15 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if *l4RuleSpec.ApplicationProfileRef != utils.DEFAULT_L4_APP_PROFILE {
 				if isSSLEnabled {
 					sslErr := fmt.Errorf("SSL is enabled in l4rule listener Spec but App Profile %s is not of type SSL", *l4RuleSpec.ApplicationProfileRef)
-					status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-						Status: lib.StatusRejected,
-						Error:  sslErr.Error(),
-					})
 					return sslErr
 				}
 			}
 			if l4RuleSpec.SslProfileRef != nil {
 				sslProfileErr := fmt.Errorf("App Profile %s is not of type SSL but SslProfileRef is set", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-					Status: lib.StatusRejected,
-					Error:  sslProfileErr.Error(),
-				})
 				return sslProfileErr
 			}
 			if len(l4RuleSpec.SslKeyAndCertificateRefs) != 0 {
 				sslKeyCertErr := fmt.Errorf("App Profile %s is not of type SSL but SslKeyAndCertificateRefs are set", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-					Status: lib.StatusRejected,
-					Error:  sslKeyCertErr.Error(),
-				})
 				return sslKeyCertErr
 			}
 		}
@@ -724,38 +770,39 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 
 		if err := validateLBAlgorithm(backendProperties); err != nil {
-			status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-				Status: lib.StatusRejected,
-				Error:  err.Error(),
-			})
 			return err
 		}
 	}
 	tenant := lib.GetTenantInNamespace(l4Rule.Namespace)
-	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateL7RuleObj would do validation checks and updates the status before
+// pushing to ingestion
+func (l *leader) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) error {
+	if err := validateL7Rule(key, l7Rule, leaderValidationOptions); err != nil {
+		status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{
+			Status:         lib.StatusRejected,
+			Error:          err.Error(),
+			UnresolvedRefs: validateL7Rule(key, l7Rule, crdValidationOptions{}) == nil,
 		})
 		return err
 	}
-
-	// No need to update status of l4rule object as accepted since it was accepted before.
-	if l4Rule.Status.Status == lib.StatusAccepted {
+	// No need to update status of l7rule object as accepted since it was accepted before.
+	if l7Rule.Status.Status == lib.StatusAccepted && l7Rule.Status.ObservedGeneration == l7Rule.Generation {
 		return nil
 	}
-
-	status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
-		Status: lib.StatusAccepted,
-		Error:  "",
-	})
-
+	status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
-// ValidateL7RuleObj would do validation checks and updates the status before
-// pushing to ingestion
-func (l *leader) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) error {
+// validateL7Rule does the validation checks of an L7Rule, without updating its status.
+func validateL7Rule(key string, l7Rule *akov1alpha2.L7Rule, options crdValidationOptions) error {
 	l7RuleSpec := l7Rule.Spec
 	refData := make(map[string]string)
 	if l7RuleSpec.BotPolicyRef != nil {
@@ -771,18 +818,11 @@ func (l *leader) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) error
 	}
 	tenant := lib.GetTenantInNamespace(l7Rule.Namespace)
 
-	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{
-			Status: lib.StatusRejected,
-			Error:  err.Error(),
-		})
-		return err
-	}
-	// No need to update status of l7rule object as accepted since it was accepted before.
-	if l7Rule.Status.Status == lib.StatusAccepted {
-		return nil
+	if options.checkRefs {
+		if err := checkRefsOnController(key, refData, tenant); err != nil {
+			return err
+		}
 	}
-	status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
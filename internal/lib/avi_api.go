@@ -147,6 +147,10 @@ func AviGetRaw(client *clients.AviClient, uri string, retryNum ...int) ([]byte,
 }
 
 func AviPut(client *clients.AviClient, uri string, payload interface{}, response interface{}, retryNum ...int) error {
+	if IsDryRunEnabled() {
+		utils.AviLog.Infof("msg: dry run, skipping Put on uri %s", uri)
+		return nil
+	}
 	retry := 0
 	if len(retryNum) > 0 {
 		retry = retryNum[0]
@@ -184,6 +188,10 @@ func AviPut(client *clients.AviClient, uri string, payload interface{}, response
 }
 
 func AviPost(client *clients.AviClient, uri string, payload interface{}, response interface{}, retryNum ...int) error {
+	if IsDryRunEnabled() {
+		utils.AviLog.Infof("msg: dry run, skipping Post on uri %s", uri)
+		return nil
+	}
 	retry := 0
 	if len(retryNum) > 0 {
 		retry = retryNum[0]
@@ -220,6 +228,10 @@ func AviPost(client *clients.AviClient, uri string, payload interface{}, respons
 }
 
 func AviDelete(client *clients.AviClient, uri string, retryNum ...int) error {
+	if IsDryRunEnabled() {
+		utils.AviLog.Infof("msg: dry run, skipping Delete on uri %s", uri)
+		return nil
+	}
 	retry := 0
 	if len(retryNum) > 0 {
 		retry = retryNum[0]
@@ -18,6 +18,7 @@ const (
 	DISABLE_STATIC_ROUTE_SYNC = "DISABLE_STATIC_ROUTE_SYNC"
 	ENABLE_RHI                = "ENABLE_RHI"
 	ENABLE_EVH                = "ENABLE_EVH"
+	ENABLE_NGINX_ANNOTATIONS  = "ENABLE_NGINX_ANNOTATIONS"
 	CNI_PLUGIN                = "CNI_PLUGIN"
 	CALICO_CNI                = "calico"
 	ANTREA_CNI                = "antrea"
@@ -43,6 +44,7 @@ const (
 	NODE_NETWORK_LIST                          = "NODE_NETWORK_LIST"
 	NODE_NETWORK_MAX_ENTRIES                   = 5
 	DEFAULT_DOMAIN                             = "DEFAULT_DOMAIN"
+	AUTO_L4_FQDN                               = "AUTO_L4_FQDN"
 	ADVANCED_L4                                = "ADVANCED_L4"
 	SERVICES_API                               = "SERVICES_API"
 	CLUSTER_NAME                               = "CLUSTER_NAME"
@@ -68,6 +70,9 @@ const (
 	EnableEvents                               = "enableEvents"
 	LAYER7_ONLY                                = "layer7Only"
 	NO_PG_FOR_SNI                              = "noPGForSNI"
+	DefaultDomainConfig                        = "defaultDomain"
+	AutoFQDNConfig                             = "autoFQDN"
+	BlockedNSListConfig                        = "blockedNamespaceList"
 	SERVICE_TYPE                               = "SERVICE_TYPE"
 	NODE_PORT                                  = "NodePort"
 	NODE_KEY                                   = "NODE_KEY"
@@ -96,6 +101,7 @@ const (
 	SSORule                                    = "SSORule"
 	L4Rule                                     = "L4Rule"
 	L7Rule                                     = "L7Rule"
+	RouteRuleExtension                         = "RouteRuleExtension"
 	IstioVirtualService                        = "IstioVirtualService"
 	IstioDestinationRule                       = "DestinationRule"
 	IstioGateway                               = "IstioGateway"
@@ -116,9 +122,12 @@ const (
 	Gateway                                    = "Gateway"
 	GatewayClass                               = "GatewayClass"
 	HTTPRoute                                  = "HTTPRoute"
+	GRPCRoute                                  = "GRPCRoute"
 	TCPRoute                                   = "TCPRoute"
 	TLSRoute                                   = "TLSRoute"
 	UDPRoute                                   = "UDPRoute"
+	ReferenceGrant                             = "ReferenceGrant"
+	BackendTLSPolicy                           = "BackendTLSPolicy"
 	DuplicateBackends                          = "MultipleBackendsWithSameServiceError"
 	DummyVSForStaleData                        = "DummyVSForStaleData"
 	ControllerReqWaitTime                      = 300
@@ -134,6 +143,7 @@ const (
 	DefaultRouteCert                           = "router-certs-default"
 	autoAnnotateService                        = "AUTO_ANNOTATE_SERVICE"
 	ClusterNameLabelKey                        = "clustername"
+	CreatedByLabelKey                          = "created_by"
 	UpdateStatus                               = "UpdateStatus"
 	DeleteStatus                               = "DeleteStatus"
 	NPLService                                 = "NPLService"
@@ -158,6 +168,22 @@ const (
 	L4AdvPool                                  = "L4 Advance Pool"
 	L4PS                                       = "L4 Policyset"
 	L4PSRule                                   = "L4 Policyset Rule"
+	NetworkSecurityPolicy                      = "NetworkSecurityPolicy"
+	HealthMonitor                              = "HealthMonitor"
+	HealthMonitorTypeHTTP                      = "HEALTH_MONITOR_HTTP"
+	LocalTrafficHealthCheckRequest             = "GET /healthz HTTP/1.0"
+	ApplicationPersistenceProfile              = "ApplicationPersistenceProfile"
+	PersistenceTypeClientIP                    = "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS"
+	MinClientIPPersistenceTimeout              = 1
+	MaxClientIPPersistenceTimeout              = 720
+	PersistenceTypeHTTPCookie                  = "PERSISTENCE_TYPE_HTTP_COOKIE"
+	SystemHTTPCookiePersistenceProfile         = "System-Persistence-Http-Cookie"
+	MaxHTTPCookiePersistenceTimeout            = 14400
+	ApplicationProfile                         = "ApplicationProfile"
+	SSLClientCertificateModeRequire            = "SSL_CLIENT_CERTIFICATE_REQUIRE"
+	SSLClientCertificateModeRequest            = "SSL_CLIENT_CERTIFICATE_REQUEST"
+	ClonedAppProfileDescription                = "Created by AKO from the application profile "
+	ClientMaxBodySizeDescription               = ", with the client max body size of the ingress-nginx annotations"
 	SNIVS                                      = "SNI VirtualService"
 	StringGroup                                = "StringGroup"
 	StringGroupNode                            = "StringGroupNode"
@@ -198,6 +224,8 @@ const (
 	IstioCertOutputPath                        = "/etc/istio-output-certs"
 	IstioSecret                                = "istio-secret"
 	IstioModel                                 = "istioModel"
+	ValidatingWebhookCertDir                   = "/etc/ako-webhook-certs"
+	ValidatingWebhookPath                      = "/validate"
 	CTRL_VERSION_21_1_3                        = "21.1.3"
 	FullSyncInterval                           = 300
 	Namespace                                  = "Namespace"
@@ -218,17 +246,38 @@ const (
 	AKOPause                 = "AKOPause"
 	DuplicateHostPath        = "DuplicateHostPath"
 	DuplicateHost            = "DuplicateHost"
+	DuplicateDefaultBackend  = "DuplicateDefaultBackend"
 	Removed                  = "Removed"
 	Synced                   = "Synced"
 	Attached                 = "Attached"
 	Detached                 = "Detached"
 	InvalidConfiguration     = "InvalidConfiguration"
+	InvalidSourceRange       = "InvalidSourceRange"
+	UnsupportedAnnotation    = "UnsupportedAnnotation"
 	AKODeleteConfigSet       = "AKODeleteConfigSet"
 	AKODeleteConfigUnset     = "AKODeleteConfigUnset"
 	AKODeleteConfigDone      = "AKODeleteConfigDone"
 	AKODeleteConfigTimeout   = "AKODeleteConfigTimeout"
+	AKOConfigUpdated         = "AKOConfigUpdated"
+	AKORestartRequired       = "AKORestartRequired"
+	CertificateExpiring      = "CertificateExpiring"
+	CertificateExpired       = "CertificateExpired"
+	CertificateHostMismatch  = "CertificateHostMismatch"
 	AKOGatewayEventComponent = "avi-kubernetes-operator-gateway-api"
 
+	// Condition types and reasons in the status of the AKO CRDs.
+	CRDConditionAccepted     = "Accepted"
+	CRDConditionResolvedRefs = "ResolvedRefs"
+	CRDConditionProgrammed   = "Programmed"
+	CRDReasonAccepted        = "Accepted"
+	CRDReasonInvalid         = "Invalid"
+	CRDReasonResolvedRefs    = "ResolvedRefs"
+	CRDReasonRefNotFound     = "RefNotFound"
+	CRDReasonPending         = "Pending"
+	CRDReasonProgrammed      = "Programmed"
+	CRDReasonNotAttached     = "NotAttached"
+	AttachedVirtualService   = "VirtualService"
+
 	DefaultIngressClassAnnotation    = "ingressclass.kubernetes.io/is-default-class"
 	ExternalDNSAnnotation            = "external-dns.alpha.kubernetes.io/hostname"
 	GatewayFinalizer                 = "gateway.ako.vmware.com"
@@ -264,6 +313,36 @@ const (
 	AntreaTransportAddressAnnotation = "node.antrea.io/transport-addresses"
 	TenantAnnotation                 = "ako.vmware.com/tenant-name"
 
+	// Annotations of a canary Ingress, whose backends receive a share of the traffic of the Ingress with the same host and path
+	CanaryAnnotation            = "canary.ako.vmware.com/enabled"
+	CanaryWeightAnnotation      = "canary.ako.vmware.com/weight"
+	CanaryHeaderAnnotation      = "canary.ako.vmware.com/header"
+	CanaryHeaderValueAnnotation = "canary.ako.vmware.com/header-value"
+	CanaryCookieAnnotation      = "canary.ako.vmware.com/cookie"
+
+	// ingress-nginx annotations, which are translated when enableNginxAnnotations is set
+	NginxAnnotationPrefix     = "nginx.ingress.kubernetes.io/"
+	NginxRewriteTarget        = "nginx.ingress.kubernetes.io/rewrite-target"
+	NginxSSLRedirect          = "nginx.ingress.kubernetes.io/ssl-redirect"
+	NginxForceSSLRedirect     = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+	NginxWhitelistSourceRange = "nginx.ingress.kubernetes.io/whitelist-source-range"
+	NginxAllowlistSourceRange = "nginx.ingress.kubernetes.io/allowlist-source-range"
+	NginxAffinity             = "nginx.ingress.kubernetes.io/affinity"
+	NginxSessionCookieName    = "nginx.ingress.kubernetes.io/session-cookie-name"
+	NginxSessionCookieMaxAge  = "nginx.ingress.kubernetes.io/session-cookie-max-age"
+	NginxSessionCookieExpires = "nginx.ingress.kubernetes.io/session-cookie-expires"
+	NginxBackendProtocol      = "nginx.ingress.kubernetes.io/backend-protocol"
+	NginxUpstreamVhost        = "nginx.ingress.kubernetes.io/upstream-vhost"
+	NginxXForwardedPrefix     = "nginx.ingress.kubernetes.io/x-forwarded-prefix"
+	NginxProxyBodySize        = "nginx.ingress.kubernetes.io/proxy-body-size"
+	NginxCustomHeaders        = "nginx.ingress.kubernetes.io/custom-headers"
+	NginxConfigurationSnippet = "nginx.ingress.kubernetes.io/configuration-snippet"
+	NginxCanary               = "nginx.ingress.kubernetes.io/canary"
+	NginxCanaryWeight         = "nginx.ingress.kubernetes.io/canary-weight"
+	NginxCanaryByHeader       = "nginx.ingress.kubernetes.io/canary-by-header"
+	NginxCanaryByHeaderValue  = "nginx.ingress.kubernetes.io/canary-by-header-value"
+	NginxCanaryByCookie       = "nginx.ingress.kubernetes.io/canary-by-cookie"
+
 	// Specifies command used in namespace event handler
 	NsFilterAdd                    = "ADD"
 	NsFilterDelete                 = "DELETE"
@@ -58,12 +58,13 @@ type ServicesAPIInformers struct {
 }
 
 type AKOCrdInformers struct {
-	HostRuleInformer        v1beta1akoinformer.HostRuleInformer
-	HTTPRuleInformer        v1beta1akoinformer.HTTPRuleInformer
-	AviInfraSettingInformer v1beta1akoinformer.AviInfraSettingInformer
-	SSORuleInformer         v1alpha2akoinformer.SSORuleInformer
-	L4RuleInformer          v1alpha2akoinformer.L4RuleInformer
-	L7RuleInformer          v1alpha2akoinformer.L7RuleInformer
+	HostRuleInformer           v1beta1akoinformer.HostRuleInformer
+	HTTPRuleInformer           v1beta1akoinformer.HTTPRuleInformer
+	AviInfraSettingInformer    v1beta1akoinformer.AviInfraSettingInformer
+	SSORuleInformer            v1alpha2akoinformer.SSORuleInformer
+	L4RuleInformer             v1alpha2akoinformer.L4RuleInformer
+	L7RuleInformer             v1alpha2akoinformer.L7RuleInformer
+	RouteRuleExtensionInformer v1alpha2akoinformer.RouteRuleExtensionInformer
 }
 
 type IstioCRDInformers struct {
@@ -75,6 +76,8 @@ type IstioCRDInformers struct {
 type BlockedNamespaces struct {
 	BlockedNSMap map[string]struct{}
 	nsChecksum   uint32
+	// The list is updated at runtime when the blocked namespace list is edited in the configmap.
+	lock sync.RWMutex
 }
 
 // akoControlConfig struct is intended to store all AKO related global
@@ -220,6 +223,8 @@ func (c *akoControlConfig) SetAKOBlockedNSList(nsList []string) {
 	sort.Strings(nsList)
 	val := strings.Join(nsList, ":")
 	cksum := utils.Hash(val)
+	c.blockedNS.lock.Lock()
+	defer c.blockedNS.lock.Unlock()
 	if c.blockedNS.nsChecksum != cksum {
 		nsMap := make(map[string]struct{})
 		for _, ns := range nsList {
@@ -230,6 +235,8 @@ func (c *akoControlConfig) SetAKOBlockedNSList(nsList []string) {
 	}
 }
 func (c *akoControlConfig) GetAKOBlockedNSList() map[string]struct{} {
+	c.blockedNS.lock.RLock()
+	defer c.blockedNS.lock.RUnlock()
 	return c.blockedNS.BlockedNSMap
 }
 func (c *akoControlConfig) SetAdvL4Clientset(cs advl4crd.Interface) {
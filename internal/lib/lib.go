@@ -190,6 +190,8 @@ func RegisterPromMetrics() *prometheus.Registry {
 		},
 	)
 	reg.MustRegister(ObjectsInQueue)
+
+	registerPipelineMetrics(subSystem)
 	return reg
 }
 
@@ -411,7 +413,7 @@ func GetL4FqdnFormat() string {
 		return AutoFQDNDisabled
 	}
 
-	fqdnFormat := os.Getenv("AUTO_L4_FQDN")
+	fqdnFormat := os.Getenv(AUTO_L4_FQDN)
 	val, ok := fqdnMap[fqdnFormat]
 	if ok {
 		return val
@@ -440,6 +442,13 @@ func GetL4PoolName(svcName, namespace, protocol string, port int32) string {
 	return Encode(poolName, L4Pool)
 }
 
+// GetL4HealthMonitorName returns the name of the health monitor of an L4 pool, which probes
+// the healthCheckNodePort of a Service with externalTrafficPolicy Local.
+func GetL4HealthMonitorName(svcName, namespace, protocol string, port int32) string {
+	hmName := NamePrefix + namespace + "-" + svcName + "-" + protocol + "-" + strconv.Itoa(int(port)) + "-healthcheck"
+	return Encode(hmName, HealthMonitor)
+}
+
 func GetAdvL4PoolName(svcName, namespace, gwName, protocol string, port int32) string {
 	poolName := NamePrefix + namespace + "-" + svcName + "-" + gwName + "-" + protocol + "--" + strconv.Itoa(int(port))
 	return Encode(poolName, L4AdvPool)
@@ -469,6 +478,18 @@ func GetL7SharedPGName(vsName string) string {
 	return l7PGName
 }
 
+// GetL7DefaultBackendPoolName returns the name of the pool for the Ingress default backend attached to the VS.
+func GetL7DefaultBackendPoolName(vsName string) string {
+	poolName := vsName + "--default-backend"
+	return Encode(poolName, Pool)
+}
+
+// GetL7DefaultBackendPGName returns the name of the poolgroup for the Ingress default backend attached to the VS.
+func GetL7DefaultBackendPGName(vsName string) string {
+	pgName := vsName + "--default-backend"
+	return Encode(pgName, PG)
+}
+
 func GetPassthroughPGName(hostname, infrasettingName string) string {
 	var pgName string
 	if infrasettingName != "" {
@@ -674,6 +695,36 @@ func GetPoolPKIProfileName(poolName string) string {
 	return Encode(poolName+"-pkiprofile", PKIProfile)
 }
 
+// GetClientPKIProfileName returns the name of the PKI profile, with which the client certificates
+// of a virtual service are verified, when the CA certificate is provided via a Secret in the HostRule.
+func GetClientPKIProfileName(vsName string) string {
+	return Encode(vsName+"-client-pkiprofile", PKIProfile)
+}
+
+// GetClientAppProfileName returns the name of the application profile, which enables the client
+// certificate authentication of a virtual service.
+func GetClientAppProfileName(vsName string) string {
+	return Encode(vsName+"-client-appprofile", ApplicationProfile)
+}
+
+// GetPoolPersistenceProfileName returns the name of the client IP persistence profile of a pool,
+// derived from the sessionAffinity of its Service.
+func GetPoolPersistenceProfileName(poolName string) string {
+	return Encode(poolName+"-persistence", ApplicationPersistenceProfile)
+}
+
+// GetNginxHTTPPolicySetName returns the name of the HTTP policy set of a pool, which is derived
+// from the ingress-nginx annotations of its Ingress.
+func GetNginxHTTPPolicySetName(poolName string) string {
+	return Encode(poolName+"-nginx", HTTPPS)
+}
+
+// GetCanaryHTTPPolicySetName returns the name of the HTTP policy set, which switches the requests
+// matching the canary header or cookie to the pool of a canary backend.
+func GetCanaryHTTPPolicySetName(poolName string) string {
+	return Encode(poolName+"-canary", HTTPPS)
+}
+
 var VRFContext string
 var VRFUuid string
 
@@ -781,6 +832,43 @@ func IsPrometheusEnabled() bool {
 	return false
 }
 
+// IsValidatingWebhookEnabled returns true if AKO serves the validation of its CRDs as a ValidatingAdmissionWebhook.
+func IsValidatingWebhookEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("VALIDATING_WEBHOOK_ENABLED"))
+	return ok
+}
+
+func GetValidatingWebhookPort() string {
+	port := os.Getenv("VALIDATING_WEBHOOK_PORT")
+	if port != "" {
+		return port
+	}
+	// Default case, if not specified.
+	return "9443"
+}
+
+// IsValidatingWebhookFailOpen returns true if the CRDs are admitted without validating the references to the
+// Avi objects, when the Avi controller is unreachable. It is true by default.
+func IsValidatingWebhookFailOpen() bool {
+	failOpen, err := strconv.ParseBool(os.Getenv("VALIDATING_WEBHOOK_FAIL_OPEN"))
+	if err != nil {
+		return true
+	}
+	return failOpen
+}
+
+// IsIntrospectionEnabled returns true if AKO's API server exposes the models, caches and object provenance APIs.
+func IsIntrospectionEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("INTROSPECTION_ENABLED"))
+	return ok
+}
+
+// IsDryRunEnabled returns true if AKO computes the changes to the Avi objects without applying them to the controller.
+func IsDryRunEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("DRY_RUN"))
+	return ok
+}
+
 var VipNetworkList []akov1beta1.AviInfraSettingVipNetwork
 var VipInfraNetworkList map[string][]akov1beta1.AviInfraSettingVipNetwork
 
@@ -1061,6 +1149,13 @@ func IsEvhEnabled() bool {
 	return utils.IsVCFCluster()
 }
 
+// IsNginxAnnotationsEnabled returns true if the ingress-nginx annotations of the Ingresses
+// are translated to the Avi objects built for them.
+func IsNginxAnnotationsEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(ENABLE_NGINX_ANNOTATIONS))
+	return ok
+}
+
 // If this flag is set to true, then AKO uses services API. Currently the support is limited for layer 4 Virtualservices
 func UseServicesAPI() bool {
 	if ok, _ := strconv.ParseBool(os.Getenv(SERVICES_API)); ok {
@@ -1457,6 +1552,11 @@ func InformersToRegister(kclient *kubernetes.Clientset, oclient *oshiftclient.Cl
 		if !isOshift {
 			allInformers = append(allInformers, utils.IngressInformer)
 			allInformers = append(allInformers, utils.IngressClassInformer)
+			// The ConfigMaps of the custom headers of the Ingresses are watched over only when
+			// the ingress-nginx annotations are translated.
+			if IsNginxAnnotationsEnabled() {
+				allInformers = append(allInformers, utils.NginxConfigMapInformer)
+			}
 		}
 
 		// Add MultiClusterIngress and ServiceImport informers if enabled.
@@ -1518,6 +1618,147 @@ func L4PolicyChecksum(ports []int64, protocols []string, ingestionMarkers utils.
 	return checksum
 }
 
+func NetworkSecurityPolicyChecksum(sourceRanges []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	sort.Strings(sourceRanges)
+	checksum := utils.Hash(utils.Stringify(sourceRanges))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func HealthMonitorChecksum(hmType string, monitorPort int32, httpRequest string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(hmType + "-" + strconv.Itoa(int(monitorPort)) + "-" + httpRequest)
+	if populateCache {
+		checksum += createdByObjectLabelChecksum(markers)
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func PersistenceProfileChecksum(persistenceType string, timeout int32, cookieName string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksumStr := persistenceType + "-" + strconv.Itoa(int(timeout))
+	if cookieName != "" {
+		checksumStr += "-" + cookieName
+	}
+	checksum := utils.Hash(checksumStr)
+	if populateCache {
+		checksum += createdByObjectLabelChecksum(markers)
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func ApplicationProfileChecksum(baseProfile, pkiProfileName, clientCertificateMode string, forwardClientIdentity bool, clientMaxBodySize *int64, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksumStr := baseProfile + "-" + pkiProfileName + "-" + clientCertificateMode + "-" + strconv.FormatBool(forwardClientIdentity)
+	if clientMaxBodySize != nil {
+		checksumStr += "-" + strconv.FormatInt(*clientMaxBodySize, 10)
+	}
+	checksum := utils.Hash(checksumStr)
+	if populateCache {
+		checksum += createdByObjectLabelChecksum(markers)
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// GetApplicationProfileSettings returns the base profile the application profile is cloned from, the PKI profile
+// name, the client certificate mode, whether the client identity is forwarded to the backend and the client max
+// body size, which are the settings of an application profile managed by AKO. The base profile is recorded in the
+// description, along with whether the client max body size is overridden.
+// The PKI profile reference is either of the form /api/pkiprofile?name=<name> or <url>#<name>.
+func GetApplicationProfileSettings(appProfile models.ApplicationProfile) (string, string, string, bool, *int64) {
+	var baseProfile, pkiProfileName, clientCertificateMode string
+	var forwardClientIdentity, clientMaxBodySizeOverridden bool
+	var clientMaxBodySize *int64
+	if appProfile.Description != nil {
+		if name, found := strings.CutPrefix(*appProfile.Description, ClonedAppProfileDescription); found {
+			baseProfile, clientMaxBodySizeOverridden = strings.CutSuffix(name, ClientMaxBodySizeDescription)
+		}
+	}
+	if httpProfile := appProfile.HTTPProfile; httpProfile != nil {
+		if httpProfile.PkiProfileRef != nil {
+			if _, name, found := strings.Cut(*httpProfile.PkiProfileRef, "#"); found {
+				pkiProfileName = name
+			} else if _, name, found := strings.Cut(*httpProfile.PkiProfileRef, "name="); found {
+				pkiProfileName = name
+			}
+		}
+		if httpProfile.SslClientCertificateMode != nil {
+			clientCertificateMode = *httpProfile.SslClientCertificateMode
+		}
+		if httpProfile.SslClientCertificateAction != nil && len(httpProfile.SslClientCertificateAction.Headers) != 0 {
+			forwardClientIdentity = true
+		}
+		if clientMaxBodySizeOverridden && httpProfile.ClientMaxBodySize != nil {
+			clientMaxBodySize = httpProfile.ClientMaxBodySize
+		}
+	}
+	return baseProfile, pkiProfileName, clientCertificateMode, forwardClientIdentity, clientMaxBodySize
+}
+
+// GetPersistenceProfileSettings returns the persistence type, the timeout and the cookie name of a persistence
+// profile, which are the settings managed by AKO.
+func GetPersistenceProfileSettings(persistenceProfile models.ApplicationPersistenceProfile) (string, int32, string) {
+	var persistenceType, cookieName string
+	var timeout int32
+	if persistenceProfile.PersistenceType != nil {
+		persistenceType = *persistenceProfile.PersistenceType
+	}
+	if persistenceProfile.IPPersistenceProfile != nil && persistenceProfile.IPPersistenceProfile.IPPersistentTimeout != nil {
+		timeout = *persistenceProfile.IPPersistenceProfile.IPPersistentTimeout
+	}
+	if cookieProfile := persistenceProfile.HTTPCookiePersistenceProfile; cookieProfile != nil {
+		if cookieProfile.Timeout != nil {
+			timeout = *cookieProfile.Timeout
+		}
+		if cookieProfile.CookieName != nil {
+			cookieName = *cookieProfile.CookieName
+		}
+	}
+	return persistenceType, timeout, cookieName
+}
+
+// createdByObjectLabelChecksum returns the checksum of the markers of an object which is identified
+// by the created_by label, as that label is not part of the ingestion markers.
+func createdByObjectLabelChecksum(markers []*models.RoleFilterMatchLabel) uint32 {
+	var objectLabels []*models.RoleFilterMatchLabel
+	for _, label := range markers {
+		if label != nil && label.Key != nil && *label.Key != CreatedByLabelKey {
+			objectLabels = append(objectLabels, label)
+		}
+	}
+	if objectLabels == nil {
+		return 0
+	}
+	return ObjectLabelChecksum(objectLabels)
+}
+
+// GetNetworkSecurityPolicySourceRanges returns the client IP prefixes matched by the
+// rules of a network security policy, in CIDR notation.
+func GetNetworkSecurityPolicySourceRanges(rules []*models.NetworkSecurityRule) []string {
+	var sourceRanges []string
+	for _, rule := range rules {
+		if rule == nil || rule.Match == nil || rule.Match.ClientIP == nil {
+			continue
+		}
+		for _, prefix := range rule.Match.ClientIP.Prefixes {
+			if prefix == nil || prefix.IPAddr == nil || prefix.IPAddr.Addr == nil || prefix.Mask == nil {
+				continue
+			}
+			sourceRanges = append(sourceRanges, fmt.Sprintf("%s/%d", *prefix.IPAddr.Addr, *prefix.Mask))
+		}
+	}
+	return sourceRanges
+}
+
 func IsNodePortMode() bool {
 	nodePortType := os.Getenv(SERVICE_TYPE)
 	if nodePortType == NODE_PORT {
@@ -1658,6 +1899,12 @@ func IsNamespaceBlocked(namespace string) bool {
 	return ok
 }
 
+// IsNamespaceSynced returns true if the objects of the namespace are synced by AKO, that is
+// the namespace is accepted by the namespace sync labels and is not in the blocked namespace list.
+func IsNamespaceSynced(namespace string) bool {
+	return utils.CheckIfNamespaceAccepted(namespace) && !IsNamespaceBlocked(namespace)
+}
+
 func GetPassthroughShardVSName(s, aviInfraSettingName, key string, shardSize uint32) string {
 	var vsNum uint32
 	shardVsPrefix := GetClusterName() + "--" + GetAKOIDPrefix() + PassthroughPrefix
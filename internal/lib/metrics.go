@@ -0,0 +1,337 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vmware/alb-sdk/go/session"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// Stages of the AKO pipeline, for which the processing latency is reported.
+const (
+	// IngestionStage is the time a kubernetes object event waits in the ingestion queue.
+	IngestionStage = "ingestion"
+	// GraphStage is the time taken to build the models for a kubernetes object.
+	GraphStage = "graph"
+	// RestStage is the time taken to sync a model with the Avi controller.
+	RestStage = "rest"
+	// StatusStage is the time taken to update the status of the kubernetes objects.
+	StatusStage = "status"
+)
+
+const (
+	FullSyncKubernetes = "kubernetes"
+	FullSyncController = "controller"
+)
+
+var StageDuration *prometheus.HistogramVec
+var AviApiResponses *prometheus.CounterVec
+var RetriesPerVS *prometheus.CounterVec
+var FullSyncDuration *prometheus.GaugeVec
+var FullSyncLastSuccess *prometheus.GaugeVec
+var FullSyncModels *prometheus.GaugeVec
+var promSubsystem string
+
+// GetPromSubsystem returns the subsystem of the AKO metrics, derived from the AKO pod.
+func GetPromSubsystem() string {
+	return promSubsystem
+}
+
+func registerPipelineMetrics(subSystem string) {
+	promSubsystem = subSystem
+	StageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "stage_duration_seconds",
+			Help:      "Latency of a key in each stage of the AKO pipeline.",
+			Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{
+			// ingestion, graph, rest or status
+			"stage",
+		},
+	)
+	reg.MustRegister(StageDuration)
+
+	AviApiResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "avi_api_responses_total",
+			Help:      "Number of responses of the Avi controller to the rest operations from AKO, per object type and response code.",
+		},
+		[]string{
+			// Avi object type, e.g. VirtualService
+			"object_type",
+			// Rest method of the operation
+			"method",
+			// 2xx for the successful operations, the http status code of the failed ones, and error when there is no response.
+			"code",
+		},
+	)
+	reg.MustRegister(AviApiResponses)
+
+	RetriesPerVS = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "vs_retries_total",
+			Help:      "Number of times a virtualservice is published to the retry queues.",
+		},
+		[]string{
+			// FastRetryLayer or SlowRetryLayer
+			"queuename",
+			// tenant/virtualservice name
+			"vs",
+		},
+	)
+	reg.MustRegister(RetriesPerVS)
+
+	FullSyncDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "full_sync_duration_seconds",
+			Help:      "Duration of the last successful full sync.",
+		},
+		[]string{
+			// kubernetes, for the sync of all the kubernetes objects, or controller, for the refresh of the Avi object caches.
+			"type",
+		},
+	)
+	reg.MustRegister(FullSyncDuration)
+
+	FullSyncLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "full_sync_last_success_timestamp_seconds",
+			Help:      "Unix time of the completion of the last successful full sync.",
+		},
+		[]string{
+			"type",
+		},
+	)
+	reg.MustRegister(FullSyncLastSuccess)
+
+	FullSyncModels = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "full_sync_models",
+			Help:      "Number of models published to the rest layer, and skipped as unchanged, by the last full sync of the kubernetes objects.",
+		},
+		[]string{
+			// rebuilt or skipped
+			"result",
+		},
+	)
+	reg.MustRegister(FullSyncModels)
+
+	reg.MustRegister(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "is_leader",
+			Help:      "1 if the AKO instance is the leader, which applies the changes to the Avi controller, 0 if it is a follower.",
+		},
+		func() float64 {
+			if AKOControlConfig().IsLeader() {
+				return 1
+			}
+			return 0
+		},
+	))
+
+	// The queues are created after the metrics are registered, hence the workqueue metrics are reported for all the queues.
+	workqueueProvider := newWorkqueueMetricsProvider(subSystem)
+	workqueue.SetProvider(workqueueProvider)
+	utils.SetQueueLaneMetricsProvider(workqueueProvider)
+}
+
+// ObserveStageDuration records the latency of a key in a stage of the pipeline, which started at the given time.
+func ObserveStageDuration(stage string, start time.Time) {
+	if AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		StageDuration.With(prometheus.Labels{"stage": stage}).Observe(time.Since(start).Seconds())
+	}
+}
+
+// IncrementAviApiResponseCounter records the response of the Avi controller to a rest operation.
+func IncrementAviApiResponseCounter(objectType, method string, err error) {
+	if !AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		return
+	}
+	code := "2xx"
+	if err != nil {
+		code = "error"
+		if aviErr, ok := err.(session.AviError); ok && aviErr.HttpStatusCode != 0 {
+			code = strconv.Itoa(aviErr.HttpStatusCode)
+		}
+	}
+	AviApiResponses.With(prometheus.Labels{"object_type": objectType, "method": method, "code": code}).Inc()
+}
+
+func IncrementRetryCounter(queueName, vsKey string) {
+	if AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		RetriesPerVS.With(prometheus.Labels{"queuename": queueName, "vs": vsKey}).Inc()
+	}
+}
+
+// ObserveFullSync records a successful full sync, which started at the given time.
+func ObserveFullSync(syncType string, start time.Time) {
+	if AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		FullSyncDuration.With(prometheus.Labels{"type": syncType}).Set(time.Since(start).Seconds())
+		FullSyncLastSuccess.With(prometheus.Labels{"type": syncType}).SetToCurrentTime()
+	}
+}
+
+// ObserveFullSyncModels records the number of models, which are published and skipped by a full sync of the kubernetes objects.
+func ObserveFullSyncModels(rebuilt, skipped int) {
+	if AKOControlConfig().GetAKOAKOPrometheusFlag() {
+		FullSyncModels.With(prometheus.Labels{"result": "rebuilt"}).Set(float64(rebuilt))
+		FullSyncModels.With(prometheus.Labels{"result": "skipped"}).Set(float64(skipped))
+	}
+}
+
+// workqueueMetricsProvider reports the depth, adds, latency and retries of the AKO worker queues, and the depth and
+// latency of each of their priority lanes.
+// The metrics of the worker queues of a layer are aggregated under the name of the layer.
+type workqueueMetricsProvider struct {
+	depth        *prometheus.GaugeVec
+	adds         *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	workDuration *prometheus.HistogramVec
+	retries      *prometheus.CounterVec
+	laneDepth    *prometheus.GaugeVec
+	laneLatency  *prometheus.HistogramVec
+}
+
+func newWorkqueueMetricsProvider(subSystem string) *workqueueMetricsProvider {
+	p := &workqueueMetricsProvider{
+		depth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_depth",
+			Help:      "Number of keys waiting in the queues of a layer.",
+		}, []string{"queuename"}),
+		adds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_adds_total",
+			Help:      "Number of keys added to the queues of a layer.",
+		}, []string{"queuename"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_queue_duration_seconds",
+			Help:      "Time a key waits in the queues of a layer before it is processed.",
+			Buckets:   prometheus.ExponentialBuckets(10e-6, 10, 8),
+		}, []string{"queuename"}),
+		workDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_work_duration_seconds",
+			Help:      "Time taken to process a key from the queues of a layer.",
+			Buckets:   prometheus.ExponentialBuckets(10e-6, 10, 8),
+		}, []string{"queuename"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_retries_total",
+			Help:      "Number of rate limited additions of keys to the queues of a layer.",
+		}, []string{"queuename"}),
+		laneDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_lane_depth",
+			Help:      "Number of keys waiting in a priority lane of the queues of a layer.",
+		}, []string{"queuename", "lane"}),
+		laneLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "ako",
+			Subsystem: subSystem,
+			Name:      "workqueue_lane_queue_duration_seconds",
+			Help:      "Time a key waits in a priority lane of the queues of a layer before it is processed.",
+			Buckets:   prometheus.ExponentialBuckets(10e-6, 10, 8),
+		}, []string{"queuename", "lane"}),
+	}
+	reg.MustRegister(p.depth, p.adds, p.latency, p.workDuration, p.retries, p.laneDepth, p.laneLatency)
+	return p
+}
+
+// getQueueName strips the prefix, which is added to the name of the worker queues.
+func getQueueName(name string) string {
+	return strings.TrimPrefix(name, "avi-")
+}
+
+func (p *workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.depth.WithLabelValues(getQueueName(name))
+}
+
+func (p *workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.adds.WithLabelValues(getQueueName(name))
+}
+
+func (p *workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	queueName := getQueueName(name)
+	if queueName == utils.ObjectIngestionLayer {
+		// The wait in the ingestion queue is the ingestion stage of the pipeline.
+		return ingestionLatencyMetric{
+			queueLatency: p.latency.WithLabelValues(queueName),
+		}
+	}
+	return p.latency.WithLabelValues(queueName)
+}
+
+func (p *workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.workDuration.WithLabelValues(getQueueName(name))
+}
+
+// The unfinished work and the longest running processor are set per worker queue, hence these can not be aggregated per layer.
+func (p *workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return prometheus.NewGauge(prometheus.GaugeOpts{Name: "unused"})
+}
+
+func (p *workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return prometheus.NewGauge(prometheus.GaugeOpts{Name: "unused"})
+}
+
+func (p *workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.retries.WithLabelValues(getQueueName(name))
+}
+
+func (p *workqueueMetricsProvider) NewLaneDepthMetric(name, lane string) workqueue.GaugeMetric {
+	return p.laneDepth.WithLabelValues(getQueueName(name), lane)
+}
+
+func (p *workqueueMetricsProvider) NewLaneLatencyMetric(name, lane string) workqueue.HistogramMetric {
+	return p.laneLatency.WithLabelValues(getQueueName(name), lane)
+}
+
+type ingestionLatencyMetric struct {
+	queueLatency prometheus.Observer
+}
+
+func (m ingestionLatencyMetric) Observe(seconds float64) {
+	m.queueLatency.Observe(seconds)
+	StageDuration.With(prometheus.Labels{"stage": IngestionStage}).Observe(seconds)
+}
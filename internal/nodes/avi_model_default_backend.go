@@ -0,0 +1,286 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// GetDefaultBackendVSNames returns the VSes which get the default backend of the ingress, keyed by model name.
+// These are the VSes of the ingress hosts, or the VS of the generated hostname for an ingress without hosts.
+func GetDefaultBackendVSNames(routeIgrObj RouteIngressModel, parsedIng IngressConfig, key string) map[string]lib.VSNameMetadata {
+	vsNames := make(map[string]lib.VSNameMetadata)
+	if parsedIng.DefaultBackend == nil {
+		return vsNames
+	}
+	hosts := sets.NewString()
+	for host := range parsedIng.IngressHostMap {
+		hosts.Insert(host)
+	}
+	for _, tlsSettings := range parsedIng.TlsCollection {
+		for host := range tlsSettings.Hosts {
+			hosts.Insert(host)
+		}
+	}
+	if hosts.Len() == 0 {
+		subDomains := GetDefaultSubDomain()
+		if subDomains == nil {
+			utils.AviLog.Warnf("key: %s, msg: No sub-domain configured in cloud, not processing the default backend", key)
+			return vsNames
+		}
+		hosts.Insert(getHostNameFromSubDomain(routeIgrObj.GetName(), routeIgrObj.GetNamespace(), subDomains[0]))
+	}
+	for _, host := range hosts.List() {
+		var shardVsName lib.VSNameMetadata
+		if lib.IsEvhEnabled() {
+			_, shardVsName = DeriveShardVSForEvh(host, key, routeIgrObj)
+		} else {
+			_, shardVsName = DeriveShardVS(host, key, routeIgrObj)
+		}
+		vsNames[lib.GetModelName(shardVsName.Tenant, shardVsName.Name)] = shardVsName
+	}
+	return vsNames
+}
+
+// AddModelsWithDefaultBackend adds the models out of modelList, which have an ingress with a default backend, to
+// defaultBackendModels. The child VSes created on these models while processing another ingress get the default backend.
+func AddModelsWithDefaultBackend(defaultBackendModels, modelList []string) []string {
+	for _, modelName := range modelList {
+		if len(SharedHostNameLister().GetModelToDefaultBackendIngresses(modelName)) > 0 && !utils.HasElem(defaultBackendModels, modelName) {
+			defaultBackendModels = append(defaultBackendModels, modelName)
+		}
+	}
+	return defaultBackendModels
+}
+
+// ProcessDefaultBackend rebuilds the default backend of the VS in each of the given models. The models of vsNames
+// are created if they do not exist yet.
+func ProcessDefaultBackend(routeIgrObj RouteIngressModel, key string, modelNames []string, vsNames map[string]lib.VSNameMetadata, modelList *[]string) {
+	for _, modelName := range modelNames {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			shardVsName, ok := vsNames[modelName]
+			if !ok {
+				utils.AviLog.Debugf("key: %s, msg: model not found for default backend: %s", key, modelName)
+				continue
+			}
+			utils.AviLog.Infof("key: %s, msg: model not found, generating new model with name: %s", key, modelName)
+			aviModel = NewAviObjectGraph()
+			if lib.IsEvhEnabled() {
+				aviModel.(*AviObjectGraph).ConstructAviL7SharedVsNodeForEvh(shardVsName.Name, shardVsName.Tenant, key, routeIgrObj, shardVsName.Dedicated, false)
+			} else {
+				aviModel.(*AviObjectGraph).ConstructAviL7VsNode(shardVsName.Name, shardVsName.Tenant, key, routeIgrObj, shardVsName.Dedicated, false)
+			}
+		}
+		modelGraph := aviModel.(*AviObjectGraph)
+		var vsNode AviVsEvhSniModel
+		if evhNodes := modelGraph.GetAviEvhVS(); len(evhNodes) > 0 {
+			vsNode = evhNodes[0]
+		} else if vsNodes := modelGraph.GetAviVS(); len(vsNodes) > 0 {
+			vsNode = vsNodes[0]
+		}
+		if vsNode == nil {
+			continue
+		}
+		modelGraph.BuildDefaultBackendForVS(vsNode, modelName, routeIgrObj, key)
+		changedModel := saveAviModel(modelName, modelGraph, key)
+		if !utils.HasElem(modelList, modelName) && changedModel {
+			*modelList = append(*modelList, modelName)
+		}
+	}
+}
+
+// BuildDefaultBackendForVS attaches the default backend of the oldest ingress on the model as the default poolgroup of the VS,
+// which handles the requests not matching any of the hosts and paths. The SNI and EVH child VSes get the default backend of the
+// oldest ingress with their host, which handles the requests to the host not matching any of the paths.
+func (o *AviObjectGraph) BuildDefaultBackendForVS(vsNode AviVsEvhSniModel, modelName string, routeIgrObj RouteIngressModel, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+
+	o.buildDefaultBackend(vsNode, modelName, routeIgrObj, nil, key)
+	var childNodes []AviVsEvhSniModel
+	switch node := vsNode.(type) {
+	case *AviVsNode:
+		for _, sniNode := range node.SniNodes {
+			childNodes = append(childNodes, sniNode)
+		}
+	case *AviEvhVsNode:
+		for _, evhNode := range node.EvhNodes {
+			childNodes = append(childNodes, evhNode)
+		}
+	}
+	for _, childNode := range childNodes {
+		if len(childNode.GetVHDomainNames()) > 0 {
+			o.buildDefaultBackend(childNode, modelName, routeIgrObj, childNode.GetVHDomainNames(), key)
+		}
+	}
+}
+
+// buildDefaultBackend rebuilds the default backend of the VS, from the ingresses on the model having any of the hosts.
+// All the ingresses on the model are considered if no hosts are given.
+func (o *AviObjectGraph) buildDefaultBackend(vsNode AviVsEvhSniModel, modelName string, routeIgrObj RouteIngressModel, hosts []string, key string) {
+	poolName := lib.GetL7DefaultBackendPoolName(vsNode.GetName())
+	pgName := lib.GetL7DefaultBackendPGName(vsNode.GetName())
+
+	// Remove the existing default backend, it is rebuilt from the current ingress.
+	var poolRefs []*AviPoolNode
+	for _, poolNode := range vsNode.GetPoolRefs() {
+		if poolNode.Name != poolName {
+			poolRefs = append(poolRefs, poolNode)
+		}
+	}
+	vsNode.SetPoolRefs(poolRefs)
+	var pgRefs []*AviPoolGroupNode
+	for _, pgNode := range vsNode.GetPoolGroupRefs() {
+		if pgNode.Name != pgName {
+			pgRefs = append(pgRefs, pgNode)
+		}
+	}
+	vsNode.SetPoolGroupRefs(pgRefs)
+	vsNode.SetDefaultPoolGroup("")
+
+	ingObj, defaultBackend, infraSetting := getDefaultBackendIngress(vsNode.GetName(), modelName, routeIgrObj, hosts, key)
+	if ingObj == nil {
+		return
+	}
+	if lib.CheckObjectNameLength(poolName, lib.Pool) || lib.CheckObjectNameLength(pgName, lib.PG) {
+		return
+	}
+
+	var infraSettingName string
+	if infraSetting != nil && !lib.IsInfraSettingNSScoped(infraSetting.Name, ingObj.Namespace) {
+		infraSettingName = infraSetting.Name
+	}
+	poolNode := buildPoolNode(key, poolName, ingObj.Name, ingObj.Namespace, "", "", infraSetting, defaultBackend.ServiceName, nil, false, *defaultBackend)
+	// The default backend is not tied to any host or path of the ingress, hence the ingress status is not derived from its pool.
+	poolNode.ServiceMetadata = lib.ServiceMetadataObj{}
+	poolNode.AviMarkers.Host, poolNode.AviMarkers.Path = nil, nil
+
+	pgNode := &AviPoolGroupNode{Name: pgName, Tenant: vsNode.GetTenant()}
+	pgNode.AviMarkers = utils.AviObjectMarkers{
+		Namespace:        ingObj.Namespace,
+		IngressName:      []string{ingObj.Name},
+		InfrasettingName: infraSettingName,
+	}
+	poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+	ratio := defaultBackend.weight
+	pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+
+	vsNode.SetPoolRefs(append(vsNode.GetPoolRefs(), poolNode))
+	vsNode.SetPoolGroupRefs(append(vsNode.GetPoolGroupRefs(), pgNode))
+	vsNode.SetDefaultPoolGroup(pgName)
+	utils.AviLog.Infof("key: %s, msg: attached default backend of ingress %s/%s to VS %s", key, ingObj.Namespace, ingObj.Name, vsNode.GetName())
+}
+
+// getDefaultBackendIngress returns the oldest ingress with a default backend on the model and any of the hosts, along with
+// its parsed default backend and AviInfraSetting. An event is raised on the processed ingress if its default backend is not used.
+func getDefaultBackendIngress(vsName, modelName string, routeIgrObj RouteIngressModel, hosts []string, key string) (*networkingv1.Ingress, *IngressHostPathSvc, *akov1beta1.AviInfraSetting) {
+	var ingresses []*networkingv1.Ingress
+	for _, ingKey := range SharedHostNameLister().GetModelToDefaultBackendIngresses(modelName) {
+		nsName := strings.Split(ingKey, "/")
+		if len(nsName) != 2 {
+			continue
+		}
+		ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(nsName[0]).Get(nsName[1])
+		if err != nil || ingObj.GetDeletionTimestamp() != nil || ingObj.Spec.DefaultBackend == nil {
+			continue
+		}
+		if len(hosts) > 0 && !getIngressHosts(ingObj).HasAny(hosts...) {
+			continue
+		}
+		ingresses = append(ingresses, ingObj)
+	}
+	if len(ingresses) == 0 {
+		return nil, nil, nil
+	}
+	sort.Slice(ingresses, func(i, j int) bool {
+		if !ingresses[i].CreationTimestamp.Equal(&ingresses[j].CreationTimestamp) {
+			return ingresses[i].CreationTimestamp.Before(&ingresses[j].CreationTimestamp)
+		}
+		return ingresses[i].Namespace+"/"+ingresses[i].Name < ingresses[j].Namespace+"/"+ingresses[j].Name
+	})
+
+	ingObj := ingresses[0]
+	for _, ing := range ingresses[1:] {
+		if ing.Namespace == routeIgrObj.GetNamespace() && ing.Name == routeIgrObj.GetName() {
+			utils.AviLog.Warnf("key: %s, msg: default backend of ingress %s/%s is not applied to VS %s, as it is already used by ingress %s/%s",
+				key, ing.Namespace, ing.Name, vsName, ingObj.Namespace, ingObj.Name)
+			lib.AKOControlConfig().EventRecorder().Eventf(ing, corev1.EventTypeWarning, lib.DuplicateDefaultBackend,
+				"Default backend is not applied to VirtualService %s, as it is already used by Ingress %s/%s", vsName, ingObj.Namespace, ingObj.Name)
+		}
+	}
+
+	defaultBackend := NewNodesValidator().ParseDefaultBackendForIngress(ingObj.Namespace, ingObj.Spec, key)
+	if defaultBackend == nil {
+		return nil, nil, nil
+	}
+	infraSetting, err := getL7IngressInfraSetting(key, utils.String(ingObj.Spec.IngressClassName), ingObj.Namespace)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: error while fetching AviInfraSetting for ingress %s/%s: %v", key, ingObj.Namespace, ingObj.Name, err)
+		return nil, nil, nil
+	}
+	return ingObj, defaultBackend, infraSetting
+}
+
+// getIngressHosts returns the hosts of the ingress rules and TLS settings, or the generated hostname for an ingress without hosts.
+func getIngressHosts(ingObj *networkingv1.Ingress) sets.String {
+	hosts := sets.NewString()
+	for _, rule := range ingObj.Spec.Rules {
+		if rule.Host != "" {
+			hosts.Insert(rule.Host)
+		}
+	}
+	for _, tls := range ingObj.Spec.TLS {
+		hosts.Insert(tls.Hosts...)
+	}
+	if hosts.Len() == 0 {
+		if subDomains := GetDefaultSubDomain(); subDomains != nil {
+			hosts.Insert(getHostNameFromSubDomain(ingObj.Name, ingObj.Namespace, subDomains[0]))
+		}
+	}
+	return hosts
+}
+
+// hasNonDefaultBackendPools reports whether the VS has any pool other than the one of the ingress default backend.
+func hasNonDefaultBackendPools(vsNode AviVsEvhSniModel) bool {
+	for _, poolNode := range vsNode.GetPoolRefs() {
+		if poolNode.Name != lib.GetL7DefaultBackendPoolName(vsNode.GetName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNonDefaultBackendPoolGroups reports whether the VS has any poolgroup other than the one of the ingress default backend.
+func hasNonDefaultBackendPoolGroups(vsNode AviVsEvhSniModel) bool {
+	for _, pgNode := range vsNode.GetPoolGroupRefs() {
+		if pgNode.Name != lib.GetL7DefaultBackendPGName(vsNode.GetName()) {
+			return true
+		}
+	}
+	return false
+}
@@ -52,6 +52,9 @@ type AviVsEvhSniModel interface {
 	GetPoolGroupRefs() []*AviPoolGroupNode
 	SetPoolGroupRefs([]*AviPoolGroupNode)
 
+	GetDefaultPoolGroup() string
+	SetDefaultPoolGroup(string)
+
 	GetSSLKeyCertRefs() []*AviTLSKeyCertNode
 	SetSSLKeyCertRefs([]*AviTLSKeyCertNode)
 
@@ -110,6 +113,9 @@ type AviVsEvhSniModel interface {
 	GetStringGroupRefs() []*AviStringGroupNode
 	SetStringGroupRefs([]*AviStringGroupNode)
 
+	GetClientCertAppProfile() *AviApplicationProfileNode
+	SetClientCertAppProfile(*AviApplicationProfileNode)
+
 	GetPaths() []string
 }
 
@@ -154,6 +160,12 @@ type AviEvhVsNode struct {
 	Secure              bool
 	Caller              string
 	StringGroupRefs     []*AviStringGroupNode
+	// PassthroughChildNodes are the L4 VSes sharing the VSVIP of this parent, used for TLS passthrough.
+	PassthroughChildNodes []*AviVsNode
+	// L4PolicyRefs select the pools for the TCP/UDP ports of this parent.
+	L4PolicyRefs []*AviL4PolicyNode
+	// ClientCertAppProfile is the AKO owned application profile, which verifies the client certificates.
+	ClientCertAppProfile *AviApplicationProfileNode
 
 	AviVsNodeCommonFields
 
@@ -206,6 +218,14 @@ func (v *AviEvhVsNode) SetPoolGroupRefs(poolGroupRefs []*AviPoolGroupNode) {
 	v.PoolGroupRefs = poolGroupRefs
 }
 
+func (v *AviEvhVsNode) GetDefaultPoolGroup() string {
+	return v.DefaultPoolGroup
+}
+
+func (v *AviEvhVsNode) SetDefaultPoolGroup(defaultPoolGroup string) {
+	v.DefaultPoolGroup = defaultPoolGroup
+}
+
 func (v *AviEvhVsNode) GetSSLKeyCertRefs() []*AviTLSKeyCertNode {
 	return v.SSLKeyCertRefs
 }
@@ -367,6 +387,14 @@ func (v *AviEvhVsNode) SetStringGroupRefs(stringGroupRefs []*AviStringGroupNode)
 	v.StringGroupRefs = stringGroupRefs
 }
 
+func (v *AviEvhVsNode) GetClientCertAppProfile() *AviApplicationProfileNode {
+	return v.ClientCertAppProfile
+}
+
+func (v *AviEvhVsNode) SetClientCertAppProfile(clientCertAppProfile *AviApplicationProfileNode) {
+	v.ClientCertAppProfile = clientCertAppProfile
+}
+
 func (v *AviEvhVsNode) GetPaths() []string {
 	return v.Paths
 }
@@ -672,6 +700,14 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		}
 	}
 
+	for _, passthroughChild := range v.PassthroughChildNodes {
+		checksumStringSlice = append(checksumStringSlice, "PassthroughChild"+passthroughChild.Name)
+	}
+
+	for _, l4policy := range v.L4PolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, "L4Policy"+l4policy.Name)
+	}
+
 	// Note: Changing the order of strings being appended, while computing vsRefs and checksum,
 	// will change the eventual checksum Hash.
 
@@ -753,6 +789,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(v.DefaultPoolGroup)
 	}
 
+	if v.ClientCertAppProfile != nil {
+		checksum += v.ClientCertAppProfile.GetCheckSum()
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -976,6 +1016,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 
 		poolNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, hosts[0],
 			infraSettingName, path.ServiceName, []string{ingName}, []string{path.Path})
+		BuildCanaryHTTPPolicy(key, childNode, poolNode, path)
 		if tlsSettings != nil && tlsSettings.reencrypt {
 			o.BuildPoolSecurity(poolNode, *tlsSettings, key, poolNode.AviMarkers)
 		}
@@ -1006,6 +1047,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 		}
 
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
+		buildPoolWithServiceSessionAffinity(key, poolNode, namespace, path.ServiceName)
 		if lib.IsIstioEnabled() {
 			poolNode.UpdatePoolNodeForIstio()
 		}
@@ -1039,6 +1081,9 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 	for _, path := range paths {
 		BuildPoolHTTPRule(hosts[0], path.Path, ingName, namespace, infraSettingName, key, childNode, true, vsNode[0].Dedicated)
 	}
+	if modelType == utils.Ingress {
+		BuildNginxAnnotations(hosts[0], ingName, namespace, key, childNode)
+	}
 
 	utils.AviLog.Infof("key: %s, msg: added pools and poolgroups. childNodeChecksum for childNode :%s is :%v", key, childNode.Name, childNode.GetCheckSum())
 
@@ -1850,6 +1895,8 @@ func (o *AviObjectGraph) RemovePoolNodeRefsFromEvh(poolName string, evhNode *Avi
 		if pool.Name == poolName {
 			utils.AviLog.Debugf("Removing pool ref: %s", poolName)
 			evhNode.PoolRefs = append(evhNode.PoolRefs[:i], evhNode.PoolRefs[i+1:]...)
+			RemoveNginxHTTPPolicy(evhNode, poolName)
+			RemoveCanaryHTTPPolicy(evhNode, poolName)
 			break
 		}
 	}
@@ -1918,6 +1965,7 @@ func (o *AviObjectGraph) manipulateEVHVsNode(vsNode *AviEvhVsNode, ingName, name
 		pgName := lib.GetEvhPGName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 		pgNode := vsNode.GetPGForVSByName(pgName)
 		for _, svc := range services {
+			_, svc = isCanaryService(svc)
 			evhPool := lib.GetEvhPoolName(ingName, namespace, hostname, path, infraSettingName, svc, vsNode.Dedicated)
 			o.RemovePoolNodeRefsFromEvh(evhPool, vsNode)
 			o.RemovePoolRefsFromPG(evhPool, pgNode)
@@ -1950,7 +1998,7 @@ func (o *AviObjectGraph) ManipulateEvhNode(currentEvhNodeName, ingName, namespac
 			}
 			o.manipulateEVHVsNode(modelEvhNode, ingName, namespace, hostname, pathSvc, infraSettingName, key)
 			// After going through the paths, if the EVH node does not have any PGs - then delete it.
-			if len(modelEvhNode.PoolGroupRefs) == 0 {
+			if !hasNonDefaultBackendPoolGroups(modelEvhNode) {
 				RemoveEvhInModel(currentEvhNodeName, vsNode, key)
 				// Remove the evhhost mapping
 				SharedHostNameLister().Delete(hostname)
@@ -16,6 +16,7 @@ package nodes
 
 import (
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -121,7 +122,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 	}
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipName := lib.GetL4VSVipName(svcObj.ObjectMeta.Name, svcObj.ObjectMeta.Namespace)
 	vsVipNode := &AviVSVIPNode{
@@ -147,6 +148,11 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		buildWithL4Rule(key, avi_vs_meta, l4Rule)
 	}
 
+	// A network security policy attached via the L4Rule takes precedence over the source ranges.
+	if avi_vs_meta.NetworkSecurityPolicyRef == nil {
+		buildWithLoadBalancerSourceRanges(key, svcObj, avi_vs_meta)
+	}
+
 	if lib.HasSpecLoadBalancerIP(svcObj) {
 		vsVipNode.IPAddress = svcObj.Spec.LoadBalancerIP
 	} else if lib.HasLoadBalancerIPAnnotation(svcObj) {
@@ -229,6 +235,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 			if servers := PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
 			}
+			buildPoolWithLocalTrafficPolicy(key, svcObj, poolNode, portProto)
 		} else {
 			if servers := PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
@@ -241,6 +248,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 		portPoolSet = append(portPoolSet, portPool)
 
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
+		buildPoolWithSessionAffinity(key, svcObj, poolNode)
 
 		if isSSLEnabled {
 			vsNode.DefaultPool = poolNode.Name
@@ -427,6 +435,12 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 		utils.AviLog.Debugf("key: %s, msg: ClusterIP is not processed in NodePort: %s", key, serviceName)
 		return poolMeta
 	}
+	// With externalTrafficPolicy Local, only the nodes hosting a ready endpoint of
+	// the LoadBalancer Service accept its traffic.
+	var localNodes sets.String
+	if !ingress && isExternalTrafficPolicyLocal(svcObj) {
+		localNodes = getNodesWithReadyEndpoints(ns, serviceName, key)
+	}
 	for _, port := range svcObj.Spec.Ports {
 		if port.Name != poolNode.PortName && len(svcObj.Spec.Ports) != 1 {
 			// continue only if port name does not match and its multiport svcobj
@@ -451,6 +465,9 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 				}
 
 			}
+			if localNodes != nil && !localNodes.Has(node.Name) {
+				continue
+			}
 			nodeIP, nodeIP6 := lib.GetIPFromNode(node)
 			var atype string
 			var serverIP avimodels.IPAddr
@@ -485,6 +502,115 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 	return poolMeta
 }
 
+func isExternalTrafficPolicyLocal(svcObj *corev1.Service) bool {
+	return svcObj.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		svcObj.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyLocal
+}
+
+// getNodesWithReadyEndpoints returns the names of the nodes hosting the ready endpoints of a Service.
+func getNodesWithReadyEndpoints(ns, serviceName, key string) sets.String {
+	nodeNames := sets.NewString()
+	if lib.AKOControlConfig().GetEndpointSlicesEnabled() {
+		epSliceIntList, err := utils.GetInformers().EpSlicesInformer.Informer().GetIndexer().ByIndex(discovery.LabelServiceName, ns+"/"+serviceName)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: error while retrieving endpointslice: %s", key, err)
+			return nodeNames
+		}
+		for _, epSliceInt := range epSliceIntList {
+			epSlice, isEpSliceClass := epSliceInt.(*discovery.EndpointSlice)
+			if !isEpSliceClass {
+				utils.AviLog.Warnf("key: %s, msg: invalid endpointslice object", key)
+				continue
+			}
+			for _, ep := range epSlice.Endpoints {
+				if ep.NodeName == nil || !*enableServer(ep.Conditions) {
+					continue
+				}
+				nodeNames.Insert(*ep.NodeName)
+			}
+		}
+	} else {
+		epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(ns).Get(serviceName)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: error while retrieving endpoints: %s", key, err)
+			return nodeNames
+		}
+		for _, ss := range epObj.Subsets {
+			for _, addr := range ss.Addresses {
+				if addr.NodeName != nil {
+					nodeNames.Insert(*addr.NodeName)
+				}
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: nodes with ready endpoints for service %s/%s: %v", key, ns, serviceName, nodeNames.List())
+	return nodeNames
+}
+
+// buildPoolWithLocalTrafficPolicy attaches a health monitor on the healthCheckNodePort of a LoadBalancer
+// Service with externalTrafficPolicy Local, so that the nodes losing their local endpoints are marked down
+// before the pool membership is updated.
+func buildPoolWithLocalTrafficPolicy(key string, svcObj *corev1.Service, pool *AviPoolNode, portProto AviPortHostProtocol) {
+	if !isExternalTrafficPolicyLocal(svcObj) || svcObj.Spec.HealthCheckNodePort == 0 {
+		return
+	}
+	pool.HealthMonitor = &AviHealthMonitorNode{
+		Name:        lib.GetL4HealthMonitorName(svcObj.Name, svcObj.Namespace, portProto.Protocol, portProto.Port),
+		Tenant:      pool.Tenant,
+		Type:        lib.HealthMonitorTypeHTTP,
+		MonitorPort: svcObj.Spec.HealthCheckNodePort,
+		HTTPRequest: lib.LocalTrafficHealthCheckRequest,
+		AviMarkers:  lib.PopulateL4PoolNodeMarkers(svcObj.Namespace, svcObj.Name, strconv.Itoa(int(portProto.Port))),
+	}
+	utils.AviLog.Debugf("key: %s, msg: attached health monitor %s on healthCheckNodePort %d to pool %s", key,
+		pool.HealthMonitor.Name, svcObj.Spec.HealthCheckNodePort, pool.Name)
+}
+
+// buildPoolWithSessionAffinity attaches a client IP persistence profile to the pool of a Service with
+// sessionAffinity ClientIP, unless the persistence of the pool is already set via an L4Rule or HTTPRule.
+func buildPoolWithSessionAffinity(key string, svcObj *corev1.Service, pool *AviPoolNode) {
+	pool.PersistenceProfile = nil
+	if svcObj == nil || svcObj.Spec.SessionAffinity != corev1.ServiceAffinityClientIP || pool.ApplicationPersistenceProfileRef != nil {
+		return
+	}
+	timeoutSeconds := corev1.DefaultClientIPServiceAffinitySeconds
+	if config := svcObj.Spec.SessionAffinityConfig; config != nil && config.ClientIP != nil && config.ClientIP.TimeoutSeconds != nil {
+		timeoutSeconds = *config.ClientIP.TimeoutSeconds
+	}
+	pool.PersistenceProfile = &AviPersistenceProfileNode{
+		Name:            lib.GetPoolPersistenceProfileName(pool.Name),
+		Tenant:          pool.Tenant,
+		PersistenceType: lib.PersistenceTypeClientIP,
+		Timeout:         getClientIPPersistenceTimeout(timeoutSeconds),
+		AviMarkers:      pool.AviMarkers,
+	}
+	utils.AviLog.Debugf("key: %s, msg: attached persistence profile %s with timeout %d minutes to pool %s", key,
+		pool.PersistenceProfile.Name, pool.PersistenceProfile.Timeout, pool.Name)
+}
+
+// buildPoolWithServiceSessionAffinity applies the sessionAffinity of the Service backing an L7 pool.
+func buildPoolWithServiceSessionAffinity(key string, pool *AviPoolNode, namespace, serviceName string) {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(serviceName)
+	if err != nil {
+		pool.PersistenceProfile = nil
+		return
+	}
+	buildPoolWithSessionAffinity(key, svcObj, pool)
+}
+
+// getClientIPPersistenceTimeout converts the sessionAffinity timeout of a Service to minutes, within the
+// range supported for the client IP persistence timeout.
+func getClientIPPersistenceTimeout(timeoutSeconds int32) int32 {
+	timeout := (timeoutSeconds + 59) / 60
+	if timeout < lib.MinClientIPPersistenceTimeout {
+		return lib.MinClientIPPersistenceTimeout
+	}
+	if timeout > lib.MaxClientIPPersistenceTimeout {
+		return lib.MaxClientIPPersistenceTimeout
+	}
+	return timeout
+}
+
 // an endpoint can be unique on four constraints
 type endpointKey struct {
 	address     string
@@ -916,6 +1042,35 @@ func buildWithL4Rule(key string, vs *AviVsNode, l4Rule *akov1alpha2.L4Rule) {
 	utils.AviLog.Debugf("key: %s, msg: Applied L4Rule %s configuration over VS %s", key, l4Rule.Name, vs.Name)
 }
 
+// buildWithLoadBalancerSourceRanges restricts the clients of the L4 VS to the
+// loadBalancerSourceRanges of the Service, using an AKO owned network security policy.
+func buildWithLoadBalancerSourceRanges(key string, svcObj *corev1.Service, vs *AviVsNode) {
+	sourceRanges := sets.NewString()
+	for _, sourceRange := range svcObj.Spec.LoadBalancerSourceRanges {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(sourceRange))
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: invalid loadBalancerSourceRange %s in Service %s/%s: %v", key, sourceRange, svcObj.Namespace, svcObj.Name, err)
+			lib.AKOControlConfig().EventRecorder().Eventf(svcObj, corev1.EventTypeWarning, lib.InvalidSourceRange,
+				"Invalid loadBalancerSourceRange %s, ignoring it: %v", sourceRange, err)
+			continue
+		}
+		sourceRanges.Insert(ipNet.String())
+	}
+	if sourceRanges.Len() == 0 {
+		return
+	}
+
+	nspNode := &AviNetworkSecurityPolicyNode{
+		Name:         vs.Name,
+		Tenant:       vs.Tenant,
+		SourceRanges: sourceRanges.List(),
+		AviMarkers:   lib.PopulateL4VSNodeMarkers(svcObj.Namespace, svcObj.Name),
+	}
+	vs.NetworkSecurityPolicyRefs = append(vs.NetworkSecurityPolicyRefs, nspNode)
+	vs.NetworkSecurityPolicyRef = proto.String(fmt.Sprintf("/api/networksecuritypolicy?name=%s", nspNode.Name))
+	utils.AviLog.Debugf("key: %s, msg: restricted VS %s to the source ranges %v", key, vs.Name, nspNode.SourceRanges)
+}
+
 func buildPoolWithL4Rule(key string, pool *AviPoolNode, l4Rule *akov1alpha2.L4Rule) {
 
 	if l4Rule == nil {
@@ -947,7 +1102,7 @@ func buildPoolWithL4Rule(key string, pool *AviPoolNode, l4Rule *akov1alpha2.L4Ru
 // and override required services with UDP Fast Path or SCTP proxy. Having a separate
 // internally used network profile (MIXED_NET_PROFILE) helps ensure PUT calls
 // on existing VSes.
-func getNetworkProfile(isSCTP, isTCP, isUDP bool) string {
+func GetNetworkProfile(isSCTP, isTCP, isUDP bool) string {
 	if isSCTP && !isTCP && !isUDP {
 		return utils.SYSTEM_SCTP_PROXY
 	}
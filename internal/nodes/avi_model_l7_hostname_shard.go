@@ -169,7 +169,7 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		} else {
 			priorityLabel = hostname
 		}
-		if isIngr {
+		if isIngr && obj.canary == nil {
 			poolName = lib.GetSniPoolName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated)
 		} else {
 			poolName = lib.GetSniPoolName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated, obj.ServiceName)
@@ -206,6 +206,7 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		var storedHosts []string
 		storedHosts = append(storedHosts, hostname)
 		poolNode := buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, obj.ServiceName, storedHosts, insecureEdgeTermAllow, obj)
+		BuildCanaryHTTPPolicy(key, vsNode[0], poolNode, obj)
 		isPoolNameLenExceedAviLimit = false
 		if lib.CheckObjectNameLength(poolNode.Name, lib.Pool) {
 			isPoolNameLenExceedAviLimit = true
@@ -245,6 +246,9 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		}
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, infraSettingName, key, vsNode[0], true, vsNode[0].Dedicated)
 	}
+	if isIngr {
+		BuildNginxAnnotations(hostname, ingName, namespace, key, vsNode[0])
+	}
 	vsNode[0].Paths = pathSet.List()
 	vsNode[0].IngressNames = ingressNameSet.List()
 	utils.AviLog.Infof("key: %s, msg: added pools and poolgroups. NodeChecksum for Insecure Dedicated Vs :%s is :%v", key, vsNode[0].Name, vsNode[0].GetCheckSum())
@@ -287,8 +291,8 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 		}
 
 		// Using servicename in poolname for routes, but not in ingress for consistency with existing naming convention.
-		// If possible, we would make this uniform
-		if routeIgrObj.GetType() == utils.Ingress {
+		// If possible, we would make this uniform. The backends of a canary ingress use the servicename, as in routes.
+		if routeIgrObj.GetType() == utils.Ingress && obj.canary == nil {
 			poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName)
 			serviceName = ""
 		} else {
@@ -319,6 +323,15 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 				// as this object will not be created at AviController, continue from here.
 				continue
 			}
+			if obj.canary != nil {
+				if obj.canary.header != "" || obj.canary.cookie != "" {
+					// The pools of the shared virtualservice are selected by the datascript, which overrides the HTTP policies.
+					// The canary is skipped, instead of switching the requests with the header or the cookie by the weight.
+					reportSkippedCanary(namespace, hostname, obj.canary, key)
+					continue
+				}
+				serviceName = obj.ServiceName
+			}
 			poolNode := buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, serviceName, storedHosts, insecureEdgeTermAllow, obj)
 			vsNode[0].PoolRefs = append(vsNode[0].PoolRefs, poolNode)
 			utils.AviLog.Debugf("key: %s, msg: the pools after append are: %v", key, utils.Stringify(vsNode[0].PoolRefs))
@@ -328,10 +341,16 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	for _, obj := range pathsvc {
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, infraSettingName, key, vsNode[0], false, vsNode[0].Dedicated)
 	}
+	if routeIgrObj.GetType() == utils.Ingress {
+		BuildNginxAnnotations(hostname, ingName, namespace, key, vsNode[0])
+	}
 
-	// Reset the PG Node members and rebuild them
+	// Reset the PG Node members and rebuild them, the default backend pool is attached to the VS directly.
 	pgNode.Members = nil
 	for _, poolNode := range vsNode[0].PoolRefs {
+		if poolNode.Name == lib.GetL7DefaultBackendPoolName(vsName) {
+			continue
+		}
 		ratio := poolNode.ServiceMetadata.PoolRatio
 		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
@@ -393,6 +412,7 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 	poolNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, hostname, infraSettingName, serviceName, []string{ingName}, []string{obj.Path})
 
 	buildPoolWithInfraSetting(key, poolNode, infraSetting)
+	buildPoolWithServiceSessionAffinity(key, poolNode, namespace, obj.ServiceName)
 	if lib.IsIstioEnabled() {
 		poolNode.UpdatePoolNodeForIstio()
 	}
@@ -423,7 +443,9 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 					priorityLabel = hostname
 				}
 				for _, svcName := range services {
-					if routeIgrObj.GetType() == utils.Ingress {
+					if isCanary, canarySvc := isCanaryService(svcName); isCanary {
+						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName, canarySvc)
+					} else if routeIgrObj.GetType() == utils.Ingress {
 						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName)
 					} else {
 						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName, svcName)
@@ -440,6 +462,9 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 		if pgNode != nil {
 			pgNode.Members = nil
 			for _, poolNode := range vsNode[0].PoolRefs {
+				if poolNode.Name == lib.GetL7DefaultBackendPoolName(vsName) {
+					continue
+				}
 				ratio := poolNode.ServiceMetadata.PoolRatio
 				pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 				pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
@@ -500,7 +525,9 @@ func (o *AviObjectGraph) manipulateVsNode(vsNode *AviVsNode, ingName, namespace,
 		}
 		for _, svc := range services {
 			var sniPool string
-			if isIngr {
+			if isCanary, canarySvc := isCanaryService(svc); isCanary {
+				sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated, canarySvc)
+			} else if isIngr {
 				sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 			} else {
 				sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated, svc)
@@ -549,7 +576,7 @@ func (o *AviObjectGraph) ManipulateSniNode(currentSniNodeName, ingName, namespac
 			}
 			o.manipulateVsNode(modelSniNode, ingName, namespace, hostname, infraSettingName, pathSvc, isIngr)
 			// After going through the paths, if the SNI node does not have any PGs - then delete it.
-			if len(modelSniNode.PoolRefs) == 0 {
+			if !hasNonDefaultBackendPools(modelSniNode) {
 				RemoveSniInModel(currentSniNodeName, vsNode, key)
 				// Remove the snihost mapping
 				SharedHostNameLister().Delete(hostname)
@@ -398,7 +398,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			var pgfound bool
 			var pgNode *AviPoolGroupNode
 			// Do not use serviceName in SNI Pool Name for ingress for backward compatibility
-			if isIngr {
+			if isIngr && path.canary == nil {
 				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated)
 			} else {
 				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated, path.ServiceName)
@@ -468,6 +468,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 
 			poolNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, host, infraSettingName,
 				path.ServiceName, []string{ingName}, []string{path.Path})
+			BuildCanaryHTTPPolicy(key, tlsNode, poolNode, path)
 			if hostpath.reencrypt {
 				o.BuildPoolSecurity(poolNode, hostpath, key, poolNode.AviMarkers)
 			}
@@ -488,6 +489,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			}
 
 			buildPoolWithInfraSetting(key, poolNode, infraSetting)
+			buildPoolWithServiceSessionAffinity(key, poolNode, namespace, path.ServiceName)
 
 			if lib.CheckObjectNameLength(poolNode.Name, lib.Pool) {
 				isPoolNameLenExceedAviLimit = true
@@ -535,6 +537,9 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				poolNode.UpdatePoolNodeForIstio()
 			}
 		}
+		if isIngr {
+			BuildNginxAnnotations(host, ingName, namespace, key, tlsNode)
+		}
 		sniFQDNs = append(sniFQDNs, pathFQDNs...)
 	}
 	tlsNode.Paths = pathSet.List()
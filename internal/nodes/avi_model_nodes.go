@@ -162,6 +162,9 @@ func (v *AviVsNode) CalculateForGraphChecksum() uint32 {
 	for _, passthrough := range v.PassthroughChildNodes {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(passthrough.CalculateForGraphChecksum()))
 	}
+	for _, l4pol := range v.L4PolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(l4pol.GetCheckSum()))
+	}
 	for _, cacert := range v.CACertRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(cacert.GetCheckSum()))
 	}
@@ -177,8 +180,8 @@ func (v *AviVsNode) CalculateForGraphChecksum() uint32 {
 	for _, vsvip := range v.VSVIPRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(vsvip.GetCheckSum()))
 	}
-	for _, l4pol := range v.L4PolicyRefs {
-		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(l4pol.GetCheckSum()))
+	for _, nsp := range v.NetworkSecurityPolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(nsp.GetCheckSum()))
 	}
 	for _, stringGroup := range v.StringGroupRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(stringGroup.GetCheckSum()))
@@ -201,6 +204,12 @@ func (v *AviEvhVsNode) CalculateForGraphChecksum() uint32 {
 	for _, evh := range v.EvhNodes {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(evh.CalculateForGraphChecksum()))
 	}
+	for _, passthrough := range v.PassthroughChildNodes {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(passthrough.CalculateForGraphChecksum()))
+	}
+	for _, l4pol := range v.L4PolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(l4pol.GetCheckSum()))
+	}
 	for _, cacert := range v.CACertRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(cacert.GetCheckSum()))
 	}
@@ -238,6 +247,8 @@ func (o *AviObjectGraph) RemovePoolNodeRefs(poolName string) {
 					utils.AviLog.Debugf("Removing poolref: %s", poolName)
 					utils.AviLog.Debugf("Before removing the pool nodes are: %s", utils.Stringify(node.(*AviVsNode).PoolRefs))
 					node.(*AviVsNode).PoolRefs = append(node.(*AviVsNode).PoolRefs[:i], node.(*AviVsNode).PoolRefs[i+1:]...)
+					RemoveNginxHTTPPolicy(node.(*AviVsNode), poolName)
+					RemoveCanaryHTTPPolicy(node.(*AviVsNode), poolName)
 					break
 				}
 			}
@@ -296,6 +307,8 @@ func (o *AviObjectGraph) RemovePoolNodeRefsFromSni(poolName string, sniNode *Avi
 		if pool.Name == poolName {
 			utils.AviLog.Debugf("Removing pool ref: %s", poolName)
 			sniNode.PoolRefs = append(sniNode.PoolRefs[:i], sniNode.PoolRefs[i+1:]...)
+			RemoveNginxHTTPPolicy(sniNode, poolName)
+			RemoveCanaryHTTPPolicy(sniNode, poolName)
 			break
 		}
 	}
@@ -433,6 +446,11 @@ type AviVsNode struct {
 	Secure                bool
 	StringGroupRefs       []*AviStringGroupNode
 
+	// NetworkSecurityPolicyRefs holds the AKO owned network security policy of an L4 VS.
+	NetworkSecurityPolicyRefs []*AviNetworkSecurityPolicyNode
+	// ClientCertAppProfile is the AKO owned application profile, which verifies the client certificates.
+	ClientCertAppProfile *AviApplicationProfileNode
+
 	AviVsNodeCommonFields
 
 	AviVsNodeGeneratedFields
@@ -500,6 +518,14 @@ func (v *AviVsNode) SetPoolGroupRefs(poolGroupRefs []*AviPoolGroupNode) {
 	v.PoolGroupRefs = poolGroupRefs
 }
 
+func (v *AviVsNode) GetDefaultPoolGroup() string {
+	return v.DefaultPoolGroup
+}
+
+func (v *AviVsNode) SetDefaultPoolGroup(defaultPoolGroup string) {
+	v.DefaultPoolGroup = defaultPoolGroup
+}
+
 func (v *AviVsNode) GetSSLKeyCertRefs() []*AviTLSKeyCertNode {
 	return v.SSLKeyCertRefs
 }
@@ -661,6 +687,14 @@ func (v *AviVsNode) SetStringGroupRefs(stringGroupRefs []*AviStringGroupNode) {
 	v.StringGroupRefs = stringGroupRefs
 }
 
+func (v *AviVsNode) GetClientCertAppProfile() *AviApplicationProfileNode {
+	return v.ClientCertAppProfile
+}
+
+func (v *AviVsNode) SetClientCertAppProfile(clientCertAppProfile *AviApplicationProfileNode) {
+	v.ClientCertAppProfile = clientCertAppProfile
+}
+
 func (v *AviVsNode) GetPaths() []string {
 	return v.Paths
 }
@@ -1086,6 +1120,14 @@ func (v *AviVsNode) CalculateCheckSum() {
 
 	checksum += v.AviVsNodeGeneratedFields.CalculateCheckSumOfGeneratedCode()
 
+	if v.DefaultPoolGroup != "" {
+		checksum += utils.Hash(v.DefaultPoolGroup)
+	}
+
+	if v.ClientCertAppProfile != nil {
+		checksum += v.ClientCertAppProfile.GetCheckSum()
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -1154,6 +1196,43 @@ func (v *AviL4PolicyNode) CopyNode() AviModelNode {
 	return &newNode
 }
 
+// AviNetworkSecurityPolicyNode is the AKO owned network security policy of an L4 VS,
+// allowing traffic only from the loadBalancerSourceRanges of the Service.
+type AviNetworkSecurityPolicyNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	SourceRanges     []string
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviNetworkSecurityPolicyNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.NetworkSecurityPolicyChecksum(v.SourceRanges, v.AviMarkers, nil, false)
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetNodeType() string {
+	return "AviNetworkSecurityPolicyNode"
+}
+
+func (v *AviNetworkSecurityPolicyNode) CopyNode() AviModelNode {
+	newNode := AviNetworkSecurityPolicyNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviNetworkSecurityPolicyNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviNetworkSecurityPolicyNode: %s", err)
+	}
+	return &newNode
+}
+
 type AviHttpPolicySetNode struct {
 	Name               string
 	Tenant             string
@@ -1166,6 +1245,10 @@ type AviHttpPolicySetNode struct {
 	AttachedToSharedVS bool
 	RequestRules       []*avimodels.HTTPRequestRule
 	ResponseRules      []*avimodels.HTTPResponseRule
+	// PoolPathScope is the pool, for whose host and path the rules of the policy set are built. The rules are not
+	// applied to the ExcludedPaths, the longer paths of the host which are switched to the other pools.
+	PoolPathScope string
+	ExcludedPaths []*avimodels.PathMatch
 }
 
 func (v *AviHttpPolicySetNode) GetCheckSum() uint32 {
@@ -1580,6 +1663,137 @@ func (v *AviPkiProfileNode) CalculateCheckSum() {
 	v.CloudConfigCksum = checksum
 }
 
+// AviHealthMonitorNode is the AKO owned HTTP health monitor of an L4 pool, which probes the
+// healthCheckNodePort of a Service with externalTrafficPolicy Local.
+type AviHealthMonitorNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	Type             string
+	MonitorPort      int32
+	HTTPRequest      string
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviHealthMonitorNode) GetNodeType() string {
+	return "HealthMonitorNode"
+}
+
+func (v *AviHealthMonitorNode) CopyNode() AviModelNode {
+	newNode := AviHealthMonitorNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviHealthMonitorNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviHealthMonitorNode: %s", err)
+	}
+	return &newNode
+}
+
+func (v *AviHealthMonitorNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviHealthMonitorNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.HealthMonitorChecksum(v.Type, v.MonitorPort, v.HTTPRequest, v.AviMarkers, nil, false)
+}
+
+// AviPersistenceProfileNode is the AKO owned persistence profile of a pool, which is either a client IP
+// persistence derived from the sessionAffinity of its Service, or an HTTP cookie persistence derived
+// from the ingress-nginx annotations of its Ingress.
+type AviPersistenceProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	PersistenceType  string
+	Timeout          int32
+	CookieName       string
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviPersistenceProfileNode) GetNodeType() string {
+	return "PersistenceProfileNode"
+}
+
+func (v *AviPersistenceProfileNode) CopyNode() AviModelNode {
+	newNode := AviPersistenceProfileNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviPersistenceProfileNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviPersistenceProfileNode: %s", err)
+	}
+	return &newNode
+}
+
+func (v *AviPersistenceProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviPersistenceProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.PersistenceProfileChecksum(v.PersistenceType, v.Timeout, v.CookieName, v.AviMarkers, nil, false)
+}
+
+// AviApplicationProfileNode is the AKO owned HTTP application profile of a virtual service, which verifies
+// the client certificates against a PKI profile, as configured in the HostRule.
+type AviApplicationProfileNode struct {
+	Name                  string
+	Tenant                string
+	CloudConfigCksum      uint32
+	BaseProfile           string
+	PkiProfile            *AviPkiProfileNode
+	PkiProfileName        string
+	ClientCertificateMode string
+	ForwardClientIdentity bool
+	// ClientMaxBodySize overrides the client max body size of the base profile, in KB.
+	ClientMaxBodySize *int64
+	AviMarkers        utils.AviObjectMarkers
+}
+
+func (v *AviApplicationProfileNode) GetNodeType() string {
+	return "ApplicationProfileNode"
+}
+
+func (v *AviApplicationProfileNode) CopyNode() AviModelNode {
+	newNode := AviApplicationProfileNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviApplicationProfileNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviApplicationProfileNode: %s", err)
+	}
+	return &newNode
+}
+
+func (v *AviApplicationProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviApplicationProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.ApplicationProfileChecksum(v.BaseProfile, v.GetPkiProfileName(), v.ClientCertificateMode, v.ForwardClientIdentity, v.ClientMaxBodySize, v.AviMarkers, nil, false)
+}
+
+// GetPkiProfileName returns the name of the PKI profile, which is either created by AKO from a Secret,
+// or is an existing PKI profile on the Avi Controller.
+func (v *AviApplicationProfileNode) GetPkiProfileName() string {
+	if v.PkiProfile != nil {
+		return v.PkiProfile.Name
+	}
+	return v.PkiProfileName
+}
+
 type AviPoolNode struct {
 	Name                     string
 	Tenant                   string
@@ -1594,11 +1808,17 @@ type AviPoolNode struct {
 	ServiceMetadata          lib.ServiceMetadataObj
 	SniEnabled               bool
 	PkiProfile               *AviPkiProfileNode
+	HealthMonitor            *AviHealthMonitorNode
+	PersistenceProfile       *AviPersistenceProfileNode
 	NetworkPlacementSettings map[string]lib.NodeNetworkMap
 	VrfContext               string
 	T1Lr                     string // Only applicable to NSX-T cloud, if this value is set, we automatically should unset the VRF context value.
 	AviMarkers               utils.AviObjectMarkers
 	AttachedWithSharedVS     bool
+	EnableHTTP2              bool
+	ServerTimeout            uint32
+	ServerReselect           *avimodels.HttpserverReselect
+	ServerName               string
 
 	AviPoolCommonFields
 
@@ -1656,6 +1876,22 @@ func (v *AviPoolNode) CalculateCheckSum() {
 
 	checksumStringSlice = append(checksumStringSlice, utils.Stringify(v.SniEnabled))
 
+	if v.EnableHTTP2 {
+		checksumStringSlice = append(checksumStringSlice, utils.Stringify(v.EnableHTTP2))
+	}
+
+	if v.ServerTimeout != 0 {
+		checksumStringSlice = append(checksumStringSlice, strconv.Itoa(int(v.ServerTimeout)))
+	}
+
+	if v.ServerReselect != nil {
+		checksumStringSlice = append(checksumStringSlice, utils.Stringify(v.ServerReselect))
+	}
+
+	if v.ServerName != "" {
+		checksumStringSlice = append(checksumStringSlice, v.ServerName)
+	}
+
 	if v.SslProfileRef != nil {
 		checksumStringSlice = append(checksumStringSlice, *v.SslProfileRef)
 	}
@@ -1694,6 +1930,14 @@ func (v *AviPoolNode) CalculateCheckSum() {
 		checksum += v.PkiProfile.GetCheckSum()
 	}
 
+	if v.HealthMonitor != nil {
+		checksum += v.HealthMonitor.GetCheckSum()
+	}
+
+	if v.PersistenceProfile != nil {
+		checksum += v.PersistenceProfile.GetCheckSum()
+	}
+
 	if v.ApplicationPersistenceProfileRef != nil {
 		checksum += utils.Hash(*v.ApplicationPersistenceProfileRef)
 	}
@@ -1774,8 +2018,9 @@ type IngressHostPathSvc struct {
 	weight         uint32 //required for alternate backends in openshift route
 	PortName       string
 	TargetPort     intstr.IntOrString
-	clusterContext string // required for Multi-cluster ingress
-	svcNamespace   string // required for Multi-cluster ingress
+	clusterContext string         // required for Multi-cluster ingress
+	svcNamespace   string         // required for Multi-cluster ingress
+	canary         *canaryBackend // required for the backends of a canary ingress
 }
 
 type IngressHostMap map[string]HostMetadata
@@ -1811,6 +2056,7 @@ type IngressConfig struct {
 	TlsCollection         []TlsSettings
 	IngressHostMap
 	InsecureEdgeTermAllow bool
+	DefaultBackend        *IngressHostPathSvc
 }
 
 type SecureHostNameMapProp struct {
@@ -15,6 +15,8 @@
 package nodes
 
 import (
+	"sort"
+
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
@@ -77,6 +79,17 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		// Detect a delete condition here.
 		if k8serrors.IsNotFound(err) || !processObj {
 			utils.AviLog.Infof("key: %s, Deleting Pool for ingress delete", key)
+			if objType == utils.Ingress {
+				// Detach the default backend first, so that the VSes left without any pools get deleted.
+				var modelList []string
+				staleModels := SharedHostNameLister().UpdateDefaultBackendMappings(namespace+"/"+objname, nil)
+				ProcessDefaultBackend(routeIgrObj, key, staleModels, nil, &modelList)
+				if !fullsync {
+					for _, modelName := range modelList {
+						PublishKeyToRestLayer(modelName, key, sharedQueue)
+					}
+				}
+			}
 			if lib.IsEvhEnabled() {
 				RouteIngrDeletePoolsByHostnameForEvh(routeIgrObj, namespace, objname, key, fullsync, sharedQueue)
 			} else {
@@ -98,6 +111,19 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 
 	hostsMap := make(map[string]*objects.RouteIngrhost)
 
+	// Detach the default backend from the VSes which no longer have any host of this ingress.
+	var defaultBackendVSNames map[string]lib.VSNameMetadata
+	var defaultBackendModels []string
+	if objType == utils.Ingress {
+		defaultBackendVSNames = GetDefaultBackendVSNames(routeIgrObj, parsedIng, key)
+		for modelName := range defaultBackendVSNames {
+			defaultBackendModels = append(defaultBackendModels, modelName)
+		}
+		sort.Strings(defaultBackendModels)
+		staleModels := SharedHostNameLister().UpdateDefaultBackendMappings(namespace+"/"+objname, defaultBackendModels)
+		ProcessDefaultBackend(routeIgrObj, key, staleModels, nil, &modelList)
+	}
+
 	if lib.IsEvhEnabled() {
 		// Process insecure hosts
 		ProcessInsecureHostsForEVH(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
@@ -106,6 +132,9 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		ProcessPassthroughHosts(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
 		// delete stale data
 		DeleteStaleDataForEvh(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
+		// attach the default backend
+		defaultBackendModels = AddModelsWithDefaultBackend(defaultBackendModels, modelList)
+		ProcessDefaultBackend(routeIgrObj, key, defaultBackendModels, defaultBackendVSNames, &modelList)
 		// hostNamePathStore cache operation
 		_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 		updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
@@ -132,6 +161,9 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 	utils.AviLog.Debugf("key: %s, msg: Stored hosts: %v, hosts map: %v", key, Storedhosts, hostsMap)
 	DeleteStaleData(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
 
+	defaultBackendModels = AddModelsWithDefaultBackend(defaultBackendModels, modelList)
+	ProcessDefaultBackend(routeIgrObj, key, defaultBackendModels, defaultBackendVSNames, &modelList)
+
 	// hostNamePathStore cache operation
 	_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 	updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
@@ -149,7 +181,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 func getPathSvc(currentPathSvc []IngressHostPathSvc) map[string][]string {
 	pathSvcMap := make(map[string][]string)
 	for _, val := range currentPathSvc {
-		pathSvcMap[val.Path] = append(pathSvcMap[val.Path], val.ServiceName)
+		pathSvcMap[val.Path] = append(pathSvcMap[val.Path], val.getStoredServiceName())
 	}
 	return pathSvcMap
 }
@@ -0,0 +1,397 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// canaryServicePrefix is prefixed to the services of the canary backends in the stored paths of an ingress,
+// so that the pools of the canary backends are removed when the canary ingress is deleted.
+const canaryServicePrefix = "canary/"
+
+// canaryBackend is a backend of a canary ingress, which is added to the ingress with the same host and path.
+// The requests with the header or the cookie are always switched to the pool of the canary backend, and the
+// requests with the header or the cookie set to never are switched to the pool of the service of the ingress.
+type canaryBackend struct {
+	ingName       string
+	header        string
+	headerValue   string
+	cookie        string
+	stableService string
+}
+
+// canarySettings are the annotations of a canary ingress.
+type canarySettings struct {
+	weight      uint32
+	header      string
+	headerValue string
+	cookie      string
+}
+
+// getCanaryAnnotationKeys returns the canary annotations in use for an ingress. The ingress-nginx canary annotations
+// are honoured when enableNginxAnnotations is set, and the AKO canary annotation is not present.
+func getCanaryAnnotationKeys(annotations map[string]string) (canary, weight, header, headerValue, cookie string) {
+	if _, ok := annotations[lib.CanaryAnnotation]; !ok && lib.IsNginxAnnotationsEnabled() {
+		if _, ok := annotations[lib.NginxCanary]; ok {
+			return lib.NginxCanary, lib.NginxCanaryWeight, lib.NginxCanaryByHeader, lib.NginxCanaryByHeaderValue, lib.NginxCanaryByCookie
+		}
+	}
+	return lib.CanaryAnnotation, lib.CanaryWeightAnnotation, lib.CanaryHeaderAnnotation, lib.CanaryHeaderValueAnnotation, lib.CanaryCookieAnnotation
+}
+
+func isCanaryIngress(annotations map[string]string) bool {
+	canaryKey, _, _, _, _ := getCanaryAnnotationKeys(annotations)
+	isCanary, _ := strconv.ParseBool(strings.TrimSpace(annotations[canaryKey]))
+	return isCanary
+}
+
+// getCanarySettings returns the settings of a canary ingress. An invalid weight is treated as 0, so that only the
+// requests with the canary header or cookie are switched to the canary backends.
+func getCanarySettings(annotations map[string]string) (canarySettings, error) {
+	_, weightKey, headerKey, headerValueKey, cookieKey := getCanaryAnnotationKeys(annotations)
+	settings := canarySettings{
+		header:      strings.TrimSpace(annotations[headerKey]),
+		headerValue: strings.TrimSpace(annotations[headerValueKey]),
+		cookie:      strings.TrimSpace(annotations[cookieKey]),
+	}
+	if weight, ok := annotations[weightKey]; ok {
+		w, err := strconv.ParseUint(strings.TrimSpace(weight), 10, 32)
+		if err != nil || w > 100 {
+			return settings, fmt.Errorf("invalid weight %s in annotation %s, weight should be between 0 and 100", weight, weightKey)
+		}
+		settings.weight = uint32(w)
+	}
+	return settings, nil
+}
+
+// validateCanaryIngress raises an event on a canary ingress with invalid annotations.
+func validateCanaryIngress(namespace, ingName string, annotations map[string]string, key string) {
+	if _, err := getCanarySettings(annotations); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: canary ingress %s/%s: %v", key, namespace, ingName, err)
+		if ingress, err2 := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName); err2 == nil {
+			lib.AKOControlConfig().EventRecorder().Eventf(ingress, corev1.EventTypeWarning, lib.InvalidConfiguration, "Canary ingress: %v", err)
+		}
+	}
+}
+
+// reportSkippedCanary raises an event on a canary ingress with the header or the cookie, whose backend is skipped
+// for an insecure host of the shared virtualservice.
+func reportSkippedCanary(namespace, host string, canary *canaryBackend, key string) {
+	utils.AviLog.Warnf("key: %s, msg: canary header and cookie of ingress %s/%s are not supported for the insecure host %s, skipping the canary",
+		key, namespace, canary.ingName, host)
+	if ingress, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(canary.ingName); err == nil {
+		lib.AKOControlConfig().EventRecorder().Eventf(ingress, corev1.EventTypeWarning, lib.InvalidConfiguration,
+			"Canary ingress: header and cookie are not supported for the insecure host %s of the shared virtualservice, the canary is skipped", host)
+	}
+}
+
+// getCanaryIngresses returns the canary ingresses of a namespace, sorted by name.
+func getCanaryIngresses(namespace, key string) []*networkingv1.Ingress {
+	if utils.GetInformers().IngressInformer == nil {
+		return nil
+	}
+	ingresses, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the ingresses in namespace %s: %v", key, namespace, err)
+		return nil
+	}
+	var canaryIngresses []*networkingv1.Ingress
+	for _, ingress := range ingresses {
+		if ingress.GetDeletionTimestamp() != nil || !isCanaryIngress(ingress.GetAnnotations()) || !lib.ValidateIngressForClass(key, ingress) {
+			continue
+		}
+		canaryIngresses = append(canaryIngresses, ingress)
+	}
+	sort.Slice(canaryIngresses, func(i, j int) bool {
+		return canaryIngresses[i].Name < canaryIngresses[j].Name
+	})
+	return canaryIngresses
+}
+
+// getCanaryBackend returns the backend of the first canary ingress with the host and the path of an ingress backend.
+// The weight of the canary backend is deducted from the weight of the ingress backend.
+func (v *Validator) getCanaryBackend(namespace, host string, hostPathMapSvc *IngressHostPathSvc, canaryIngresses []*networkingv1.Ingress, key string) *IngressHostPathSvc {
+	for _, ingress := range canaryIngresses {
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host != host || rule.IngressRuleValue.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.IngressRuleValue.HTTP.Paths {
+				if path.Path != hostPathMapSvc.Path || path.Backend.Service == nil {
+					continue
+				}
+				settings, _ := getCanarySettings(ingress.GetAnnotations())
+				canaryPathMapSvc := IngressHostPathSvc{
+					Path:        hostPathMapSvc.Path,
+					PathType:    hostPathMapSvc.PathType,
+					ServiceName: path.Backend.Service.Name,
+					Port:        path.Backend.Service.Port.Number,
+					PortName:    path.Backend.Service.Port.Name,
+					TargetPort:  v.findTargetPort(path.Backend.Service.Name, namespace, &path.Backend.Service.Port, key),
+					weight:      settings.weight,
+					canary: &canaryBackend{
+						ingName:       ingress.Name,
+						header:        settings.header,
+						headerValue:   settings.headerValue,
+						cookie:        settings.cookie,
+						stableService: hostPathMapSvc.ServiceName,
+					},
+				}
+				if canaryPathMapSvc.PortName == "" {
+					canaryPathMapSvc.PortName = v.findPortName(path.Backend.Service.Name, namespace, path.Backend.Service.Port.Number, key)
+				}
+				if canaryPathMapSvc.Port == 0 {
+					canaryPathMapSvc.Port = 80
+				}
+				hostPathMapSvc.weight = 100 - settings.weight
+				utils.AviLog.Infof("key: %s, msg: added backend %s of canary ingress %s/%s for host %s path %s with weight %d",
+					key, canaryPathMapSvc.ServiceName, namespace, ingress.Name, host, hostPathMapSvc.Path, settings.weight)
+				return &canaryPathMapSvc
+			}
+		}
+	}
+	return nil
+}
+
+// getIngressesForCanary returns the ingresses in the namespace of a canary ingress, which have the hosts of the
+// canary ingress, before or after the update of the canary ingress.
+func getIngressesForCanary(namespace, ingName, key string) []string {
+	canaryIng := namespace + "/" + ingName
+	hosts := SharedHostNameLister().GetCanaryIngressHosts(canaryIng)
+	var currentHosts []string
+	ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName)
+	if err == nil && isCanaryIngress(ingObj.GetAnnotations()) {
+		for _, rule := range ingObj.Spec.Rules {
+			if rule.Host != "" && !utils.HasElem(currentHosts, rule.Host) {
+				currentHosts = append(currentHosts, rule.Host)
+			}
+		}
+	}
+	SharedHostNameLister().UpdateCanaryIngressHosts(canaryIng, currentHosts)
+	for _, host := range currentHosts {
+		if !utils.HasElem(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return getIngressesWithCanaryHosts(namespace, ingName, hosts, key)
+}
+
+// getIngressesForCanaryServices returns the ingresses, along with the ingresses having the hosts of the
+// canary ingresses among them, so that the updates of the services of a canary ingress reach its hosts.
+func getIngressesForCanaryServices(namespace string, ingresses []string, key string) []string {
+	allIngresses := ingresses
+	for _, ingName := range ingresses {
+		hosts := SharedHostNameLister().GetCanaryIngressHosts(namespace + "/" + ingName)
+		for _, ing := range getIngressesWithCanaryHosts(namespace, ingName, hosts, key) {
+			if !utils.HasElem(allIngresses, ing) {
+				allIngresses = append(allIngresses, ing)
+			}
+		}
+	}
+	return allIngresses
+}
+
+// getIngressesWithCanaryHosts returns the ingresses in the namespace, other than the canary ingresses,
+// which have any of the hosts of a canary ingress.
+func getIngressesWithCanaryHosts(namespace, canaryIngName string, hosts []string, key string) []string {
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	ingresses, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the ingresses in namespace %s: %v", key, namespace, err)
+		return nil
+	}
+	var ingNames []string
+	for _, ingress := range ingresses {
+		if ingress.Name == canaryIngName || isCanaryIngress(ingress.GetAnnotations()) {
+			continue
+		}
+		for _, rule := range ingress.Spec.Rules {
+			if utils.HasElem(hosts, rule.Host) {
+				ingNames = append(ingNames, ingress.Name)
+				break
+			}
+		}
+	}
+	if len(ingNames) > 0 {
+		utils.AviLog.Infof("key: %s, msg: ingresses with the hosts of canary ingress %s/%s: %v", key, namespace, canaryIngName, ingNames)
+	}
+	return ingNames
+}
+
+// getStoredServiceName returns the service of a backend, as stored in the paths of an ingress.
+func (p IngressHostPathSvc) getStoredServiceName() string {
+	if p.canary != nil {
+		return canaryServicePrefix + p.ServiceName
+	}
+	return p.ServiceName
+}
+
+// isCanaryService returns true for the service of a canary backend in the stored paths of an ingress,
+// along with the name of the service.
+func isCanaryService(storedSvc string) (bool, string) {
+	if strings.HasPrefix(storedSvc, canaryServicePrefix) {
+		return true, strings.TrimPrefix(storedSvc, canaryServicePrefix)
+	}
+	return false, storedSvc
+}
+
+// getCanaryStablePool returns the pool of the service of the ingress, with the host and the path of the pool of
+// a canary backend. The backend of the ingress precedes the canary backend, hence its pool is already built.
+func getCanaryStablePool(vsNode AviVsEvhSniModel, pool *AviPoolNode, canary *canaryBackend) *AviPoolNode {
+	for _, stablePool := range vsNode.GetPoolRefs() {
+		if stablePool.Name != pool.Name &&
+			stablePool.AviMarkers.ServiceName == canary.stableService &&
+			reflect.DeepEqual(stablePool.AviMarkers.Host, pool.AviMarkers.Host) &&
+			reflect.DeepEqual(stablePool.AviMarkers.Path, pool.AviMarkers.Path) &&
+			reflect.DeepEqual(stablePool.AviMarkers.IngressName, pool.AviMarkers.IngressName) {
+			return stablePool
+		}
+	}
+	return nil
+}
+
+// BuildCanaryHTTPPolicy builds the HTTP policy set of the pool of a canary backend, which switches the requests
+// with the canary header or cookie to the pool. The policy set precedes the other policy sets of the virtualservice,
+// so that the requests are switched to the pool of the canary backend instead of the pool group of the path.
+// As in ingress-nginx, the requests with the header or the cookie set to never are switched to the pool of the
+// service of the ingress, and the header takes precedence over the cookie. The rules apply only to the path of
+// the canary backend, and not to the longer paths of the host, which are switched to the other pools.
+func BuildCanaryHTTPPolicy(key string, vsNode AviVsEvhSniModel, pool *AviPoolNode, hostPathSvc IngressHostPathSvc) {
+	RemoveCanaryHTTPPolicy(vsNode, pool.Name)
+	if hostPathSvc.canary == nil || (hostPathSvc.canary.header == "" && hostPathSvc.canary.cookie == "") {
+		return
+	}
+	policyName := lib.GetCanaryHTTPPolicySetName(pool.Name)
+	getMatchTarget := func() *avimodels.MatchTarget {
+		matchTarget := &avimodels.MatchTarget{
+			HostHdr: &avimodels.HostHdrMatch{
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				Value:         pool.AviMarkers.Host,
+			},
+		}
+		if hostPathSvc.Path != "" {
+			matchCriteria := "BEGINS_WITH"
+			if hostPathSvc.PathType == networkingv1.PathTypeExact {
+				matchCriteria = "EQUALS"
+			}
+			matchTarget.Path = &avimodels.PathMatch{
+				MatchCriteria: proto.String(matchCriteria),
+				MatchCase:     proto.String("SENSITIVE"),
+				MatchStr:      []string{hostPathSvc.Path},
+			}
+		}
+		return matchTarget
+	}
+
+	stablePool := getCanaryStablePool(vsNode, pool, hostPathSvc.canary)
+	var requestRules []*avimodels.HTTPRequestRule
+	addRule := func(matchTarget *avimodels.MatchTarget, poolName string) {
+		requestRules = append(requestRules, &avimodels.HTTPRequestRule{
+			Name:   proto.String(fmt.Sprintf("%s-%d", policyName, len(requestRules))),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(int32(len(requestRules))),
+			Match:  matchTarget,
+			SwitchingAction: &avimodels.HttpswitchingAction{
+				Action:  proto.String("HTTP_SWITCHING_SELECT_POOL"),
+				PoolRef: proto.String(fmt.Sprintf("/api/pool/?name=%s", poolName)),
+			},
+		})
+	}
+	addNeverRule := func(matchTarget *avimodels.MatchTarget) {
+		if stablePool == nil {
+			utils.AviLog.Warnf("key: %s, msg: pool of the service %s not found for canary ingress %s, the requests set to never are not switched",
+				key, hostPathSvc.canary.stableService, hostPathSvc.canary.ingName)
+			return
+		}
+		addRule(matchTarget, stablePool.Name)
+	}
+	if hostPathSvc.canary.header != "" {
+		// As in ingress-nginx, the requests with the header set to always are switched to the canary backend,
+		// and the requests with the header set to never are not, unless a header value is specified.
+		headerValues := []string{hostPathSvc.canary.headerValue}
+		if hostPathSvc.canary.headerValue == "" {
+			headerValues = []string{"always", "never"}
+		}
+		for _, headerValue := range headerValues {
+			matchTarget := getMatchTarget()
+			matchTarget.Hdrs = []*avimodels.HdrMatch{{
+				Hdr:           proto.String(hostPathSvc.canary.header),
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				MatchCase:     proto.String("SENSITIVE"),
+				Value:         []string{headerValue},
+			}}
+			if headerValue == "never" && hostPathSvc.canary.headerValue == "" {
+				addNeverRule(matchTarget)
+			} else {
+				addRule(matchTarget, pool.Name)
+			}
+		}
+	}
+	if hostPathSvc.canary.cookie != "" {
+		for _, cookieValue := range []string{"always", "never"} {
+			matchTarget := getMatchTarget()
+			matchTarget.Cookie = &avimodels.CookieMatch{
+				Name:          proto.String(hostPathSvc.canary.cookie),
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				MatchCase:     proto.String("SENSITIVE"),
+				Value:         proto.String(cookieValue),
+			}
+			if cookieValue == "never" {
+				addNeverRule(matchTarget)
+			} else {
+				addRule(matchTarget, pool.Name)
+			}
+		}
+	}
+
+	policyNode := &AviHttpPolicySetNode{
+		Name:          policyName,
+		Tenant:        vsNode.GetTenant(),
+		RequestRules:  requestRules,
+		AviMarkers:    lib.PopulateHTTPPolicysetNodeMarkers(pool.AviMarkers.Namespace, pool.AviMarkers.Host[0], pool.AviMarkers.InfrasettingName, pool.AviMarkers.IngressName, pool.AviMarkers.Path),
+		PoolPathScope: pool.Name,
+	}
+	vsNode.SetHttpPolicyRefs(append([]*AviHttpPolicySetNode{policyNode}, vsNode.GetHttpPolicyRefs()...))
+	utils.AviLog.Debugf("key: %s, msg: attached HTTP policy set %s for canary ingress %s", key, policyName, hostPathSvc.canary.ingName)
+}
+
+// RemoveCanaryHTTPPolicy removes the HTTP policy set of the pool of a canary backend.
+func RemoveCanaryHTTPPolicy(vsNode AviVsEvhSniModel, poolName string) {
+	policyName := lib.GetCanaryHTTPPolicySetName(poolName)
+	httpPolicyRefs := vsNode.GetHttpPolicyRefs()
+	for i, policy := range httpPolicyRefs {
+		if policy.Name == policyName {
+			vsNode.SetHttpPolicyRefs(append(httpPolicyRefs[:i], httpPolicyRefs[i+1:]...))
+			return
+		}
+	}
+}
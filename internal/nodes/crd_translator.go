@@ -76,6 +76,7 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 	vsDatascripts := []string{}
 	var analyticsPolicy *models.AnalyticsPolicy
 	var vsStringGroupRefs []*AviStringGroupNode
+	var clientCertAppProfile *AviApplicationProfileNode
 
 	// Get the existing VH domain names and then manipulate it based on the aliases in Hostrule CRD.
 	VHDomainNames := vsNode.GetVHDomainNames()
@@ -113,6 +114,16 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 			vsAppProfile = proto.String(fmt.Sprintf("/api/applicationprofile?name=%s", hostrule.Spec.VirtualHost.ApplicationProfile))
 		}
 
+		if hostrule.Spec.VirtualHost.TLS.ClientCertificate != nil {
+			if vsNode.IsSharedVS() || vsNode.IsDedicatedVS() {
+				utils.AviLog.Warnf("key: %s, can not associate client certificate authentication with host which is not attached to child virtual service. Configuration is ignored", key)
+				lib.AKOControlConfig().EventRecorder().Eventf(hostrule, corev1.EventTypeWarning, lib.InvalidConfiguration,
+					"can not associate client certificate authentication with host which is not attached to child virtual service. Configuration is ignored")
+			} else if clientCertAppProfile = buildClientCertAppProfile(hostrule, vsNode, host, key); clientCertAppProfile != nil {
+				vsAppProfile = proto.String(fmt.Sprintf("/api/applicationprofile?name=%s", clientCertAppProfile.Name))
+			}
+		}
+
 		if len(hostrule.Spec.VirtualHost.ICAPProfile) != 0 {
 			vsICAPProfile = []string{fmt.Sprintf("/api/icapprofile?name=%s", hostrule.Spec.VirtualHost.ICAPProfile[0])}
 		}
@@ -227,11 +238,21 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 		vsNode.GetGeneratedFields().ConvertL7RuleFieldsToNil()
 	}
 
+	var baseAppProfile string
+	if !deleteCase {
+		baseAppProfile = hostrule.Spec.VirtualHost.ApplicationProfile
+	}
+	// The request body size of the ingress-nginx annotations is set on the application profile owned by AKO.
+	if clientCertAppProfile = BuildNginxAppProfile(host, key, vsNode, clientCertAppProfile, baseAppProfile); clientCertAppProfile != nil {
+		vsAppProfile = proto.String(fmt.Sprintf("/api/applicationprofile?name=%s", clientCertAppProfile.Name))
+	}
+
 	vsNode.SetSslKeyAndCertificateRefs(vsSslKeyCertificates)
 	vsNode.SetWafPolicyRef(vsWafPolicy)
 	vsNode.SetHttpPolicySetRefs(vsHTTPPolicySets)
 	vsNode.SetICAPProfileRefs(vsICAPProfile)
 	vsNode.SetAppProfileRef(vsAppProfile)
+	vsNode.SetClientCertAppProfile(clientCertAppProfile)
 	vsNode.SetAnalyticsProfileRef(vsAnalyticsProfile)
 	vsNode.SetErrorPageProfileRef(vsErrorPageProfile)
 	vsNode.SetSSLProfileRef(vsSslProfile)
@@ -251,6 +272,52 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 	vsNode.SetStringGroupRefs(vsStringGroupRefs)
 }
 
+// buildClientCertAppProfile builds the application profile, which verifies the client certificates of the host
+// against the CA certificate in the HostRule. The profile is a clone of the application profile in the HostRule,
+// or of the default secure HTTP profile, with the client certificate settings overlaid.
+// A CA certificate in a Secret is rendered as a PKI profile owned by AKO.
+func buildClientCertAppProfile(hostrule *akov1beta1.HostRule, vsNode AviVsEvhSniModel, host, key string) *AviApplicationProfileNode {
+	clientCertificate := hostrule.Spec.VirtualHost.TLS.ClientCertificate
+	markers := lib.PopulateVSNodeMarkers(hostrule.Namespace, host, "")
+	baseProfile := utils.DEFAULT_L7_SECURE_APP_PROFILE
+	if hostrule.Spec.VirtualHost.ApplicationProfile != "" {
+		baseProfile = hostrule.Spec.VirtualHost.ApplicationProfile
+	}
+	appProfile := &AviApplicationProfileNode{
+		Name:                  lib.GetClientAppProfileName(vsNode.GetName()),
+		Tenant:                vsNode.GetTenant(),
+		BaseProfile:           baseProfile,
+		ClientCertificateMode: lib.SSLClientCertificateModeRequire,
+		ForwardClientIdentity: clientCertificate.ForwardClientIdentity,
+		AviMarkers:            markers,
+	}
+	if clientCertificate.Mode == akov1beta1.HostRuleClientCertificateModeRequest {
+		appProfile.ClientCertificateMode = lib.SSLClientCertificateModeRequest
+	}
+
+	if clientCertificate.CACertificate.Type != akov1beta1.HostRuleSecretTypeSecretReference {
+		appProfile.PkiProfileName = clientCertificate.CACertificate.Name
+		return appProfile
+	}
+	secretObj, err := utils.GetInformers().SecretInformer.Lister().Secrets(hostrule.Namespace).Get(clientCertificate.CACertificate.Name)
+	if err != nil || secretObj == nil {
+		utils.AviLog.Warnf("key: %s, msg: CA certificate secret %s not found for hostrule %s/%s: %v", key, clientCertificate.CACertificate.Name, hostrule.Namespace, hostrule.Name, err)
+		return nil
+	}
+	caCert, ok := secretObj.Data[utils.K8S_TLS_SECRET_CA_CERT]
+	if !ok || len(caCert) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: %s not found in CA certificate secret %s for hostrule %s/%s", key, utils.K8S_TLS_SECRET_CA_CERT, clientCertificate.CACertificate.Name, hostrule.Namespace, hostrule.Name)
+		return nil
+	}
+	appProfile.PkiProfile = &AviPkiProfileNode{
+		Name:       lib.GetClientPKIProfileName(vsNode.GetName()),
+		Tenant:     vsNode.GetTenant(),
+		CACert:     string(caCert),
+		AviMarkers: markers,
+	}
+	return appProfile
+}
+
 func BuildRegexAppRootForHostRule(hostrule *akov1beta1.HostRule, vsNode AviVsEvhSniModel, host, key string) []*AviStringGroupNode {
 	var vsStringGroupRefs []*AviStringGroupNode
 
@@ -485,6 +552,10 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 				pool.PkiProfile = destinationCertNode
 				pool.HealthMonitorRefs = pathHMs
 				pool.ApplicationPersistenceProfileRef = persistenceProfile
+				if persistenceProfile != nil {
+					// The persistence set via HTTPRule takes precedence over the sessionAffinity of the Service.
+					pool.PersistenceProfile = nil
+				}
 
 				// from this path, generate refs to this pool node
 				if httpRulePath.LoadBalancerPolicy.Algorithm != "" {
@@ -163,7 +163,7 @@ func DequeueIngestion(key string, fullsync bool) {
 	// Push Services from InfraSetting updates. Valid for annotation based approach.
 	if objType == lib.AviInfraSetting && !lib.UseServicesAPI() && !lib.IsWCP() {
 		svcNames, svcFound := schema.GetParentServices(name, namespace, key)
-		if svcFound && utils.CheckIfNamespaceAccepted(namespace) {
+		if svcFound && lib.IsNamespaceSynced(namespace) {
 			for _, svcNSNameKey := range svcNames {
 				handleL4Service(utils.L4LBService+"/"+svcNSNameKey, fullsync)
 			}
@@ -173,7 +173,7 @@ func DequeueIngestion(key string, fullsync bool) {
 	// L4Rule CRD processing.
 	if objType == lib.L4Rule {
 
-		if !utils.CheckIfNamespaceAccepted(namespace) {
+		if !lib.IsNamespaceSynced(namespace) {
 			utils.AviLog.Debugf("key: %s, msg: namespace of l4rule is not in accepted state", key)
 			return
 		}
@@ -206,7 +206,7 @@ func DequeueIngestion(key string, fullsync bool) {
 		// If ingress is not found, let's do the other checks.
 		if objType == lib.SharedVipServiceKey {
 			sharedVipKeys, keysFound := schema.GetParentServices(name, namespace, key)
-			if keysFound && utils.CheckIfNamespaceAccepted(namespace) {
+			if keysFound && lib.IsNamespaceSynced(namespace) {
 				for _, sharedVipKey := range sharedVipKeys {
 					handleL4SharedVipService(sharedVipKey, key, fullsync)
 				}
@@ -222,7 +222,7 @@ func DequeueIngestion(key string, fullsync bool) {
 			}
 
 			// Do not handle service update if it belongs to unaccepted namespace or if associated LBService is under different LBClass
-			if svcObj.Spec.Type == utils.LoadBalancer && !lib.GetLayer7Only() && utils.CheckIfNamespaceAccepted(namespace) && lib.ValidateSvcforClass(key, svcObj) {
+			if svcObj.Spec.Type == utils.LoadBalancer && !lib.GetLayer7Only() && lib.IsNamespaceSynced(namespace) && lib.ValidateSvcforClass(key, svcObj) {
 				// This endpoint update affects a LB service.
 				aviModelGraph := NewAviObjectGraph()
 				if sharedVipKey, ok := svcObj.Annotations[lib.SharedVipSvcLBAnnotation]; ok && sharedVipKey != "" {
@@ -504,7 +504,7 @@ func isGatewayDelete(gatewayKey, key string) bool {
 		}
 	} else if lib.UseServicesAPI() {
 		// If namespace is not accepted, return true to delete model
-		if !utils.CheckIfNamespaceAccepted(namespace) {
+		if !lib.IsNamespaceSynced(namespace) {
 			return true
 		}
 
@@ -685,7 +685,7 @@ func handleL4Service(key string, fullsync bool) {
 	}
 	_, namespace, name := lib.ExtractTypeNameNamespace(key)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-	if deleteCase := isServiceDelete(name, namespace, key); !deleteCase && utils.CheckIfNamespaceAccepted(namespace) {
+	if deleteCase := isServiceDelete(name, namespace, key); !deleteCase && lib.IsNamespaceSynced(namespace) {
 		// If Service is Not Annotated with NPL annotation, annotate the service and return.
 		if lib.AutoAnnotateNPLSvc() {
 			if !status.CheckNPLSvcAnnotation(key, namespace, name) {
@@ -789,6 +789,7 @@ func saveAviModel(modelName string, aviGraph *AviObjectGraph, key string) bool {
 		utils.AviLog.Infof("key: %s, msg: Disable Sync is True, model %s can not be saved", key, modelName)
 		return false
 	}
+	aviGraph.ExcludeLongerPathsFromPoolPolicies()
 	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
 	if found && aviModel != nil {
 		prevChecksum := aviModel.(*AviObjectGraph).GraphChecksum
@@ -854,8 +855,14 @@ func processNodeObj(key, nodename string, sharedQueue *utils.WorkerQueue, fullsy
 }
 
 func PublishKeyToRestLayer(modelName string, key string, sharedQueue *utils.WorkerQueue) {
+	PublishKeyToRestLayerWithPriority(modelName, key, sharedQueue, utils.PriorityInteractive)
+}
+
+// PublishKeyToRestLayerWithPriority publishes the model to the lane of the priority in the rest layer queue, so that
+// the models published by a full sync or a retry do not delay the models published for the changes made by the users.
+func PublishKeyToRestLayerWithPriority(modelName string, key string, sharedQueue *utils.WorkerQueue, priority utils.QueuePriority) {
 	bkt := utils.Bkt(modelName, sharedQueue.NumWorkers)
-	sharedQueue.Workqueue[bkt].AddRateLimited(modelName)
+	sharedQueue.Workqueue[bkt].AddRateLimitedWithPriority(modelName, priority)
 	lib.IncrementQueueCounter(utils.GraphLayer)
 	utils.AviLog.Infof("key: %s, msg: Published key with modelName: %s", key, modelName)
 }
@@ -35,6 +35,13 @@ func SharedHostNameLister() *HostNameLister {
 			HostNamePathStore: HostNamePathStore{
 				hostNamePathStore: objects.NewObjectMapStore(),
 			},
+			DefaultBackendStore: DefaultBackendStore{
+				modelToIngressStore: objects.NewObjectMapStore(),
+				ingressToModelStore: objects.NewObjectMapStore(),
+			},
+			CanaryIngressStore: CanaryIngressStore{
+				canaryToHostStore: objects.NewObjectMapStore(),
+			},
 		}
 	})
 	return hostNameListerInstance
@@ -44,6 +51,8 @@ type HostNameLister struct {
 	secureHostNameStore *objects.ObjectMapStore
 	namespaceStore      *objects.ObjectMapStore
 	HostNamePathStore
+	DefaultBackendStore
+	CanaryIngressStore
 }
 
 func (a *HostNameLister) Save(hostname string, hsGraph SecureHostNameMapProp) {
@@ -172,6 +181,97 @@ func (h *HostNamePathStore) DeleteHostPathStore(host string) {
 	h.hostNamePathStore.Delete(host)
 }
 
+// DefaultBackendStore keeps the ingresses with a default backend for each model, and the models of each such ingress.
+// cache sample: modelName -> [ns1/ingress1, ns2/ingress2], ns1/ingress1 -> [modelName]
+type DefaultBackendStore struct {
+	sync.RWMutex
+	modelToIngressStore *objects.ObjectMapStore
+	ingressToModelStore *objects.ObjectMapStore
+}
+
+func (d *DefaultBackendStore) GetModelToDefaultBackendIngresses(modelName string) []string {
+	d.RLock()
+	defer d.RUnlock()
+	ok, obj := d.modelToIngressStore.Get(modelName)
+	if !ok {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+func (d *DefaultBackendStore) GetIngressToDefaultBackendModels(ing string) []string {
+	d.RLock()
+	defer d.RUnlock()
+	ok, obj := d.ingressToModelStore.Get(ing)
+	if !ok {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+// UpdateDefaultBackendMappings replaces the models of the ingress default backend, and returns the models
+// which no longer have the default backend of this ingress.
+func (d *DefaultBackendStore) UpdateDefaultBackendMappings(ing string, modelNames []string) []string {
+	d.Lock()
+	defer d.Unlock()
+	var oldModelNames, staleModelNames []string
+	if ok, obj := d.ingressToModelStore.Get(ing); ok {
+		oldModelNames = obj.([]string)
+	}
+	for _, modelName := range oldModelNames {
+		if utils.HasElem(modelNames, modelName) {
+			continue
+		}
+		staleModelNames = append(staleModelNames, modelName)
+		if ok, obj := d.modelToIngressStore.Get(modelName); ok {
+			ingresses := utils.Remove(append([]string{}, obj.([]string)...), ing)
+			if len(ingresses) == 0 {
+				d.modelToIngressStore.Delete(modelName)
+			} else {
+				d.modelToIngressStore.AddOrUpdate(modelName, ingresses)
+			}
+		}
+	}
+	for _, modelName := range modelNames {
+		var ingresses []string
+		if ok, obj := d.modelToIngressStore.Get(modelName); ok {
+			ingresses = obj.([]string)
+		}
+		if !utils.HasElem(ingresses, ing) {
+			d.modelToIngressStore.AddOrUpdate(modelName, append(ingresses, ing))
+		}
+	}
+	if len(modelNames) == 0 {
+		d.ingressToModelStore.Delete(ing)
+	} else {
+		d.ingressToModelStore.AddOrUpdate(ing, modelNames)
+	}
+	return staleModelNames
+}
+
+// CanaryIngressStore keeps the hosts of each canary ingress, so that the ingresses with the same hosts
+// are processed again when the canary ingress is deleted.
+// cache sample: ns1/canary-ingress1 -> [host1, host2]
+type CanaryIngressStore struct {
+	canaryToHostStore *objects.ObjectMapStore
+}
+
+func (c *CanaryIngressStore) GetCanaryIngressHosts(ing string) []string {
+	ok, obj := c.canaryToHostStore.Get(ing)
+	if !ok {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+func (c *CanaryIngressStore) UpdateCanaryIngressHosts(ing string, hosts []string) {
+	if len(hosts) == 0 {
+		c.canaryToHostStore.Delete(ing)
+		return
+	}
+	c.canaryToHostStore.AddOrUpdate(ing, hosts)
+}
+
 func PopulateIngHostMap(namespace, hostName, ingName, secretName string, pathsvcMap HostMetadata) {
 	hostMap := HostNamePathSecrets{paths: getPaths(pathsvcMap.ingressHPSvc), secretName: secretName}
 	found, ingressHostMap := SharedHostNameLister().Get(hostName)
@@ -89,6 +89,10 @@ var (
 		GetParentGateways:              SecretToGateway,
 		GetParentMultiClusterIngresses: SecretToMultiClusterIng,
 	}
+	ConfigMap = GraphSchema{
+		Type:               utils.ConfigMap,
+		GetParentIngresses: ConfigMapToIng,
+	}
 	Route = GraphSchema{
 		Type:            utils.OshiftRoute,
 		GetParentRoutes: RouteChanges,
@@ -141,6 +145,7 @@ var (
 		Endpoint,
 		EndpointSlices,
 		Secret,
+		ConfigMap,
 		Route,
 		Node,
 		HostRule,
@@ -322,6 +327,8 @@ func GWClassToGateway(gwClassName string, namespace string, key string) ([]strin
 func IngressChanges(ingName string, namespace string, key string) ([]string, bool) {
 	var ingresses []string
 	ingresses = append(ingresses, ingName)
+	// The backends of a canary ingress are added to the ingresses having the same hosts.
+	ingresses = append(ingresses, getIngressesForCanary(namespace, ingName, key)...)
 	ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName)
 
 	if err != nil {
@@ -453,7 +460,7 @@ func SvcToIng(svcName string, namespace string, key string) ([]string, bool) {
 			if len(ingresses) == 0 {
 				objects.SharedSvcLister().IngressMappings(namespace).DeleteSvcToIngMapping(svcName)
 			}
-			return ingresses, true
+			return getIngressesForCanaryServices(namespace, ingresses, key), true
 		}
 		return nil, false
 	}
@@ -488,7 +495,7 @@ func SvcToIng(svcName string, namespace string, key string) ([]string, bool) {
 			return nil, false
 		}
 	}
-	return ingresses, true
+	return getIngressesForCanaryServices(namespace, ingresses, key), true
 }
 
 func NodeToIng(nodeName string, namespace string, key string) ([]string, bool) {
@@ -541,14 +548,79 @@ func EPToIng(epName string, namespace string, key string) ([]string, bool) {
 }
 
 func SecretToIng(secretName string, namespace string, key string) ([]string, bool) {
-	ok, ingNames := objects.SharedSvcLister().IngressMappings(namespace).GetSecretToIng(secretName)
+	_, ingNames := objects.SharedSvcLister().IngressMappings(namespace).GetSecretToIng(secretName)
+	for _, ing := range hostRuleCASecretToIng(secretName, namespace, key) {
+		if !utils.HasElem(ingNames, ing) {
+			ingNames = append(ingNames, ing)
+		}
+	}
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingNames)
-	if ok {
+	if len(ingNames) > 0 {
 		return ingNames, true
 	}
 	return nil, false
 }
 
+// hostRuleCASecretToIng returns the Ingresses of the hosts, whose accepted HostRules refer to the Secret
+// for the CA certificate of the client certificate verification.
+func hostRuleCASecretToIng(secretName string, namespace string, key string) []string {
+	var ingNames []string
+	if lib.AKOControlConfig().CRDInformers().HostRuleInformer == nil {
+		return ingNames
+	}
+	hostRules, err := lib.AKOControlConfig().CRDInformers().HostRuleInformer.Lister().HostRules(namespace).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: failed to list hostrules in namespace %s, err: %v", key, namespace, err)
+		return ingNames
+	}
+	for _, hostRule := range hostRules {
+		clientCert := hostRule.Spec.VirtualHost.TLS.ClientCertificate
+		if hostRule.Status.Status != lib.StatusAccepted || clientCert == nil ||
+			clientCert.CACertificate.Type != akov1beta1.HostRuleSecretTypeSecretReference ||
+			clientCert.CACertificate.Name != secretName {
+			continue
+		}
+		fqdnType := string(hostRule.Spec.VirtualHost.FqdnType)
+		if fqdnType == "" {
+			fqdnType = string(akov1beta1.Exact)
+		}
+		for _, host := range SharedHostNameLister().GetHostsFromHostPathStore(hostRule.Spec.VirtualHost.Fqdn, fqdnType) {
+			ok, obj := SharedHostNameLister().GetHostPathStore(host)
+			if !ok {
+				continue
+			}
+			for _, ingresses := range obj {
+				for _, ing := range ingresses {
+					if !utils.HasElem(ingNames, ing) {
+						ingNames = append(ingNames, ing)
+					}
+				}
+			}
+		}
+	}
+	return ingNames
+}
+
+// ConfigMapToIng returns the Ingresses of a namespace, whose custom-headers annotation refers to a ConfigMap.
+func ConfigMapToIng(configMapName string, namespace string, key string) ([]string, bool) {
+	if !lib.IsNginxAnnotationsEnabled() {
+		return nil, false
+	}
+	ingresses, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: failed to list ingresses in namespace %s, err: %v", key, namespace, err)
+		return nil, false
+	}
+	var ingNames []string
+	for _, ingress := range ingresses {
+		if isNginxCustomHeadersConfigMap(ingress, configMapName) {
+			ingNames = append(ingNames, ingress.Name)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingNames)
+	return ingNames, len(ingNames) > 0
+}
+
 func SecretToRoute(secretName string, namespace string, key string) ([]string, bool) {
 	ok, ingNames := objects.OshiftRouteSvcLister().IngressMappings(namespace).GetSecretToIng(secretName)
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingNames)
@@ -979,6 +1051,9 @@ func parseServicesForIngress(ingSpec networkingv1.IngressSpec, key string) []str
 			}
 		}
 	}
+	if ingSpec.DefaultBackend != nil && ingSpec.DefaultBackend.Service != nil {
+		services = append(services, ingSpec.DefaultBackend.Service.Name)
+	}
 	utils.AviLog.Debugf("key: %s, msg: total services retrieved from corev1: %s", key, services)
 	return services
 }
@@ -0,0 +1,547 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// nginxAnnotations are the ingress-nginx annotations of an Ingress, which are translated to the settings of
+// its pools and to an HTTP policy set per pool.
+type nginxAnnotations struct {
+	rewriteTarget    string
+	sourceRanges     []*avimodels.IPAddrPrefix
+	cookieAffinity   bool
+	cookieName       string
+	cookieTimeout    int32
+	backendProtocol  string
+	upstreamVhost    string
+	xForwardedPrefix string
+	// clientMaxBodySize is the maximum size of the request body in KB, 0 allows any size.
+	clientMaxBodySize *int64
+	customHeaders     string
+}
+
+// nginxAnnotationWarning is raised as an event on the Ingress, for an annotation which can not be honoured.
+type nginxAnnotationWarning struct {
+	annotation string
+	reason     string
+	message    string
+}
+
+// nginxUnsupportedAnnotations are the commonly used ingress-nginx annotations, which do not have a per Ingress
+// equivalent in AKO, along with the alternative.
+var nginxUnsupportedAnnotations = map[string]string{
+	lib.NginxConfigurationSnippet: "nginx configuration snippets can not be translated, an HTTP policy set or a DataScript can be attached using a HostRule",
+}
+
+// parseNginxAnnotations returns the ingress-nginx annotations which are translated by AKO, and the warnings for the
+// ones which can not be honoured.
+func parseNginxAnnotations(annotations map[string]string) (nginxAnnotations, []nginxAnnotationWarning) {
+	var nginx nginxAnnotations
+	var warnings []nginxAnnotationWarning
+	unsupported := func(annotation, message string) {
+		warnings = append(warnings, nginxAnnotationWarning{annotation: annotation, reason: lib.UnsupportedAnnotation, message: message})
+	}
+
+	for annotation, value := range annotations {
+		if !strings.HasPrefix(annotation, lib.NginxAnnotationPrefix) {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch annotation {
+		case lib.NginxRewriteTarget:
+			if strings.Contains(value, "$") {
+				unsupported(annotation, "capture groups of regular expression paths are not supported in the rewrite target")
+			} else {
+				nginx.rewriteTarget = value
+			}
+		case lib.NginxSSLRedirect:
+			if _, err := strconv.ParseBool(value); err != nil {
+				unsupported(annotation, fmt.Sprintf("invalid value %s", value))
+			}
+		case lib.NginxForceSSLRedirect:
+			// The redirect to HTTPS is added for all the hosts with TLS, and can not be forced for the other hosts.
+			if _, err := strconv.ParseBool(value); err != nil {
+				unsupported(annotation, fmt.Sprintf("invalid value %s", value))
+			}
+		case lib.NginxWhitelistSourceRange, lib.NginxAllowlistSourceRange:
+			for _, sourceRange := range strings.Split(value, ",") {
+				prefix, err := getIPAddrPrefix(strings.TrimSpace(sourceRange))
+				if err != nil {
+					warnings = append(warnings, nginxAnnotationWarning{annotation: annotation, reason: lib.InvalidSourceRange,
+						message: fmt.Sprintf("invalid source range %s, ignoring it: %v", sourceRange, err)})
+					continue
+				}
+				nginx.sourceRanges = append(nginx.sourceRanges, prefix)
+			}
+		case lib.NginxAffinity:
+			if value == "cookie" {
+				nginx.cookieAffinity = true
+			} else {
+				unsupported(annotation, fmt.Sprintf("affinity %s is not supported, only cookie affinity is supported", value))
+			}
+		case lib.NginxSessionCookieName:
+			nginx.cookieName = value
+		case lib.NginxSessionCookieMaxAge, lib.NginxSessionCookieExpires:
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				unsupported(annotation, fmt.Sprintf("invalid value %s", value))
+				continue
+			}
+			// session-cookie-max-age takes precedence over session-cookie-expires, as in ingress-nginx.
+			if annotation == lib.NginxSessionCookieExpires {
+				if _, ok := annotations[lib.NginxSessionCookieMaxAge]; ok {
+					continue
+				}
+			}
+			nginx.cookieTimeout = getHTTPCookiePersistenceTimeout(seconds)
+		case lib.NginxBackendProtocol:
+			protocol := strings.ToUpper(value)
+			if protocol == "HTTP" || protocol == "HTTPS" {
+				nginx.backendProtocol = protocol
+			} else {
+				unsupported(annotation, fmt.Sprintf("backend protocol %s is not supported, only HTTP and HTTPS are supported", value))
+			}
+		case lib.NginxUpstreamVhost:
+			nginx.upstreamVhost = value
+		case lib.NginxXForwardedPrefix:
+			nginx.xForwardedPrefix = value
+		case lib.NginxProxyBodySize:
+			clientMaxBodySize, err := getClientMaxBodySize(value)
+			if err != nil {
+				unsupported(annotation, fmt.Sprintf("invalid value %s", value))
+				continue
+			}
+			nginx.clientMaxBodySize = &clientMaxBodySize
+		case lib.NginxCustomHeaders:
+			nginx.customHeaders = value
+		case lib.NginxCanary, lib.NginxCanaryWeight, lib.NginxCanaryByHeader, lib.NginxCanaryByHeaderValue, lib.NginxCanaryByCookie:
+			// The canary annotations are translated along with the backends of the canary ingress.
+		default:
+			if message, ok := nginxUnsupportedAnnotations[annotation]; ok {
+				unsupported(annotation, message)
+			} else {
+				unsupported(annotation, "annotation is not supported by AKO")
+			}
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].annotation < warnings[j].annotation
+	})
+	return nginx, warnings
+}
+
+// getIPAddrPrefix parses a source range of the ingress-nginx annotations, which is either a CIDR or an IP address.
+func getIPAddrPrefix(sourceRange string) (*avimodels.IPAddrPrefix, error) {
+	_, ipNet, err := net.ParseCIDR(sourceRange)
+	if err != nil {
+		ip := net.ParseIP(sourceRange)
+		if ip == nil {
+			return nil, err
+		}
+		bits := 128
+		if ip.To4() != nil {
+			bits = 32
+		}
+		ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+	}
+	addr := ipNet.IP.String()
+	addrType := "V4"
+	if ipNet.IP.To4() == nil {
+		addrType = "V6"
+	}
+	ones, _ := ipNet.Mask.Size()
+	return &avimodels.IPAddrPrefix{
+		IPAddr: &avimodels.IPAddr{Addr: &addr, Type: &addrType},
+		Mask:   proto.Int32(int32(ones)),
+	}, nil
+}
+
+// getClientMaxBodySize converts a size of the ingress-nginx annotations, which is in bytes or has one of the
+// k, m and g suffixes, to KB.
+func getClientMaxBodySize(size string) (int64, error) {
+	multiplier := int64(1)
+	if size != "" {
+		switch size[len(size)-1] {
+		case 'k', 'K':
+			multiplier = 1 << 10
+		case 'm', 'M':
+			multiplier = 1 << 20
+		case 'g', 'G':
+			multiplier = 1 << 30
+		}
+	}
+	if multiplier != 1 {
+		size = size[:len(size)-1]
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("negative size %d", value)
+	}
+	return (value*multiplier + 1023) / 1024, nil
+}
+
+// getNginxCustomHeaders returns the response headers in the ConfigMap referred by the custom-headers annotation of
+// an Ingress, which is of the form <name>, or <namespace>/<name> with the namespace of the Ingress. The ConfigMaps
+// of the other namespaces are not read, so that an Ingress can not add their data to its responses.
+func getNginxCustomHeaders(namespace, configMapRef string) (map[string]string, error) {
+	name := configMapRef
+	if ns, n, found := strings.Cut(configMapRef, "/"); found {
+		if ns != namespace {
+			return nil, fmt.Errorf("ConfigMap is not in the namespace %s of the ingress", namespace)
+		}
+		name = n
+	}
+	if utils.GetInformers().NginxConfigMapInformer == nil {
+		return nil, fmt.Errorf("ConfigMap informer is not initialized")
+	}
+	configMap, err := utils.GetInformers().NginxConfigMapInformer.Lister().ConfigMaps(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return configMap.Data, nil
+}
+
+// isNginxCustomHeadersConfigMap returns true if the custom-headers annotation of an Ingress refers to a ConfigMap
+// in the namespace of the Ingress.
+func isNginxCustomHeadersConfigMap(ingress *networkingv1.Ingress, configMapName string) bool {
+	configMapRef := strings.TrimSpace(ingress.Annotations[lib.NginxCustomHeaders])
+	return configMapRef == configMapName || configMapRef == ingress.Namespace+"/"+configMapName
+}
+
+// getHTTPCookiePersistenceTimeout converts the lifetime of the session cookie to minutes, within the range
+// supported for the HTTP cookie persistence timeout.
+func getHTTPCookiePersistenceTimeout(seconds int) int32 {
+	timeout := (seconds + 59) / 60
+	if timeout > lib.MaxHTTPCookiePersistenceTimeout {
+		return lib.MaxHTTPCookiePersistenceTimeout
+	}
+	return int32(timeout)
+}
+
+// isNginxSSLRedirectDisabled returns true if the ssl-redirect annotation of an Ingress allows the HTTP traffic
+// for the hosts with TLS, instead of redirecting it to HTTPS.
+func isNginxSSLRedirectDisabled(annotations map[string]string) bool {
+	if !lib.IsNginxAnnotationsEnabled() {
+		return false
+	}
+	sslRedirect, err := strconv.ParseBool(strings.TrimSpace(annotations[lib.NginxSSLRedirect]))
+	return err == nil && !sslRedirect
+}
+
+// reportNginxAnnotations raises an event on the Ingress for each of its ingress-nginx annotations,
+// which can not be honoured.
+func reportNginxAnnotations(namespace, ingName string, annotations map[string]string, hasTLS bool, key string) {
+	if !lib.IsNginxAnnotationsEnabled() || utils.GetInformers().IngressInformer == nil {
+		return
+	}
+	nginx, warnings := parseNginxAnnotations(annotations)
+	if nginx.customHeaders != "" {
+		if _, err := getNginxCustomHeaders(namespace, nginx.customHeaders); err != nil {
+			warnings = append(warnings, nginxAnnotationWarning{annotation: lib.NginxCustomHeaders, reason: lib.InvalidConfiguration,
+				message: fmt.Sprintf("ConfigMap %s of the custom headers can not be used: %v", nginx.customHeaders, err)})
+		}
+	}
+	if forceSSLRedirect, err := strconv.ParseBool(strings.TrimSpace(annotations[lib.NginxForceSSLRedirect])); err == nil && forceSSLRedirect && !hasTLS {
+		warnings = append(warnings, nginxAnnotationWarning{annotation: lib.NginxForceSSLRedirect, reason: lib.UnsupportedAnnotation,
+			message: "the redirect to HTTPS is added only for the hosts with TLS"})
+	}
+	if len(warnings) == 0 {
+		return
+	}
+	ingress, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName)
+	if err != nil {
+		return
+	}
+	for _, warning := range warnings {
+		utils.AviLog.Warnf("key: %s, msg: annotation %s of ingress %s/%s is not honoured: %s", key, warning.annotation, namespace, ingName, warning.message)
+		lib.AKOControlConfig().EventRecorder().Eventf(ingress, corev1.EventTypeWarning, warning.reason,
+			"Annotation %s is not honoured: %s", warning.annotation, warning.message)
+	}
+}
+
+// BuildNginxAnnotations translates the ingress-nginx annotations of an Ingress to the settings of its pools for
+// a host, and to an HTTP policy set per pool. The pool settings which are set via an HTTPRule take precedence.
+func BuildNginxAnnotations(host, ingName, namespace, key string, vsNode AviVsEvhSniModel) {
+	if !lib.IsNginxAnnotationsEnabled() || utils.GetInformers().IngressInformer == nil {
+		return
+	}
+	ingress, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: ingress %s/%s not found, err: %v", key, namespace, ingName, err)
+		return
+	}
+	nginx, _ := parseNginxAnnotations(ingress.Annotations)
+	var customHeaders map[string]string
+	if nginx.customHeaders != "" {
+		if customHeaders, err = getNginxCustomHeaders(namespace, nginx.customHeaders); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: ConfigMap %s of the custom headers of ingress %s/%s can not be used, err: %v", key, nginx.customHeaders, namespace, ingName, err)
+		}
+	}
+	if nginx.clientMaxBodySize != nil && (vsNode.IsSharedVS() || vsNode.IsDedicatedVS()) {
+		utils.AviLog.Warnf("key: %s, msg: annotation %s of ingress %s/%s is not honoured for host %s, which is not attached to a child virtualservice", key, lib.NginxProxyBodySize, namespace, ingName, host)
+		lib.AKOControlConfig().EventRecorder().Eventf(ingress, corev1.EventTypeWarning, lib.UnsupportedAnnotation,
+			"Annotation %s is not honoured: the request body size is set only for the hosts attached to a child virtualservice", lib.NginxProxyBodySize)
+	}
+	for _, pool := range vsNode.GetPoolRefs() {
+		if pool.AviMarkers.Namespace != namespace || !utils.HasElem(pool.AviMarkers.IngressName, ingName) || !utils.HasElem(pool.AviMarkers.Host, host) {
+			continue
+		}
+		RemoveNginxHTTPPolicy(vsNode, pool.Name)
+		buildPoolWithNginxAnnotations(key, pool, nginx)
+		buildNginxHTTPPolicy(key, vsNode, pool, nginx, customHeaders)
+	}
+}
+
+func buildPoolWithNginxAnnotations(key string, pool *AviPoolNode, nginx nginxAnnotations) {
+	if nginx.backendProtocol == "HTTPS" && !pool.SniEnabled {
+		pool.SniEnabled = true
+		pool.SslProfileRef = proto.String(fmt.Sprintf("/api/sslprofile?name=%s", lib.DefaultPoolSSLProfile))
+		utils.AviLog.Debugf("key: %s, msg: enabled TLS to the backend servers of pool %s", key, pool.Name)
+	}
+	if !nginx.cookieAffinity || pool.ApplicationPersistenceProfileRef != nil {
+		return
+	}
+	if nginx.cookieName == "" && nginx.cookieTimeout == 0 {
+		pool.ApplicationPersistenceProfileRef = proto.String(fmt.Sprintf("/api/applicationpersistenceprofile?name=%s", lib.SystemHTTPCookiePersistenceProfile))
+		pool.PersistenceProfile = nil
+	} else {
+		pool.PersistenceProfile = &AviPersistenceProfileNode{
+			Name:            lib.GetPoolPersistenceProfileName(pool.Name),
+			Tenant:          pool.Tenant,
+			PersistenceType: lib.PersistenceTypeHTTPCookie,
+			Timeout:         nginx.cookieTimeout,
+			CookieName:      nginx.cookieName,
+			AviMarkers:      pool.AviMarkers,
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: attached HTTP cookie persistence to pool %s", key, pool.Name)
+}
+
+// buildNginxHTTPPolicy builds the HTTP policy set of a pool, which denies the clients outside the allowed source
+// ranges, rewrites the requests switched to the pool and adds the custom headers to the responses. The rules match
+// the host and the path of the pool as the path switching rules, and exclude the longer paths of the host.
+func buildNginxHTTPPolicy(key string, vsNode AviVsEvhSniModel, pool *AviPoolNode, nginx nginxAnnotations, customHeaders map[string]string) {
+	policyName := lib.GetNginxHTTPPolicySetName(pool.Name)
+	path := getPoolPath(pool)
+	exact := path != "" && isPoolPathExact(vsNode, pool.Name)
+	getMatchTarget := func() *avimodels.MatchTarget {
+		return &avimodels.MatchTarget{
+			HostHdr: &avimodels.HostHdrMatch{
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				Value:         pool.AviMarkers.Host,
+			},
+			Path: getPathMatch(path, exact),
+		}
+	}
+
+	var requestRules []*avimodels.HTTPRequestRule
+	if len(nginx.sourceRanges) > 0 {
+		matchTarget := getMatchTarget()
+		matchTarget.ClientIP = &avimodels.IPAddrMatch{
+			MatchCriteria: proto.String("IS_NOT_IN"),
+			Prefixes:      nginx.sourceRanges,
+		}
+		requestRules = append(requestRules, &avimodels.HTTPRequestRule{
+			Name:   proto.String(fmt.Sprintf("%s-%d", policyName, len(requestRules))),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(int32(len(requestRules))),
+			Match:  matchTarget,
+			SwitchingAction: &avimodels.HttpswitchingAction{
+				Action:     proto.String("HTTP_SWITCHING_SELECT_LOCAL"),
+				StatusCode: proto.String("HTTP_LOCAL_RESPONSE_STATUS_CODE_403"),
+			},
+		})
+	}
+
+	if nginx.rewriteTarget != "" || nginx.upstreamVhost != "" || nginx.xForwardedPrefix != "" {
+		// The pool is selected in the same rule, so that the rewritten path does not alter the pool selection.
+		requestRule := &avimodels.HTTPRequestRule{
+			Name:   proto.String(fmt.Sprintf("%s-%d", policyName, len(requestRules))),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(int32(len(requestRules))),
+			Match:  getMatchTarget(),
+			SwitchingAction: &avimodels.HttpswitchingAction{
+				Action:  proto.String("HTTP_SWITCHING_SELECT_POOL"),
+				PoolRef: proto.String(fmt.Sprintf("/api/pool/?name=%s", pool.Name)),
+			},
+		}
+		if nginx.rewriteTarget != "" {
+			// The path tokens are joined after the leading slash, the root path is rewritten as is.
+			rewritePath := strings.Trim(nginx.rewriteTarget, "/")
+			if rewritePath == "" {
+				rewritePath = "/"
+			}
+			requestRule.RewriteURLAction = &avimodels.HTTPRewriteURLAction{
+				Path: &avimodels.URIParam{
+					Type: proto.String("URI_PARAM_TYPE_TOKENIZED"),
+					Tokens: []*avimodels.URIParamToken{{
+						StrValue: proto.String(rewritePath),
+						Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+					}},
+				},
+			}
+		}
+		var hdrIndex uint32
+		addHdrAction := func(action, name, value string) {
+			requestRule.HdrAction = append(requestRule.HdrAction, &avimodels.HTTPHdrAction{
+				Action:   proto.String(action),
+				HdrIndex: proto.Uint32(hdrIndex),
+				Hdr: &avimodels.HTTPHdrData{
+					Name:  proto.String(name),
+					Value: &avimodels.HTTPHdrValue{Val: proto.String(value), IsSensitive: proto.Bool(false)},
+				},
+			})
+			hdrIndex++
+		}
+		if nginx.upstreamVhost != "" {
+			addHdrAction("HTTP_REPLACE_HDR", "Host", nginx.upstreamVhost)
+		}
+		if nginx.xForwardedPrefix != "" {
+			addHdrAction("HTTP_ADD_HDR", "X-Forwarded-Prefix", nginx.xForwardedPrefix)
+		}
+		requestRules = append(requestRules, requestRule)
+	}
+
+	var responseRules []*avimodels.HTTPResponseRule
+	if len(customHeaders) > 0 {
+		matchTarget := getMatchTarget()
+		responseRule := &avimodels.HTTPResponseRule{
+			Name:   proto.String(fmt.Sprintf("%s-response-0", policyName)),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(0),
+			Match:  &avimodels.ResponseMatchTarget{HostHdr: matchTarget.HostHdr, Path: matchTarget.Path},
+		}
+		headers := make([]string, 0, len(customHeaders))
+		for header := range customHeaders {
+			headers = append(headers, header)
+		}
+		sort.Strings(headers)
+		for i, header := range headers {
+			responseRule.HdrAction = append(responseRule.HdrAction, &avimodels.HTTPHdrAction{
+				Action:   proto.String("HTTP_REPLACE_HDR"),
+				HdrIndex: proto.Uint32(uint32(i)),
+				Hdr: &avimodels.HTTPHdrData{
+					Name:  proto.String(header),
+					Value: &avimodels.HTTPHdrValue{Val: proto.String(customHeaders[header]), IsSensitive: proto.Bool(false)},
+				},
+			})
+		}
+		responseRules = append(responseRules, responseRule)
+	}
+
+	if len(requestRules) == 0 && len(responseRules) == 0 {
+		return
+	}
+	policyNode := &AviHttpPolicySetNode{
+		Name:          policyName,
+		Tenant:        vsNode.GetTenant(),
+		RequestRules:  requestRules,
+		ResponseRules: responseRules,
+		PoolPathScope: pool.Name,
+		AviMarkers:    lib.PopulateHTTPPolicysetNodeMarkers(pool.AviMarkers.Namespace, pool.AviMarkers.Host[0], pool.AviMarkers.InfrasettingName, pool.AviMarkers.IngressName, pool.AviMarkers.Path),
+	}
+	vsNode.SetHttpPolicyRefs(append(vsNode.GetHttpPolicyRefs(), policyNode))
+	utils.AviLog.Debugf("key: %s, msg: attached HTTP policy set %s for the ingress-nginx annotations of pool %s", key, policyName, pool.Name)
+}
+
+// BuildNginxAppProfile overlays the request body size of the ingress-nginx annotations of the Ingresses of a host on
+// the application profile owned by AKO. When the Ingresses set different sizes, the largest one is used. The profile
+// is cloned from the application profile of the HostRule, or from the default profile of the virtualservice, unless
+// it is already built for the client certificate authentication. The size is set only on the child virtualservices.
+func BuildNginxAppProfile(host, key string, vsNode AviVsEvhSniModel, appProfile *AviApplicationProfileNode, baseProfile string) *AviApplicationProfileNode {
+	if !lib.IsNginxAnnotationsEnabled() || utils.GetInformers().IngressInformer == nil || vsNode.IsSharedVS() || vsNode.IsDedicatedVS() {
+		return appProfile
+	}
+	var clientMaxBodySize *int64
+	var namespace string
+	ingresses := make(map[string]bool)
+	for _, pool := range vsNode.GetPoolRefs() {
+		if !utils.HasElem(pool.AviMarkers.Host, host) {
+			continue
+		}
+		for _, ingName := range pool.AviMarkers.IngressName {
+			ingKey := pool.AviMarkers.Namespace + "/" + ingName
+			if ingresses[ingKey] {
+				continue
+			}
+			ingresses[ingKey] = true
+			ingress, err := utils.GetInformers().IngressInformer.Lister().Ingresses(pool.AviMarkers.Namespace).Get(ingName)
+			if err != nil {
+				continue
+			}
+			nginx, _ := parseNginxAnnotations(ingress.Annotations)
+			if nginx.clientMaxBodySize == nil {
+				continue
+			}
+			if clientMaxBodySize == nil || *clientMaxBodySize != 0 && (*nginx.clientMaxBodySize == 0 || *nginx.clientMaxBodySize > *clientMaxBodySize) {
+				clientMaxBodySize = nginx.clientMaxBodySize
+				namespace = pool.AviMarkers.Namespace
+			}
+		}
+	}
+	if clientMaxBodySize == nil {
+		return appProfile
+	}
+
+	if appProfile == nil {
+		if baseProfile == "" {
+			baseProfile = utils.DEFAULT_L7_SECURE_APP_PROFILE
+			if evhNode, ok := vsNode.(*AviEvhVsNode); ok && evhNode.ApplicationProfile != "" {
+				baseProfile = evhNode.ApplicationProfile
+			}
+		}
+		appProfile = &AviApplicationProfileNode{
+			Name:        lib.GetClientAppProfileName(vsNode.GetName()),
+			Tenant:      vsNode.GetTenant(),
+			BaseProfile: baseProfile,
+			AviMarkers:  lib.PopulateVSNodeMarkers(namespace, host, ""),
+		}
+	}
+	appProfile.ClientMaxBodySize = clientMaxBodySize
+	utils.AviLog.Debugf("key: %s, msg: client max body size of application profile %s set to %d KB", key, appProfile.Name, *clientMaxBodySize)
+	return appProfile
+}
+
+// RemoveNginxHTTPPolicy removes the HTTP policy set built from the ingress-nginx annotations for a pool.
+func RemoveNginxHTTPPolicy(vsNode AviVsEvhSniModel, poolName string) {
+	if !lib.IsNginxAnnotationsEnabled() {
+		return
+	}
+	policyName := lib.GetNginxHTTPPolicySetName(poolName)
+	httpPolicyRefs := vsNode.GetHttpPolicyRefs()
+	for i, policy := range httpPolicyRefs {
+		if policy.Name == policyName {
+			vsNode.SetHttpPolicyRefs(append(httpPolicyRefs[:i], httpPolicyRefs[i+1:]...))
+			return
+		}
+	}
+}
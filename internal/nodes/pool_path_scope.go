@@ -0,0 +1,179 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// getPoolPath returns the path of a pool, which is empty for a pool of all the paths of the host.
+func getPoolPath(pool *AviPoolNode) string {
+	if len(pool.AviMarkers.Path) == 0 {
+		return ""
+	}
+	return pool.AviMarkers.Path[0]
+}
+
+// isPoolPathExact returns true if the requests are switched to a pool by an exact match of its path, as for an Exact
+// path of an Ingress. The paths of the pools of the shared virtualservice, selected by the datascript, are prefixes.
+func isPoolPathExact(vsNode AviVsEvhSniModel, poolName string) bool {
+	poolRef := fmt.Sprintf("/api/pool?name=%s", poolName)
+	for _, policy := range vsNode.GetHttpPolicyRefs() {
+		for _, hppMap := range policy.HppMap {
+			if hppMap.Pool == poolName {
+				return hppMap.MatchCriteria == "EQUALS"
+			}
+			if hppMap.PoolGroup == "" {
+				continue
+			}
+			for _, pgNode := range vsNode.GetPoolGroupRefs() {
+				if pgNode.Name != hppMap.PoolGroup {
+					continue
+				}
+				for _, member := range pgNode.Members {
+					if member.PoolRef != nil && *member.PoolRef == poolRef {
+						return hppMap.MatchCriteria == "EQUALS"
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// getPathMatch returns the match of a path, with the same criteria as the path switching rules.
+func getPathMatch(path string, exact bool) *avimodels.PathMatch {
+	if path == "" {
+		return nil
+	}
+	matchCriteria := "BEGINS_WITH"
+	if exact {
+		matchCriteria = "EQUALS"
+	}
+	return &avimodels.PathMatch{
+		MatchCriteria: proto.String(matchCriteria),
+		MatchCase:     proto.String("SENSITIVE"),
+		MatchStr:      []string{path},
+	}
+}
+
+// getLongerPathMatches returns the matches of the paths of the host of a pool, which begin with the path of the pool
+// and are switched to the other pools.
+func getLongerPathMatches(vsNode AviVsEvhSniModel, pool *AviPoolNode) []*avimodels.PathMatch {
+	path := getPoolPath(pool)
+	if len(pool.AviMarkers.Host) == 0 || path != "" && isPoolPathExact(vsNode, pool.Name) {
+		return nil
+	}
+	matches := make(map[string]*avimodels.PathMatch)
+	for _, otherPool := range vsNode.GetPoolRefs() {
+		otherPath := getPoolPath(otherPool)
+		if otherPath == path || !strings.HasPrefix(otherPath, path) || !utils.HasElem(otherPool.AviMarkers.Host, pool.AviMarkers.Host[0]) {
+			continue
+		}
+		match := getPathMatch(otherPath, isPoolPathExact(vsNode, otherPool.Name))
+		matches[*match.MatchCriteria+otherPath] = match
+	}
+	pathMatches := make([]*avimodels.PathMatch, 0, len(matches))
+	for _, match := range matches {
+		pathMatches = append(pathMatches, match)
+	}
+	sort.Slice(pathMatches, func(i, j int) bool {
+		if pathMatches[i].MatchStr[0] != pathMatches[j].MatchStr[0] {
+			return pathMatches[i].MatchStr[0] > pathMatches[j].MatchStr[0]
+		}
+		return *pathMatches[i].MatchCriteria > *pathMatches[j].MatchCriteria
+	})
+	return pathMatches
+}
+
+// excludeLongerPaths prepends a rule without an action for each of the longer paths of the host to the policy sets
+// built for the path of a pool, as the first matching rule ends the evaluation of a policy set. The policy sets of the
+// pools do not apply to the requests which are switched to the other pools, as in the path switching rules. The
+// paths of a host change along with the other Ingresses of the host, so the rules are rebuilt before saving a model.
+func excludeLongerPaths(vsNode AviVsEvhSniModel) {
+	for _, policy := range vsNode.GetHttpPolicyRefs() {
+		if policy.PoolPathScope == "" {
+			continue
+		}
+		excludedCount := len(policy.ExcludedPaths)
+		if len(policy.RequestRules) > excludedCount {
+			policy.RequestRules = policy.RequestRules[excludedCount:]
+		}
+		if len(policy.ResponseRules) > excludedCount {
+			policy.ResponseRules = policy.ResponseRules[excludedCount:]
+		}
+		policy.ExcludedPaths = nil
+		var hosts []string
+		for _, pool := range vsNode.GetPoolRefs() {
+			if pool.Name == policy.PoolPathScope {
+				hosts = pool.AviMarkers.Host
+				policy.ExcludedPaths = getLongerPathMatches(vsNode, pool)
+				break
+			}
+		}
+
+		var requestRules []*avimodels.HTTPRequestRule
+		var responseRules []*avimodels.HTTPResponseRule
+		for i, pathMatch := range policy.ExcludedPaths {
+			hostHdr := &avimodels.HostHdrMatch{MatchCriteria: proto.String("HDR_EQUALS"), Value: hosts}
+			if len(policy.RequestRules) > 0 {
+				requestRules = append(requestRules, &avimodels.HTTPRequestRule{
+					Name:   proto.String(fmt.Sprintf("%s-excluded-%d", policy.Name, i)),
+					Enable: proto.Bool(true),
+					Match:  &avimodels.MatchTarget{HostHdr: hostHdr, Path: pathMatch},
+				})
+			}
+			if len(policy.ResponseRules) > 0 {
+				responseRules = append(responseRules, &avimodels.HTTPResponseRule{
+					Name:   proto.String(fmt.Sprintf("%s-response-excluded-%d", policy.Name, i)),
+					Enable: proto.Bool(true),
+					Match:  &avimodels.ResponseMatchTarget{HostHdr: hostHdr, Path: pathMatch},
+				})
+			}
+		}
+		policy.RequestRules = append(requestRules, policy.RequestRules...)
+		for i, rule := range policy.RequestRules {
+			rule.Index = proto.Int32(int32(i))
+		}
+		policy.ResponseRules = append(responseRules, policy.ResponseRules...)
+		for i, rule := range policy.ResponseRules {
+			rule.Index = proto.Int32(int32(i))
+		}
+	}
+}
+
+// ExcludeLongerPathsFromPoolPolicies rebuilds the rules of the longer paths of the hosts, in the policy sets built
+// for the paths of the pools of the virtualservices of a model.
+func (o *AviObjectGraph) ExcludeLongerPathsFromPoolPolicies() {
+	for _, vsNode := range o.GetAviVS() {
+		excludeLongerPaths(vsNode)
+		for _, sniNode := range vsNode.SniNodes {
+			excludeLongerPaths(sniNode)
+		}
+	}
+	for _, vsNode := range o.GetAviEvhVS() {
+		excludeLongerPaths(vsNode)
+		for _, evhNode := range vsNode.EvhNodes {
+			excludeLongerPaths(evhNode)
+		}
+	}
+}
@@ -83,7 +83,7 @@ func GetOshiftRouteModel(name, namespace, key string) (*OshiftRouteModel, error,
 		namespace: namespace,
 	}
 	processObj := true
-	processObj = utils.CheckIfNamespaceAccepted(namespace)
+	processObj = lib.IsNamespaceSynced(namespace)
 
 	routeObj, err := utils.GetInformers().RouteInformer.Lister().Routes(namespace).Get(name)
 	if err != nil {
@@ -175,7 +175,7 @@ func GetK8sIngressModel(name, namespace, key string) (*K8sIngressModel, error, b
 	if ingObj.GetDeletionTimestamp() != nil {
 		return &ingrModel, err, processObj
 	}
-	processObj = lib.ValidateIngressForClass(key, ingObj) && utils.CheckIfNamespaceAccepted(namespace)
+	processObj = lib.ValidateIngressForClass(key, ingObj) && lib.IsNamespaceSynced(namespace)
 	ingrModel.spec = ingObj.Spec
 	ingrModel.annotations = ingObj.GetAnnotations()
 	ingrModel.infrasetting, err = getL7IngressInfraSetting(key, utils.String(ingObj.Spec.IngressClassName), namespace)
@@ -225,7 +225,7 @@ func (m *K8sIngressModel) GetDiffPathSvc(storedPathSvc map[string][]string, curr
 	}
 	currPathSvcMap := make(map[string][]string)
 	for _, val := range currentPathSvc {
-		currPathSvcMap[val.Path] = append(currPathSvcMap[val.Path], val.ServiceName)
+		currPathSvcMap[val.Path] = append(currPathSvcMap[val.Path], val.getStoredServiceName())
 	}
 	for path, services := range currPathSvcMap {
 		storedServices, ok := pathSvcCopy[path]
@@ -236,7 +236,19 @@ func (m *K8sIngressModel) GetDiffPathSvc(storedPathSvc map[string][]string, curr
 					delete(pathSvcCopy, path)
 				}
 			} else {
-				delete(pathSvcCopy, path)
+				// Pools of canary backends are specific to the service, so the services
+				// no longer referred by the canary ingress are retained for deletion.
+				var staleCanarySvcs []string
+				for _, svc := range lib.Difference(storedServices, services) {
+					if isCanary, _ := isCanaryService(svc); isCanary {
+						staleCanarySvcs = append(staleCanarySvcs, svc)
+					}
+				}
+				if len(staleCanarySvcs) == 0 {
+					delete(pathSvcCopy, path)
+				} else {
+					pathSvcCopy[path] = staleCanarySvcs
+				}
 			}
 		}
 	}
@@ -310,7 +322,7 @@ func GetMultiClusterIngressModel(name, namespace, key string) (RouteIngressModel
 		name:      name,
 		namespace: namespace,
 	}
-	processObj := utils.CheckIfNamespaceAccepted(namespace)
+	processObj := lib.IsNamespaceSynced(namespace)
 
 	ingObj, err := utils.GetInformers().MultiClusterIngressInformer.Lister().MultiClusterIngresses(namespace).Get(name)
 	if err != nil {
@@ -211,6 +211,17 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		passthroughEnabled = strings.EqualFold(val, "true")
 	}
 
+	// The backends of a canary ingress are added to the ingress with the same host and path.
+	if isCanaryIngress(annotations) {
+		validateCanaryIngress(ns, ingName, annotations, key)
+		utils.AviLog.Infof("key: %s, msg: ingress %s is a canary ingress, its backends are added to the ingresses with the same host and path", key, ingName)
+		return ingressConfig
+	}
+	var canaryIngresses []*networkingv1.Ingress
+	if !passthroughEnabled {
+		canaryIngresses = getCanaryIngresses(ns, key)
+	}
+
 	var tlsConfigs []TlsSettings
 	for _, rule := range ingSpec.Rules {
 		var hostPathMapSvcList HostMetadata
@@ -221,11 +232,7 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 				continue
 			} else {
 				// The Host field is empty. Generate a hostName using the sub-domain info
-				if strings.HasPrefix(subDomains[0], ".") {
-					hostName = ingName + "." + ns + subDomains[0]
-				} else {
-					hostName = ingName + "." + ns + "." + subDomains[0]
-				}
+				hostName = getHostNameFromSubDomain(ingName, ns, subDomains[0])
 			}
 		} else {
 			if !v.IsValidHostName(rule.Host) {
@@ -285,7 +292,11 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 				}
 				// for ingress use 100 as default weight
 				hostPathMapSvc.weight = 100
+				canaryPathMapSvc := v.getCanaryBackend(ns, hostName, &hostPathMapSvc, canaryIngresses, key)
 				hostPathMapSvcList.ingressHPSvc = append(hostPathMapSvcList.ingressHPSvc, hostPathMapSvc)
+				if canaryPathMapSvc != nil {
+					hostPathMapSvcList.ingressHPSvc = append(hostPathMapSvcList.ingressHPSvc, *canaryPathMapSvc)
+				}
 			}
 		}
 
@@ -324,6 +335,10 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		return ingressConfig
 	}
 
+	// The ssl-redirect annotation of ingress-nginx allows the HTTP traffic for the hosts with TLS, like the routes
+	// with the Allow insecure edge termination policy.
+	sslRedirect := !isNginxSSLRedirectDisabled(annotations)
+	reportNginxAnnotations(ns, ingName, annotations, len(ingSpec.TLS) > 0, key)
 	for _, tlsSettings := range ingSpec.TLS {
 		tlsHostSvcMap := make(IngressHostMap)
 		tls := TlsSettings{}
@@ -339,12 +354,16 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 			hostSvcMap, ok := hostMap[host]
 			if ok {
 				tlsHostSvcMap[host] = hostSvcMap
-				delete(hostMap, host)
+				if sslRedirect {
+					delete(hostMap, host)
+				} else {
+					ingressConfig.InsecureEdgeTermAllow = true
+				}
 			}
 		}
 		tls.Hosts = tlsHostSvcMap
-		// Always add http -> https redirect rule for secure ingress
-		tls.redirect = true
+		// Always add http -> https redirect rule for secure ingress, unless disabled via the ssl-redirect annotation
+		tls.redirect = sslRedirect
 		tlsConfigs = append(tlsConfigs, tls)
 		// If svc for an ingress gets processed before the ingress itself,
 		// then secret mapping may not be updated, update it here.
@@ -398,10 +417,47 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 
 	ingressConfig.TlsCollection = tlsConfigs
 	ingressConfig.IngressHostMap = hostMap
+	ingressConfig.DefaultBackend = v.ParseDefaultBackendForIngress(ns, ingSpec, key)
 	utils.AviLog.Infof("key: %s, msg: host path config from ingress: %+v", key, utils.Stringify(ingressConfig))
 	return ingressConfig
 }
 
+// ParseDefaultBackendForIngress returns the backend which handles the requests not matching any of the ingress rules.
+// Only service backends are supported.
+func (v *Validator) ParseDefaultBackendForIngress(ns string, ingSpec networkingv1.IngressSpec, key string) *IngressHostPathSvc {
+	if ingSpec.DefaultBackend == nil {
+		return nil
+	}
+	if ingSpec.DefaultBackend.Service == nil {
+		utils.AviLog.Warnf("key: %s, msg: only service backends are supported as the ingress default backend", key)
+		return nil
+	}
+	backend := ingSpec.DefaultBackend.Service
+	defaultBackend := &IngressHostPathSvc{
+		PathType:    networkingv1.PathTypeImplementationSpecific,
+		ServiceName: backend.Name,
+		Port:        backend.Port.Number,
+		PortName:    backend.Port.Name,
+		TargetPort:  v.findTargetPort(backend.Name, ns, &backend.Port, key),
+	}
+	if defaultBackend.PortName == "" {
+		defaultBackend.PortName = v.findPortName(backend.Name, ns, backend.Port.Number, key)
+	}
+	if defaultBackend.Port == 0 {
+		defaultBackend.Port = 80
+	}
+	defaultBackend.weight = 100
+	return defaultBackend
+}
+
+// getHostNameFromSubDomain generates the hostname of an ingress without hosts, using the sub-domain of the cloud.
+func getHostNameFromSubDomain(ingName, ns, subDomain string) string {
+	if strings.HasPrefix(subDomain, ".") {
+		return ingName + "." + ns + subDomain
+	}
+	return ingName + "." + ns + "." + subDomain
+}
+
 func (v *Validator) findTargetPort(serviceName, ns string, serviceBackendPort *networkingv1.ServiceBackendPort, key string) intstr.IntOrString {
 	// Query the service and obtain the targetPort
 	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(serviceName)
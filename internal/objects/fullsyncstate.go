@@ -0,0 +1,88 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// APIs to access the state of the last full sync, which is used to skip the unchanged objects and models in the next full sync.
+
+package objects
+
+import (
+	"sync"
+)
+
+var fullSyncStateInstance *FullSyncStateLister
+var fullSyncStateOnce sync.Once
+
+func SharedFullSyncStateLister() *FullSyncStateLister {
+	fullSyncStateOnce.Do(func() {
+		fullSyncStateInstance = &FullSyncStateLister{
+			ResourceVerStore:   NewObjectMapStore(),
+			GraphChecksumStore: NewObjectMapStore(),
+		}
+	})
+	return fullSyncStateInstance
+}
+
+// FullSyncStateLister holds the resource versions of the kubernetes objects, which were translated in a full sync,
+// and the graph checksums of the models, which were published to the rest layer.
+type FullSyncStateLister struct {
+	ResourceVerStore   *ObjectMapStore
+	GraphChecksumStore *ObjectMapStore
+	lock               sync.RWMutex
+	synced             bool
+}
+
+func (a *FullSyncStateLister) SaveResourceVersion(key, resVer string) {
+	a.ResourceVerStore.AddOrUpdate(key, resVer)
+}
+
+// IsResourceVersionSynced returns true if the object was translated at the resource version in a full sync.
+func (a *FullSyncStateLister) IsResourceVersionSynced(key, resVer string) bool {
+	ok, obj := a.ResourceVerStore.Get(key)
+	return ok && resVer != "" && obj.(string) == resVer
+}
+
+func (a *FullSyncStateLister) SaveGraphChecksum(modelName string, checksum uint32) {
+	a.GraphChecksumStore.AddOrUpdate(modelName, checksum)
+}
+
+// IsGraphChecksumSynced returns true if the model was published to the rest layer with the checksum.
+func (a *FullSyncStateLister) IsGraphChecksumSynced(modelName string, checksum uint32) bool {
+	ok, obj := a.GraphChecksumStore.Get(modelName)
+	return ok && obj.(uint32) == checksum
+}
+
+// SetSynced marks the completion of a full sync, after which the next full sync can skip the unchanged objects.
+func (a *FullSyncStateLister) SetSynced() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.synced = true
+}
+
+func (a *FullSyncStateLister) IsSynced() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.synced
+}
+
+// Reset clears the state, so that the next full sync translates all the objects and publishes all the models.
+func (a *FullSyncStateLister) Reset() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.synced = false
+	for _, store := range []*ObjectMapStore{a.ResourceVerStore, a.GraphChecksumStore} {
+		for _, key := range store.GetAllKeys() {
+			store.Delete(key)
+		}
+	}
+}
@@ -41,9 +41,13 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	var string_groups_to_delete []avicache.NamespaceName
 	var vsvipErr error
 	var publishKey string
+	var passthroughChildToDelete string
 
 	vsKey := avicache.NamespaceName{Namespace: namespace, Name: vsName}
 	aviVsNode := avimodel.GetAviEvhVS()[0]
+	if vs_cache_obj != nil && vs_cache_obj.ServiceMetadataObj.PassthroughChildRef != "" && len(aviVsNode.PassthroughChildNodes) == 0 {
+		passthroughChildToDelete = vs_cache_obj.ServiceMetadataObj.PassthroughChildRef
+	}
 	if avimodel != nil && len(avimodel.GetAviEvhVS()) > 0 {
 		publishKey = avimodel.GetAviEvhVS()[0].Name
 	}
@@ -72,6 +76,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		string_groups_to_delete, rest_ops = rest.StringGroupVsCU(aviVsNode.StringGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		l4pol_to_delete, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -101,6 +106,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.StringGroupVsCU(aviVsNode.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, nil, namespace, rest_ops, key)
 
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuildForEvh(aviVsNode, utils.RestPost, nil, key)
@@ -167,6 +173,61 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 
 	}
 
+	for _, passChildNode := range aviVsNode.PassthroughChildNodes {
+		var rest_ops []*utils.RestOp
+		passChildVSKey := avicache.NamespaceName{Namespace: namespace, Name: passChildNode.Name}
+		passChildVSCacheObj := rest.getVsCacheObj(passChildVSKey, key)
+		utils.AviLog.Debugf("key: %s, msg: processing passthrough node: %s", key, passChildNode.Name)
+		rest_ops = rest.EvhPassthroughChildCU(passChildNode, passChildVSCacheObj, namespace, rest_ops, key)
+		if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, passChildVSKey, avimodel, key, false); !success {
+			return
+		}
+	}
+
+	if passthroughChildToDelete != "" {
+		passChildVSKey := avicache.NamespaceName{Namespace: namespace, Name: passthroughChildToDelete}
+		passChildVSCacheObj := rest.getVsCacheObj(passChildVSKey, key)
+		utils.AviLog.Infof("key: %s, msg: passthrough child %s is no longer present in the model, deleting", key, passthroughChildToDelete)
+		rest.DeleteVSOper(passChildVSKey, passChildVSCacheObj, namespace, key, false, true)
+	}
+}
+
+// EvhPassthroughChildCU creates or updates the L4 passthrough VS attached to an EVH parent along with its
+// pools, poolgroups and datascripts. The VSVIP is owned by the parent and is not processed here.
+func (rest *RestOperations) EvhPassthroughChildCU(passChildNode *nodes.AviVsNode, vsCacheObj *avicache.AviVsCache, namespace string, restOps []*utils.RestOp, key string) []*utils.RestOp {
+	var poolsToDelete []avicache.NamespaceName
+	var pgsToDelete []avicache.NamespaceName
+	var dsToDelete []avicache.NamespaceName
+	if vsCacheObj != nil {
+		utils.AviLog.Debugf("key: %s, msg: Cache Passthrough Node - %s", key, utils.Stringify(vsCacheObj))
+		poolsToDelete, restOps = rest.PoolCU(passChildNode.PoolRefs, vsCacheObj, namespace, restOps, key)
+		pgsToDelete, restOps = rest.PoolGroupCU(passChildNode.PoolGroupRefs, vsCacheObj, namespace, restOps, key)
+		dsToDelete, restOps = rest.DatascriptCU(passChildNode.HTTPDSrefs, vsCacheObj, namespace, restOps, key)
+
+		// The checksums are different, so it should be a PUT call.
+		if vsCacheObj.CloudConfigCksum != strconv.Itoa(int(passChildNode.GetCheckSum())) {
+			restOp := rest.AviVsBuild(passChildNode, utils.RestPut, vsCacheObj, key)
+			if restOp != nil {
+				restOps = append(restOps, restOp...)
+			}
+			utils.AviLog.Debugf("key: %s, msg: the checksums are different for passthrough child %s, operation: PUT", key, passChildNode.Name)
+		}
+		restOps = rest.DSDelete(dsToDelete, namespace, restOps, key)
+		restOps = rest.PoolGroupDelete(pgsToDelete, namespace, restOps, key)
+		restOps = rest.PoolDelete(poolsToDelete, namespace, restOps, key)
+	} else {
+		utils.AviLog.Infof("key: %s, msg: passthrough Child %s not found in cache", key, passChildNode.Name)
+		_, restOps = rest.PoolCU(passChildNode.PoolRefs, nil, namespace, restOps, key)
+		_, restOps = rest.PoolGroupCU(passChildNode.PoolGroupRefs, nil, namespace, restOps, key)
+		_, restOps = rest.DatascriptCU(passChildNode.HTTPDSrefs, nil, namespace, restOps, key)
+
+		// Not found - it should be a POST call.
+		restOp := rest.AviVsBuild(passChildNode, utils.RestPost, nil, key)
+		if restOp != nil {
+			restOps = append(restOps, restOp...)
+		}
+	}
+	return restOps
 }
 
 func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj *avicache.AviVsCache, namespace string, cache_sni_nodes []avicache.NamespaceName, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
@@ -175,6 +236,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 	var http_policies_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var string_groups_to_delete []avicache.NamespaceName
+	var client_profiles_to_delete []avicache.NamespaceName
 	if vs_cache_obj != nil {
 		sni_key := avicache.NamespaceName{Namespace: namespace, Name: sni_node.Name}
 		// Search the VS cache and obtain the UUID of this VS. Then see if this UUID is part of the SNIChildCollection or not.
@@ -194,6 +256,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				string_groups_to_delete, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				client_profiles_to_delete, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
@@ -213,6 +276,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -225,6 +289,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		rest_ops = rest.StringGroupDelete(string_groups_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.ClientCertAppProfileDelete(client_profiles_to_delete, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: the EVH VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: EVH child %s not found in cache and EVH parent also does not exist in cache", key, sni_node.Name)
@@ -234,6 +299,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -371,9 +437,26 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 		for i, pp := range vs_meta.PortProto {
 			port := uint32(pp.Port)
 			svc := avimodels.Service{Port: &port, EnableSsl: &vs_meta.PortProto[i].EnableSSL, EnableHttp2: &vs_meta.PortProto[i].EnableHTTP2}
+			// TCP/UDP ports of the parent are served by the l4 policies, override the L7 profiles for them.
+			if pp.Protocol == utils.TCP || pp.Protocol == utils.UDP {
+				overrideAppProfile := "/api/applicationprofile/?name=" + utils.DEFAULT_L4_APP_PROFILE
+				overrideNetworkProfile := "/api/networkprofile/?name=" + nodes.GetNetworkProfile(false, pp.Protocol == utils.TCP, pp.Protocol == utils.UDP)
+				svc.OverrideApplicationProfileRef = &overrideAppProfile
+				svc.OverrideNetworkProfileRef = &overrideNetworkProfile
+			}
 			vs.Services = append(vs.Services, &svc)
 		}
 
+		if len(vs_meta.L4PolicyRefs) > 0 {
+			var l4Policies []*avimodels.L4Policies
+			for i, l4pol := range vs_meta.L4PolicyRefs {
+				j := int32(i)
+				l4PolicyRef := fmt.Sprintf("/api/l4policyset/?name=%s", l4pol.Name)
+				l4Policies = append(l4Policies, &avimodels.L4Policies{L4PolicySetRef: &l4PolicyRef, Index: &j})
+			}
+			vs.L4Policies = l4Policies
+		}
+
 		var httpPolicyCollection []*avimodels.HTTPPolicies
 		internalPolicyIndexBuffer := int32(11)
 		if len(vs_meta.HttpPolicyRefs) > 0 {
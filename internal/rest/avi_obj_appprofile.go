@@ -0,0 +1,346 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	"github.com/davecgh/go-spew/spew"
+	"google.golang.org/protobuf/proto"
+)
+
+// clientIdentityHeaders are the request headers, with which the properties of a verified client
+// certificate are forwarded to the backend servers.
+var clientIdentityHeaders = []struct {
+	header string
+	value  string
+}{
+	{header: "X-Client-Cert-Subject", value: "HTTP_POLICY_VAR_SSL_CLIENT_SUBJECT"},
+	{header: "X-Client-Cert-Issuer", value: "HTTP_POLICY_VAR_SSL_CLIENT_ISSUER"},
+	{header: "X-Client-Cert-Serial", value: "HTTP_POLICY_VAR_SSL_CLIENT_SERIAL"},
+	{header: "X-Client-Cert-Fingerprint", value: "HTTP_POLICY_VAR_SSL_CLIENT_FINGERPRINT"},
+}
+
+func (rest *RestOperations) AviAppProfileBuild(appprofile_meta *nodes.AviApplicationProfileNode, cache_obj *avicache.AviAppProfileCache, key string) *utils.RestOp {
+
+	if lib.CheckObjectNameLength(appprofile_meta.Name, lib.ApplicationProfile) {
+		utils.AviLog.Warnf("key: %s not processing application profile object", key)
+		return nil
+	}
+	// The client certificate settings and the client max body size are overlaid on a clone of the base profile,
+	// so that the virtual service keeps the HTTP settings of the profile it would have used otherwise.
+	appProfile, err := rest.AviAppProfileGet(key, appprofile_meta.BaseProfile, appprofile_meta.Tenant)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: base application profile %s not found, err: %v", key, appprofile_meta.BaseProfile, err)
+		return nil
+	}
+	name := appprofile_meta.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", appprofile_meta.Tenant)
+	cr := lib.AKOUser
+	description := lib.ClonedAppProfileDescription + appprofile_meta.BaseProfile
+	if appprofile_meta.ClientMaxBodySize != nil {
+		description += lib.ClientMaxBodySizeDescription
+	}
+
+	appProfile.Name = &name
+	appProfile.TenantRef = &tenant
+	appProfile.CreatedBy = &cr
+	appProfile.Description = &description
+	appProfile.UUID = nil
+	appProfile.URL = nil
+	appProfile.LastModified = nil
+	appProfile.CloudConfigCksum = nil
+	appProfile.ConfigpbAttributes = nil
+	if appProfile.Type == nil {
+		profileType := lib.AllowedL7ApplicationProfile
+		appProfile.Type = &profileType
+	}
+	if appProfile.HTTPProfile == nil {
+		appProfile.HTTPProfile = &avimodels.HTTPApplicationProfile{}
+	}
+	httpProfile := appProfile.HTTPProfile
+	if appprofile_meta.ClientCertificateMode != "" {
+		pkiProfileRef := "/api/pkiprofile?name=" + appprofile_meta.GetPkiProfileName()
+		clientCertificateMode := appprofile_meta.ClientCertificateMode
+		httpProfile.PkiProfileRef = &pkiProfileRef
+		httpProfile.SslClientCertificateMode = &clientCertificateMode
+	}
+	httpProfile.SslClientCertificateAction = nil
+	if appprofile_meta.ForwardClientIdentity {
+		var headers []*avimodels.SSLClientRequestHeader
+		for _, clientIdentityHeader := range clientIdentityHeaders {
+			headers = append(headers, &avimodels.SSLClientRequestHeader{
+				RequestHeader:      proto.String(clientIdentityHeader.header),
+				RequestHeaderValue: proto.String(clientIdentityHeader.value),
+			})
+		}
+		httpProfile.SslClientCertificateAction = &avimodels.SSLClientCertificateAction{
+			Headers: headers,
+		}
+	}
+	if appprofile_meta.ClientMaxBodySize != nil {
+		clientMaxBodySize := *appprofile_meta.ClientMaxBodySize
+		httpProfile.ClientMaxBodySize = &clientMaxBodySize
+	}
+	appProfile.Markers = lib.GetAllMarkers(appprofile_meta.AviMarkers)
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/applicationprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{
+			ObjName: appprofile_meta.Name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     *appProfile,
+			Tenant:  appprofile_meta.Tenant,
+			Model:   "ApplicationProfile",
+		}
+	} else {
+		path = "/api/applicationprofile/"
+		rest_op = utils.RestOp{
+			ObjName: appprofile_meta.Name,
+			Path:    path,
+			Method:  utils.RestPost,
+			Obj:     *appProfile,
+			Tenant:  appprofile_meta.Tenant,
+			Model:   "ApplicationProfile",
+		}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: ApplicationProfile Restop %v AviApplicationProfileMeta %v", key,
+		rest_op, utils.Stringify(appprofile_meta)))
+	return &rest_op
+}
+
+// AviAppProfileGet fetches the application profile with the given name, which is visible in the tenant.
+func (rest *RestOperations) AviAppProfileGet(key, name, tenant string) (*avimodels.ApplicationProfile, error) {
+	aviRestPoolClient := avicache.SharedAVIClients(tenant)
+	if aviRestPoolClient == nil || len(aviRestPoolClient.AviClient) < 1 {
+		utils.AviLog.Warnf("key: %s, msg: client in aviRestPoolClient during application profile not initialized", key)
+		return nil, errors.New("client in aviRestPoolClient during application profile not initialized")
+	}
+	client := aviRestPoolClient.AviClient[0]
+	uri := "/api/applicationprofile?name=" + name
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Get uri %v returned err for applicationprofile %v", key, uri, err)
+		return nil, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	if err = json.Unmarshal(result.Results, &elems); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Failed to unmarshal applicationprofile data, err: %v", key, err)
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("application profile %s not found", name)
+	}
+	appProfile := avimodels.ApplicationProfile{}
+	if err = json.Unmarshal(elems[0], &appProfile); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Failed to unmarshal applicationprofile data, err: %v", key, err)
+		return nil, err
+	}
+	return &appProfile, nil
+}
+
+func (rest *RestOperations) AviAppProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationprofile/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  "ApplicationProfile",
+	}
+	utils.AviLog.Infof(spew.Sprintf("key: %s, msg: ApplicationProfile DELETE Restop %v ", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAppProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for applicationprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "applicationprofile", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find application profile obj in resp %v", key, rest_op.Response)
+		return errors.New("application profile object not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		lastModifiedIntf, ok := resp["_last_modified"]
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: last_modified not present in response %v", key, resp)
+		} else {
+			lastModifiedStr, ok = lastModifiedIntf.(string)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: last_modified is not of type string", key)
+			}
+		}
+
+		var appProfile avimodels.ApplicationProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			appProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile)
+		case avimodels.ApplicationProfile:
+			appProfile = rest_op.Obj.(avimodels.ApplicationProfile)
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		baseProfile, pkiProfileName, clientCertificateMode, forwardClientIdentity, clientMaxBodySize := lib.GetApplicationProfileSettings(appProfile)
+		cksum := lib.ApplicationProfileChecksum(baseProfile, pkiProfileName, clientCertificateMode, forwardClientIdentity, clientMaxBodySize, emptyIngestionMarkers, appProfile.Markers, true)
+		appprofile_cache_obj := avicache.AviAppProfileCache{Name: name, Tenant: rest_op.Tenant,
+			Uuid:             uuid,
+			LastModified:     lastModifiedStr,
+			CloudConfigCksum: cksum,
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AppProfileCache.AviCacheAdd(k, &appprofile_cache_obj)
+		utils.AviLog.Infof(spew.Sprintf("key: %s, msg: added ApplicationProfile cache k %v val %v", key, k,
+			appprofile_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviAppProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	appProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting application profile cache %v", key, appProfileKey)
+	rest.cache.AppProfileCache.AviCacheDelete(appProfileKey)
+	return nil
+}
+
+// ClientCertAppProfileCU creates or updates the application profile and the PKI profile, with which the client
+// certificates of a virtual service are verified. The profiles are named after the virtual service, and the ones
+// which are no longer required are returned, so that these are deleted once the virtual service stops referring them.
+// If the application profile can not be created, the virtual service does not refer to it.
+func (rest *RestOperations) ClientCertAppProfileCU(vsNode nodes.AviVsEvhSniModel, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var profiles_to_delete []avicache.NamespaceName
+	vsName := vsNode.GetName()
+	appprofile_node := vsNode.GetClientCertAppProfile()
+	appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetClientAppProfileName(vsName)}
+	pkiProfileKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetClientPKIProfileName(vsName)}
+
+	if appprofile_node == nil || appprofile_node.PkiProfile == nil {
+		if _, ok := rest.cache.PKIProfileCache.AviCacheGet(pkiProfileKey); ok {
+			profiles_to_delete = append(profiles_to_delete, pkiProfileKey)
+		}
+	} else {
+		// The PKI profile has to be created first, as it is referred by the application profile.
+		pkiCache, ok := rest.cache.PKIProfileCache.AviCacheGet(pkiProfileKey)
+		if !ok {
+			restOp := rest.AviPkiProfileBuild(appprofile_node.PkiProfile, nil)
+			if restOp != nil {
+				rest_ops = append(rest_ops, restOp)
+			}
+		} else if pkiCacheObj, ok := pkiCache.(*avicache.AviPkiProfileCache); !ok {
+			utils.AviLog.Warnf("key: %s, msg: invalid PKI profile object found in cache for %v", key, pkiProfileKey)
+		} else if pkiCacheObj.CloudConfigCksum != appprofile_node.PkiProfile.GetCheckSum() {
+			restOp := rest.AviPkiProfileBuild(appprofile_node.PkiProfile, pkiCacheObj)
+			if restOp != nil {
+				rest_ops = append(rest_ops, restOp)
+			}
+		}
+	}
+
+	if appprofile_node == nil {
+		if _, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey); ok {
+			// The application profile is deleted before the PKI profile referred by it.
+			profiles_to_delete = append([]avicache.NamespaceName{appProfileKey}, profiles_to_delete...)
+		}
+		return profiles_to_delete, rest_ops
+	}
+
+	appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey)
+	if !ok {
+		restOp := rest.AviAppProfileBuild(appprofile_node, nil, key)
+		if restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		} else {
+			utils.AviLog.Warnf("key: %s, msg: application profile %s can not be created, not referring it from the virtual service %s", key, appprofile_node.Name, vsName)
+			vsNode.SetAppProfileRef(nil)
+		}
+	} else if appProfileCacheObj, ok := appProfileCache.(*avicache.AviAppProfileCache); !ok {
+		utils.AviLog.Warnf("key: %s, msg: invalid application profile object found in cache for %v", key, appProfileKey)
+	} else if appProfileCacheObj.CloudConfigCksum != appprofile_node.GetCheckSum() {
+		restOp := rest.AviAppProfileBuild(appprofile_node, appProfileCacheObj, key)
+		if restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	} else {
+		utils.AviLog.Debugf("key: %s, msg: the checksums are same for application profile %s, not doing anything", key, appprofile_node.Name)
+	}
+	return profiles_to_delete, rest_ops
+}
+
+// ClientCertAppProfileDelete deletes the application profile and the PKI profile, with which the client
+// certificates of a virtual service are verified.
+func (rest *RestOperations) ClientCertAppProfileDelete(profiles_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, profileKey := range profiles_to_delete {
+		if appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(profileKey); ok {
+			appProfileCacheObj, ok := appProfileCache.(*avicache.AviAppProfileCache)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: invalid application profile object found in cache for %v", key, profileKey)
+				continue
+			}
+			restOp := rest.AviAppProfileDel(appProfileCacheObj.Uuid, namespace, key)
+			restOp.ObjName = profileKey.Name
+			rest_ops = append(rest_ops, restOp)
+			continue
+		}
+		rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{profileKey}, namespace, rest_ops, key)
+	}
+	return rest_ops
+}
+
+// getClientCertAppProfiles returns the application profile and the PKI profile created by AKO, with which the
+// client certificates of a virtual service are verified, in the order in which these have to be deleted.
+func (rest *RestOperations) getClientCertAppProfiles(vsName, namespace string) []avicache.NamespaceName {
+	var profiles []avicache.NamespaceName
+	appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetClientAppProfileName(vsName)}
+	if _, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey); ok {
+		profiles = append(profiles, appProfileKey)
+	}
+	pkiProfileKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetClientPKIProfileName(vsName)}
+	if _, ok := rest.cache.PKIProfileCache.AviCacheGet(pkiProfileKey); ok {
+		profiles = append(profiles, pkiProfileKey)
+	}
+	return profiles
+}
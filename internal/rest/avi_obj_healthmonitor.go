@@ -0,0 +1,209 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// GetCreatedByMarkers returns the markers of an AKO created object which does not have a created_by
+// field, such as a health monitor. A created_by label is added to identify such objects.
+func GetCreatedByMarkers(markers utils.AviObjectMarkers) []*avimodels.RoleFilterMatchLabel {
+	objMarkers := lib.GetAllMarkers(markers)
+	labelKey := lib.CreatedByLabelKey
+	rfml := &avimodels.RoleFilterMatchLabel{
+		Key:    &labelKey,
+		Values: []string{lib.GetAKOUser()},
+	}
+	objMarkers = append(objMarkers, rfml)
+	return objMarkers
+}
+
+func (rest *RestOperations) AviHealthMonitorBuild(hm_meta *nodes.AviHealthMonitorNode, cache_obj *avicache.AviHealthMonitorCache, key string) *utils.RestOp {
+
+	if lib.CheckObjectNameLength(hm_meta.Name, lib.HealthMonitor) {
+		utils.AviLog.Warnf("key: %s not processing health monitor object", key)
+		return nil
+	}
+	name := hm_meta.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", hm_meta.Tenant)
+	hmType := hm_meta.Type
+	monitorPort := hm_meta.MonitorPort
+	httpRequest := hm_meta.HTTPRequest
+
+	hm := avimodels.HealthMonitor{
+		Name:        &name,
+		TenantRef:   &tenant,
+		Type:        &hmType,
+		MonitorPort: &monitorPort,
+		HTTPMonitor: &avimodels.HealthMonitorHTTP{
+			HTTPRequest:      &httpRequest,
+			HTTPResponseCode: []string{"HTTP_2XX"},
+		},
+	}
+
+	hm.Markers = GetCreatedByMarkers(hm_meta.AviMarkers)
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/healthmonitor/" + cache_obj.Uuid
+		rest_op = utils.RestOp{
+			ObjName: hm_meta.Name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     hm,
+			Tenant:  hm_meta.Tenant,
+			Model:   "HealthMonitor",
+		}
+	} else {
+		// Patch an existing health monitor if it exists in the cache but not associated with this pool.
+		hm_key := avicache.NamespaceName{Namespace: hm_meta.Tenant, Name: hm_meta.Name}
+		hm_cache, ok := rest.cache.HMCache.AviCacheGet(hm_key)
+		if ok {
+			hm_cache_obj, _ := hm_cache.(*avicache.AviHealthMonitorCache)
+			path = "/api/healthmonitor/" + hm_cache_obj.Uuid
+			rest_op = utils.RestOp{
+				ObjName: hm_meta.Name,
+				Path:    path,
+				Method:  utils.RestPut,
+				Obj:     hm,
+				Tenant:  hm_meta.Tenant,
+				Model:   "HealthMonitor",
+			}
+		} else {
+			path = "/api/healthmonitor/"
+			rest_op = utils.RestOp{
+				ObjName: hm_meta.Name,
+				Path:    path,
+				Method:  utils.RestPost,
+				Obj:     hm,
+				Tenant:  hm_meta.Tenant,
+				Model:   "HealthMonitor",
+			}
+		}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: HealthMonitor Restop %v AviHealthMonitorMeta %v", key,
+		rest_op, utils.Stringify(hm_meta)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/healthmonitor/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  "HealthMonitor",
+	}
+	utils.AviLog.Infof(spew.Sprintf("key: %s, msg: HealthMonitor DELETE Restop %v ", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for healthmonitor, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "healthmonitor", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find health monitor obj in resp %v", key, rest_op.Response)
+		return errors.New("health monitor object not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		lastModifiedIntf, ok := resp["_last_modified"]
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: last_modified not present in response %v", key, resp)
+		} else {
+			lastModifiedStr, ok = lastModifiedIntf.(string)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: last_modified is not of type string", key)
+			}
+		}
+
+		var hm avimodels.HealthMonitor
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			hm = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor)
+		case avimodels.HealthMonitor:
+			hm = rest_op.Obj.(avimodels.HealthMonitor)
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		cksum := lib.HealthMonitorChecksum(*hm.Type, *hm.MonitorPort, *hm.HTTPMonitor.HTTPRequest, emptyIngestionMarkers, hm.Markers, true)
+		hm_cache_obj := avicache.AviHealthMonitorCache{Name: name, Tenant: rest_op.Tenant,
+			Uuid:             uuid,
+			LastModified:     lastModifiedStr,
+			CloudConfigCksum: cksum,
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.HMCache.AviCacheAdd(k, &hm_cache_obj)
+		utils.AviLog.Infof(spew.Sprintf("key: %s, msg: added HealthMonitor cache k %v val %v", key, k,
+			hm_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheDel(rest_op *utils.RestOp, key string) error {
+	hmKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting health monitor cache %v", key, hmKey)
+	rest.cache.HMCache.AviCacheDelete(hmKey)
+	return nil
+}
+
+// getPoolHealthMonitorKey returns the AKO created health monitor among the health monitors of a pool.
+func (rest *RestOperations) getPoolHealthMonitorKey(healthMonitorRefs []string, tenant string) avicache.NamespaceName {
+	for _, hmRef := range healthMonitorRefs {
+		refSplit := strings.Split(hmRef, "name=")
+		if len(refSplit) != 2 {
+			continue
+		}
+		hmKey := avicache.NamespaceName{Namespace: tenant, Name: refSplit[1]}
+		if _, ok := rest.cache.HMCache.AviCacheGet(hmKey); ok {
+			return hmKey
+		}
+	}
+	return avicache.NamespaceName{}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	"github.com/davecgh/go-spew/spew"
+	"google.golang.org/protobuf/proto"
+)
+
+func (rest *RestOperations) AviPersistenceProfileBuild(persistence_meta *nodes.AviPersistenceProfileNode, cache_obj *avicache.AviPersistenceProfileCache, key string) *utils.RestOp {
+
+	if lib.CheckObjectNameLength(persistence_meta.Name, lib.ApplicationPersistenceProfile) {
+		utils.AviLog.Warnf("key: %s not processing persistence profile object", key)
+		return nil
+	}
+	name := persistence_meta.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", persistence_meta.Tenant)
+	persistenceType := persistence_meta.PersistenceType
+	timeout := persistence_meta.Timeout
+
+	persistenceProfile := avimodels.ApplicationPersistenceProfile{
+		Name:            &name,
+		TenantRef:       &tenant,
+		PersistenceType: &persistenceType,
+	}
+	if persistenceType == lib.PersistenceTypeHTTPCookie {
+		cookieProfile := &avimodels.HTTPCookiePersistenceProfile{}
+		if persistence_meta.CookieName != "" {
+			cookieProfile.CookieName = &persistence_meta.CookieName
+		}
+		if timeout != 0 {
+			cookieProfile.Timeout = &timeout
+			cookieProfile.IsPersistentCookie = proto.Bool(true)
+		}
+		persistenceProfile.HTTPCookiePersistenceProfile = cookieProfile
+	} else {
+		persistenceProfile.IPPersistenceProfile = &avimodels.IPPersistenceProfile{
+			IPPersistentTimeout: &timeout,
+		}
+	}
+
+	persistenceProfile.Markers = GetCreatedByMarkers(persistence_meta.AviMarkers)
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/applicationpersistenceprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{
+			ObjName: persistence_meta.Name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     persistenceProfile,
+			Tenant:  persistence_meta.Tenant,
+			Model:   "ApplicationPersistenceProfile",
+		}
+	} else {
+		// Patch an existing persistence profile if it exists in the cache but not associated with this pool.
+		persistence_key := avicache.NamespaceName{Namespace: persistence_meta.Tenant, Name: persistence_meta.Name}
+		persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key)
+		if ok {
+			persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+			path = "/api/applicationpersistenceprofile/" + persistence_cache_obj.Uuid
+			rest_op = utils.RestOp{
+				ObjName: persistence_meta.Name,
+				Path:    path,
+				Method:  utils.RestPut,
+				Obj:     persistenceProfile,
+				Tenant:  persistence_meta.Tenant,
+				Model:   "ApplicationPersistenceProfile",
+			}
+		} else {
+			path = "/api/applicationpersistenceprofile/"
+			rest_op = utils.RestOp{
+				ObjName: persistence_meta.Name,
+				Path:    path,
+				Method:  utils.RestPost,
+				Obj:     persistenceProfile,
+				Tenant:  persistence_meta.Tenant,
+				Model:   "ApplicationPersistenceProfile",
+			}
+		}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: ApplicationPersistenceProfile Restop %v AviPersistenceProfileMeta %v", key,
+		rest_op, utils.Stringify(persistence_meta)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationpersistenceprofile/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  "ApplicationPersistenceProfile",
+	}
+	utils.AviLog.Infof(spew.Sprintf("key: %s, msg: ApplicationPersistenceProfile DELETE Restop %v ", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for applicationpersistenceprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "applicationpersistenceprofile", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find persistence profile obj in resp %v", key, rest_op.Response)
+		return errors.New("persistence profile object not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		lastModifiedIntf, ok := resp["_last_modified"]
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: last_modified not present in response %v", key, resp)
+		} else {
+			lastModifiedStr, ok = lastModifiedIntf.(string)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: last_modified is not of type string", key)
+			}
+		}
+
+		var persistenceProfile avimodels.ApplicationPersistenceProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			persistenceProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile)
+		case avimodels.ApplicationPersistenceProfile:
+			persistenceProfile = rest_op.Obj.(avimodels.ApplicationPersistenceProfile)
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		persistenceType, timeout, cookieName := lib.GetPersistenceProfileSettings(persistenceProfile)
+		cksum := lib.PersistenceProfileChecksum(persistenceType, timeout, cookieName, emptyIngestionMarkers, persistenceProfile.Markers, true)
+		persistence_cache_obj := avicache.AviPersistenceProfileCache{Name: name, Tenant: rest_op.Tenant,
+			Uuid:             uuid,
+			LastModified:     lastModifiedStr,
+			CloudConfigCksum: cksum,
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AppPersistCache.AviCacheAdd(k, &persistence_cache_obj)
+		utils.AviLog.Infof(spew.Sprintf("key: %s, msg: added ApplicationPersistenceProfile cache k %v val %v", key, k,
+			persistence_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	persistenceKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting persistence profile cache %v", key, persistenceKey)
+	rest.cache.AppPersistCache.AviCacheDelete(persistenceKey)
+	return nil
+}
+
+// getPoolPersistenceProfileKey returns the persistence profile of a pool, if it is created by AKO.
+func (rest *RestOperations) getPoolPersistenceProfileKey(persistenceProfileRef *string, tenant string) avicache.NamespaceName {
+	if persistenceProfileRef == nil {
+		return avicache.NamespaceName{}
+	}
+	refSplit := strings.Split(*persistenceProfileRef, "name=")
+	if len(refSplit) != 2 {
+		return avicache.NamespaceName{}
+	}
+	persistenceKey := avicache.NamespaceName{Namespace: tenant, Name: refSplit[1]}
+	if _, ok := rest.cache.AppPersistCache.AviCacheGet(persistenceKey); ok {
+		return persistenceKey
+	}
+	return avicache.NamespaceName{}
+}
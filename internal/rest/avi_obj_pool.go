@@ -117,6 +117,25 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		pool.Tier1Lr = &pool_meta.T1Lr
 	}
 
+	if pool_meta.EnableHTTP2 {
+		pool.EnableHttp2 = &pool_meta.EnableHTTP2
+	}
+
+	if pool_meta.ServerTimeout != 0 {
+		pool.ServerTimeout = &pool_meta.ServerTimeout
+	}
+
+	if pool_meta.ServerReselect != nil {
+		pool.ServerReselect = pool_meta.ServerReselect
+	}
+
+	// the server name is used as the SNI and the certificate of the server is validated against it.
+	if pool_meta.ServerName != "" {
+		pool.ServerName = &pool_meta.ServerName
+		pool.HostCheckEnabled = proto.Bool(true)
+		pool.DomainName = []string{pool_meta.ServerName}
+	}
+
 	if !pool_meta.AttachedWithSharedVS {
 		pool.Markers = lib.GetAllMarkers(pool_meta.AviMarkers)
 	} else {
@@ -141,6 +160,9 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 
 	if pool_meta.ApplicationPersistenceProfileRef != nil {
 		pool.ApplicationPersistenceProfileRef = pool_meta.ApplicationPersistenceProfileRef
+	} else if pool_meta.PersistenceProfile != nil {
+		persistenceProfileRef := fmt.Sprintf("/api/applicationpersistenceprofile?name=%s", pool_meta.PersistenceProfile.Name)
+		pool.ApplicationPersistenceProfileRef = &persistenceProfileRef
 	}
 
 	for i, server := range pool_meta.Servers {
@@ -173,6 +195,14 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		}
 		pool.HealthMonitorRefs = append(pool.HealthMonitorRefs, hm)
 	}
+	if pool_meta.HealthMonitor != nil {
+		hm := fmt.Sprintf("/api/healthmonitor?name=%s", pool_meta.HealthMonitor.Name)
+		if len(pool_meta.HealthMonitorRefs) > 0 {
+			pool.HealthMonitorRefs = append(pool.HealthMonitorRefs, hm)
+		} else {
+			pool.HealthMonitorRefs = []string{hm}
+		}
+	}
 
 	if err := copier.CopyWithOption(&pool, &pool_meta.AviPoolGeneratedFields, copier.Option{IgnoreEmpty: true}); err != nil {
 		utils.AviLog.Warnf("key: %s, msg: unable to set few parameters in the Pool, err: %v", key, err)
@@ -291,6 +321,14 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 			}
 		}
 
+		var poolObj avimodels.Pool
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			poolObj = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.Pool)
+		case avimodels.Pool:
+			poolObj = rest_op.Obj.(avimodels.Pool)
+		}
+
 		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
 		oldCacheServiceMetadataCRD := lib.CRDMetadata{}
 		if poolCache, ok := rest.cache.PoolCache.AviCacheGet(k); ok {
@@ -300,13 +338,15 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 		}
 
 		pool_cache_obj := avicache.AviPoolCache{
-			Name:                 name,
-			Tenant:               rest_op.Tenant,
-			Uuid:                 uuid,
-			CloudConfigCksum:     cksum,
-			ServiceMetadataObj:   svc_mdata_obj,
-			PkiProfileCollection: pkiKey,
-			LastModified:         lastModifiedStr,
+			Name:                         name,
+			Tenant:                       rest_op.Tenant,
+			Uuid:                         uuid,
+			CloudConfigCksum:             cksum,
+			ServiceMetadataObj:           svc_mdata_obj,
+			PkiProfileCollection:         pkiKey,
+			HealthMonitorCollection:      rest.getPoolHealthMonitorKey(poolObj.HealthMonitorRefs, rest_op.Tenant),
+			PersistenceProfileCollection: rest.getPoolPersistenceProfileKey(poolObj.ApplicationPersistenceProfileRef, rest_op.Tenant),
+			LastModified:                 lastModifiedStr,
 		}
 		if lastModifiedStr == "" {
 			pool_cache_obj.InvalidData = true
@@ -378,6 +418,7 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 			vs_cache_obj.AddToPoolKeyCollection(k)
 			utils.AviLog.Debugf("key: %s, msg: added VS cache key during pool update %v val %v", key, vsKey, utils.Stringify(vs_cache_obj))
 		}
+		status.UpdatePoolCRDAttachments(key, k, vsKey, svc_mdata_obj)
 		utils.AviLog.Infof("key: %s, msg: Added Pool cache k %v val %v", key, k, utils.Stringify(pool_cache_obj))
 	}
 
@@ -407,6 +448,7 @@ func (rest *RestOperations) AviPoolCacheDel(rest_op *utils.RestOp, vsKey avicach
 	if (cacheServiceMetadataCRD != lib.CRDMetadata{}) {
 		status.HttpRuleEventBroadcast(poolKey.Name, cacheServiceMetadataCRD, lib.CRDMetadata{})
 	}
+	status.DeletePoolCRDAttachments(key, poolKey)
 	return nil
 }
 
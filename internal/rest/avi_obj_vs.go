@@ -366,6 +366,10 @@ func (rest *RestOperations) AviVsSniBuild(vs_meta *nodes.AviVsNode, rest_method
 		sniChild.PoolRef = &pool_ref
 	}
 
+	if vs_meta.DefaultPoolGroup != "" {
+		sniChild.PoolGroupRef = proto.String("/api/poolgroup/?name=" + vs_meta.DefaultPoolGroup)
+	}
+
 	var datascriptCollection []*avimodels.VSDataScripts
 	for i, script := range vs_meta.VsDatascriptRefs {
 		j := int32(i)
@@ -716,6 +720,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 				}
 				utils.AviLog.Debug(spew.Sprintf("key: %s, msg: updated VS cache key %v val %v", key, k,
 					utils.Stringify(vs_cache_obj)))
+				status.UpdateVSCRDAttachments(key, k, svc_mdata_obj)
 
 				// This code is most likely hit when the first time a shard vs is created and the vs_cache_obj is populated from the pool update.
 				// But before this a pool may have got created as a part of the macro operation, so update the ingress status here.
@@ -744,6 +749,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 			rest.cache.VsCacheMeta.AviCacheAdd(k, vs_cache_obj)
 			status.HostRuleEventBroadcast(vs_cache_obj.Name, lib.CRDMetadata{}, svc_mdata_obj.CRDStatus)
 			status.SSORuleEventBroadcast(vs_cache_obj.Name, lib.CRDMetadata{}, svc_mdata_obj.CRDStatus)
+			status.UpdateVSCRDAttachments(key, k, svc_mdata_obj)
 			utils.AviLog.Infof("key: %s, msg: added VS cache key %v val %v", key, k, utils.Stringify(vs_cache_obj))
 		}
 
@@ -860,6 +866,7 @@ func (rest *RestOperations) AviVsCacheDel(rest_op *utils.RestOp, vsKey avicache.
 	}
 	utils.AviLog.Infof("key: %s, msg: deleting vs cache for key: %s", key, vsKey)
 	rest.cache.VsCacheMeta.AviCacheDelete(vsKey)
+	status.DeleteVSCRDAttachments(key, vsKey)
 
 	return nil
 }
@@ -60,6 +60,10 @@ func (rest *RestOperations) CleanupVS(key string, skipVS bool) {
 func (rest *RestOperations) DequeueNodes(key string) {
 	utils.AviLog.Infof("key: %s, msg: start rest layer sync.", key)
 	lib.DecrementQueueCounter(utils.GraphLayer)
+	if lib.IsDryRunEnabled() {
+		// The pending changes are computed afresh from the current model.
+		models.DryRun.ResetPlan(key)
+	}
 	// Got the key from the Graph Layer - let's fetch the model
 	ok, avimodelIntf := objects.SharedAviGraphLister().Get(key)
 	if !ok {
@@ -301,6 +305,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	var sni_to_delete []avicache.NamespaceName
 	var httppol_to_delete []avicache.NamespaceName
 	var l4pol_to_delete []avicache.NamespaceName
+	var nsp_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var string_groups_to_delete []avicache.NamespaceName
 	var vsvipErr error
@@ -341,6 +346,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		ds_to_delete, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, vs_cache_obj, namespace, rest_ops, key)
 		l4pol_to_delete, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		nsp_to_delete, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -373,6 +379,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		_, rest_ops = rest.StringGroupVsCU(aviVsNode.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, nil, namespace, rest_ops, key)
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuild(aviVsNode, utils.RestPost, nil, key)
@@ -404,6 +411,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.StringGroupDelete(string_groups_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.NetworkSecurityPolicyDelete(nsp_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.DSDelete(ds_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
@@ -542,8 +550,11 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 		rest_ops = rest.HTTPPolicyDelete(vs_cache_obj.HTTPKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.StringGroupDelete(vs_cache_obj.StringGroupKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.L4PolicyDelete(vs_cache_obj.L4PolicyCollection, namespace, rest_ops, key)
+		rest_ops = rest.NetworkSecurityPolicyDelete(vs_cache_obj.NetworkSecurityPolicyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.HealthMonitorDelete(vs_cache_obj.HealthMonitorCollection, namespace, rest_ops, key)
+		rest_ops = rest.PersistenceProfileDelete(vs_cache_obj.PersistenceProfileCollection, namespace, rest_ops, key)
 		success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, nil, key, false)
 		if success {
 			vsKeysPending := rest.cache.VsCacheMeta.AviGetAllKeys()
@@ -584,6 +595,7 @@ func (rest *RestOperations) deleteSniVs(vsKey avicache.NamespaceName, vs_cache_o
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.StringGroupDelete(vs_cache_obj.StringGroupKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.ClientCertAppProfileDelete(rest.getClientCertAppProfiles(vsKey.Name, namespace), namespace, rest_ops, key)
 		success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false)
 		return success
 	}
@@ -598,6 +610,10 @@ func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp
 		shardSize = 8
 	}
 	var retry, fastRetry, processNextObj bool
+	if lib.IsDryRunEnabled() {
+		recordDryRunOperations(rest_ops, key)
+		return true, true
+	}
 	bkt := utils.Bkt(key, shardSize)
 	aviRestPoolClient := avicache.SharedAVIClients(aviObjKey.Namespace)
 	if len(aviRestPoolClient.AviClient) > 0 && len(rest_ops) > 0 {
@@ -784,6 +800,12 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 		utils.AviLog.Infof("key: %s, msg: creating/updating %s cache, method: %s", key, rest_op.Model, rest_op.Method)
 		if rest_op.Model == "PKIprofile" {
 			rest.AviPkiProfileAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheAdd(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheAdd(rest_op, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheAdd(rest_op, key)
 		} else if rest_op.Model == "Pool" {
 			rest.AviPoolCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VirtualService" {
@@ -798,6 +820,8 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviSSLKeyCertAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "L4PolicySet" {
 			rest.AviL4PolicyCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VrfContext" {
 			rest.AviVrfCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VsVip" {
@@ -811,6 +835,12 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 		utils.AviLog.Infof("key: %s, msg: deleting %s cache", key, rest_op.Model)
 		if rest_op.Model == "PKIprofile" {
 			rest.AviPkiProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheDel(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheDel(rest_op, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheDel(rest_op, key)
 		} else if rest_op.Model == "Pool" {
 			rest.AviPoolCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VirtualService" {
@@ -823,6 +853,8 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviSSLCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "L4PolicySet" {
 			rest.AviL4PolicyCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VsVip" {
 			rest.AviVsVipCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VSDataScriptSet" {
@@ -837,6 +869,7 @@ func (rest *RestOperations) PublishKeyToRetryLayer(parentVsKey avicache.Namespac
 	fastRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.FAST_RETRY_LAYER)
 	fastRetryQueue.Workqueue[0].AddRateLimited(fmt.Sprintf("%s/%s", parentVsKey.Namespace, parentVsKey.Name))
 	lib.IncrementQueueCounter(lib.FAST_RETRY_LAYER)
+	lib.IncrementRetryCounter(lib.FAST_RETRY_LAYER, fmt.Sprintf("%s/%s", parentVsKey.Namespace, parentVsKey.Name))
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to fast path retry queue: %s", key, parentVsKey)
 }
 
@@ -844,6 +877,7 @@ func (rest *RestOperations) PublishKeyToSlowRetryLayer(parentVsKey avicache.Name
 	slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.SLOW_RETRY_LAYER)
 	slowRetryQueue.Workqueue[0].AddRateLimited(fmt.Sprintf("%s/%s", parentVsKey.Namespace, parentVsKey.Name))
 	lib.IncrementQueueCounter(lib.SLOW_RETRY_LAYER)
+	lib.IncrementRetryCounter(lib.SLOW_RETRY_LAYER, fmt.Sprintf("%s/%s", parentVsKey.Namespace, parentVsKey.Name))
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to slow path retry queue: %s", key, parentVsKey)
 }
 
@@ -1001,6 +1035,18 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					rest_op.ObjName = L4PolicySet
 				}
 				rest.AviL4PolicyCacheDel(rest_op, aviObjKey, key)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				if NetworkSecurityPolicy != "" {
+					rest_op.ObjName = NetworkSecurityPolicy
+				}
+				rest.AviNetworkSecurityPolicyCacheDel(rest_op, aviObjKey, key)
 			case "SSLKeyAndCertificate":
 				var SSLKeyAndCertificate string
 				switch rest_op.Obj.(type) {
@@ -1025,6 +1071,42 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					rest_op.ObjName = PKIprofile
 				}
 				rest.AviPkiProfileCacheDel(rest_op, aviObjKey, key)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				if HealthMonitor != "" {
+					rest_op.ObjName = HealthMonitor
+				}
+				rest.AviHealthMonitorCacheDel(rest_op, key)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				if ApplicationPersistenceProfile != "" {
+					rest_op.ObjName = ApplicationPersistenceProfile
+				}
+				rest.AviPersistenceProfileCacheDel(rest_op, key)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				if ApplicationProfile != "" {
+					rest_op.ObjName = ApplicationProfile
+				}
+				rest.AviAppProfileCacheDel(rest_op, key)
 			case "VirtualService":
 				rest.AviVsCacheDel(rest_op, aviObjKey, key)
 			case "VSDataScriptSet":
@@ -1101,6 +1183,15 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					L4PolicySet = *rest_op.Obj.(avimodels.L4PolicySet).Name
 				}
 				aviObjCache.AviPopulateOneVsL4PolCache(c, utils.CloudName, L4PolicySet)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				aviObjCache.AviPopulateOneNetworkSecurityPolicyCache(c, utils.CloudName, NetworkSecurityPolicy)
 			case "SSLKeyAndCertificate":
 				var SSLKeyAndCertificate string
 				switch rest_op.Obj.(type) {
@@ -1119,6 +1210,33 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					PKIprofile = *rest_op.Obj.(avimodels.PKIprofile).Name
 				}
 				aviObjCache.AviPopulateOnePKICache(c, utils.CloudName, PKIprofile)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				aviObjCache.AviPopulateOneHealthMonitorCache(c, utils.CloudName, HealthMonitor)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				aviObjCache.AviPopulateOnePersistenceProfileCache(c, utils.CloudName, ApplicationPersistenceProfile)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				aviObjCache.AviPopulateOneAppProfileCache(c, utils.CloudName, ApplicationProfile)
 			case "VirtualService":
 				aviObjCache.AviObjOneVSCachePopulate(c, utils.CloudName, aviObjKey.Name, aviObjKey.Namespace)
 				vsObjMeta, ok := rest.cache.VsCacheMeta.AviCacheGet(aviObjKey)
@@ -1189,6 +1307,14 @@ func (rest *RestOperations) PoolDelete(pools_to_delete []avicache.NamespaceName,
 			if pkiProfile.Name != "" {
 				rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{pkiProfile}, namespace, rest_ops, key)
 			}
+			healthMonitor := pool_cache_obj.HealthMonitorCollection
+			if healthMonitor.Name != "" {
+				rest_ops = rest.HealthMonitorDelete([]avicache.NamespaceName{healthMonitor}, namespace, rest_ops, key)
+			}
+			persistenceProfile := pool_cache_obj.PersistenceProfileCollection
+			if persistenceProfile.Name != "" {
+				rest_ops = rest.PersistenceProfileDelete([]avicache.NamespaceName{persistenceProfile}, namespace, rest_ops, key)
+			}
 		}
 	}
 	return rest_ops
@@ -1264,6 +1390,8 @@ func (rest *RestOperations) DSDelete(ds_to_delete []avicache.NamespaceName, name
 func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
 	var cache_pool_nodes []avicache.NamespaceName
 	var pool_pkiprofile_delete []avicache.NamespaceName
+	var pool_healthmonitor_delete []avicache.NamespaceName
+	var pool_persistenceprofile_delete []avicache.NamespaceName
 	if vs_cache_obj != nil {
 		cache_pool_nodes = make([]avicache.NamespaceName, len(vs_cache_obj.PoolKeyCollection))
 		copy(cache_pool_nodes, vs_cache_obj.PoolKeyCollection)
@@ -1281,6 +1409,8 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 				if ok {
 					pool_cache_obj, _ := pool_cache.(*avicache.AviPoolCache)
 					pool_pkiprofile_delete, rest_ops = rest.PkiProfileCU(pool.PkiProfile, pool_cache_obj, namespace, rest_ops, key)
+					pool_healthmonitor_delete, rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, pool_cache_obj, namespace, rest_ops, key)
+					pool_persistenceprofile_delete, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, pool_cache_obj, namespace, rest_ops, key)
 
 					// Cache found. Let's compare the checksums
 					utils.AviLog.Debugf("key: %s, msg: poolcache: %v", key, pool_cache_obj)
@@ -1298,6 +1428,8 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 			} else {
 				utils.AviLog.Debugf("key: %s, msg: pool %s not found in cache, operation: POST", key, pool.Name)
 				_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+				_, rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, nil, namespace, rest_ops, key)
+				_, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, nil, namespace, rest_ops, key)
 				// Not found - it should be a POST call.
 				restOp := rest.AviPoolBuild(pool, nil, key)
 				if restOp != nil {
@@ -1307,12 +1439,20 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 			if len(pool_pkiprofile_delete) > 0 {
 				rest_ops = rest.PkiProfileDelete(pool_pkiprofile_delete, namespace, rest_ops, key)
 			}
+			if len(pool_healthmonitor_delete) > 0 {
+				rest_ops = rest.HealthMonitorDelete(pool_healthmonitor_delete, namespace, rest_ops, key)
+			}
+			if len(pool_persistenceprofile_delete) > 0 {
+				rest_ops = rest.PersistenceProfileDelete(pool_persistenceprofile_delete, namespace, rest_ops, key)
+			}
 		}
 
 	} else {
 		// Everything is a POST call
 		for _, pool := range pool_nodes {
 			_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, nil, namespace, rest_ops, key)
 
 			utils.AviLog.Debugf("key: %s, msg: pool cache does not exist %s, operation: POST", key, pool.Name)
 			restOp := rest.AviPoolBuild(pool, nil, key)
@@ -1376,6 +1516,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 	var http_policies_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var string_groups_to_delete []avicache.NamespaceName
+	var client_profiles_to_delete []avicache.NamespaceName
 	if vs_cache_obj != nil {
 		sni_key := avicache.NamespaceName{Namespace: namespace, Name: sni_node.Name}
 		// Search the VS cache and obtain the UUID of this VS. Then see if this UUID is part of the SNIChildCollection or not.
@@ -1395,6 +1536,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				string_groups_to_delete, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				client_profiles_to_delete, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
 					restOp := rest.AviVsBuild(sni_node, utils.RestPut, sni_cache_obj, key)
@@ -1413,6 +1555,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -1425,6 +1568,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		rest_ops = rest.StringGroupDelete(string_groups_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.ClientCertAppProfileDelete(client_profiles_to_delete, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: the SNI VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: sni child %s not found in cache and SNI parent also does not exist in cache", key, sni_node.Name)
@@ -1434,6 +1578,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.ClientCertAppProfileCU(sni_node, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -1751,6 +1896,67 @@ func (rest *RestOperations) L4PolicyDelete(l4_to_delete []avicache.NamespaceName
 	return rest_ops
 }
 
+func (rest *RestOperations) NetworkSecurityPolicyCU(nsp_nodes []*nodes.AviNetworkSecurityPolicyNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_nsp_nodes []avicache.NamespaceName
+	// Default is POST
+	if vs_cache_obj != nil {
+		cache_nsp_nodes = make([]avicache.NamespaceName, len(vs_cache_obj.NetworkSecurityPolicyCollection))
+		copy(cache_nsp_nodes, vs_cache_obj.NetworkSecurityPolicyCollection)
+		for _, nsp := range nsp_nodes {
+			nsp_key := avicache.NamespaceName{Namespace: namespace, Name: nsp.Name}
+			found := utils.HasElem(cache_nsp_nodes, nsp_key)
+			if found {
+				nsp_cache, ok := rest.cache.NSPCache.AviCacheGet(nsp_key)
+				if ok {
+					cache_nsp_nodes = avicache.RemoveNamespaceName(cache_nsp_nodes, nsp_key)
+					nsp_cache_obj, _ := nsp_cache.(*avicache.AviNetworkSecurityPolicyCache)
+					// Cache found. Let's compare the checksums
+					if nsp_cache_obj.CloudConfigCksum == nsp.GetCheckSum() {
+						utils.AviLog.Debugf("key: %s, msg: the checksums are same for network security policy cache obj %s, not doing anything", key, nsp_cache_obj.Name)
+					} else {
+						// The checksums are different, so it should be a PUT call.
+						restOp := rest.AviNetworkSecurityPolicyBuild(nsp, nsp_cache_obj, key)
+						if restOp != nil {
+							rest_ops = append(rest_ops, restOp)
+						}
+					}
+				}
+			} else {
+				// Not found - it should be a POST call.
+				restOp := rest.AviNetworkSecurityPolicyBuild(nsp, nil, key)
+				if restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+			}
+		}
+	} else {
+		// Everything is a POST call
+		for _, nsp := range nsp_nodes {
+			restOp := rest.AviNetworkSecurityPolicyBuild(nsp, nil, key)
+			if restOp != nil {
+				rest_ops = append(rest_ops, restOp)
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: the network security policies to be deleted are: %s", key, cache_nsp_nodes)
+	return cache_nsp_nodes, rest_ops
+}
+
+func (rest *RestOperations) NetworkSecurityPolicyDelete(nsp_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, del_nsp := range nsp_to_delete {
+		// fetch the network security policy uuid from cache
+		nsp_key := avicache.NamespaceName{Namespace: namespace, Name: del_nsp.Name}
+		nsp_cache, ok := rest.cache.NSPCache.AviCacheGet(nsp_key)
+		if ok {
+			nsp_cache_obj, _ := nsp_cache.(*avicache.AviNetworkSecurityPolicyCache)
+			restOp := rest.AviNetworkSecurityPolicyDel(nsp_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_nsp.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
 func (rest *RestOperations) KeyCertCU(sslkey_nodes []*nodes.AviTLSKeyCertNode, certKeys []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
 	// Default is POST
 	var cache_ssl_nodes []avicache.NamespaceName
@@ -1898,6 +2104,116 @@ func (rest *RestOperations) PkiProfileDelete(pkiProfileDelete []avicache.Namespa
 	return rest_ops
 }
 
+func (rest *RestOperations) HealthMonitorCU(hm_node *nodes.AviHealthMonitorNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	// Default is POST
+	var cache_hm_nodes []avicache.NamespaceName
+	if pool_cache_obj != nil {
+		if pool_cache_obj.HealthMonitorCollection.Name != "" {
+			cache_hm_nodes = []avicache.NamespaceName{pool_cache_obj.HealthMonitorCollection}
+		}
+		if hm_node != nil {
+			hm_key := avicache.NamespaceName{Namespace: namespace, Name: hm_node.Name}
+			found := utils.HasElem(cache_hm_nodes, hm_key)
+			hm_cache, ok := rest.cache.HMCache.AviCacheGet(hm_key)
+			if found && ok {
+				cache_hm_nodes = avicache.RemoveNamespaceName(cache_hm_nodes, hm_key)
+				hm_cache_obj, _ := hm_cache.(*avicache.AviHealthMonitorCache)
+				if hm_cache_obj.CloudConfigCksum == hm_node.GetCheckSum() {
+					utils.AviLog.Debugf("key: %s, msg: the checksums are same for health monitor cache obj %s, not doing anything", key, hm_cache_obj.Name)
+				} else {
+					// The checksums are different, so it should be a PUT call.
+					restOp := rest.AviHealthMonitorBuild(hm_node, hm_cache_obj, key)
+					if restOp != nil {
+						rest_ops = append(rest_ops, restOp)
+					}
+				}
+			} else {
+				restOp := rest.AviHealthMonitorBuild(hm_node, nil, key)
+				if restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+			}
+		}
+	} else if hm_node != nil {
+		// Everything is a POST call
+		restOp := rest.AviHealthMonitorBuild(hm_node, nil, key)
+		if restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return cache_hm_nodes, rest_ops
+}
+
+func (rest *RestOperations) HealthMonitorDelete(hm_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	utils.AviLog.Debugf("key: %s, msg: about to delete health monitors %s", key, utils.Stringify(hm_to_delete))
+	for _, del_hm := range hm_to_delete {
+		hm_key := avicache.NamespaceName{Namespace: namespace, Name: del_hm.Name}
+		hm_cache, ok := rest.cache.HMCache.AviCacheGet(hm_key)
+		if ok {
+			hm_cache_obj, _ := hm_cache.(*avicache.AviHealthMonitorCache)
+			restOp := rest.AviHealthMonitorDel(hm_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_hm.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) PersistenceProfileCU(persistence_node *nodes.AviPersistenceProfileNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	// Default is POST
+	var cache_persistence_nodes []avicache.NamespaceName
+	if pool_cache_obj != nil {
+		if pool_cache_obj.PersistenceProfileCollection.Name != "" {
+			cache_persistence_nodes = []avicache.NamespaceName{pool_cache_obj.PersistenceProfileCollection}
+		}
+		if persistence_node != nil {
+			persistence_key := avicache.NamespaceName{Namespace: namespace, Name: persistence_node.Name}
+			found := utils.HasElem(cache_persistence_nodes, persistence_key)
+			persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key)
+			if found && ok {
+				cache_persistence_nodes = avicache.RemoveNamespaceName(cache_persistence_nodes, persistence_key)
+				persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+				if persistence_cache_obj.CloudConfigCksum == persistence_node.GetCheckSum() {
+					utils.AviLog.Debugf("key: %s, msg: the checksums are same for persistence profile cache obj %s, not doing anything", key, persistence_cache_obj.Name)
+				} else {
+					// The checksums are different, so it should be a PUT call.
+					restOp := rest.AviPersistenceProfileBuild(persistence_node, persistence_cache_obj, key)
+					if restOp != nil {
+						rest_ops = append(rest_ops, restOp)
+					}
+				}
+			} else {
+				restOp := rest.AviPersistenceProfileBuild(persistence_node, nil, key)
+				if restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+			}
+		}
+	} else if persistence_node != nil {
+		// Everything is a POST call
+		restOp := rest.AviPersistenceProfileBuild(persistence_node, nil, key)
+		if restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return cache_persistence_nodes, rest_ops
+}
+
+func (rest *RestOperations) PersistenceProfileDelete(persistence_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	utils.AviLog.Debugf("key: %s, msg: about to delete persistence profiles %s", key, utils.Stringify(persistence_to_delete))
+	for _, del_persistence := range persistence_to_delete {
+		persistence_key := avicache.NamespaceName{Namespace: namespace, Name: del_persistence.Name}
+		persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key)
+		if ok {
+			persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+			restOp := rest.AviPersistenceProfileDel(persistence_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_persistence.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
 func (rest *RestOperations) stringGroupCU(key, stringGroupName string, avimodel *nodes.AviObjectGraph) {
 	var cache_sg_node avicache.NamespaceName
 	var rest_ops []*utils.RestOp
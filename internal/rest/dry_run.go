@@ -0,0 +1,63 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+)
+
+// recordDryRunOperations records the rest operations of a model as the pending changes of its virtualservice,
+// in place of executing them on the controller. The object caches are not updated, so that the subsequent syncs
+// keep reporting the changes against the objects present on the controller.
+func recordDryRunOperations(restOps []*utils.RestOp, key string) {
+	var operations []models.DryRunOperation
+	for _, restOp := range restOps {
+		obj := redactDryRunObject(restOp.Obj)
+		utils.AviLog.Infof("key: %s, msg: dry run, skipping %s of %s %s in tenant %s", key, restOp.Method, restOp.Model, restOp.ObjName, restOp.Tenant)
+		utils.AviLog.Debugf("key: %s, msg: dry run, object: %s", key, utils.Stringify(obj))
+		operations = append(operations, models.DryRunOperation{
+			Method: string(restOp.Method),
+			Model:  restOp.Model,
+			Name:   restOp.ObjName,
+			Tenant: restOp.Tenant,
+			Path:   restOp.Path,
+			Object: obj,
+		})
+	}
+	models.DryRun.AddOperations(key, operations)
+}
+
+// redactDryRunObject returns the object of a rest operation as recorded in the dry run plan, without the private
+// key and its passphrase, as the plans are served by the API server.
+func redactDryRunObject(obj interface{}) interface{} {
+	switch sslkeycert := obj.(type) {
+	case avimodels.SSLKeyAndCertificate:
+		sslkeycert.Key = nil
+		sslkeycert.KeyPassphrase = nil
+		return sslkeycert
+	case *avimodels.SSLKeyAndCertificate:
+		if sslkeycert == nil {
+			return obj
+		}
+		redacted := *sslkeycert
+		redacted.Key = nil
+		redacted.KeyPassphrase = nil
+		return &redacted
+	}
+	return obj
+}
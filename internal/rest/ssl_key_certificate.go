@@ -170,6 +170,7 @@ func (rest *RestOperations) AviSSLKeyCertAdd(rest_op *utils.RestOp, vsKey avicac
 			Uuid:             uuid,
 			CloudConfigCksum: lib.SSLKeyCertChecksum(name, cert, cacert, emptyIngestionMarkers, SSLKeyAndCertificate.Markers, true),
 			HasCARef:         hasCA,
+			Cert:             cert,
 		}
 
 		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
@@ -324,8 +325,8 @@ func (rest *RestOperations) AviPkiProfileAdd(rest_op *utils.RestOp, poolKey avic
 		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
 		rest.cache.PKIProfileCache.AviCacheAdd(k, &pki_cache_obj)
 
-		// Update the Pool object
-		if poolKey != (avicache.NamespaceName{}) {
+		// Update the Pool object, the PKI profile for the client certificates is referred by the application profile of a VS.
+		if poolKey != (avicache.NamespaceName{}) && name != lib.GetClientPKIProfileName(poolKey.Name) {
 			pool_cache, ok := rest.cache.PoolCache.AviCacheGet(poolKey)
 			if ok {
 				pool_cache_obj, found := pool_cache.(*avicache.AviPoolCache)
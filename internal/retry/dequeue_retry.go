@@ -23,7 +23,7 @@ func DequeueFastRetry(vsKey string) {
 	utils.AviLog.Infof("Retrieved the key for fast retry: %s", vsKey)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 	//modelName := lib.GetTenant() + "/" + vsKey
-	nodes.PublishKeyToRestLayer(vsKey, "retry", sharedQueue)
+	nodes.PublishKeyToRestLayerWithPriority(vsKey, "retry", sharedQueue, utils.PriorityRetry)
 
 }
 
@@ -31,6 +31,6 @@ func DequeueSlowRetry(vsKey string) {
 	utils.AviLog.Infof("Retrieved the key for slow retry: %s", vsKey)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 	//modelName := lib.GetTenant() + "/" + vsKey
-	nodes.PublishKeyToRestLayer(vsKey, "retry", sharedQueue)
+	nodes.PublishKeyToRestLayerWithPriority(vsKey, "retry", sharedQueue, utils.PriorityRetry)
 
 }
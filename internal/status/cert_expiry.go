@@ -0,0 +1,100 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// certificateExpiryWarningPeriod is the period before the expiry of a certificate, during which it is reported as expiring.
+const certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// CheckCertificates records warning events on the kubernetes objects, whose certificates programmed on the Avi controller
+// are expired, are about to expire, or do not match the hostnames for which these are served.
+// getOwners returns the kubernetes objects, for which a virtual service is built.
+func CheckCertificates(recorder *utils.EventRecorder, getOwners func(svcMetadata lib.ServiceMetadataObj) []runtime.Object) {
+	now := time.Now()
+	for _, vsCertificate := range avicache.GetVSCertificates() {
+		certificate := vsCertificate.Certificate
+		vsName := vsCertificate.VS.Tenant + "/" + vsCertificate.VS.Name
+		var reason, message string
+		if now.After(certificate.NotAfter) {
+			reason = lib.CertificateExpired
+			message = "Certificate %s (%s) on virtualservice %s expired at %s"
+		} else if now.Add(certificateExpiryWarningPeriod).After(certificate.NotAfter) {
+			reason = lib.CertificateExpiring
+			message = "Certificate %s (%s) on virtualservice %s expires at %s"
+		}
+		owners := getOwners(vsCertificate.VS.ServiceMetadataObj)
+		if reason != "" {
+			expiry := certificate.NotAfter.UTC().Format(time.RFC3339)
+			utils.AviLog.Warnf(message, vsCertificate.Key.Name, certificate.Subject.CommonName, vsName, expiry)
+			for _, owner := range owners {
+				recorder.Eventf(owner, corev1.EventTypeWarning, reason, message, vsCertificate.Key.Name, certificate.Subject.CommonName, vsName, expiry)
+			}
+		}
+		if len(vsCertificate.MismatchedHosts) > 0 {
+			hosts := strings.Join(vsCertificate.MismatchedHosts, ", ")
+			utils.AviLog.Warnf("Certificate %s (%s) on virtualservice %s does not match the hosts %s", vsCertificate.Key.Name, certificate.Subject.CommonName, vsName, hosts)
+			for _, owner := range owners {
+				recorder.Eventf(owner, corev1.EventTypeWarning, lib.CertificateHostMismatch, "Certificate %s (%s) on virtualservice %s does not match the hosts %s",
+					vsCertificate.Key.Name, certificate.Subject.CommonName, vsName, hosts)
+			}
+		}
+	}
+}
+
+// GetCertificateOwners returns the Ingresses or Routes, and the HostRule, for which a virtual service is built.
+func GetCertificateOwners(svcMetadata lib.ServiceMetadataObj) []runtime.Object {
+	var owners []runtime.Object
+	for _, k8sObject := range appendAttachments(nil, getK8sObjects(svcMetadata)...) {
+		var obj runtime.Object
+		var err error
+		switch k8sObject.Kind {
+		case utils.Ingress:
+			if utils.GetInformers().IngressInformer == nil {
+				continue
+			}
+			obj, err = utils.GetInformers().IngressInformer.Lister().Ingresses(k8sObject.Namespace).Get(k8sObject.Name)
+		case "Route":
+			obj, err = utils.GetInformers().RouteInformer.Lister().Routes(k8sObject.Namespace).Get(k8sObject.Name)
+		default:
+			continue
+		}
+		if err == nil {
+			owners = append(owners, obj)
+		}
+	}
+
+	crdStatus := svcMetadata.CRDStatus
+	if crdStatus.Type == lib.HostRule && crdStatus.Status == lib.CRDActive && lib.AKOControlConfig().CRDInformers() != nil &&
+		lib.AKOControlConfig().CRDInformers().HostRuleInformer != nil {
+		if namespace, name, found := strings.Cut(crdStatus.Value, "/"); found {
+			hostrule, err := lib.AKOControlConfig().CRDInformers().HostRuleInformer.Lister().HostRules(namespace).Get(name)
+			if err == nil {
+				owners = append(owners, hostrule)
+			}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,513 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	servicesapi "sigs.k8s.io/service-apis/apis/v1alpha1"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// crdAttachmentIndex maps the CRDs to the Avi virtual services and pools in the cache, to which these are applied. It is
+// updated when the virtual services and the pools are added to or deleted from the cache, so that the attachments of
+// a CRD are looked up without scanning the cache.
+type crdAttachmentIndex struct {
+	lock sync.RWMutex
+	// objs maps the key of a virtual service or a pool to the CRDs applied to it.
+	objs map[string]indexedObj
+	// crds maps the key of a CRD to the keys of the virtual services and the pools, to which it is applied.
+	crds map[string]map[string]struct{}
+}
+
+type indexedObj struct {
+	// vsKey is the virtual service, or the virtual service of the pool.
+	vsKey   avicache.NamespaceName
+	crdKeys map[string]struct{}
+}
+
+var crdIndex = &crdAttachmentIndex{
+	objs: make(map[string]indexedObj),
+	crds: make(map[string]map[string]struct{}),
+}
+
+// set records the CRDs applied to a virtual service or a pool, and returns the CRDs applied to it earlier.
+func (i *crdAttachmentIndex) set(objKey string, vsKey avicache.NamespaceName, crdKeys map[string]struct{}) map[string]struct{} {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	oldCRDKeys := i.remove(objKey)
+	if len(crdKeys) != 0 {
+		i.add(objKey, indexedObj{vsKey: vsKey, crdKeys: crdKeys})
+	}
+	return oldCRDKeys
+}
+
+// delete removes a virtual service or a pool, and returns the CRDs applied to it.
+func (i *crdAttachmentIndex) delete(objKey string) map[string]struct{} {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.remove(objKey)
+}
+
+// reset replaces the index with the virtual services and the pools in objs.
+func (i *crdAttachmentIndex) reset(objs map[string]indexedObj) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.objs = make(map[string]indexedObj)
+	i.crds = make(map[string]map[string]struct{})
+	for objKey, obj := range objs {
+		i.add(objKey, obj)
+	}
+}
+
+func (i *crdAttachmentIndex) add(objKey string, obj indexedObj) {
+	i.objs[objKey] = obj
+	for crdKey := range obj.crdKeys {
+		if _, ok := i.crds[crdKey]; !ok {
+			i.crds[crdKey] = make(map[string]struct{})
+		}
+		i.crds[crdKey][objKey] = struct{}{}
+	}
+}
+
+func (i *crdAttachmentIndex) remove(objKey string) map[string]struct{} {
+	obj, ok := i.objs[objKey]
+	if !ok {
+		return nil
+	}
+	delete(i.objs, objKey)
+	for crdKey := range obj.crdKeys {
+		delete(i.crds[crdKey], objKey)
+		if len(i.crds[crdKey]) == 0 {
+			delete(i.crds, crdKey)
+		}
+	}
+	return obj.crdKeys
+}
+
+// getVirtualServices returns the virtual services, to which a CRD is applied directly or via their pools.
+func (i *crdAttachmentIndex) getVirtualServices(crdKey string) []avicache.NamespaceName {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	vsKeys := make(map[avicache.NamespaceName]struct{})
+	for objKey := range i.crds[crdKey] {
+		vsKeys[i.objs[objKey].vsKey] = struct{}{}
+	}
+	var vsKeyList []avicache.NamespaceName
+	for vsKey := range vsKeys {
+		vsKeyList = append(vsKeyList, vsKey)
+	}
+	return vsKeyList
+}
+
+func vsIndexKey(vsKey avicache.NamespaceName) string {
+	return lib.AttachedVirtualService + "/" + vsKey.Namespace + "/" + vsKey.Name
+}
+
+func poolIndexKey(poolKey avicache.NamespaceName) string {
+	return "Pool/" + poolKey.Namespace + "/" + poolKey.Name
+}
+
+// vsCRDKeys returns the keys of the HostRule, SSORule, L4Rules and AviInfraSettings applied to a virtual service.
+func vsCRDKeys(svcMetadataObj lib.ServiceMetadataObj) map[string]struct{} {
+	crdKeys := infraSettingKeys(svcMetadataObj)
+	crdStatus := svcMetadataObj.CRDStatus
+	if segments := strings.Split(crdStatus.Value, "/"); (crdStatus.Type == lib.HostRule || crdStatus.Type == lib.SSORule) &&
+		crdStatus.Status == lib.CRDActive && len(segments) == 2 {
+		crdKeys[crdStatus.Type+"/"+crdStatus.Value] = struct{}{}
+	}
+	for _, svcNSName := range svcMetadataObj.NamespaceServiceName {
+		if l4RuleKey := getL4RuleKey(svcNSName); l4RuleKey != "" {
+			crdKeys[l4RuleKey] = struct{}{}
+		}
+	}
+	return crdKeys
+}
+
+// poolCRDKeys returns the keys of the HTTPRule and the AviInfraSetting applied to a pool.
+func poolCRDKeys(svcMetadataObj lib.ServiceMetadataObj) map[string]struct{} {
+	crdKeys := infraSettingKeys(svcMetadataObj)
+	// The value of the HTTPRule metadata is namespace/name/path.
+	crdStatus := svcMetadataObj.CRDStatus
+	if segments := strings.SplitN(crdStatus.Value, "/", 3); crdStatus.Type == lib.HTTPRule && len(segments) == 3 {
+		crdKeys[lib.HTTPRule+"/"+segments[0]+"/"+segments[1]] = struct{}{}
+	}
+	return crdKeys
+}
+
+// getL4RuleKey returns the key of the L4Rule referred by the annotation of the Service.
+func getL4RuleKey(svcNSName string) string {
+	svcNamespace, svcName, found := strings.Cut(svcNSName, "/")
+	if !found || utils.GetInformers().ServiceInformer == nil {
+		return ""
+	}
+	svc, err := utils.GetInformers().ServiceInformer.Lister().Services(svcNamespace).Get(svcName)
+	if err != nil {
+		return ""
+	}
+	l4RuleName, ok := svc.GetAnnotations()[lib.L4RuleAnnotation]
+	if !ok || l4RuleName == "" {
+		return ""
+	}
+	l4RuleNamespace, _, l4RuleName := lib.ExtractTypeNameNamespace(l4RuleName)
+	if l4RuleNamespace != "" && l4RuleNamespace != svcNamespace {
+		return ""
+	}
+	return lib.L4Rule + "/" + svcNamespace + "/" + l4RuleName
+}
+
+// crdIndexKey returns the key of a CRD in the index. The namespace is omitted for the cluster scoped AviInfraSetting.
+func crdIndexKey(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// infraSettingKeys returns the keys of the AviInfraSettings applied to the Kubernetes objects, for which a virtual
+// service or a pool is built.
+func infraSettingKeys(svcMetadataObj lib.ServiceMetadataObj) map[string]struct{} {
+	crdKeys := make(map[string]struct{})
+	if lib.AKOControlConfig().CRDInformers() == nil || lib.AKOControlConfig().CRDInformers().AviInfraSettingInformer == nil {
+		return crdKeys
+	}
+	addInfraSetting := func(infraSettingName string) {
+		if infraSettingName != "" {
+			crdKeys[crdIndexKey(lib.AviInfraSetting, "", infraSettingName)] = struct{}{}
+		}
+	}
+	ingNSNames := append([]string{}, svcMetadataObj.NamespaceIngressName...)
+	if svcMetadataObj.Namespace != "" && svcMetadataObj.IngressName != "" {
+		ingNSNames = append(ingNSNames, svcMetadataObj.Namespace+"/"+svcMetadataObj.IngressName)
+	}
+	for _, ingNSName := range ingNSNames {
+		addInfraSetting(getIngressInfraSetting(ingNSName))
+	}
+	if svcMetadataObj.Gateway != "" {
+		addInfraSetting(getGatewayInfraSetting(svcMetadataObj.Gateway))
+		return crdKeys
+	}
+	for _, svcNSName := range svcMetadataObj.NamespaceServiceName {
+		addInfraSetting(getServiceInfraSetting(svcNSName))
+	}
+	return crdKeys
+}
+
+// getIngressInfraSetting returns the name of the AviInfraSetting applied to an Ingress via its IngressClass, or to
+// a Route via its annotation, and otherwise via their namespace.
+func getIngressInfraSetting(ingNSName string) string {
+	namespace, name, found := strings.Cut(ingNSName, "/")
+	if !found {
+		return ""
+	}
+	informers := utils.GetInformers()
+	if informers.RouteInformer != nil {
+		route, err := informers.RouteInformer.Lister().Routes(namespace).Get(name)
+		if err != nil {
+			return ""
+		}
+		if infraSettingName := route.GetAnnotations()[lib.InfraSettingNameAnnotation]; infraSettingName != "" {
+			return infraSettingName
+		}
+		return getNamespaceInfraSetting(namespace)
+	}
+	if informers.IngressInformer == nil || informers.IngressClassInformer == nil {
+		return ""
+	}
+	ingress, err := informers.IngressInformer.Lister().Ingresses(namespace).Get(name)
+	if err != nil {
+		return ""
+	}
+	ingClassName := utils.String(ingress.Spec.IngressClassName)
+	if ingClassName == "" {
+		ingClassName, _ = lib.IsAviLBDefaultIngressClass()
+	}
+	if ingClassName != "" {
+		ingClass, err := informers.IngressClassInformer.Lister().Get(ingClassName)
+		if err != nil {
+			return ""
+		}
+		if params := ingClass.Spec.Parameters; params != nil && params.APIGroup != nil && *params.APIGroup == lib.AkoGroup &&
+			params.Kind == lib.AviInfraSetting {
+			return params.Name
+		}
+	}
+	return getNamespaceInfraSetting(namespace)
+}
+
+// getServiceInfraSetting returns the name of the AviInfraSetting applied to a Service via its annotation, and
+// otherwise via its namespace.
+func getServiceInfraSetting(svcNSName string) string {
+	namespace, name, found := strings.Cut(svcNSName, "/")
+	if !found || utils.GetInformers().ServiceInformer == nil {
+		return ""
+	}
+	svc, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(name)
+	if err != nil {
+		return ""
+	}
+	if infraSettingName := svc.GetAnnotations()[lib.InfraSettingNameAnnotation]; infraSettingName != "" {
+		return infraSettingName
+	}
+	return getNamespaceInfraSetting(namespace)
+}
+
+// getGatewayInfraSetting returns the name of the AviInfraSetting applied to a services API Gateway via its
+// GatewayClass, and otherwise via its namespace.
+func getGatewayInfraSetting(gwNSName string) string {
+	namespace, name, found := strings.Cut(gwNSName, "/")
+	if !found || !lib.UseServicesAPI() || lib.AKOControlConfig().SvcAPIInformers() == nil {
+		return ""
+	}
+	svcAPIInformers := lib.AKOControlConfig().SvcAPIInformers()
+	gateway, err := svcAPIInformers.GatewayInformer.Lister().Gateways(namespace).Get(name)
+	if err != nil {
+		return ""
+	}
+	gwClass, err := svcAPIInformers.GatewayClassInformer.Lister().Get(gateway.Spec.GatewayClassName)
+	if err != nil {
+		return ""
+	}
+	if params := gwClass.Spec.ParametersRef; params != nil && params.Group == lib.AkoGroup && params.Kind == lib.AviInfraSetting {
+		return params.Name
+	}
+	return getNamespaceInfraSetting(namespace)
+}
+
+// getNamespaceInfraSetting returns the name of the AviInfraSetting applied to a namespace via its annotation.
+func getNamespaceInfraSetting(namespace string) string {
+	if utils.GetInformers().NSInformer == nil {
+		return ""
+	}
+	nsObj, err := utils.GetInformers().NSInformer.Lister().Get(namespace)
+	if err != nil {
+		return ""
+	}
+	return nsObj.GetAnnotations()[lib.InfraSettingNameAnnotation]
+}
+
+// getInfraSettingK8sObjects returns the Namespaces, Ingresses, Routes, Services and services API Gateways, to which
+// an AviInfraSetting is applied via their annotations or classes.
+func getInfraSettingK8sObjects(infraSettingName string) []akov1beta1.AttachedObject {
+	var k8sObjects []akov1beta1.AttachedObject
+	informers := utils.GetInformers()
+	if informers.NSInformer != nil {
+		namespaces, _ := informers.NSInformer.Informer().GetIndexer().ByIndex(lib.AviSettingNamespaceIndex, infraSettingName)
+		for _, namespace := range namespaces {
+			if nsObj, ok := namespace.(*corev1.Namespace); ok {
+				k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: utils.Namespace, Name: nsObj.Name})
+			}
+		}
+	}
+	if informers.IngressClassInformer != nil {
+		ingClasses, _ := informers.IngressClassInformer.Informer().GetIndexer().ByIndex(lib.AviSettingIngClassIndex, lib.AkoGroup+"/"+lib.AviInfraSetting+"/"+infraSettingName)
+		for _, ingClass := range ingClasses {
+			ingClassObj, ok := ingClass.(*networkingv1.IngressClass)
+			if !ok {
+				continue
+			}
+			_, ingNSNames := objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).GetClassToIng(ingClassObj.Name)
+			for _, ingNSName := range ingNSNames {
+				if namespace, name, found := strings.Cut(ingNSName, "/"); found {
+					k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: utils.Ingress, Namespace: namespace, Name: name})
+				}
+			}
+		}
+	}
+	if informers.RouteInformer != nil {
+		routes, _ := informers.RouteInformer.Informer().GetIndexer().ByIndex(lib.AviSettingRouteIndex, infraSettingName)
+		for _, route := range routes {
+			if routeObj, ok := route.(*routev1.Route); ok {
+				k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: "Route", Namespace: routeObj.Namespace, Name: routeObj.Name})
+			}
+		}
+	}
+	if informers.ServiceInformer != nil {
+		services, _ := informers.ServiceInformer.Informer().GetIndexer().ByIndex(lib.AviSettingServicesIndex, infraSettingName)
+		for _, svc := range services {
+			if svcObj, ok := svc.(*corev1.Service); ok {
+				k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: utils.Service, Namespace: svcObj.Namespace, Name: svcObj.Name})
+			}
+		}
+	}
+	if lib.UseServicesAPI() && lib.AKOControlConfig().SvcAPIInformers() != nil {
+		svcAPIInformers := lib.AKOControlConfig().SvcAPIInformers()
+		gwClasses, _ := svcAPIInformers.GatewayClassInformer.Informer().GetIndexer().ByIndex(lib.AviSettingGWClassIndex, lib.AkoGroup+"/"+lib.AviInfraSetting+"/"+infraSettingName)
+		for _, gwClass := range gwClasses {
+			gwClassObj, ok := gwClass.(*servicesapi.GatewayClass)
+			if !ok {
+				continue
+			}
+			gateways, _ := svcAPIInformers.GatewayInformer.Informer().GetIndexer().ByIndex(lib.GatewayClassGatewayIndex, gwClassObj.Name)
+			for _, gateway := range gateways {
+				if gatewayObj, ok := gateway.(*servicesapi.Gateway); ok {
+					k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: lib.Gateway, Namespace: gatewayObj.Namespace, Name: gatewayObj.Name})
+				}
+			}
+		}
+	}
+	return k8sObjects
+}
+
+// RebuildCRDAttachmentIndex indexes the CRDs applied to the virtual services and the pools, after the cache is
+// populated from the Avi controller.
+func RebuildCRDAttachmentIndex() {
+	objs := make(map[string]indexedObj)
+	aviObjCache := avicache.SharedAviObjCache()
+	for _, vsKey := range aviObjCache.VsCacheMeta.AviGetAllKeys() {
+		vsCache, ok := aviObjCache.VsCacheMeta.AviCacheGet(vsKey)
+		if !ok {
+			continue
+		}
+		vsCacheObj, ok := vsCache.(*avicache.AviVsCache)
+		if !ok {
+			continue
+		}
+		if crdKeys := vsCRDKeys(vsCacheObj.ServiceMetadataObj); len(crdKeys) != 0 {
+			objs[vsIndexKey(vsKey)] = indexedObj{vsKey: vsKey, crdKeys: crdKeys}
+		}
+		for _, poolKey := range vsCacheObj.PoolKeyCollection {
+			poolCache, ok := aviObjCache.PoolCache.AviCacheGet(poolKey)
+			if !ok {
+				continue
+			}
+			if poolCacheObj, ok := poolCache.(*avicache.AviPoolCache); ok {
+				if crdKeys := poolCRDKeys(poolCacheObj.ServiceMetadataObj); len(crdKeys) != 0 {
+					objs[poolIndexKey(poolKey)] = indexedObj{vsKey: vsKey, crdKeys: crdKeys}
+				}
+			}
+		}
+	}
+	crdIndex.reset(objs)
+	utils.AviLog.Debugf("Indexed the CRDs applied to %d virtual services and pools", len(objs))
+}
+
+// getCRDAttachments returns the Kubernetes objects and the Avi virtual services, to which a CRD is currently applied.
+// The attachments are derived from the Avi object caches, so that these reflect the configuration on the Avi controller.
+func getCRDAttachments(kind, namespace, name string) []akov1beta1.AttachedObject {
+	var attachments []akov1beta1.AttachedObject
+	if kind == lib.L7Rule {
+		// The L7Rule is applied to the virtual services via the HostRules.
+		if found, hostRules := objects.SharedCRDLister().GetL7RuleToHostRuleMapping(namespace + "/" + name); found {
+			for hostRule := range hostRules {
+				hrNamespace, hrName, _ := strings.Cut(hostRule, "/")
+				attachments = appendAttachments(attachments, getCRDAttachments(lib.HostRule, hrNamespace, hrName)...)
+			}
+		}
+		sortAttachments(attachments)
+		return attachments
+	}
+
+	vsCache := avicache.SharedAviObjCache().VsCacheMeta
+	for _, vsKey := range crdIndex.getVirtualServices(crdIndexKey(kind, namespace, name)) {
+		obj, ok := vsCache.AviCacheGet(vsKey)
+		if !ok {
+			continue
+		}
+		vsCacheObj, ok := obj.(*avicache.AviVsCache)
+		if !ok {
+			continue
+		}
+		attachments = appendAttachments(attachments, akov1beta1.AttachedObject{
+			Kind:   lib.AttachedVirtualService,
+			Tenant: vsCacheObj.Tenant,
+			Name:   vsCacheObj.Name,
+		})
+		if kind != lib.AviInfraSetting {
+			attachments = appendAttachments(attachments, getK8sObjects(vsCacheObj.ServiceMetadataObj)...)
+		}
+	}
+	if kind == lib.AviInfraSetting {
+		// The objects sharing a virtual service are not recorded in its metadata, hence the objects using the
+		// AviInfraSetting are listed from their classes and annotations.
+		attachments = appendAttachments(attachments, getInfraSettingK8sObjects(name)...)
+	}
+	sortAttachments(attachments)
+	return attachments
+}
+
+// getK8sObjects returns the Kubernetes objects, for which an Avi virtual service is built.
+func getK8sObjects(svcMetadata lib.ServiceMetadataObj) []akov1beta1.AttachedObject {
+	ingressKind := utils.Ingress
+	if utils.GetInformers().RouteInformer != nil {
+		ingressKind = "Route"
+	}
+	var k8sObjects []akov1beta1.AttachedObject
+	addNamespacedName := func(kind, nsName string) {
+		if namespace, name, found := strings.Cut(nsName, "/"); found {
+			k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: kind, Namespace: namespace, Name: name})
+		}
+	}
+	for _, ingNSName := range svcMetadata.NamespaceIngressName {
+		addNamespacedName(ingressKind, ingNSName)
+	}
+	if svcMetadata.Namespace != "" && svcMetadata.IngressName != "" {
+		k8sObjects = append(k8sObjects, akov1beta1.AttachedObject{Kind: ingressKind, Namespace: svcMetadata.Namespace, Name: svcMetadata.IngressName})
+	}
+	for _, svcNSName := range svcMetadata.NamespaceServiceName {
+		addNamespacedName(utils.Service, svcNSName)
+	}
+	return k8sObjects
+}
+
+func appendAttachments(attachments []akov1beta1.AttachedObject, objs ...akov1beta1.AttachedObject) []akov1beta1.AttachedObject {
+	for _, obj := range objs {
+		found := false
+		for _, attachment := range attachments {
+			if attachment == obj {
+				found = true
+				break
+			}
+		}
+		if !found {
+			attachments = append(attachments, obj)
+		}
+	}
+	return attachments
+}
+
+func sortAttachments(attachments []akov1beta1.AttachedObject) {
+	sort.Slice(attachments, func(i, j int) bool {
+		if attachments[i].Kind != attachments[j].Kind {
+			return attachments[i].Kind < attachments[j].Kind
+		}
+		if attachments[i].Namespace+attachments[i].Tenant != attachments[j].Namespace+attachments[j].Tenant {
+			return attachments[i].Namespace+attachments[i].Tenant < attachments[j].Namespace+attachments[j].Tenant
+		}
+		return attachments[i].Name < attachments[j].Name
+	})
+}
+
+// countVirtualServices returns the number of the Avi virtual services in the attachments.
+func countVirtualServices(attachments []akov1beta1.AttachedObject) int {
+	count := 0
+	for _, attachment := range attachments {
+		if attachment.Kind == lib.AttachedVirtualService {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// setValidationConditions sets the Accepted and ResolvedRefs conditions of a CRD, from the result of its validation.
+func setValidationConditions(conditions *[]metav1.Condition, generation int64, updateStatus UpdateCRDStatusOptions) {
+	accepted := metav1.Condition{
+		Type:               lib.CRDConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             lib.CRDReasonAccepted,
+		ObservedGeneration: generation,
+	}
+	resolvedRefs := metav1.Condition{
+		Type:               lib.CRDConditionResolvedRefs,
+		Status:             metav1.ConditionTrue,
+		Reason:             lib.CRDReasonResolvedRefs,
+		ObservedGeneration: generation,
+	}
+	if updateStatus.Status == lib.StatusRejected {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = lib.CRDReasonInvalid
+		accepted.Message = updateStatus.Error
+		if updateStatus.UnresolvedRefs {
+			accepted.Reason = lib.CRDReasonRefNotFound
+			resolvedRefs.Status = metav1.ConditionFalse
+			resolvedRefs.Reason = lib.CRDReasonRefNotFound
+			resolvedRefs.Message = updateStatus.Error
+		} else {
+			// The references are not validated, if the object is invalid.
+			resolvedRefs.Status = metav1.ConditionUnknown
+			resolvedRefs.Reason = lib.CRDReasonPending
+		}
+	}
+	meta.SetStatusCondition(conditions, accepted)
+	meta.SetStatusCondition(conditions, resolvedRefs)
+}
+
+// setProgrammedCondition sets the Programmed condition of a CRD, from the Avi virtual services it is applied to.
+func setProgrammedCondition(conditions *[]metav1.Condition, generation int64, accepted bool, attachments []akov1beta1.AttachedObject) {
+	programmed := metav1.Condition{
+		Type:               lib.CRDConditionProgrammed,
+		Status:             metav1.ConditionFalse,
+		Reason:             lib.CRDReasonInvalid,
+		Message:            "Not applied to any virtual service, since the object is rejected",
+		ObservedGeneration: generation,
+	}
+	if accepted {
+		if count := countVirtualServices(attachments); count > 0 {
+			programmed.Status = metav1.ConditionTrue
+			programmed.Reason = lib.CRDReasonProgrammed
+			programmed.Message = fmt.Sprintf("Applied to %d virtual service(s)", count)
+		} else {
+			programmed.Reason = lib.CRDReasonNotAttached
+			programmed.Message = "Not applied to any virtual service"
+		}
+	}
+	meta.SetStatusCondition(conditions, programmed)
+}
+
+// statusPatchPayload returns the merge patch for the status of a CRD. The attachedTo list is removed from the status,
+// if the CRD is not applied to any object, since the empty list is omitted in the status. If only the conditions and
+// the attachments are patched, the status and the error set by the validation of the CRD are retained.
+func statusPatchPayload(crdStatus interface{}, attachmentsOnly bool) []byte {
+	statusMap := make(map[string]interface{})
+	statusBytes, _ := json.Marshal(crdStatus)
+	json.Unmarshal(statusBytes, &statusMap)
+	if attachmentsOnly {
+		delete(statusMap, "status")
+		delete(statusMap, "error")
+	}
+	if _, ok := statusMap["attachedTo"]; !ok {
+		statusMap["attachedTo"] = nil
+	}
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": statusMap,
+	})
+	return patchPayload
+}
+
+func toV1alpha2Attachments(attachments []akov1beta1.AttachedObject) []akov1alpha2.AttachedObject {
+	var v1alpha2Attachments []akov1alpha2.AttachedObject
+	for _, attachment := range attachments {
+		v1alpha2Attachments = append(v1alpha2Attachments, akov1alpha2.AttachedObject(attachment))
+	}
+	return v1alpha2Attachments
+}
+
+// UpdateVSCRDAttachments indexes the CRDs applied to a virtual service, which is added to or updated in the cache,
+// and queues the update of the attachments in the status of the CRDs applied to it before and after the update.
+func UpdateVSCRDAttachments(key string, vsKey avicache.NamespaceName, svcMetadataObj lib.ServiceMetadataObj) {
+	crdKeys := vsCRDKeys(svcMetadataObj)
+	oldCRDKeys := crdIndex.set(vsIndexKey(vsKey), vsKey, crdKeys)
+	queueCRDAttachmentUpdates(key, oldCRDKeys, crdKeys)
+}
+
+// DeleteVSCRDAttachments removes a virtual service deleted from the cache from the index, and queues the update of
+// the attachments in the status of the CRDs applied to it.
+func DeleteVSCRDAttachments(key string, vsKey avicache.NamespaceName) {
+	queueCRDAttachmentUpdates(key, crdIndex.delete(vsIndexKey(vsKey)))
+}
+
+// UpdatePoolCRDAttachments indexes the HTTPRule applied to a pool of a virtual service, which is added to or updated
+// in the cache, and queues the update of the attachments in the status of the HTTPRules applied to it before and after
+// the update.
+func UpdatePoolCRDAttachments(key string, poolKey, vsKey avicache.NamespaceName, svcMetadataObj lib.ServiceMetadataObj) {
+	crdKeys := poolCRDKeys(svcMetadataObj)
+	oldCRDKeys := crdIndex.set(poolIndexKey(poolKey), vsKey, crdKeys)
+	queueCRDAttachmentUpdates(key, oldCRDKeys, crdKeys)
+}
+
+// DeletePoolCRDAttachments removes a pool deleted from the cache from the index, and queues the update of the
+// attachments in the status of the HTTPRule applied to it.
+func DeletePoolCRDAttachments(key string, poolKey avicache.NamespaceName) {
+	queueCRDAttachmentUpdates(key, crdIndex.delete(poolIndexKey(poolKey)))
+}
+
+// queueCRDAttachmentUpdates adds the updates of the Programmed condition and the attachments in the status of the CRDs,
+// and of the L7Rules referred by the HostRules among them, to the status queue.
+func queueCRDAttachmentUpdates(key string, crdKeySets ...map[string]struct{}) {
+	if lib.AKOControlConfig().CRDInformers() == nil {
+		return
+	}
+	crdKeys := make(map[string]struct{})
+	for _, crdKeySet := range crdKeySets {
+		for crdKey := range crdKeySet {
+			crdKeys[crdKey] = struct{}{}
+			kind, namespace, name := lib.ExtractTypeNameNamespace(crdKey)
+			// The L7Rule referred by the HostRule is applied to the same virtual services.
+			if kind != lib.HostRule || lib.AKOControlConfig().CRDInformers().HostRuleInformer == nil {
+				continue
+			}
+			hostRule, err := lib.AKOControlConfig().CRDInformers().HostRuleInformer.Lister().HostRules(namespace).Get(name)
+			if err == nil && hostRule.Spec.VirtualHost.L7Rule != "" {
+				crdKeys[lib.L7Rule+"/"+namespace+"/"+hostRule.Spec.VirtualHost.L7Rule] = struct{}{}
+			}
+		}
+	}
+	for crdKey := range crdKeys {
+		kind, namespace, name := lib.ExtractTypeNameNamespace(crdKey)
+		PublishToStatusQueue(crdKey, StatusOptions{
+			ObjType:   kind,
+			Op:        lib.UpdateStatus,
+			ObjName:   name,
+			Namespace: namespace,
+			Key:       key,
+		})
+	}
+}
+
+// updateCRDAttachments patches the Programmed condition and the attachments in the status of a CRD, if these are changed.
+func updateCRDAttachments(key, kind, namespace, name string) {
+	informers := lib.AKOControlConfig().CRDInformers()
+	attachments := getCRDAttachments(kind, namespace, name)
+	var crdStatus interface{}
+	var err error
+	switch kind {
+	case lib.HostRule:
+		if informers.HostRuleInformer == nil {
+			return
+		}
+		hostRule, getErr := informers.HostRuleInformer.Lister().HostRules(namespace).Get(name)
+		if getErr != nil || hostRule.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, hostRule.Status.Conditions...)
+		setProgrammedCondition(&conditions, hostRule.Generation, hostRule.Status.Status == lib.StatusAccepted, attachments)
+		if reflect.DeepEqual(conditions, hostRule.Status.Conditions) && reflect.DeepEqual(attachments, hostRule.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1beta1.HostRuleStatus{Conditions: conditions, AttachedTo: attachments}
+		_, err = lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HostRules(namespace).Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	case lib.HTTPRule:
+		if informers.HTTPRuleInformer == nil {
+			return
+		}
+		httpRule, getErr := informers.HTTPRuleInformer.Lister().HTTPRules(namespace).Get(name)
+		if getErr != nil || httpRule.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, httpRule.Status.Conditions...)
+		setProgrammedCondition(&conditions, httpRule.Generation, httpRule.Status.Status == lib.StatusAccepted, attachments)
+		if reflect.DeepEqual(conditions, httpRule.Status.Conditions) && reflect.DeepEqual(attachments, httpRule.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1beta1.HTTPRuleStatus{Conditions: conditions, AttachedTo: attachments}
+		_, err = lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules(namespace).Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	case lib.SSORule:
+		if informers.SSORuleInformer == nil {
+			return
+		}
+		ssoRule, getErr := informers.SSORuleInformer.Lister().SSORules(namespace).Get(name)
+		if getErr != nil || ssoRule.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, ssoRule.Status.Conditions...)
+		setProgrammedCondition(&conditions, ssoRule.Generation, ssoRule.Status.Status == lib.StatusAccepted, attachments)
+		v1alpha2Attachments := toV1alpha2Attachments(attachments)
+		if reflect.DeepEqual(conditions, ssoRule.Status.Conditions) && reflect.DeepEqual(v1alpha2Attachments, ssoRule.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1alpha2.SSORuleStatus{Conditions: conditions, AttachedTo: v1alpha2Attachments}
+		_, err = lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().SSORules(namespace).Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	case lib.L4Rule:
+		if informers.L4RuleInformer == nil {
+			return
+		}
+		l4Rule, getErr := informers.L4RuleInformer.Lister().L4Rules(namespace).Get(name)
+		if getErr != nil || l4Rule.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, l4Rule.Status.Conditions...)
+		setProgrammedCondition(&conditions, l4Rule.Generation, l4Rule.Status.Status == lib.StatusAccepted, attachments)
+		v1alpha2Attachments := toV1alpha2Attachments(attachments)
+		if reflect.DeepEqual(conditions, l4Rule.Status.Conditions) && reflect.DeepEqual(v1alpha2Attachments, l4Rule.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1alpha2.L4RuleStatus{Conditions: conditions, AttachedTo: v1alpha2Attachments}
+		_, err = lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().L4Rules(namespace).Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	case lib.L7Rule:
+		if informers.L7RuleInformer == nil {
+			return
+		}
+		l7Rule, getErr := informers.L7RuleInformer.Lister().L7Rules(namespace).Get(name)
+		if getErr != nil || l7Rule.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, l7Rule.Status.Conditions...)
+		setProgrammedCondition(&conditions, l7Rule.Generation, l7Rule.Status.Status == lib.StatusAccepted, attachments)
+		v1alpha2Attachments := toV1alpha2Attachments(attachments)
+		if reflect.DeepEqual(conditions, l7Rule.Status.Conditions) && reflect.DeepEqual(v1alpha2Attachments, l7Rule.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1alpha2.L7RuleStatus{Conditions: conditions, AttachedTo: v1alpha2Attachments}
+		_, err = lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().L7Rules(namespace).Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	case lib.AviInfraSetting:
+		if informers.AviInfraSettingInformer == nil {
+			return
+		}
+		infraSetting, getErr := informers.AviInfraSettingInformer.Lister().Get(name)
+		if getErr != nil || infraSetting.Status.Status == "" {
+			return
+		}
+		conditions := append([]metav1.Condition{}, infraSetting.Status.Conditions...)
+		setProgrammedCondition(&conditions, infraSetting.Generation, infraSetting.Status.Status == lib.StatusAccepted, attachments)
+		if reflect.DeepEqual(conditions, infraSetting.Status.Conditions) && reflect.DeepEqual(attachments, infraSetting.Status.AttachedTo) {
+			return
+		}
+		crdStatus = akov1beta1.AviInfraSettingStatus{Conditions: conditions, AttachedTo: attachments}
+		_, err = lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Patch(context.TODO(), name, types.MergePatchType, statusPatchPayload(crdStatus, true), metav1.PatchOptions{}, "status")
+	default:
+		return
+	}
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the attachments of %s %s/%s: %v", key, kind, namespace, name, err)
+		return
+	}
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the attachments of %s %s/%s: %s", key, kind, namespace, name, utils.Stringify(attachments))
+}
@@ -34,6 +34,8 @@ import (
 type UpdateCRDStatusOptions struct {
 	Status string
 	Error  string
+	// UnresolvedRefs is set if the CRD is rejected, since the objects it refers to are not found.
+	UnresolvedRefs bool
 }
 
 // UpdateHostRuleStatus HostRule status updates
@@ -47,9 +49,17 @@ func UpdateHostRuleStatus(key string, hr *akov1beta1.HostRule, updateStatus Upda
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1beta1.HostRuleStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, hr.Status.Conditions...)
+	setValidationConditions(&conditions, hr.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.HostRule, hr.Namespace, hr.Name)
+	setProgrammedCondition(&conditions, hr.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1beta1.HostRuleStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: hr.Generation,
+		Conditions:         conditions,
+		AttachedTo:         attachments,
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HostRules(hr.Namespace).Patch(context.TODO(), hr.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -117,9 +127,17 @@ func UpdateHTTPRuleStatus(key string, rr *akov1beta1.HTTPRule, updateStatus Upda
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1beta1.HTTPRuleStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, rr.Status.Conditions...)
+	setValidationConditions(&conditions, rr.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.HTTPRule, rr.Namespace, rr.Name)
+	setProgrammedCondition(&conditions, rr.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1beta1.HTTPRuleStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: rr.Generation,
+		Conditions:         conditions,
+		AttachedTo:         attachments,
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules(rr.Namespace).Patch(context.TODO(), rr.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -188,9 +206,17 @@ func UpdateAviInfraSettingStatus(key string, infraSetting *akov1beta1.AviInfraSe
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1beta1.AviInfraSettingStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, infraSetting.Status.Conditions...)
+	setValidationConditions(&conditions, infraSetting.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.AviInfraSetting, "", infraSetting.Name)
+	setProgrammedCondition(&conditions, infraSetting.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1beta1.AviInfraSettingStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: infraSetting.Generation,
+		Conditions:         conditions,
+		AttachedTo:         attachments,
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Patch(context.TODO(), infraSetting.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -220,9 +246,17 @@ func UpdateL4RuleStatus(key string, l4Rule *akov1alpha2.L4Rule, updateStatus Upd
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1alpha2.L4RuleStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, l4Rule.Status.Conditions...)
+	setValidationConditions(&conditions, l4Rule.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.L4Rule, l4Rule.Namespace, l4Rule.Name)
+	setProgrammedCondition(&conditions, l4Rule.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1alpha2.L4RuleStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: l4Rule.Generation,
+		Conditions:         conditions,
+		AttachedTo:         toV1alpha2Attachments(attachments),
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().L4Rules(l4Rule.Namespace).Patch(context.TODO(), l4Rule.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -291,9 +325,17 @@ func UpdateSSORuleStatus(key string, sr *akov1alpha2.SSORule, updateStatus Updat
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1alpha2.SSORuleStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, sr.Status.Conditions...)
+	setValidationConditions(&conditions, sr.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.SSORule, sr.Namespace, sr.Name)
+	setProgrammedCondition(&conditions, sr.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1alpha2.SSORuleStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: sr.Generation,
+		Conditions:         conditions,
+		AttachedTo:         toV1alpha2Attachments(attachments),
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().SSORules(sr.Namespace).Patch(context.TODO(), sr.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -361,9 +403,17 @@ func UpdateL7RuleStatus(key string, l7Rule *akov1alpha2.L7Rule, updateStatus Upd
 		}
 	}
 
-	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1alpha2.L7RuleStatus(updateStatus),
-	})
+	conditions := append([]metav1.Condition{}, l7Rule.Status.Conditions...)
+	setValidationConditions(&conditions, l7Rule.Generation, updateStatus)
+	attachments := getCRDAttachments(lib.L7Rule, l7Rule.Namespace, l7Rule.Name)
+	setProgrammedCondition(&conditions, l7Rule.Generation, updateStatus.Status == lib.StatusAccepted, attachments)
+	patchPayload := statusPatchPayload(akov1alpha2.L7RuleStatus{
+		Status:             updateStatus.Status,
+		Error:              updateStatus.Error,
+		ObservedGeneration: l7Rule.Generation,
+		Conditions:         conditions,
+		AttachedTo:         toV1alpha2Attachments(attachments),
+	}, false)
 
 	_, err := lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().L7Rules(l7Rule.Namespace).Patch(context.TODO(), l7Rule.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 	if err != nil {
@@ -382,6 +432,38 @@ func UpdateL7RuleStatus(key string, l7Rule *akov1alpha2.L7Rule, updateStatus Upd
 	utils.AviLog.Infof("key: %s, msg: Successfully updated the L7Rule %s status %+v", key, l7Rule.Name, utils.Stringify(updateStatus))
 }
 
+// UpdateRouteRuleExtensionStatus updates the RouteRuleExtension status
+func UpdateRouteRuleExtensionStatus(key string, routeRuleExtension *akov1alpha2.RouteRuleExtension, updateStatus UpdateCRDStatusOptions, retryNum ...int) {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 3 {
+			utils.AviLog.Errorf("key: %s, msg: UpdateRouteRuleExtensionStatus retried 3 times, aborting", key)
+			return
+		}
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": akov1alpha2.RouteRuleExtensionStatus{Status: updateStatus.Status, Error: updateStatus.Error},
+	})
+
+	_, err := lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().RouteRuleExtensions(routeRuleExtension.Namespace).Patch(context.TODO(), routeRuleExtension.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: %d there was an error in updating the RouteRuleExtension status: %+v", key, retry, err)
+		updatedRouteRuleExtensionObj, err := lib.AKOControlConfig().V1alpha2CRDClientset().AkoV1alpha2().RouteRuleExtensions(routeRuleExtension.Namespace).Get(context.TODO(), routeRuleExtension.Name, metav1.GetOptions{})
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: RouteRuleExtension not found %v", key, err)
+			if strings.Contains(err.Error(), utils.K8S_ETIMEDOUT) {
+				UpdateRouteRuleExtensionStatus(key, updatedRouteRuleExtensionObj, updateStatus, retry+1)
+			}
+			return
+		}
+		UpdateRouteRuleExtensionStatus(key, updatedRouteRuleExtensionObj, updateStatus, retry+1)
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the RouteRuleExtension %s status %+v", key, routeRuleExtension.Name, utils.Stringify(updateStatus))
+}
+
 // L7RuleEventBroadcast is responsible from broadcasting L7Rule specific events when the VS Cache is Added/Updated/Deleted.
 func L7RuleEventBroadcast(vsName string, vsCacheMetadataOld, vsMetadataNew lib.CRDMetadata) {
 	if vsCacheMetadataOld.Value != vsMetadataNew.Value {
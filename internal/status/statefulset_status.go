@@ -28,6 +28,9 @@ import (
 const ObjectDeletionStatus = "AviObjectDeletionStatus"
 const GatewayObjectDeletionStatus = "AviGatewayObjectDeletionStatus"
 
+// ConfigRestartRequiredStatus lists the settings changed in the configmap, which are applied only after AKO restarts.
+const ConfigRestartRequiredStatus = "AviConfigRestartRequired"
+
 // ResetStatefulSetStatus removes the condition set by AKO from AKO statefulset
 func ResetStatefulSetStatus() {
 	ss, err := utils.GetInformers().ClientSet.AppsV1().StatefulSets(utils.GetAKONamespace()).Get(context.TODO(), lib.AKOStatefulSet, metav1.GetOptions{})
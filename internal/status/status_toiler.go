@@ -87,6 +87,10 @@ func (l *leader) DequeueStatus(objIntf interface{}) error {
 		} else if obj.Op == lib.DeleteStatus {
 			l.DeleteMultiClusterIngressStatusAndAnnotation(obj.Key, obj.Options)
 		}
+	case lib.HostRule, lib.HTTPRule, lib.SSORule, lib.L4Rule, lib.L7Rule, lib.AviInfraSetting:
+		if obj.Op == lib.UpdateStatus {
+			updateCRDAttachments(obj.Key, obj.ObjType, obj.Namespace, obj.ObjName)
+		}
 	}
 	return nil
 }
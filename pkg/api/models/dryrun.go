@@ -0,0 +1,133 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DryRunOperation is a create, update or delete operation on an Avi object, which is not applied in the dry run mode.
+type DryRunOperation struct {
+	Method string      `json:"method"`
+	Model  string      `json:"model"`
+	Name   string      `json:"name,omitempty"`
+	Tenant string      `json:"tenant"`
+	Path   string      `json:"path"`
+	Object interface{} `json:"object,omitempty"`
+}
+
+// DryRunPlan holds the pending operations for a virtualservice, computed in the last sync of its model.
+type DryRunPlan struct {
+	Operations  []DryRunOperation `json:"operations"`
+	LastUpdated time.Time         `json:"last_updated"`
+}
+
+var DryRun *DryRunModel
+var dryrunonce sync.Once
+
+// DryRunModel implements ApiModel
+type DryRunModel struct {
+	Plans    map[string]*DryRunPlan `json:"virtualservices"`
+	planLock sync.RWMutex
+}
+
+func (a *DryRunModel) InitModel() {
+	dryrunonce.Do(func() {
+		DryRun = &DryRunModel{
+			Plans: make(map[string]*DryRunPlan),
+		}
+	})
+}
+
+func (a *DryRunModel) ApiOperationMap(prometheusEnavbled bool, reg *prometheus.Registry) []OperationMap {
+	var operationMapList []OperationMap
+
+	getAll := OperationMap{
+		Route:  "/api/dryrun",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			DryRun.planLock.RLock()
+			defer DryRun.planLock.RUnlock()
+			utils.Respond(w, DryRun)
+		},
+	}
+	operationMapList = append(operationMapList, getAll)
+
+	get := OperationMap{
+		Route:  "/api/dryrun/{tenant}/{name}",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			plan := DryRun.GetPlan(vars["tenant"] + "/" + vars["name"])
+			if plan == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			utils.Respond(w, plan)
+		},
+	}
+	operationMapList = append(operationMapList, get)
+	return operationMapList
+}
+
+// ResetPlan clears the pending operations of a virtualservice, at the start of the sync of its model.
+func (a *DryRunModel) ResetPlan(vsKey string) {
+	// The model is initialized only when the dry run mode is enabled.
+	if a == nil {
+		return
+	}
+	a.planLock.Lock()
+	defer a.planLock.Unlock()
+	delete(a.Plans, vsKey)
+}
+
+// AddOperations records the pending operations of a virtualservice.
+func (a *DryRunModel) AddOperations(vsKey string, operations []DryRunOperation) {
+	if a == nil || len(operations) == 0 {
+		return
+	}
+	a.planLock.Lock()
+	defer a.planLock.Unlock()
+	plan, ok := a.Plans[vsKey]
+	if !ok {
+		plan = &DryRunPlan{}
+		a.Plans[vsKey] = plan
+	}
+	plan.Operations = append(plan.Operations, operations...)
+	plan.LastUpdated = time.Now()
+}
+
+// GetPlan returns a copy of the pending operations of a virtualservice, nil if there are none.
+func (a *DryRunModel) GetPlan(vsKey string) *DryRunPlan {
+	if a == nil {
+		return nil
+	}
+	a.planLock.RLock()
+	defer a.planLock.RUnlock()
+	plan, ok := a.Plans[vsKey]
+	if !ok {
+		return nil
+	}
+	planCopy := &DryRunPlan{LastUpdated: plan.LastUpdated}
+	planCopy.Operations = append(planCopy.Operations, plan.Operations...)
+	return planCopy
+}
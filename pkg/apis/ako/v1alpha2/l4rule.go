@@ -39,6 +39,15 @@ type L4RuleSpec struct {
 type L4RuleStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error"`
+	// ObservedGeneration is the generation of the L4Rule, which was last processed by AKO.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Accepted, ResolvedRefs and Programmed conditions of the L4Rule.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// AttachedTo lists the Kubernetes objects and the Avi virtual services, to which the L4Rule is currently applied.
+	// +optional
+	AttachedTo []AttachedObject `json:"attachedTo,omitempty"`
 }
 
 // +genclient
@@ -37,6 +37,15 @@ type L7RuleSpec struct {
 type L7RuleStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error"`
+	// ObservedGeneration is the generation of the L7Rule, which was last processed by AKO.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Accepted, ResolvedRefs and Programmed conditions of the L7Rule.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// AttachedTo lists the Kubernetes objects and the Avi virtual services, to which the L7Rule is currently applied.
+	// +optional
+	AttachedTo []AttachedObject `json:"attachedTo,omitempty"`
 }
 
 // +genclient
@@ -56,6 +56,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&SSORuleList{},
 		&L4Rule{},
 		&L4RuleList{},
+		&RouteRuleExtension{},
+		&RouteRuleExtensionList{},
 	)
 
 	scheme.AddKnownTypes(
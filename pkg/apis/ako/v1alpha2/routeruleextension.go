@@ -0,0 +1,59 @@
+/*
+* Copyright 2024 VMware, Inc.
+* All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteRuleExtensionSpec holds the settings applied to the child virtual service and the pools
+// built for the HTTPRoute rules referring to the RouteRuleExtension through an ExtensionRef filter.
+type RouteRuleExtensionSpec struct {
+	WAFPolicy              string                     `json:"wafPolicy,omitempty"`
+	Datascripts            []string                   `json:"datascripts,omitempty"`
+	HealthMonitors         []string                   `json:"healthMonitors,omitempty"`
+	ApplicationPersistence string                     `json:"applicationPersistence,omitempty"`
+	LoadBalancerPolicy     RouteRuleExtensionLBPolicy `json:"loadBalancerPolicy,omitempty"`
+}
+
+// RouteRuleExtensionLBPolicy holds the load balancer policy of the pools of the rule
+type RouteRuleExtensionLBPolicy struct {
+	Algorithm  string `json:"algorithm,omitempty"`
+	Hash       string `json:"hash,omitempty"`
+	HostHeader string `json:"hostHeader,omitempty"`
+}
+
+type RouteRuleExtensionStatus struct {
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RouteRuleExtension struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RouteRuleExtensionSpec   `json:"spec,omitempty"`
+	Status            RouteRuleExtensionStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RouteRuleExtensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteRuleExtension `json:"items"`
+}
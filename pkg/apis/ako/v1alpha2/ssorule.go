@@ -30,6 +30,15 @@ type SSORuleSpec struct {
 type SSORuleStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error"`
+	// ObservedGeneration is the generation of the SSORule, which was last processed by AKO.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Accepted, ResolvedRefs and Programmed conditions of the SSORule.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// AttachedTo lists the Kubernetes objects and the Avi virtual services, to which the SSORule is currently applied.
+	// +optional
+	AttachedTo []AttachedObject `json:"attachedTo,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package v1alpha2
+
+// AttachedObject is a Kubernetes object or an Avi virtual service, to which an AKO CRD is applied.
+type AttachedObject struct {
+	// Kind is the kind of the Kubernetes object, or VirtualService for an Avi virtual service.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the Kubernetes object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Tenant is the Avi tenant of the virtual service.
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+	Name   string `json:"name"`
+}
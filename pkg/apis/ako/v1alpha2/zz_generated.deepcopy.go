@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -46,6 +47,22 @@ func (in *AnalyticsPolicy) DeepCopy() *AnalyticsPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachedObject) DeepCopyInto(out *AttachedObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedObject.
+func (in *AttachedObject) DeepCopy() *AttachedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendProperties) DeepCopyInto(out *BackendProperties) {
 	*out = *in
@@ -185,7 +202,7 @@ func (in *L4Rule) DeepCopyInto(out *L4Rule) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -336,6 +353,18 @@ func (in *L4RuleSpec) DeepCopy() *L4RuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L4RuleStatus) DeepCopyInto(out *L4RuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -355,7 +384,7 @@ func (in *L7Rule) DeepCopyInto(out *L7Rule) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -484,6 +513,18 @@ func (in *L7RuleSpec) DeepCopy() *L7RuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L7RuleStatus) DeepCopyInto(out *L7RuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -756,6 +797,126 @@ func (in *PoolAnalyticsPolicy) DeepCopy() *PoolAnalyticsPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRuleExtension) DeepCopyInto(out *RouteRuleExtension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRuleExtension.
+func (in *RouteRuleExtension) DeepCopy() *RouteRuleExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRuleExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteRuleExtension) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRuleExtensionLBPolicy) DeepCopyInto(out *RouteRuleExtensionLBPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRuleExtensionLBPolicy.
+func (in *RouteRuleExtensionLBPolicy) DeepCopy() *RouteRuleExtensionLBPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRuleExtensionLBPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRuleExtensionList) DeepCopyInto(out *RouteRuleExtensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteRuleExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRuleExtensionList.
+func (in *RouteRuleExtensionList) DeepCopy() *RouteRuleExtensionList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRuleExtensionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteRuleExtensionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRuleExtensionSpec) DeepCopyInto(out *RouteRuleExtensionSpec) {
+	*out = *in
+	if in.Datascripts != nil {
+		in, out := &in.Datascripts, &out.Datascripts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthMonitors != nil {
+		in, out := &in.HealthMonitors, &out.HealthMonitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.LoadBalancerPolicy = in.LoadBalancerPolicy
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRuleExtensionSpec.
+func (in *RouteRuleExtensionSpec) DeepCopy() *RouteRuleExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRuleExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRuleExtensionStatus) DeepCopyInto(out *RouteRuleExtensionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRuleExtensionStatus.
+func (in *RouteRuleExtensionStatus) DeepCopy() *RouteRuleExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRuleExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SAMLSPConfig) DeepCopyInto(out *SAMLSPConfig) {
 	*out = *in
@@ -818,7 +979,7 @@ func (in *SSORule) DeepCopyInto(out *SSORule) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -912,6 +1073,18 @@ func (in *SSORuleSpec) DeepCopy() *SSORuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSORuleStatus) DeepCopyInto(out *SSORuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
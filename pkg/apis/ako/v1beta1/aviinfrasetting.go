@@ -83,6 +83,16 @@ type AviInfraL7Settings struct {
 type AviInfraSettingStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error"`
+	// ObservedGeneration is the generation of the AviInfraSetting, which was last processed by AKO.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Accepted, ResolvedRefs and Programmed conditions of the AviInfraSetting.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// AttachedTo lists the Kubernetes objects and the Avi virtual services, to which the AviInfraSetting is currently
+	// applied.
+	// +optional
+	AttachedTo []AttachedObject `json:"attachedTo,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
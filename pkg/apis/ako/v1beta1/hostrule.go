@@ -96,11 +96,28 @@ type HostRuleTCPListeners struct {
 
 // HostRuleTLS holds secure host specific properties
 type HostRuleTLS struct {
-	SSLKeyCertificate HostRuleSSLKeyCertificate `json:"sslKeyCertificate,omitempty"`
-	SSLProfile        string                    `json:"sslProfile,omitempty"`
-	Termination       string                    `json:"termination,omitempty"`
+	SSLKeyCertificate HostRuleSSLKeyCertificate  `json:"sslKeyCertificate,omitempty"`
+	SSLProfile        string                     `json:"sslProfile,omitempty"`
+	Termination       string                     `json:"termination,omitempty"`
+	ClientCertificate *HostRuleClientCertificate `json:"clientCertificate,omitempty"`
 }
 
+// HostRuleClientCertificate holds the properties for verifying the client certificates (mTLS)
+// presented to the virtual host. The CA certificate is either a K8s Secret with the ca.crt key,
+// or a PKI profile on the Avi Controller.
+type HostRuleClientCertificate struct {
+	CACertificate         HostRuleSecret                `json:"caCertificate,omitempty"`
+	Mode                  HostRuleClientCertificateMode `json:"mode,omitempty"`
+	ForwardClientIdentity bool                          `json:"forwardClientIdentity,omitempty"`
+}
+
+type HostRuleClientCertificateMode string
+
+const (
+	HostRuleClientCertificateModeRequire HostRuleClientCertificateMode = "require"
+	HostRuleClientCertificateModeRequest HostRuleClientCertificateMode = "request"
+)
+
 // HostRuleSecret is required to provide distinction between Avi SSLKeyCertificate
 // or K8s Secret Objects
 type HostRuleSecret struct {
@@ -136,6 +153,15 @@ type HostRuleGSLB struct {
 type HostRuleStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error"`
+	// ObservedGeneration is the generation of the HostRule, which was last processed by AKO.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Accepted, ResolvedRefs and Programmed conditions of the HostRule.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// AttachedTo lists the Kubernetes objects and the Avi virtual services, to which the HostRule is currently applied.
+	// +optional
+	AttachedTo []AttachedObject `json:"attachedTo,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
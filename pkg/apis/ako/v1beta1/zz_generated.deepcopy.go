@@ -22,9 +22,26 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachedObject) DeepCopyInto(out *AttachedObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedObject.
+func (in *AttachedObject) DeepCopy() *AttachedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AviInfraL7Settings) DeepCopyInto(out *AviInfraL7Settings) {
 	*out = *in
@@ -98,7 +115,7 @@ func (in *AviInfraSetting) DeepCopyInto(out *AviInfraSetting) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
@@ -264,6 +281,18 @@ func (in *AviInfraSettingSpec) DeepCopy() *AviInfraSettingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AviInfraSettingStatus) DeepCopyInto(out *AviInfraSettingStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -319,7 +348,7 @@ func (in *HTTPRule) DeepCopyInto(out *HTTPRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
@@ -440,6 +469,18 @@ func (in *HTTPRuleSpec) DeepCopy() *HTTPRuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleStatus) DeepCopyInto(out *HTTPRuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -474,7 +515,7 @@ func (in *HostRule) DeepCopyInto(out *HostRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
@@ -523,6 +564,23 @@ func (in *HostRuleAnalyticsPolicy) DeepCopy() *HostRuleAnalyticsPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleClientCertificate) DeepCopyInto(out *HostRuleClientCertificate) {
+	*out = *in
+	out.CACertificate = in.CACertificate
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleClientCertificate.
+func (in *HostRuleClientCertificate) DeepCopy() *HostRuleClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleGSLB) DeepCopyInto(out *HostRuleGSLB) {
 	*out = *in
@@ -646,6 +704,18 @@ func (in *HostRuleSpec) DeepCopy() *HostRuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleStatus) DeepCopyInto(out *HostRuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedTo != nil {
+		in, out := &in.AttachedTo, &out.AttachedTo
+		*out = make([]AttachedObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -700,6 +770,11 @@ func (in *HostRuleTCPSettings) DeepCopy() *HostRuleTCPSettings {
 func (in *HostRuleTLS) DeepCopyInto(out *HostRuleTLS) {
 	*out = *in
 	out.SSLKeyCertificate = in.SSLKeyCertificate
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(HostRuleClientCertificate)
+		**out = **in
+	}
 	return
 }
 
@@ -728,7 +803,7 @@ func (in *HostRuleVirtualHost) DeepCopyInto(out *HostRuleVirtualHost) {
 	}
 	in.HTTPPolicy.DeepCopyInto(&out.HTTPPolicy)
 	out.Gslb = in.Gslb
-	out.TLS = in.TLS
+	in.TLS.DeepCopyInto(&out.TLS)
 	if in.AnalyticsPolicy != nil {
 		in, out := &in.AnalyticsPolicy, &out.AnalyticsPolicy
 		*out = new(HostRuleAnalyticsPolicy)
@@ -30,6 +30,7 @@ type AkoV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	L4RulesGetter
 	L7RulesGetter
+	RouteRuleExtensionsGetter
 	SSORulesGetter
 }
 
@@ -46,6 +47,10 @@ func (c *AkoV1alpha2Client) L7Rules(namespace string) L7RuleInterface {
 	return newL7Rules(c, namespace)
 }
 
+func (c *AkoV1alpha2Client) RouteRuleExtensions(namespace string) RouteRuleExtensionInterface {
+	return newRouteRuleExtensions(c, namespace)
+}
+
 func (c *AkoV1alpha2Client) SSORules(namespace string) SSORuleInterface {
 	return newSSORules(c, namespace)
 }
@@ -22,4 +22,6 @@ type L4RuleExpansion interface{}
 
 type L7RuleExpansion interface{}
 
+type RouteRuleExtensionExpansion interface{}
+
 type SSORuleExpansion interface{}
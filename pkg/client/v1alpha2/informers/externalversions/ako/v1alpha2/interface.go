@@ -28,6 +28,8 @@ type Interface interface {
 	L4Rules() L4RuleInformer
 	// L7Rules returns a L7RuleInformer.
 	L7Rules() L7RuleInformer
+	// RouteRuleExtensions returns a RouteRuleExtensionInformer.
+	RouteRuleExtensions() RouteRuleExtensionInformer
 	// SSORules returns a SSORuleInformer.
 	SSORules() SSORuleInformer
 }
@@ -53,6 +55,11 @@ func (v *version) L7Rules() L7RuleInformer {
 	return &l7RuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// RouteRuleExtensions returns a RouteRuleExtensionInformer.
+func (v *version) RouteRuleExtensions() RouteRuleExtensionInformer {
+	return &routeRuleExtensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SSORules returns a SSORuleInformer.
 func (v *version) SSORules() SSORuleInformer {
 	return &sSORuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -57,6 +57,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha2().L4Rules().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("l7rules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha2().L7Rules().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("routeruleextensions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha2().RouteRuleExtensions().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("ssorules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha2().SSORules().Informer()}, nil
 
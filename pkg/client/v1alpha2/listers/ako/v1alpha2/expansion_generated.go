@@ -34,6 +34,14 @@ type L7RuleListerExpansion interface{}
 // L7RuleNamespaceLister.
 type L7RuleNamespaceListerExpansion interface{}
 
+// RouteRuleExtensionListerExpansion allows custom methods to be added to
+// RouteRuleExtensionLister.
+type RouteRuleExtensionListerExpansion interface{}
+
+// RouteRuleExtensionNamespaceListerExpansion allows custom methods to be added to
+// RouteRuleExtensionNamespaceLister.
+type RouteRuleExtensionNamespaceListerExpansion interface{}
+
 // SSORuleListerExpansion allows custom methods to be added to
 // SSORuleLister.
 type SSORuleListerExpansion interface{}
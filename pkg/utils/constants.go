@@ -31,12 +31,14 @@ const (
 	EndpointInformer              = "EndpointInformer"
 	EndpointSlicesInformer        = "EndpointSlicesInformer"
 	ConfigMapInformer             = "ConfigMapInformer"
+	NginxConfigMapInformer        = "NginxConfigMapInformer"
 	MultiClusterIngressInformer   = "MultiClusterIngressInformer"
 	ServiceImportInformer         = "ServiceImportInformer"
 	K8S_TLS_SECRET_CERT           = "tls.crt"
 	K8S_TLS_SECRET_KEY            = "tls.key"
 	K8S_TLS_SECRET_ALT_CERT       = "alt.crt"
 	K8S_TLS_SECRET_ALT_KEY        = "alt.key"
+	K8S_TLS_SECRET_CA_CERT        = "ca.crt"
 	IngressInformer               = "IngressInformer"
 	RouteInformer                 = "RouteInformer"
 	IngressClassInformer          = "IngressClassInformer"
@@ -51,6 +53,7 @@ const (
 	OshiftRoute                   = "OshiftRoute"
 	Service                       = "Service"
 	Secret                        = "Secret"
+	ConfigMap                     = "ConfigMap"
 	HTTP                          = "HTTP"
 	HTTPRoute                     = "HTTPRoute"
 	HeaderMethod                  = ":method"
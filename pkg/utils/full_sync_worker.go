@@ -25,6 +25,8 @@ type FullSyncThread struct {
 	Interval          time.Duration
 	SyncFunction      func()
 	QuickSyncFunction func(bool) error
+	// PeriodicSyncFunction, if set, runs after SyncFunction on every interval.
+	PeriodicSyncFunction func()
 }
 
 func NewFullSyncThread(interval time.Duration) *FullSyncThread {
@@ -51,8 +53,11 @@ func (w *FullSyncThread) Run() {
 			w.QuickSyncFunction(true)
 			break
 		case <-time.After(w.Interval):
-			// Just the cache sync functions.
+			// The cache sync function, followed by the periodic sync of the k8s objects, if any.
 			w.SyncFunction()
+			if w.PeriodicSyncFunction != nil {
+				w.PeriodicSyncFunction()
+			}
 			break
 		}
 	}
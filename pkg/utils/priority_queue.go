@@ -0,0 +1,284 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// QueuePriority is the priority class of a key in a worker queue.
+type QueuePriority int
+
+const (
+	// PriorityInteractive is for the changes made to the kubernetes objects by the users.
+	PriorityInteractive QueuePriority = iota
+	// PriorityDelete is for the deletion of the kubernetes objects.
+	PriorityDelete
+	// PriorityRetry is for the keys, which are republished by the retry layers.
+	PriorityRetry
+	// PriorityBulk is for the keys, which are published by a full sync or by a change to all the objects of a namespace.
+	PriorityBulk
+)
+
+var queuePriorityNames = [...]string{"interactive", "delete", "retry", "bulk"}
+
+// queuePriorityWeights are the shares of the worker, which the lanes get when all of these have keys.
+var queuePriorityWeights = [...]int{8, 4, 2, 1}
+
+func (p QueuePriority) String() string {
+	return queuePriorityNames[p]
+}
+
+// QueueLaneMetricsProvider creates the metrics of the priority lanes of the worker queues.
+type QueueLaneMetricsProvider interface {
+	NewLaneDepthMetric(queueName, lane string) workqueue.GaugeMetric
+	NewLaneLatencyMetric(queueName, lane string) workqueue.HistogramMetric
+}
+
+type noopLaneMetricsProvider struct{}
+
+func (noopLaneMetricsProvider) NewLaneDepthMetric(queueName, lane string) workqueue.GaugeMetric {
+	return noopLaneMetric{}
+}
+
+func (noopLaneMetricsProvider) NewLaneLatencyMetric(queueName, lane string) workqueue.HistogramMetric {
+	return noopLaneMetric{}
+}
+
+type noopLaneMetric struct{}
+
+func (noopLaneMetric) Inc()            {}
+func (noopLaneMetric) Dec()            {}
+func (noopLaneMetric) Observe(float64) {}
+
+var laneMetricsProvider QueueLaneMetricsProvider = noopLaneMetricsProvider{}
+
+// SetQueueLaneMetricsProvider sets the provider of the lane metrics, which is used by the queues created afterwards.
+func SetQueueLaneMetricsProvider(provider QueueLaneMetricsProvider) {
+	laneMetricsProvider = provider
+}
+
+// PriorityQueue is the rate limited queue of a worker, in which the keys wait in a lane per priority class.
+// The lanes are drained by weighted round robin, so that a burst of low priority keys does not delay the
+// high priority keys, while the low priority keys are not starved either. Each lane has its own rate limiter.
+type PriorityQueue struct {
+	workqueue.DelayingInterface
+	rateLimiters []workqueue.RateLimiter
+}
+
+func NewPriorityQueue(name string) *PriorityQueue {
+	queue := &PriorityQueue{
+		DelayingInterface: workqueue.NewDelayingQueueWithCustomQueue(newPriorityLanes(name), name),
+		rateLimiters:      make([]workqueue.RateLimiter, len(queuePriorityNames)),
+	}
+	for i := range queue.rateLimiters {
+		queue.rateLimiters[i] = workqueue.DefaultControllerRateLimiter()
+	}
+	return queue
+}
+
+// AddRateLimited adds a key to the interactive lane, after the rate limiter of the lane says it's ok.
+func (q *PriorityQueue) AddRateLimited(item interface{}) {
+	q.AddRateLimitedWithPriority(item, PriorityInteractive)
+}
+
+// AddRateLimitedWithPriority adds a key to the lane of the priority, after the rate limiter of the lane says it's ok.
+func (q *PriorityQueue) AddRateLimitedWithPriority(item interface{}, priority QueuePriority) {
+	q.DelayingInterface.AddAfter(laneItem{item: item, priority: priority}, q.rateLimiters[priority].When(item))
+}
+
+func (q *PriorityQueue) Forget(item interface{}) {
+	for _, rateLimiter := range q.rateLimiters {
+		rateLimiter.Forget(item)
+	}
+}
+
+func (q *PriorityQueue) NumRequeues(item interface{}) int {
+	requeues := 0
+	for _, rateLimiter := range q.rateLimiters {
+		requeues += rateLimiter.NumRequeues(item)
+	}
+	return requeues
+}
+
+// laneItem is a key along with the lane, to which it is added.
+type laneItem struct {
+	item     interface{}
+	priority QueuePriority
+}
+
+// priorityLanes holds a queue per priority. A key waits in a single lane, which is the highest priority lane to which it
+// is added. A copy of the key, which is left behind in another lane, is skipped when it is dequeued.
+type priorityLanes struct {
+	cond           *sync.Cond
+	lanes          []*workqueue.Type
+	currentWeights []int
+	// pending is the lane of each of the waiting keys.
+	pending      map[interface{}]QueuePriority
+	pendingSince map[interface{}]time.Time
+	processing   map[interface{}]QueuePriority
+	shuttingDown bool
+	depth        []workqueue.GaugeMetric
+	latency      []workqueue.HistogramMetric
+}
+
+func newPriorityLanes(name string) *priorityLanes {
+	q := &priorityLanes{
+		cond:           sync.NewCond(&sync.Mutex{}),
+		lanes:          make([]*workqueue.Type, len(queuePriorityNames)),
+		currentWeights: make([]int, len(queuePriorityNames)),
+		pending:        make(map[interface{}]QueuePriority),
+		pendingSince:   make(map[interface{}]time.Time),
+		processing:     make(map[interface{}]QueuePriority),
+		depth:          make([]workqueue.GaugeMetric, len(queuePriorityNames)),
+		latency:        make([]workqueue.HistogramMetric, len(queuePriorityNames)),
+	}
+	for i, lane := range queuePriorityNames {
+		// The lanes share the name of the worker queue, so that the workqueue metrics are reported per layer.
+		q.lanes[i] = workqueue.NewWithConfig(workqueue.QueueConfig{Name: name})
+		q.depth[i] = laneMetricsProvider.NewLaneDepthMetric(name, lane)
+		q.latency[i] = laneMetricsProvider.NewLaneLatencyMetric(name, lane)
+	}
+	return q
+}
+
+func (q *priorityLanes) Add(obj interface{}) {
+	item, priority := obj, PriorityInteractive
+	if laneObj, ok := obj.(laneItem); ok {
+		item, priority = laneObj.item, laneObj.priority
+	}
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	// A key which is being processed is requeued by its lane once it is done, so that it is not processed concurrently.
+	if processingPriority, ok := q.processing[item]; ok {
+		priority = processingPriority
+	}
+	if current, ok := q.pending[item]; ok {
+		if current <= priority {
+			return
+		}
+		// The key is moved to the higher priority lane.
+		q.depth[current].Dec()
+	} else {
+		q.pendingSince[item] = time.Now()
+	}
+	q.pending[item] = priority
+	q.depth[priority].Inc()
+	q.lanes[priority].Add(item)
+	q.cond.Signal()
+}
+
+func (q *priorityLanes) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.pending)
+}
+
+func (q *priorityLanes) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for {
+		for len(q.pending) == 0 && !q.shuttingDown {
+			q.cond.Wait()
+		}
+		if len(q.pending) == 0 {
+			return nil, true
+		}
+		priority := q.nextLane()
+		if priority < 0 {
+			// The waiting keys are being processed, and are requeued once these are done.
+			q.cond.Wait()
+			continue
+		}
+		// The lane has keys, hence this does not block.
+		item, _ := q.lanes[priority].Get()
+		current, ok := q.pending[item]
+		if !ok || current < QueuePriority(priority) {
+			// The key has been processed already, or waits in a higher priority lane.
+			q.lanes[priority].Done(item)
+			continue
+		}
+		q.depth[current].Dec()
+		q.latency[priority].Observe(time.Since(q.pendingSince[item]).Seconds())
+		delete(q.pending, item)
+		delete(q.pendingSince, item)
+		q.processing[item] = QueuePriority(priority)
+		return item, false
+	}
+}
+
+// nextLane picks the lane to dequeue from, by smooth weighted round robin across the lanes which have keys.
+func (q *priorityLanes) nextLane() int {
+	next, totalWeight := -1, 0
+	for i, lane := range q.lanes {
+		if lane.Len() == 0 {
+			continue
+		}
+		q.currentWeights[i] += queuePriorityWeights[i]
+		totalWeight += queuePriorityWeights[i]
+		if next < 0 || q.currentWeights[i] > q.currentWeights[next] {
+			next = i
+		}
+	}
+	if next >= 0 {
+		q.currentWeights[next] -= totalWeight
+	}
+	return next
+}
+
+func (q *priorityLanes) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if priority, ok := q.processing[item]; ok {
+		delete(q.processing, item)
+		q.lanes[priority].Done(item)
+	}
+	q.cond.Signal()
+}
+
+func (q *priorityLanes) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shuttingDown = true
+	for _, lane := range q.lanes {
+		lane.ShutDown()
+	}
+	q.cond.Broadcast()
+}
+
+func (q *priorityLanes) ShutDownWithDrain() {
+	q.ShutDown()
+	for {
+		q.cond.L.Lock()
+		drained := len(q.pending) == 0 && len(q.processing) == 0
+		q.cond.L.Unlock()
+		if drained {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (q *priorityLanes) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
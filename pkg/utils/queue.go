@@ -20,7 +20,6 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/client-go/util/workqueue"
 )
 
 var queuewrapper sync.Once
@@ -59,25 +58,22 @@ func SharedWorkQueue(queueParams ...*WorkerQueue) *WorkQueueWrapper {
 // Common utils like processing worker queue, that is common for all objects.
 type WorkerQueue struct {
 	NumWorkers    uint32
-	Workqueue     []workqueue.RateLimitingInterface
+	Workqueue     []*PriorityQueue
 	WorkqueueName string
-	workerIdMutex sync.Mutex
-	workerId      uint32
 	SyncFunc      func(interface{}, *sync.WaitGroup) error
 	SlowSyncTime  int
 }
 
 func NewWorkQueue(num_workers uint32, workerQueueName string, slowSyncTime ...int) *WorkerQueue {
 	queue := &WorkerQueue{}
-	queue.Workqueue = make([]workqueue.RateLimitingInterface, num_workers)
-	queue.workerId = (uint32(1) << num_workers) - 1
+	queue.Workqueue = make([]*PriorityQueue, num_workers)
 	queue.NumWorkers = num_workers
 	queue.WorkqueueName = workerQueueName
 	if len(slowSyncTime) > 0 {
 		queue.SlowSyncTime = slowSyncTime[0]
 	}
 	for i := uint32(0); i < num_workers; i++ {
-		queue.Workqueue[i] = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("avi-%s", workerQueueName))
+		queue.Workqueue[i] = NewPriorityQueue(fmt.Sprintf("avi-%s", workerQueueName))
 	}
 	return queue
 }
@@ -91,7 +87,7 @@ func (c *WorkerQueue) Run(stopCh <-chan struct{}, wg *sync.WaitGroup) error {
 	}
 	for i := uint32(0); i < c.NumWorkers; i++ {
 		wg.Add(1)
-		go c.runWorker(wg)
+		go c.runWorker(i, wg)
 	}
 	AviLog.Infof("Started the workers for: %s", c.WorkqueueName)
 	return nil
@@ -105,25 +101,12 @@ func (c *WorkerQueue) StopWorkers(stopCh <-chan struct{}) {
 
 // runWorker is a long-running function that will continually call the
 // processNextWorkItem function in order to read and process a message on the
-// workqueue. Pick a worker_id from worker_id mask
-func (c *WorkerQueue) runWorker(wg *sync.WaitGroup) {
+// workqueue of the worker.
+func (c *WorkerQueue) runWorker(workerId uint32, wg *sync.WaitGroup) {
 	defer wg.Done()
-	workerId := uint32(0xffffffff)
-	c.workerIdMutex.Lock()
-	for i := uint32(0); i < c.NumWorkers; i++ {
-		if ((uint32(1) << i) & c.workerId) != 0 {
-			workerId = i
-			c.workerId = c.workerId & ^(uint32(1) << i)
-			break
-		}
-	}
-	c.workerIdMutex.Unlock()
 	AviLog.Infof("Worker id %d", workerId)
 	for c.processNextWorkItem(workerId, wg) {
 	}
-	c.workerIdMutex.Lock()
-	c.workerId = c.workerId | (uint32(1) << workerId)
-	c.workerIdMutex.Unlock()
 }
 
 func (c *WorkerQueue) processNextWorkItem(worker_id uint32, wg *sync.WaitGroup) bool {
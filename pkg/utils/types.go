@@ -74,6 +74,7 @@ type KubeClientIntf struct {
 
 type Informers struct {
 	ConfigMapInformer           coreinformers.ConfigMapInformer
+	NginxConfigMapInformer      coreinformers.ConfigMapInformer
 	ServiceInformer             coreinformers.ServiceInformer
 	EpInformer                  coreinformers.EndpointsInformer
 	EpSlicesInformer            discoveryinformers.EndpointSliceInformer
@@ -120,7 +120,10 @@ func CrudHashKey(obj_type string, obj interface{}) string {
 }
 
 func Bkt(key string, num_workers uint32) uint32 {
-	bkt := Hash(key) & (num_workers - 1)
+	if num_workers == 0 {
+		return Hash(key)
+	}
+	bkt := Hash(key) % num_workers
 	return bkt
 }
 
@@ -193,6 +196,9 @@ func instantiateInformers(kubeClient KubeClientIntf, registeredInformers []strin
 			informers.NodeInformer = kubeInformerFactory.Core().V1().Nodes()
 		case ConfigMapInformer:
 			informers.ConfigMapInformer = akoNSInformerFactory.Core().V1().ConfigMaps()
+		case NginxConfigMapInformer:
+			// The ConfigMaps of the custom-headers annotation are in the namespaces of the Ingresses.
+			informers.NginxConfigMapInformer = kubeInformerFactory.Core().V1().ConfigMaps()
 		case IngressInformer:
 			informers.IngressInformer = kubeInformerFactory.Networking().V1().Ingresses()
 		case IngressClassInformer:
@@ -143,12 +143,13 @@ func injectMWForCloud() {
 }
 
 // PopulateCache populates cache and triggers deletion of unused objects.
-// In this case two pool and two vsvip objects. Among these, one vsvip is
-// refeered by a Virtual Service, so we need to delete 3 objects
+// In this case two pool, two vsvip and one health monitor objects. Among these, one vsvip is
+// refeered by a Virtual Service, and the health monitor is not referred by any pool, so we need to delete 4 objects
 func TestObjDeletion(t *testing.T) {
 	uuidMap["pool-e3b87aff-a9d7-44eb-9935-6fd9ab81a37c"] = true
 	uuidMap["pool-11a38043-e51e-4c93-8187-b390d7d81abd"] = true
 	uuidMap["vsvip-a590042a-358f-4693-bfa5-cb9d0c8c1931"] = true
+	uuidMap["healthmonitor-3f2a4c1e-7d8b-4e5a-9c6f-2b1d0e9a8c7b"] = true
 	//uuidMap["vsvip-82b41dd7-5b19-4007-85d4-530acea4d86b"] = true
 
 	injectMWForObjDeletion()
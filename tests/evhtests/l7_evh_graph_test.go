@@ -29,6 +29,7 @@ import (
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -2086,3 +2087,78 @@ func TestPortsForInsecureAndSecureEVH(t *testing.T) {
 	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{})
 	TearDownTestForIngress(t, svcName, modelName)
 }
+
+func TestIngressDefaultBackendForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	svcName := objNameMap.GenerateName("avisvc")
+	defaultSvcName := objNameMap.GenerateName("avisvc-default")
+	ingName := objNameMap.GenerateName("foo-with-default-backend")
+	SetUpTestForIngress(t, svcName, modelName)
+	SetUpTestForIngress(t, defaultSvcName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: defaultSvcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	// The unmatched paths of the host fall back to the default backend on the EVH child VS.
+	g.Eventually(func() bool {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS(); len(nodes) == 1 && len(nodes[0].EvhNodes) == 1 {
+				return nodes[0].EvhNodes[0].DefaultPoolGroup != ""
+			}
+		}
+		return false
+	}, 10*time.Second).Should(gomega.BeTrue())
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].DefaultPoolGroup).To(gomega.Equal(lib.GetL7DefaultBackendPGName(nodes[0].Name)))
+	evhNode := nodes[0].EvhNodes[0]
+	g.Expect(evhNode.DefaultPoolGroup).To(gomega.Equal(lib.GetL7DefaultBackendPGName(evhNode.Name)))
+	g.Expect(evhNode.PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(evhNode.PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pool := range evhNode.PoolRefs {
+		if pool.Name == lib.GetL7DefaultBackendPoolName(evhNode.Name) {
+			g.Expect(pool.Port).To(gomega.Equal(int32(8080)))
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+		}
+	}
+
+	// Removing the default backend detaches it from the EVH child VS.
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].EvhNodes[0].DefaultPoolGroup
+	}, 10*time.Second).Should(gomega.BeEmpty())
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	evhNode = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].EvhNodes[0]
+	g.Expect(evhNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(evhNode.PoolGroupRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyEvhPoolDeletion(t, g, aviModel, 0)
+	VerifyEvhIngressDeletion(t, g, aviModel, 0)
+	TearDownTestForIngress(t, defaultSvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
@@ -50,6 +51,9 @@ const (
 func TestMain(m *testing.M) {
 	tests.KubeClient = k8sfake.NewSimpleClientset()
 	tests.GatewayClient = gatewayfake.NewSimpleClientset()
+	tests.SetExperimentalGatewayAPIResources(tests.GatewayClient)
+	tests.V1alpha2CRDClient = v1alpha2crdfake.NewSimpleClientset()
+	tests.SetAKOCRDResources(tests.V1alpha2CRDClient)
 	integrationtest.KubeClient = tests.KubeClient
 
 	// Sets the environment variables
@@ -99,6 +103,7 @@ func TestMain(m *testing.M) {
 
 	ctrl = akogatewayapik8s.SharedGatewayController()
 	ctrl.DisableSync = false
+	lib.AKOControlConfig().Setv1alpha2CRDClientset(tests.V1alpha2CRDClient)
 	ctrl.InitGatewayAPIInformers(tests.GatewayClient)
 	akoControlConfig.SetGatewayAPIClientset(tests.GatewayClient)
 
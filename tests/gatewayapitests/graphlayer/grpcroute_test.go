@@ -0,0 +1,245 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - GRPCRoute CRUD with service/method and header matches
+ * - GRPCRoute with a RegularExpression method match
+ */
+func TestGRPCRouteCRUD(t *testing.T) {
+
+	gatewayName := "gateway-gr-01"
+	gatewayClassName := "gateway-class-gr-01"
+	grpcRouteName := "grpc-route-gr-01"
+	svcName := "avisvc-gr-01"
+	ports := []int32{8080}
+	modelName, parentVSName := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    DEFAULT_NAMESPACE,
+		Type:         corev1.ServiceTypeClusterIP,
+		ServicePorts: []integrationtest.Serviceport{{PortName: "grpc", Protocol: "TCP", PortNumber: 50051, TargetPort: intstr.FromInt(50051)}},
+	}).Service()
+	_, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetGRPCRouteRuleV1Alpha2([][]string{{"helloworld.Greeter", "SayHello"}}, []string{"x-env"},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "50051", "1"}})
+	rules := []gatewayv1alpha2.GRPCRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+
+	// the listener allows GRPCRoutes, hence HTTP/2 is enabled on its port
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PortProto[0].EnableHTTP2).To(gomega.BeTrue())
+
+	childNode := nodes[0].EvhNodes[0]
+	g.Expect(childNode.VHParentName).To(gomega.Equal(parentVSName))
+	g.Expect(*childNode.VHMatches[0].Host).To(gomega.Equal("foo-8080.com"))
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.ContainElement("/helloworld.Greeter/SayHello"))
+	g.Expect(*childNode.VHMatches[0].Rules[0].Matches.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Hdrs).To(gomega.HaveLen(1))
+	g.Expect(*childNode.VHMatches[0].Rules[0].Matches.Hdrs[0].Hdr).To(gomega.Equal("x-env"))
+	g.Expect(childNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(childNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(childNode.PoolRefs[0].EnableHTTP2).To(gomega.BeTrue())
+	g.Expect(childNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+
+	// update the match to all the methods of the service
+	rule = akogatewayapitests.GetGRPCRouteRuleV1Alpha2([][]string{{"helloworld.Greeter", ""}}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "50051", "1"}})
+	rules = []gatewayv1alpha2.GRPCRouteRule{rule}
+	akogatewayapitests.UpdateGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return ""
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].VHMatches) == 0 {
+			return ""
+		}
+		return *nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches.Path.MatchCriteria
+	}, 25*time.Second).Should(gomega.Equal("BEGINS_WITH"))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.ContainElement("/helloworld.Greeter/"))
+	g.Expect(nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches.Hdrs).To(gomega.HaveLen(0))
+
+	// delete grpcroute
+	akogatewayapitests.TeardownGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestGRPCRouteWithRegularExpressionMethodMatch(t *testing.T) {
+
+	gatewayName := "gateway-gr-02"
+	gatewayClassName := "gateway-class-gr-02"
+	grpcRouteName := "grpc-route-gr-02"
+	svcName := "avisvc-gr-02"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    DEFAULT_NAMESPACE,
+		Type:         corev1.ServiceTypeClusterIP,
+		ServicePorts: []integrationtest.Serviceport{{PortName: "grpc", Protocol: "TCP", PortNumber: 50051, TargetPort: intstr.FromInt(50051)}},
+	}).Service()
+	_, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	validRule := akogatewayapitests.GetGRPCRouteRuleV1Alpha2([][]string{{"helloworld.Greeter", "SayHello"}}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "50051", "1"}})
+	regexRule := akogatewayapitests.GetGRPCRouteRuleV1Alpha2([][]string{{"helloworld.*", "Say.*"}}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "50051", "1"}})
+	regexType := gatewayv1alpha2.GRPCMethodMatchRegularExpression
+	regexRule.Matches[0].Method.Type = &regexType
+	rules := []gatewayv1alpha2.GRPCRouteRule{validRule, regexRule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	// the rule with the RegularExpression method match is dropped
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].VHMatches[0].Rules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	// and the route is partially invalid
+	g.Eventually(func() bool {
+		grpcRoute, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().GRPCRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), grpcRouteName, metav1.GetOptions{})
+		if err != nil || len(grpcRoute.Status.Parents) != 1 {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(grpcRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+	}, 25*time.Second).Should(gomega.Equal(true))
+	grpcRoute, _ := akogatewayapitests.GatewayClient.GatewayV1alpha2().GRPCRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), grpcRouteName, metav1.GetOptions{})
+	condition := apimeta.FindStatusCondition(grpcRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+	g.Expect(condition.Reason).To(gomega.Equal(string(gatewayv1.RouteReasonUnsupportedValue)))
+	g.Expect(condition.Message).To(gomega.Equal("Rule 1 is dropped, Method match type RegularExpression is not supported"))
+
+	// the route is not accepted when all its rules have a RegularExpression method match
+	rules = []gatewayv1alpha2.GRPCRouteRule{regexRule}
+	akogatewayapitests.UpdateGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		grpcRoute, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().GRPCRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), grpcRouteName, metav1.GetOptions{})
+		if err != nil || len(grpcRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(grpcRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 )
@@ -517,6 +519,168 @@ func TestHTTPRouteFilterWithRequestRedirect(t *testing.T) {
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestHTTPRouteFilterWithRequestRedirectPathSchemePort(t *testing.T) {
+
+	gatewayName := "gateway-hrf-05"
+	gatewayClassName := "gateway-class-hrf-05"
+	httpRouteName := "http-route-hrf-05"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"RequestRedirect": {"scheme", "port", "replacePrefixMatch"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	redirectAction := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RedirectAction
+	g.Expect(redirectAction).ShouldNot(gomega.BeNil())
+	g.Expect(*redirectAction.Host.Tokens[0].StrValue).To(gomega.Equal("redirect.com"))
+	g.Expect(*redirectAction.Protocol).To(gomega.Equal("HTTPS"))
+	g.Expect(*redirectAction.Port).To(gomega.Equal(uint32(8443)))
+	g.Expect(redirectAction.Path.Tokens).To(gomega.HaveLen(2))
+	g.Expect(*redirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+	g.Expect(*redirectAction.Path.Tokens[1].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+	g.Expect(*redirectAction.Path.Tokens[1].StartIndex).To(gomega.Equal(uint32(1)))
+
+	// update httproute to redirect to the full path with the well known port of the scheme
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"RequestRedirect": {"scheme", "replaceFullPath"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 ||
+			len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules) != 1 {
+			return 0
+		}
+		redirectAction := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RedirectAction
+		if redirectAction == nil || redirectAction.Port == nil {
+			return 0
+		}
+		return int(*redirectAction.Port)
+	}, 25*time.Second).Should(gomega.Equal(443))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	redirectAction = nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RedirectAction
+	g.Expect(redirectAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*redirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithURLRewrite(t *testing.T) {
+
+	gatewayName := "gateway-hrf-06"
+	gatewayClassName := "gateway-class-hrf-06"
+	httpRouteName := "http-route-hrf-06"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"URLRewrite": {"hostname", "replacePrefixMatch"}, "RequestHeaderModifier": {"add"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRule := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+	g.Expect(requestRule.HdrAction).To(gomega.HaveLen(1))
+	g.Expect(requestRule.RewriteURLAction).ShouldNot(gomega.BeNil())
+	g.Expect(*requestRule.RewriteURLAction.HostHdr.Tokens[0].StrValue).To(gomega.Equal("rewrite.com"))
+	g.Expect(requestRule.RewriteURLAction.Path.Tokens).To(gomega.HaveLen(2))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[1].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[1].StartIndex).To(gomega.Equal(uint32(1)))
+
+	// update httproute to rewrite the full path only
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"URLRewrite": {"replaceFullPath"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 ||
+			len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules) != 1 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].HdrAction)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRule = nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+	g.Expect(requestRule.RewriteURLAction.HostHdr).To(gomega.BeNil())
+	g.Expect(requestRule.RewriteURLAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
 func TestHTTPRouteWithValidConfig(t *testing.T) {
 	gatewayClassName := "gateway-class-hr-01"
 	gatewayName := "gateway-hr-01"
@@ -1416,3 +1580,767 @@ func TestHTTPRouteParentFQDN(t *testing.T) {
 	akogatewayapitests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+func TestHTTPRouteCrossNamespaceBackendRef(t *testing.T) {
+
+	gatewayName := "gateway-hr-rg-01"
+	gatewayClassName := "gateway-class-hr-rg-01"
+	httpRouteName := "http-route-hr-rg-01"
+	referenceGrantName := "reference-grant-hr-rg-01"
+	svcName := "avisvc-hr-rg-01"
+	svcNamespace := "red"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, svcNamespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, svcNamespace, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, svcNamespace, "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	// backend in another namespace is skipped without a ReferenceGrant
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs).To(gomega.HaveLen(0))
+
+	// ReferenceGrant in the backend namespace permits the reference
+	from := akogatewayapitests.GetReferenceGrantFromV1Beta1([][]string{{lib.HTTPRoute, DEFAULT_NAMESPACE}})
+	to := akogatewayapitests.GetReferenceGrantToV1Beta1([][]string{{utils.Service, svcName}})
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, svcNamespace, from, to)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs).To(gomega.HaveLen(1))
+
+	// removing the ReferenceGrant removes the backend again
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, svcNamespace)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes[0].PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, svcNamespace, svcName)
+	integrationtest.DelEPorEPS(t, svcNamespace, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteQueryParamAndMethodMatch(t *testing.T) {
+
+	gatewayName := "gateway-hr-qm-01"
+	gatewayClassName := "gateway-class-hr-qm-01"
+	httpRouteName := "http-route-hr-qm-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	methodGet := gatewayv1.HTTPMethodGet
+	queryParamMatchExact := gatewayv1.QueryParamMatchExact
+	methodMatch := akogatewayapitests.GetHTTPRouteMatchV1("/foo", "PathPrefix", []string{})
+	methodMatch.Method = &methodGet
+	methodMatch.QueryParams = []gatewayv1.HTTPQueryParamMatch{{
+		Type:  &queryParamMatchExact,
+		Name:  "version",
+		Value: "v1",
+	}}
+	headerMatch := akogatewayapitests.GetHTTPRouteMatchV1("/foo", "PathPrefix", []string{"canary"})
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(nil, []string{}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rule.Matches = []gatewayv1.HTTPRouteMatch{methodMatch, headerMatch}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childNode := nodes[0].EvhNodes[0]
+	childVSName := childNode.Name
+	g.Expect(childNode.VHMatches).To(gomega.HaveLen(1))
+	g.Expect(childNode.VHMatches[0].Rules).To(gomega.HaveLen(2))
+
+	// matches with the same path are ordered by method
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Method).To(gomega.BeNil())
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Query).To(gomega.BeNil())
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Hdrs).To(gomega.HaveLen(1))
+
+	matchTarget := childNode.VHMatches[0].Rules[1].Matches
+	g.Expect(matchTarget.Hdrs).To(gomega.HaveLen(0))
+	g.Expect(*matchTarget.Method.MatchCriteria).To(gomega.Equal("IS_IN"))
+	g.Expect(matchTarget.Method.Methods).To(gomega.Equal([]string{"HTTP_METHOD_GET"}))
+	g.Expect(*matchTarget.Query.MatchCriteria).To(gomega.Equal("QUERY_MATCH_REGEX_MATCH"))
+	g.Expect(childNode.StringGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(matchTarget.Query.StringGroupRefs).To(gomega.Equal([]string{"/api/stringgroup?name=" + *childNode.StringGroupRefs[0].Name}))
+	g.Expect(*childNode.StringGroupRefs[0].Kv[0].Key).To(gomega.Equal("(^|&)version=v1(&|$)"))
+
+	// reordering the matches in the rule doesn't change the child vs
+	rule.Matches = []gatewayv1.HTTPRouteMatch{headerMatch, methodMatch}
+	newRule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/bar"}, []string{}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules = []gatewayv1.HTTPRouteRule{rule, newRule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childNode = nodes[0].GetEvhNodeForName(childVSName)
+	g.Expect(childNode).ShouldNot(gomega.BeNil())
+	g.Expect(childNode.VHMatches[0].Rules[1].Matches.Method.Methods).To(gomega.Equal([]string{"HTTP_METHOD_GET"}))
+
+	// multiple query params are ANDed
+	methodMatch.QueryParams = append(methodMatch.QueryParams, gatewayv1.HTTPQueryParamMatch{
+		Type:  &queryParamMatchExact,
+		Name:  "env",
+		Value: "prod",
+	})
+	rule.Matches = []gatewayv1.HTTPRouteMatch{methodMatch, headerMatch}
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].StringGroupRefs) != 1 {
+			return ""
+		}
+		return *nodes[0].EvhNodes[0].StringGroupRefs[0].Kv[0].Key
+	}, 25*time.Second).Should(gomega.Equal("^(?=.*(^|&)env=prod(&|$))(?=.*(^|&)version=v1(&|$))"))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteTimeoutsAndRetryPolicy(t *testing.T) {
+
+	gatewayName := "gateway-hr-tr-01"
+	gatewayClassName := "gateway-class-hr-tr-01"
+	httpRouteName := "http-route-hr-tr-01"
+	svcName := "avisvc-hr-tr-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	requestTimeout := gatewayv1.Duration("10s")
+	backendRequestTimeout := gatewayv1.Duration("2s")
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{
+		Request:        &requestTimeout,
+		BackendRequest: &backendRequestTimeout,
+	}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	hr := &akogatewayapitests.HTTPRoute{}
+	hr.HTTPRoute = hr.HTTPRouteV1(httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+	hr.Annotations = map[string]string{
+		akogatewayapilib.RetryAttemptsAnnotation: "3",
+		akogatewayapilib.RetryCodesAnnotation:    "503, 502",
+	}
+	hr.Create(t)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	poolNode := nodes[0].EvhNodes[0].PoolRefs[0]
+	g.Expect(poolNode.ServerTimeout).To(gomega.Equal(uint32(2000)))
+	g.Expect(poolNode.ServerReselect).ShouldNot(gomega.BeNil())
+	g.Expect(*poolNode.ServerReselect.Enabled).To(gomega.BeTrue())
+	g.Expect(*poolNode.ServerReselect.NumRetries).To(gomega.Equal(uint32(3)))
+	g.Expect(*poolNode.ServerReselect.RetryTimeout).To(gomega.Equal(uint32(2000)))
+	g.Expect(poolNode.ServerReselect.SvrRespCode.Codes).To(gomega.Equal([]int64{502, 503}))
+	g.Expect(poolNode.ServerReselect.SvrRespCode.RespCodeBlock).To(gomega.HaveLen(0))
+
+	// a zero request timeout disables the timeout and removing the annotations removes the retries
+	disabledTimeout := gatewayv1.Duration("0s")
+	rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{
+		Request: &disabledTimeout,
+	}
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() uint32 {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+			return 0
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].ServerTimeout
+	}, 25*time.Second).Should(gomega.Equal(uint32(akogatewayapilib.MaxServerTimeout)))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs[0].ServerReselect).To(gomega.BeNil())
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteBackendTLSPolicy(t *testing.T) {
+
+	gatewayName := "gateway-hr-bt-01"
+	gatewayClassName := "gateway-class-hr-bt-01"
+	httpRouteName := "http-route-hr-bt-01"
+	svcName := "avisvc-hr-bt-01"
+	policyName := "backend-tls-policy-hr-bt-01"
+	configMapName := "ca-cert-hr-bt-01"
+	caCert := "-----BEGIN CERTIFICATE-----\nca-cert\n-----END CERTIFICATE-----"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	akogatewayapitests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, caCert)
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, "", configMapName, "backend.example.com")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+			return false
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	poolNode := nodes[0].EvhNodes[0].PoolRefs[0]
+	g.Expect(poolNode.SniEnabled).To(gomega.BeTrue())
+	g.Expect(*poolNode.SslProfileRef).To(gomega.Equal("/api/sslprofile?name=System-Standard"))
+	g.Expect(poolNode.ServerName).To(gomega.Equal("backend.example.com"))
+	g.Expect(poolNode.PkiProfile.CACert).To(gomega.Equal(caCert))
+	g.Expect(poolNode.PkiProfile.Name).To(gomega.Equal(lib.GetPoolPKIProfileName(poolNode.Name)))
+
+	// the pki profile is updated with the CA certificate in the ConfigMap
+	updatedCACert := "-----BEGIN CERTIFICATE-----\nupdated-ca-cert\n-----END CERTIFICATE-----"
+	akogatewayapitests.UpdateCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, updatedCACert)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 || nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile == nil {
+			return ""
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile.CACert
+	}, 25*time.Second).Should(gomega.Equal(updatedCACert))
+
+	// the policy targeting another port of the service is not attached to the pool
+	akogatewayapitests.UpdateBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, "bar", configMapName, "backend.example.com")
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+			return false
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	poolNode = nodes[0].EvhNodes[0].PoolRefs[0]
+	g.Expect(poolNode.SniEnabled).To(gomega.BeFalse())
+	g.Expect(poolNode.ServerName).To(gomega.BeEmpty())
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteBackendTLSPolicyWithInvalidCACertRef(t *testing.T) {
+
+	gatewayName := "gateway-hr-bt-02"
+	gatewayClassName := "gateway-class-hr-bt-02"
+	httpRouteName := "http-route-hr-bt-02"
+	svcName := "avisvc-hr-bt-02"
+	policyName := "backend-tls-policy-hr-bt-02"
+	configMapName := "ca-cert-hr-bt-02"
+	caCert := "-----BEGIN CERTIFICATE-----\nca-cert\n-----END CERTIFICATE-----"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	// the policy without a CA certificate reference
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, "", "", "backend.example.com")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	getPoolCount := func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes[0].PoolRefs)
+	}
+	getResolvedRefsReason := func() string {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs))
+		if condition == nil {
+			return ""
+		}
+		return string(condition.Status) + "/" + condition.Reason
+	}
+
+	// the backend is not programmed in plain text
+	g.Eventually(getPoolCount, 25*time.Second).Should(gomega.Equal(0))
+	g.Eventually(getResolvedRefsReason, 25*time.Second).Should(gomega.Equal("False/" + akogatewayapilib.RouteReasonInvalidCACertificateRef))
+
+	// the policy with a CA certificate reference of an unsupported kind
+	backendTLSPolicy, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().BackendTLSPolicies(DEFAULT_NAMESPACE).Get(context.TODO(), policyName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't get the BackendTLSPolicy, err: %+v", err)
+	}
+	backendTLSPolicy.Spec.TLS.CACertRefs = []gatewayv1.LocalObjectReference{{Kind: "Secret", Name: gatewayv1.ObjectName(configMapName)}}
+	if _, err = akogatewayapitests.GatewayClient.GatewayV1alpha2().BackendTLSPolicies(DEFAULT_NAMESPACE).Update(context.TODO(), backendTLSPolicy, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Couldn't update the BackendTLSPolicy, err: %+v", err)
+	}
+	g.Eventually(getResolvedRefsReason, 25*time.Second).Should(gomega.Equal("False/" + string(gatewayv1.RouteReasonInvalidKind)))
+	g.Expect(getPoolCount()).To(gomega.Equal(0))
+
+	// the policy referring to a missing ConfigMap
+	akogatewayapitests.UpdateBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, "", configMapName, "backend.example.com")
+	g.Eventually(getResolvedRefsReason, 25*time.Second).Should(gomega.Equal("False/" + akogatewayapilib.RouteReasonInvalidCACertificateRef))
+	g.Expect(getPoolCount()).To(gomega.Equal(0))
+
+	// the policy referring to a ConfigMap without the CA certificate
+	akogatewayapitests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, "")
+	g.Consistently(getPoolCount, 5*time.Second).Should(gomega.Equal(0))
+	g.Expect(getResolvedRefsReason()).To(gomega.Equal("False/" + akogatewayapilib.RouteReasonInvalidCACertificateRef))
+
+	// the backend is programmed with TLS once the CA certificate is added
+	akogatewayapitests.UpdateCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, caCert)
+	g.Eventually(getPoolCount, 25*time.Second).Should(gomega.Equal(1))
+	g.Eventually(getResolvedRefsReason, 25*time.Second).Should(gomega.Equal("True/" + string(gatewayv1.RouteReasonResolvedRefs)))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile).NotTo(gomega.BeNil())
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs[0].PkiProfile.CACert).To(gomega.Equal(caCert))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteExtensionRef(t *testing.T) {
+
+	gatewayName := "gateway-hr-er-01"
+	gatewayClassName := "gateway-class-hr-er-01"
+	httpRouteName := "http-route-hr-er-01"
+	svcName := "avisvc-hr-er-01"
+	routeRuleExtensionName := "route-rule-extension-hr-er-01"
+	l7RuleName := "l7rule-hr-er-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	routeRuleExtensionSpec := akov1alpha2.RouteRuleExtensionSpec{
+		WAFPolicy:              "waf-policy-01",
+		Datascripts:            []string{"datascript-01", "datascript-02"},
+		HealthMonitors:         []string{"health-monitor-01"},
+		ApplicationPersistence: "persistence-profile-01",
+		LoadBalancerPolicy: akov1alpha2.RouteRuleExtensionLBPolicy{
+			Algorithm: lib.LB_ALGORITHM_CONSISTENT_HASH,
+			Hash:      "LB_ALGORITHM_CONSISTENT_HASH_SOURCE_IP_ADDRESS",
+		},
+	}
+	akogatewayapitests.SetupRouteRuleExtension(t, routeRuleExtensionName, DEFAULT_NAMESPACE, routeRuleExtensionSpec)
+	botPolicyRef := "bot-policy-01"
+	akogatewayapitests.SetupL7Rule(t, l7RuleName, DEFAULT_NAMESPACE, akov1alpha2.L7RuleSpec{BotPolicyRef: &botPolicyRef})
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"ExtensionRef": {lib.RouteRuleExtension, routeRuleExtensionName}},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	rule.Filters = append(rule.Filters, akogatewayapitests.GetHTTPRouteFilterV1("ExtensionRef", []string{lib.L7Rule, l7RuleName}))
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childNode := nodes[0].EvhNodes[0]
+	g.Expect(*childNode.WafPolicyRef).To(gomega.Equal("/api/wafpolicy?name=waf-policy-01"))
+	g.Expect(childNode.VsDatascriptRefs).To(gomega.Equal([]string{"/api/vsdatascriptset?name=datascript-01", "/api/vsdatascriptset?name=datascript-02"}))
+	g.Expect(childNode.BotPolicyRef).ShouldNot(gomega.BeNil())
+	g.Expect(*childNode.BotPolicyRef).To(gomega.ContainSubstring(botPolicyRef))
+	g.Expect(childNode.HttpPolicyRefs).To(gomega.HaveLen(0))
+	poolNode := childNode.PoolRefs[0]
+	g.Expect(poolNode.HealthMonitorRefs).To(gomega.Equal([]string{"/api/healthmonitor?name=health-monitor-01"}))
+	g.Expect(*poolNode.ApplicationPersistenceProfileRef).To(gomega.Equal("/api/applicationpersistenceprofile?name=persistence-profile-01"))
+	g.Expect(*poolNode.LbAlgorithm).To(gomega.Equal(lib.LB_ALGORITHM_CONSISTENT_HASH))
+	g.Expect(*poolNode.LbAlgorithmHash).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH_SOURCE_IP_ADDRESS"))
+
+	// an update of the RouteRuleExtension is applied on the pools of the route
+	routeRuleExtensionSpec.LoadBalancerPolicy = akov1alpha2.RouteRuleExtensionLBPolicy{Algorithm: "LB_ALGORITHM_LEAST_CONNECTIONS"}
+	akogatewayapitests.UpdateRouteRuleExtension(t, routeRuleExtensionName, DEFAULT_NAMESPACE, routeRuleExtensionSpec)
+
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 || nodes[0].EvhNodes[0].PoolRefs[0].LbAlgorithm == nil {
+			return ""
+		}
+		return *nodes[0].EvhNodes[0].PoolRefs[0].LbAlgorithm
+	}, 25*time.Second).Should(gomega.Equal("LB_ALGORITHM_LEAST_CONNECTIONS"))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].PoolRefs[0].LbAlgorithmHash).To(gomega.BeNil())
+
+	// removing the filters removes the settings from the child VS and the pools
+	rule.Filters = nil
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 {
+			return false
+		}
+		return nodes[0].EvhNodes[0].WafPolicyRef == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childNode = nodes[0].EvhNodes[0]
+	g.Expect(childNode.VsDatascriptRefs).To(gomega.HaveLen(0))
+	g.Expect(childNode.BotPolicyRef).To(gomega.BeNil())
+	g.Expect(childNode.PoolRefs[0].HealthMonitorRefs).To(gomega.HaveLen(0))
+	g.Expect(childNode.PoolRefs[0].LbAlgorithm).To(gomega.BeNil())
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownL7Rule(t, l7RuleName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownRouteRuleExtension(t, routeRuleExtensionName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFullSyncDependencyVersions(t *testing.T) {
+
+	gatewayName := "gateway-hr-fs-01"
+	gatewayClassName := "gateway-class-hr-fs-01"
+	httpRouteName := "http-route-hr-fs-01"
+	svcName := "avisvc-hr-fs-01"
+	routeRuleExtensionName := "route-rule-extension-hr-fs-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	akogatewayapitests.SetupRouteRuleExtension(t, routeRuleExtensionName, DEFAULT_NAMESPACE, akov1alpha2.RouteRuleExtensionSpec{WAFPolicy: "waf-policy-01"})
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"ExtensionRef": {lib.RouteRuleExtension, routeRuleExtensionName}},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	getSyncedVersion := func() string {
+		_, resVer := objects.SharedFullSyncStateLister().ResourceVerStore.Get(lib.HTTPRoute + "/" + DEFAULT_NAMESPACE + "/" + httpRouteName)
+		if resVer == nil {
+			return ""
+		}
+		return resVer.(string)
+	}
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Expect(getSyncedVersion()).To(gomega.ContainSubstring(lib.Gateway + "/" + DEFAULT_NAMESPACE + "/" + gatewayName + "="))
+	g.Expect(getSyncedVersion()).To(gomega.ContainSubstring(lib.RouteRuleExtension + "/" + DEFAULT_NAMESPACE + "/" + routeRuleExtensionName + "="))
+
+	// the route is translated again, once its parent gateway changes without a change in the route
+	gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	gateway.ResourceVersion = "100"
+	if _, err = akogatewayapitests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Update(context.TODO(), gateway, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Gateway: %v", err)
+	}
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(DEFAULT_NAMESPACE).Get(gatewayName)
+		return err == nil && gateway.ResourceVersion == "100"
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Expect(getSyncedVersion()).To(gomega.ContainSubstring(lib.Gateway + "/" + DEFAULT_NAMESPACE + "/" + gatewayName + "=100"))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownRouteRuleExtension(t, routeRuleExtensionName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteWithUnsupportedFilter(t *testing.T) {
+
+	gatewayName := "gateway-hr-mr-01"
+	gatewayClassName := "gateway-class-hr-mr-01"
+	httpRouteName := "http-route-hr-mr-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"RequestHeaderModifier": {"add"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	ruleWithMirror := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/bar"}, []string{},
+		map[string][]string{"RequestMirror": {}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule, ruleWithMirror}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	// the rule with the RequestMirror filter is dropped
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	g.Consistently(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 5*time.Second).Should(gomega.Equal(1))
+
+	// the configuration of the route is removed once all its rules are unsupported
+	rules = []gatewayv1.HTTPRouteRule{ruleWithMirror}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	// the route is processed again once it is valid
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
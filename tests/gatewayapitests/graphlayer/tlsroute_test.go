@@ -0,0 +1,157 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - TLSRoute CRUD on a passthrough listener
+ */
+func TestTLSRouteCRUD(t *testing.T) {
+
+	gatewayName := "gateway-tr-01"
+	gatewayClassName := "gateway-class-tr-01"
+	tlsRouteName := "tls-route-tr-01"
+	svcName := "avisvc-tr-01"
+	ports := []int32{8443}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+	passthroughVSName := akogatewayapilib.GetGatewayPassthroughName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetPassthroughListenersV1(ports, "")
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].PassthroughChildNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    DEFAULT_NAMESPACE,
+		Type:         corev1.ServiceTypeClusterIP,
+		ServicePorts: []integrationtest.Serviceport{{PortName: "foo", Protocol: "TCP", PortNumber: 8443, TargetPort: intstr.FromInt(8443)}},
+	}).Service()
+
+	_, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	integrationtest.CreateEPorEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetTLSRouteRuleV1Alpha2([][]string{{svcName, DEFAULT_NAMESPACE, "8443", "1"}})
+	rules := []gatewayv1alpha2.TLSRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8443.com"}
+	akogatewayapitests.SetupTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].PassthroughChildNodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].PassthroughChildNodes[0].PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(0))
+	g.Expect(nodes[0].ServiceMetadata.PassthroughChildRef).To(gomega.Equal(passthroughVSName))
+	g.Expect(nodes[0].VSVIPRefs[0].FQDNs).To(gomega.ContainElement("foo-8443.com"))
+
+	passthroughNode := nodes[0].PassthroughChildNodes[0]
+	g.Expect(passthroughNode.Name).To(gomega.Equal(passthroughVSName))
+	g.Expect(passthroughNode.ServiceMetadata.PassthroughParentRef).To(gomega.Equal(nodes[0].Name))
+	g.Expect(passthroughNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(passthroughNode.PortProto[0].Port).To(gomega.Equal(int32(8443)))
+	g.Expect(passthroughNode.VSVIPRefs).To(gomega.Equal(nodes[0].VSVIPRefs))
+	g.Expect(passthroughNode.HTTPDSrefs).To(gomega.HaveLen(1))
+	pgName := akogatewayapilib.GetPassthroughPoolGroupName(DEFAULT_NAMESPACE, gatewayName, "foo-8443.com")
+	g.Expect(passthroughNode.PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+	g.Expect(passthroughNode.HTTPDSrefs[0].PoolGroupRefs).To(gomega.ContainElement(pgName))
+	g.Expect(passthroughNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(passthroughNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(passthroughNode.HTTPDSrefs[0].Script).To(gomega.ContainSubstring(lib.GetClusterName() + "--" + DEFAULT_NAMESPACE + "-" + gatewayName + "-"))
+
+	// update the hostname of the tlsroute
+	hostnames = []gatewayv1.Hostname{"bar-8443.com"}
+	akogatewayapitests.UpdateTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	pgName = akogatewayapilib.GetPassthroughPoolGroupName(DEFAULT_NAMESPACE, gatewayName, "bar-8443.com")
+	g.Eventually(func() string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return ""
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].PassthroughChildNodes) == 0 || len(nodes[0].PassthroughChildNodes[0].PoolGroupRefs) != 1 {
+			return ""
+		}
+		return nodes[0].PassthroughChildNodes[0].PoolGroupRefs[0].Name
+	}, 25*time.Second).Should(gomega.Equal(pgName))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].PassthroughChildNodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PassthroughChildNodes[0].HTTPDSrefs[0].PoolGroupRefs).To(gomega.Equal([]string{pgName}))
+
+	// delete tlsroute
+	akogatewayapitests.TeardownTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].PassthroughChildNodes) == 0 {
+			return -1
+		}
+		return len(nodes[0].PassthroughChildNodes[0].PoolGroupRefs) + len(nodes[0].PassthroughChildNodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPorEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -24,12 +24,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	akogatewayapik8s "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/k8s"
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
@@ -84,6 +87,7 @@ func setupQueue(stopCh <-chan struct{}) {
 func TestMain(m *testing.M) {
 	akogatewayapitests.KubeClient = k8sfake.NewSimpleClientset()
 	akogatewayapitests.GatewayClient = gatewayfake.NewSimpleClientset()
+	akogatewayapitests.SetExperimentalGatewayAPIResources(akogatewayapitests.GatewayClient)
 	integrationtest.KubeClient = akogatewayapitests.KubeClient
 
 	os.Setenv("CLUSTER_NAME", "cluster")
@@ -358,7 +362,7 @@ func TestGatewayInvalidListenerProtocol(t *testing.T) {
 		Status: gatewayv1.GatewayStatus{},
 	}
 	akogatewayapitests.SetGatewayGatewayClass(&gateway, gwClassName)
-	akogatewayapitests.AddGatewayListener(&gateway, "listener-example", 80, gatewayv1.TCPProtocolType, false)
+	akogatewayapitests.AddGatewayListener(&gateway, "listener-example", 80, gatewayv1.ProtocolType("SCTP"), false)
 	akogatewayapitests.SetListenerHostname(&gateway.Spec.Listeners[0], "*.example.com")
 
 	//create
@@ -739,3 +743,38 @@ func TestGatewayWithValidAllowedRoute(t *testing.T) {
 	akogatewayapitests.TeardownGateway(t, gwName, DEFAULT_NAMESPACE)
 	waitAndverify(t, gwKey)
 }
+
+func TestExperimentalGatewayAPIResourcesNotServed(t *testing.T) {
+	gatewayClient := gatewayfake.NewSimpleClientset()
+	v1alpha2GroupVersion := gatewayv1alpha2.GroupVersion.String()
+
+	// Only the standard channel CRDs are installed.
+	if akogatewayapilib.IsResourceServed(gatewayClient.Discovery(), v1alpha2GroupVersion, "tlsroutes") {
+		t.Fatalf("tlsroutes must not be served without the experimental channel CRDs")
+	}
+
+	akogatewayapitests.SetExperimentalGatewayAPIResources(gatewayClient)
+	for _, resource := range []string{"grpcroutes", "tlsroutes", "tcproutes", "udproutes", "backendtlspolicies"} {
+		if !akogatewayapilib.IsResourceServed(gatewayClient.Discovery(), v1alpha2GroupVersion, resource) {
+			t.Fatalf("%s must be served with the experimental channel CRDs", resource)
+		}
+	}
+	if akogatewayapilib.IsResourceServed(gatewayClient.Discovery(), v1alpha2GroupVersion, "unknownroutes") {
+		t.Fatalf("unknownroutes must not be served")
+	}
+}
+
+func TestRouteRuleExtensionCRDNotServed(t *testing.T) {
+	crdClient := v1alpha2crdfake.NewSimpleClientset()
+	akoCRDGroupVersion := akov1alpha2.SchemeGroupVersion.String()
+
+	// The CRD is not installed on a release upgraded without applying the new CRDs.
+	if akogatewayapilib.IsResourceServed(crdClient.Discovery(), akoCRDGroupVersion, "routeruleextensions") {
+		t.Fatalf("routeruleextensions must not be served without the CRD")
+	}
+
+	akogatewayapitests.SetAKOCRDResources(crdClient)
+	if !akogatewayapilib.IsResourceServed(crdClient.Discovery(), akoCRDGroupVersion, "routeruleextensions") {
+		t.Fatalf("routeruleextensions must be served with the CRD")
+	}
+}
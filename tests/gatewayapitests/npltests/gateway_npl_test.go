@@ -49,6 +49,7 @@ func TestMain(m *testing.M) {
 
 	tests.KubeClient = k8sfake.NewSimpleClientset()
 	tests.GatewayClient = gatewayfake.NewSimpleClientset()
+	tests.SetExperimentalGatewayAPIResources(tests.GatewayClient)
 	integrationtest.KubeClient = tests.KubeClient
 
 	// Sets the environment variables
@@ -33,6 +33,7 @@ import (
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
@@ -48,6 +49,9 @@ const (
 func TestMain(m *testing.M) {
 	tests.KubeClient = k8sfake.NewSimpleClientset()
 	tests.GatewayClient = gatewayfake.NewSimpleClientset()
+	tests.SetExperimentalGatewayAPIResources(tests.GatewayClient)
+	tests.V1alpha2CRDClient = v1alpha2crdfake.NewSimpleClientset()
+	tests.SetAKOCRDResources(tests.V1alpha2CRDClient)
 	integrationtest.KubeClient = tests.KubeClient
 
 	// Sets the environment variables
@@ -97,6 +101,7 @@ func TestMain(m *testing.M) {
 
 	ctrl = akogatewayapik8s.SharedGatewayController()
 	ctrl.DisableSync = false
+	lib.AKOControlConfig().Setv1alpha2CRDClientset(tests.V1alpha2CRDClient)
 	ctrl.InitGatewayAPIInformers(tests.GatewayClient)
 	akoControlConfig.SetGatewayAPIClientset(tests.GatewayClient)
 
@@ -833,7 +838,7 @@ func TestGatewayWithInvalidAllowedRoute(t *testing.T) {
 	}
 	expectedStatus.Listeners[0].Conditions[0].Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
 	expectedStatus.Listeners[0].Conditions[0].Status = metav1.ConditionFalse
-	expectedStatus.Listeners[0].Conditions[0].Message = "AllowedRoute kind is invalid. Only HTTPRoute is supported currently"
+	expectedStatus.Listeners[0].Conditions[0].Message = "AllowedRoute kind is invalid. Only HTTPRoute, GRPCRoute, TLSRoute, TCPRoute, UDPRoute are supported currently"
 	expectedStatus.Listeners[0].Conditions[0].Type = string(gatewayv1.ListenerConditionResolvedRefs)
 
 	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
@@ -1232,3 +1237,96 @@ func TestMultipleGatewayOverlappingHostname(t *testing.T) {
 	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+func TestGatewayWithCrossNamespaceCertificateRef(t *testing.T) {
+
+	gatewayName := "gateway-rg-01"
+	gatewayClassName := "gateway-class-rg-01"
+	referenceGrantName := "reference-grant-rg-01"
+	secretNamespace := "red"
+	ports := []int32{8080}
+	secrets := []string{"secret-rg-01"}
+	for _, secret := range secrets {
+		integrationtest.AddSecret(secret, secretNamespace, "cert", "key")
+	}
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, false, false, secrets...)
+	tests.SetListenerTLS(&listeners[0], gatewayv1.TLSModeTerminate, secrets[0], secretNamespace)
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	expectedStatus := &gatewayv1.GatewayStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(gatewayv1.GatewayConditionAccepted),
+				Status:             metav1.ConditionFalse,
+				Message:            "Gateway contains 1 invalid listener(s)",
+				ObservedGeneration: 1,
+				Reason:             string(gatewayv1.GatewayReasonListenersNotValid),
+			},
+		},
+		Listeners: tests.GetListenerStatusV1(ports, []int32{0}),
+	}
+	expectedStatus.Listeners[0].Conditions[0].Type = string(gatewayv1.ListenerConditionResolvedRefs)
+	expectedStatus.Listeners[0].Conditions[0].Reason = string(gatewayv1.ListenerReasonRefNotPermitted)
+	expectedStatus.Listeners[0].Conditions[0].Status = metav1.ConditionFalse
+	expectedStatus.Listeners[0].Conditions[0].Message = "CertificateRef red/secret-rg-01 is not permitted by any ReferenceGrant"
+
+	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		t.Fatalf("Couldn't get the gateway, err: %+v", err)
+	}
+	tests.ValidateGatewayStatus(t, &gateway.Status, expectedStatus)
+
+	// the listener becomes valid once the secret is permitted by a ReferenceGrant
+	from := tests.GetReferenceGrantFromV1Beta1([][]string{{lib.Gateway, DEFAULT_NAMESPACE}})
+	to := tests.GetReferenceGrantToV1Beta1([][]string{{utils.Secret, secrets[0]}})
+	tests.SetupReferenceGrant(t, referenceGrantName, secretNamespace, from, to)
+
+	g.Eventually(func() bool {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	expectedStatus.Conditions[0].Status = metav1.ConditionTrue
+	expectedStatus.Conditions[0].Reason = string(gatewayv1.GatewayReasonAccepted)
+	expectedStatus.Conditions[0].Message = "Gateway configuration is valid"
+	expectedStatus.Listeners = tests.GetListenerStatusV1(ports, []int32{0})
+
+	gateway, err = tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		t.Fatalf("Couldn't get the gateway, err: %+v", err)
+	}
+	tests.ValidateGatewayStatus(t, &gateway.Status, expectedStatus)
+
+	// the listener turns invalid again once the ReferenceGrant is removed
+	tests.TeardownReferenceGrant(t, referenceGrantName, secretNamespace)
+
+	g.Eventually(func() bool {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.IsStatusConditionFalse(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+	for _, secret := range secrets {
+		integrationtest.DeleteSecret(secret, secretNamespace)
+	}
+}
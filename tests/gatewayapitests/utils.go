@@ -27,19 +27,56 @@ import (
 
 	"github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 )
 
 var KubeClient *k8sfake.Clientset
 var GatewayClient *gatewayfake.Clientset
+var V1alpha2CRDClient *v1alpha2crdfake.Clientset
+
+// SetExperimentalGatewayAPIResources registers the experimental channel resources with the fake discovery of the
+// Gateway API clientset, as their informers are started only when the resources are served.
+func SetExperimentalGatewayAPIResources(gatewayClient *gatewayfake.Clientset) {
+	gatewayClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: gatewayv1alpha2.GroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "grpcroutes", Kind: "GRPCRoute", Namespaced: true},
+				{Name: "tlsroutes", Kind: "TLSRoute", Namespaced: true},
+				{Name: "tcproutes", Kind: "TCPRoute", Namespaced: true},
+				{Name: "udproutes", Kind: "UDPRoute", Namespaced: true},
+				{Name: "backendtlspolicies", Kind: "BackendTLSPolicy", Namespaced: true},
+			},
+		},
+	}
+}
+
+// SetAKOCRDResources registers the AKO CRDs, which can be referred by the ExtensionRef filters, with the fake
+// discovery of the AKO CRD clientset.
+func SetAKOCRDResources(crdClient *v1alpha2crdfake.Clientset) {
+	crdClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: akov1alpha2.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "l7rules", Kind: "L7Rule", Namespaced: true},
+				{Name: "routeruleextensions", Kind: "RouteRuleExtension", Namespaced: true},
+			},
+		},
+	}
+}
 
 func NewAviFakeClientInstance(kubeclient *k8sfake.Clientset, skipCachePopulation ...bool) {
 	if integrationtest.AviFakeClientInstance == nil {
@@ -465,10 +502,61 @@ func GetHTTPRouteFilterV1(filterType string, actions []string) gatewayv1.HTTPRou
 			Hostname:   (*gatewayv1.PreciseHostname)(&host),
 			StatusCode: &statusCode302,
 		}
+		for _, action := range actions {
+			switch action {
+			case "scheme":
+				routeFilter.RequestRedirect.Scheme = proto.String("https")
+			case "port":
+				port := gatewayv1.PortNumber(8443)
+				routeFilter.RequestRedirect.Port = &port
+			case "replaceFullPath", "replacePrefixMatch":
+				routeFilter.RequestRedirect.Path = GetHTTPPathModifierV1(action)
+			}
+		}
+	case "URLRewrite":
+		routeFilter.URLRewrite = &gatewayv1.HTTPURLRewriteFilter{}
+		for _, action := range actions {
+			switch action {
+			case "hostname":
+				host := "rewrite.com"
+				routeFilter.URLRewrite.Hostname = (*gatewayv1.PreciseHostname)(&host)
+			case "replaceFullPath", "replacePrefixMatch":
+				routeFilter.URLRewrite.Path = GetHTTPPathModifierV1(action)
+			}
+		}
+	case "RequestMirror":
+		port := gatewayv1.PortNumber(8080)
+		routeFilter.RequestMirror = &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName("avisvc-mirror"),
+				Port: &port,
+			},
+		}
+	case "ExtensionRef":
+		// actions hold the kind and the name of the referred AKO CRD
+		routeFilter.ExtensionRef = &gatewayv1.LocalObjectReference{
+			Group: gatewayv1.Group(akogatewayapilib.AKOCRDGroup),
+			Kind:  gatewayv1.Kind(actions[0]),
+			Name:  gatewayv1.ObjectName(actions[1]),
+		}
 	}
 	return routeFilter
 }
 
+func GetHTTPPathModifierV1(action string) *gatewayv1.HTTPPathModifier {
+	path := "/bar"
+	pathModifier := &gatewayv1.HTTPPathModifier{}
+	switch action {
+	case "replaceFullPath":
+		pathModifier.Type = gatewayv1.FullPathHTTPPathModifier
+		pathModifier.ReplaceFullPath = &path
+	case "replacePrefixMatch":
+		pathModifier.Type = gatewayv1.PrefixMatchHTTPPathModifier
+		pathModifier.ReplacePrefixMatch = &path
+	}
+	return pathModifier
+}
+
 func GetHTTPRouteBackendV1(backendRefs []string) gatewayv1.HTTPBackendRef {
 	serviceKind := gatewayv1.Kind("Service")
 	port, _ := strconv.Atoi(backendRefs[2])
@@ -593,6 +681,608 @@ func TeardownHTTPRoute(t *testing.T, name, namespace string) {
 	hr.Delete(t)
 }
 
+type GRPCRoute struct {
+	*gatewayv1alpha2.GRPCRoute
+}
+
+func (gr *GRPCRoute) GRPCRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) *gatewayv1alpha2.GRPCRoute {
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: hostnames,
+			Rules:     rules,
+		},
+	}
+	return grpcRoute
+}
+
+// GetGRPCRouteRuleV1Alpha2 builds a rule with one exact method match per service/method pair in methods.
+func GetGRPCRouteRuleV1Alpha2(methods [][]string, matchHeaders []string, backendRefs [][]string) gatewayv1alpha2.GRPCRouteRule {
+	rule := gatewayv1alpha2.GRPCRouteRule{}
+	for _, method := range methods {
+		routeMatch := gatewayv1alpha2.GRPCRouteMatch{}
+		routeMatch.Method = &gatewayv1alpha2.GRPCMethodMatch{
+			Type: (*gatewayv1alpha2.GRPCMethodMatchType)(proto.String("Exact")),
+		}
+		if method[0] != "" {
+			routeMatch.Method.Service = proto.String(method[0])
+		}
+		if method[1] != "" {
+			routeMatch.Method.Method = proto.String(method[1])
+		}
+		for _, header := range matchHeaders {
+			headerMatch := gatewayv1alpha2.GRPCHeaderMatch{}
+			headerMatch.Type = (*gatewayv1.HeaderMatchType)(proto.String("Exact"))
+			headerMatch.Name = gatewayv1alpha2.GRPCHeaderName(header)
+			headerMatch.Value = "some-value"
+			routeMatch.Headers = append(routeMatch.Headers, headerMatch)
+		}
+		rule.Matches = append(rule.Matches, routeMatch)
+	}
+	for _, backendRef := range backendRefs {
+		rule.BackendRefs = append(rule.BackendRefs, gatewayv1alpha2.GRPCBackendRef{BackendRef: GetHTTPRouteBackendV1(backendRef).BackendRef})
+	}
+	return rule
+}
+
+func (gr *GRPCRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Create(context.TODO(), gr.GRPCRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Created GRPCRoute %s", gr.Name)
+}
+
+func (gr *GRPCRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Update(context.TODO(), gr.GRPCRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Updated GRPCRoute %s", gr.Name)
+}
+
+func (gr *GRPCRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Delete(context.TODO(), gr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Deleted GRPCRoute %s", gr.Name)
+}
+
+func SetupGRPCRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	gr.Create(t)
+}
+
+func UpdateGRPCRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	gr.Update(t)
+}
+
+func TeardownGRPCRoute(t *testing.T, name, namespace string) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, nil, nil, nil)
+	gr.Delete(t)
+}
+
+type TLSRoute struct {
+	*gatewayv1alpha2.TLSRoute
+}
+
+func (tr *TLSRoute) TLSRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) *gatewayv1alpha2.TLSRoute {
+	tlsRoute := &gatewayv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: hostnames,
+			Rules:     rules,
+		},
+	}
+	return tlsRoute
+}
+
+func GetPassthroughListenersV1(ports []int32, hostname string) []gatewayv1.Listener {
+	listeners := make([]gatewayv1.Listener, 0, len(ports))
+	for _, port := range ports {
+		tlsMode := gatewayv1.TLSModePassthrough
+		listener := gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
+			Port:     gatewayv1.PortNumber(port),
+			Protocol: gatewayv1.TLSProtocolType,
+			TLS:      &gatewayv1.GatewayTLSConfig{Mode: &tlsMode},
+		}
+		if hostname != "" {
+			listener.Hostname = (*gatewayv1.Hostname)(&hostname)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+func GetTLSRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.TLSRouteRule {
+	rule := gatewayv1alpha2.TLSRouteRule{}
+	for _, backendRef := range backendRefs {
+		rule.BackendRefs = append(rule.BackendRefs, GetHTTPRouteBackendV1(backendRef).BackendRef)
+	}
+	return rule
+}
+
+func (tr *TLSRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Create(context.TODO(), tr.TLSRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Created TLSRoute %s", tr.Name)
+}
+
+func (tr *TLSRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Update(context.TODO(), tr.TLSRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Updated TLSRoute %s", tr.Name)
+}
+
+func (tr *TLSRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Delete(context.TODO(), tr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Deleted TLSRoute %s", tr.Name)
+}
+
+func SetupTLSRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	tr.Create(t)
+}
+
+func UpdateTLSRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	tr.Update(t)
+}
+
+func TeardownTLSRoute(t *testing.T, name, namespace string) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, nil, nil, nil)
+	tr.Delete(t)
+}
+
+func GetL4ListenersV1(ports []int32, protocol gatewayv1.ProtocolType) []gatewayv1.Listener {
+	listeners := make([]gatewayv1.Listener, 0, len(ports))
+	for _, port := range ports {
+		listeners = append(listeners, gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
+			Port:     gatewayv1.PortNumber(port),
+			Protocol: protocol,
+		})
+	}
+	return listeners
+}
+
+type TCPRoute struct {
+	*gatewayv1alpha2.TCPRoute
+}
+
+func (tr *TCPRoute) TCPRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) *gatewayv1alpha2.TCPRoute {
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Rules: rules,
+		},
+	}
+	return tcpRoute
+}
+
+func GetTCPRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.TCPRouteRule {
+	rule := gatewayv1alpha2.TCPRouteRule{}
+	for _, backendRef := range backendRefs {
+		rule.BackendRefs = append(rule.BackendRefs, GetHTTPRouteBackendV1(backendRef).BackendRef)
+	}
+	return rule
+}
+
+func (tr *TCPRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Create(context.TODO(), tr.TCPRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Created TCPRoute %s", tr.Name)
+}
+
+func (tr *TCPRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Update(context.TODO(), tr.TCPRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Updated TCPRoute %s", tr.Name)
+}
+
+func (tr *TCPRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Delete(context.TODO(), tr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Deleted TCPRoute %s", tr.Name)
+}
+
+func SetupTCPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	tr.Create(t)
+}
+
+func UpdateTCPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	tr.Update(t)
+}
+
+func TeardownTCPRoute(t *testing.T, name, namespace string) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, nil, nil)
+	tr.Delete(t)
+}
+
+type UDPRoute struct {
+	*gatewayv1alpha2.UDPRoute
+}
+
+func (ur *UDPRoute) UDPRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) *gatewayv1alpha2.UDPRoute {
+	udpRoute := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Rules: rules,
+		},
+	}
+	return udpRoute
+}
+
+func GetUDPRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.UDPRouteRule {
+	rule := gatewayv1alpha2.UDPRouteRule{}
+	for _, backendRef := range backendRefs {
+		rule.BackendRefs = append(rule.BackendRefs, GetHTTPRouteBackendV1(backendRef).BackendRef)
+	}
+	return rule
+}
+
+func (ur *UDPRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Create(context.TODO(), ur.UDPRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Created UDPRoute %s", ur.Name)
+}
+
+func (ur *UDPRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Update(context.TODO(), ur.UDPRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Updated UDPRoute %s", ur.Name)
+}
+
+func (ur *UDPRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Delete(context.TODO(), ur.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Deleted UDPRoute %s", ur.Name)
+}
+
+func SetupUDPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	ur.Create(t)
+}
+
+func UpdateUDPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	ur.Update(t)
+}
+
+func TeardownUDPRoute(t *testing.T, name, namespace string) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, nil, nil)
+	ur.Delete(t)
+}
+
+type ReferenceGrant struct {
+	*gatewayv1beta1.ReferenceGrant
+}
+
+func (rg *ReferenceGrant) ReferenceGrantV1Beta1(name, namespace string, from []gatewayv1beta1.ReferenceGrantFrom, to []gatewayv1beta1.ReferenceGrantTo) *gatewayv1beta1.ReferenceGrant {
+	referenceGrant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: from,
+			To:   to,
+		},
+	}
+	return referenceGrant
+}
+
+// GetReferenceGrantFromV1Beta1 expects each entry of froms as {kind, namespace}.
+func GetReferenceGrantFromV1Beta1(froms [][]string) []gatewayv1beta1.ReferenceGrantFrom {
+	referenceGrantFrom := make([]gatewayv1beta1.ReferenceGrantFrom, 0, len(froms))
+	for _, from := range froms {
+		referenceGrantFrom = append(referenceGrantFrom, gatewayv1beta1.ReferenceGrantFrom{
+			Group:     gatewayv1.GroupName,
+			Kind:      gatewayv1.Kind(from[0]),
+			Namespace: gatewayv1.Namespace(from[1]),
+		})
+	}
+	return referenceGrantFrom
+}
+
+// GetReferenceGrantToV1Beta1 expects each entry of tos as {kind, name}, an empty name allows all the objects of the kind.
+func GetReferenceGrantToV1Beta1(tos [][]string) []gatewayv1beta1.ReferenceGrantTo {
+	referenceGrantTo := make([]gatewayv1beta1.ReferenceGrantTo, 0, len(tos))
+	for _, to := range tos {
+		grantTo := gatewayv1beta1.ReferenceGrantTo{
+			Kind: gatewayv1.Kind(to[0]),
+		}
+		if to[1] != "" {
+			grantTo.Name = (*gatewayv1.ObjectName)(&to[1])
+		}
+		referenceGrantTo = append(referenceGrantTo, grantTo)
+	}
+	return referenceGrantTo
+}
+
+func (rg *ReferenceGrant) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1beta1().ReferenceGrants(rg.Namespace).Create(context.TODO(), rg.ReferenceGrant, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Created ReferenceGrant %s", rg.Name)
+}
+
+func (rg *ReferenceGrant) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1beta1().ReferenceGrants(rg.Namespace).Update(context.TODO(), rg.ReferenceGrant, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Updated ReferenceGrant %s", rg.Name)
+}
+
+func (rg *ReferenceGrant) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1beta1().ReferenceGrants(rg.Namespace).Delete(context.TODO(), rg.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Deleted ReferenceGrant %s", rg.Name)
+}
+
+func SetupReferenceGrant(t *testing.T, name, namespace string, from []gatewayv1beta1.ReferenceGrantFrom, to []gatewayv1beta1.ReferenceGrantTo) {
+	rg := &ReferenceGrant{}
+	rg.ReferenceGrant = rg.ReferenceGrantV1Beta1(name, namespace, from, to)
+	rg.Create(t)
+}
+
+func UpdateReferenceGrant(t *testing.T, name, namespace string, from []gatewayv1beta1.ReferenceGrantFrom, to []gatewayv1beta1.ReferenceGrantTo) {
+	rg := &ReferenceGrant{}
+	rg.ReferenceGrant = rg.ReferenceGrantV1Beta1(name, namespace, from, to)
+	rg.Update(t)
+}
+
+func TeardownReferenceGrant(t *testing.T, name, namespace string) {
+	rg := &ReferenceGrant{}
+	rg.ReferenceGrant = rg.ReferenceGrantV1Beta1(name, namespace, nil, nil)
+	rg.Delete(t)
+}
+
+type BackendTLSPolicy struct {
+	*gatewayv1alpha2.BackendTLSPolicy
+}
+
+// BackendTLSPolicyV1Alpha2 returns a BackendTLSPolicy targeting the Service svcName, the port named sectionName
+// of the Service is targeted if set, with the CA certificate in the ConfigMap caCertConfigMap.
+func (bp *BackendTLSPolicy) BackendTLSPolicyV1Alpha2(name, namespace, svcName, sectionName, caCertConfigMap, hostname string) *gatewayv1alpha2.BackendTLSPolicy {
+	backendTLSPolicy := &gatewayv1alpha2.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gatewayv1alpha2.BackendTLSPolicySpec{
+			TargetRef: gatewayv1alpha2.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gatewayv1alpha2.PolicyTargetReference{
+					Kind: "Service",
+					Name: gatewayv1alpha2.ObjectName(svcName),
+				},
+			},
+			TLS: gatewayv1alpha2.BackendTLSPolicyConfig{
+				Hostname: gatewayv1beta1.PreciseHostname(hostname),
+			},
+		},
+	}
+	if sectionName != "" {
+		backendTLSPolicy.Spec.TargetRef.SectionName = (*gatewayv1alpha2.SectionName)(&sectionName)
+	}
+	if caCertConfigMap != "" {
+		backendTLSPolicy.Spec.TLS.CACertRefs = []gatewayv1beta1.LocalObjectReference{{
+			Kind: akogatewayapilib.ConfigMap,
+			Name: gatewayv1beta1.ObjectName(caCertConfigMap),
+		}}
+	}
+	return backendTLSPolicy
+}
+
+func (bp *BackendTLSPolicy) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().BackendTLSPolicies(bp.Namespace).Create(context.TODO(), bp.BackendTLSPolicy, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the BackendTLSPolicy, err: %+v", err)
+	}
+	t.Logf("Created BackendTLSPolicy %s", bp.Name)
+}
+
+func (bp *BackendTLSPolicy) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().BackendTLSPolicies(bp.Namespace).Update(context.TODO(), bp.BackendTLSPolicy, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the BackendTLSPolicy, err: %+v", err)
+	}
+	t.Logf("Updated BackendTLSPolicy %s", bp.Name)
+}
+
+func (bp *BackendTLSPolicy) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().BackendTLSPolicies(bp.Namespace).Delete(context.TODO(), bp.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the BackendTLSPolicy, err: %+v", err)
+	}
+	t.Logf("Deleted BackendTLSPolicy %s", bp.Name)
+}
+
+func SetupBackendTLSPolicy(t *testing.T, name, namespace, svcName, sectionName, caCertConfigMap, hostname string) {
+	bp := &BackendTLSPolicy{}
+	bp.BackendTLSPolicy = bp.BackendTLSPolicyV1Alpha2(name, namespace, svcName, sectionName, caCertConfigMap, hostname)
+	bp.Create(t)
+}
+
+func UpdateBackendTLSPolicy(t *testing.T, name, namespace, svcName, sectionName, caCertConfigMap, hostname string) {
+	bp := &BackendTLSPolicy{}
+	bp.BackendTLSPolicy = bp.BackendTLSPolicyV1Alpha2(name, namespace, svcName, sectionName, caCertConfigMap, hostname)
+	bp.Update(t)
+}
+
+func TeardownBackendTLSPolicy(t *testing.T, name, namespace string) {
+	bp := &BackendTLSPolicy{}
+	bp.BackendTLSPolicy = bp.BackendTLSPolicyV1Alpha2(name, namespace, "", "", "", "")
+	bp.Delete(t)
+}
+
+func SetupCACertConfigMap(t *testing.T, name, namespace, caCert string) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Data: map[string]string{
+			akogatewayapilib.CACertKey: caCert,
+		},
+	}
+	_, err := KubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Created ConfigMap %s", name)
+}
+
+func UpdateCACertConfigMap(t *testing.T, name, namespace, caCert string) {
+	configMap, err := KubeClient.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't get the ConfigMap, err: %+v", err)
+	}
+	configMap.Data[akogatewayapilib.CACertKey] = caCert
+	configMap.ResourceVersion = time.Now().Local().String()
+	_, err = KubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Updated ConfigMap %s", name)
+}
+
+func TeardownCACertConfigMap(t *testing.T, name, namespace string) {
+	err := KubeClient.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Deleted ConfigMap %s", name)
+}
+
+func SetupRouteRuleExtension(t *testing.T, name, namespace string, spec akov1alpha2.RouteRuleExtensionSpec) {
+	routeRuleExtension := &akov1alpha2.RouteRuleExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+	_, err := V1alpha2CRDClient.AkoV1alpha2().RouteRuleExtensions(namespace).Create(context.TODO(), routeRuleExtension, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the RouteRuleExtension, err: %+v", err)
+	}
+	t.Logf("Created RouteRuleExtension %s", name)
+}
+
+func UpdateRouteRuleExtension(t *testing.T, name, namespace string, spec akov1alpha2.RouteRuleExtensionSpec) {
+	routeRuleExtension, err := V1alpha2CRDClient.AkoV1alpha2().RouteRuleExtensions(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't get the RouteRuleExtension, err: %+v", err)
+	}
+	routeRuleExtension.Spec = spec
+	_, err = V1alpha2CRDClient.AkoV1alpha2().RouteRuleExtensions(namespace).Update(context.TODO(), routeRuleExtension, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the RouteRuleExtension, err: %+v", err)
+	}
+	t.Logf("Updated RouteRuleExtension %s", name)
+}
+
+func TeardownRouteRuleExtension(t *testing.T, name, namespace string) {
+	err := V1alpha2CRDClient.AkoV1alpha2().RouteRuleExtensions(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the RouteRuleExtension, err: %+v", err)
+	}
+	t.Logf("Deleted RouteRuleExtension %s", name)
+}
+
+func SetupL7Rule(t *testing.T, name, namespace string, spec akov1alpha2.L7RuleSpec) {
+	l7Rule := &akov1alpha2.L7Rule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+	_, err := V1alpha2CRDClient.AkoV1alpha2().L7Rules(namespace).Create(context.TODO(), l7Rule, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the L7Rule, err: %+v", err)
+	}
+	t.Logf("Created L7Rule %s", name)
+}
+
+func TeardownL7Rule(t *testing.T, name, namespace string) {
+	err := V1alpha2CRDClient.AkoV1alpha2().L7Rules(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the L7Rule, err: %+v", err)
+	}
+	t.Logf("Deleted L7Rule %s", name)
+}
+
 func ValidateGatewayStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1.GatewayStatus) {
 
 	g := gomega.NewGomegaWithT(t)
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func generateCertificate(t *testing.T, host string, notAfter time.Time) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error in generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("error in generating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("error in marshalling key: %v", err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(key)
+}
+
+func getVSCertificate(vsName string) *cache.VSCertificate {
+	for _, vsCertificate := range cache.GetVSCertificates() {
+		if vsCertificate.VS.Name == vsName {
+			return &vsCertificate
+		}
+	}
+	return nil
+}
+
+func TestCertificateExpiryAndHostMismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+
+	// The certificate of foo.com is issued for bar.com, and expires in 10 days.
+	notAfter := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+	cert, key := generateCertificate(t, "bar.com", notAfter)
+	integrationtest.AddSecret(secretName, "default", cert, key)
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		isTLS:       true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	g.Eventually(func() *cache.VSCertificate {
+		return getVSCertificate("cluster--foo.com")
+	}, 30*time.Second).ShouldNot(gomega.BeNil())
+	vsCertificate := getVSCertificate("cluster--foo.com")
+	g.Expect(vsCertificate.Certificate.Subject.CommonName).To(gomega.Equal("bar.com"))
+	g.Expect(vsCertificate.Certificate.NotAfter.Equal(notAfter)).To(gomega.BeTrue())
+	g.Expect(vsCertificate.MismatchedHosts).To(gomega.Equal([]string{"foo.com"}))
+
+	// The warning events are recorded on the Ingress, for which the virtual service is built.
+	owners := status.GetCertificateOwners(vsCertificate.VS.ServiceMetadataObj)
+	g.Expect(owners).To(gomega.HaveLen(1))
+	g.Expect(owners[0].(*networkingv1.Ingress).Name).To(gomega.Equal(ingName))
+	status.CheckCertificates(lib.AKOControlConfig().EventRecorder(), status.GetCertificateOwners)
+
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
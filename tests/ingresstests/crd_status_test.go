@@ -0,0 +1,217 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func getHostRuleCondition(hrname, conditionType string) *metav1.Condition {
+	hostrule, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return meta.FindStatusCondition(hostrule.Status.Conditions, conditionType)
+}
+
+func TestHostRuleStatusConditionsAndAttachments(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	hrname := objNameMap.GenerateName("samplehr-foo")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		isTLS:       true,
+		withSecret:  true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
+
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.VerifyMetadataHostRule(t, g, sniVSKey, "default/"+hrname, true)
+
+	accepted := getHostRuleCondition(hrname, lib.CRDConditionAccepted)
+	g.Expect(accepted).NotTo(gomega.BeNil())
+	g.Expect(accepted.Status).To(gomega.Equal(metav1.ConditionTrue))
+	resolvedRefs := getHostRuleCondition(hrname, lib.CRDConditionResolvedRefs)
+	g.Expect(resolvedRefs).NotTo(gomega.BeNil())
+	g.Expect(resolvedRefs.Status).To(gomega.Equal(metav1.ConditionTrue))
+
+	// The HostRule is reported as programmed, once it is applied to the SNI virtual service.
+	g.Eventually(func() metav1.ConditionStatus {
+		if programmed := getHostRuleCondition(hrname, lib.CRDConditionProgrammed); programmed != nil {
+			return programmed.Status
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(metav1.ConditionTrue))
+	g.Eventually(func() []v1beta1.AttachedObject {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.AttachedTo
+	}, 10*time.Second).Should(gomega.ContainElement(v1beta1.AttachedObject{
+		Kind:   lib.AttachedVirtualService,
+		Tenant: "admin",
+		Name:   "cluster--foo.com",
+	}))
+
+	// The attachments are removed, once the HostRule is not applied to any virtual service.
+	hrUpdate := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "foo-nomatch.com",
+	}.HostRule()
+	hrUpdate.ResourceVersion = "2"
+	if _, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		if programmed := getHostRuleCondition(hrname, lib.CRDConditionProgrammed); programmed != nil {
+			return programmed.Reason
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(lib.CRDReasonNotAttached))
+	hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	g.Expect(hostrule.Status.AttachedTo).To(gomega.BeEmpty())
+	g.Expect(hostrule.Status.Status).To(gomega.Equal("Accepted"))
+
+	integrationtest.TearDownHostRuleWithNoVerify(t, g, hrname)
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
+
+func TestRejectedHostRuleStatusConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hrname := objNameMap.GenerateName("samplehr-foo")
+	hostrule := integrationtest.FakeHostRule{
+		Name:               hrname,
+		Namespace:          "default",
+		Fqdn:               "foo-rejected.com",
+		SslKeyCertificate:  "thisisaviref-sslkey",
+		WafPolicy:          "thisisBADaviref-waf",
+		ApplicationProfile: "thisisaviref-appprof",
+		SslProfile:         "thisisaviref-sslprof",
+	}.HostRule()
+	if _, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// A missing reference on the Avi controller is reported as an unresolved reference.
+	accepted := getHostRuleCondition(hrname, lib.CRDConditionAccepted)
+	g.Expect(accepted).NotTo(gomega.BeNil())
+	g.Expect(accepted.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(accepted.Reason).To(gomega.Equal(lib.CRDReasonRefNotFound))
+	resolvedRefs := getHostRuleCondition(hrname, lib.CRDConditionResolvedRefs)
+	g.Expect(resolvedRefs).NotTo(gomega.BeNil())
+	g.Expect(resolvedRefs.Status).To(gomega.Equal(metav1.ConditionFalse))
+	programmed := getHostRuleCondition(hrname, lib.CRDConditionProgrammed)
+	g.Expect(programmed).NotTo(gomega.BeNil())
+	g.Expect(programmed.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(programmed.Reason).To(gomega.Equal(lib.CRDReasonInvalid))
+
+	integrationtest.TearDownHostRuleWithNoVerify(t, g, hrname)
+}
+
+func TestAviInfraSettingStatusConditionsAndAttachments(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	ns := integrationtest.DEFAULT_NAMESPACE
+	ingClassName := objNameMap.GenerateName("avi-lb")
+	ingressName := objNameMap.GenerateName("foo-with-class")
+	settingName := objNameMap.GenerateName("my-infrasetting")
+	modelName := MODEL_NAME_PREFIX + "1"
+	settingModelName := MODEL_NAME_PREFIX + settingName + "-0"
+	svcName := objNameMap.GenerateName("avisvc")
+
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.SetupAviInfraSetting(t, settingName, "SMALL")
+	integrationtest.SetupIngressClass(t, ingClassName, lib.AviIngressController, settingName)
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().IngressClassInformer.Lister().Get(ingClassName)
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	ingressCreate := (integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   ns,
+		ClassName:   ingClassName,
+		DnsNames:    []string{"bar.com"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses(ns).Create(context.TODO(), ingressCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	// The AviInfraSetting is reported as programmed, once the pool of the Ingress is added to its shared virtual service.
+	g.Eventually(func() metav1.ConditionStatus {
+		infraSetting, err := v1beta1CRDClient.AkoV1beta1().AviInfraSettings().Get(context.TODO(), settingName, metav1.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		if programmed := meta.FindStatusCondition(infraSetting.Status.Conditions, lib.CRDConditionProgrammed); programmed != nil {
+			return programmed.Status
+		}
+		return ""
+	}, 40*time.Second).Should(gomega.Equal(metav1.ConditionTrue))
+	infraSetting, _ := v1beta1CRDClient.AkoV1beta1().AviInfraSettings().Get(context.TODO(), settingName, metav1.GetOptions{})
+	g.Expect(infraSetting.Status.Status).To(gomega.Equal("Accepted"))
+	g.Expect(infraSetting.Status.AttachedTo).To(gomega.ContainElement(v1beta1.AttachedObject{
+		Kind:      "Ingress",
+		Namespace: ns,
+		Name:      ingressName,
+	}))
+	vsCount := 0
+	for _, attachment := range infraSetting.Status.AttachedTo {
+		if attachment.Kind == lib.AttachedVirtualService {
+			vsCount++
+		}
+	}
+	g.Expect(vsCount).To(gomega.Equal(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses(ns).Delete(context.TODO(), ingressName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.TeardownAviInfraSetting(t, settingName)
+	TearDownTestForIngress(t, svcName, modelName, settingModelName)
+	integrationtest.TeardownIngressClass(t, ingClassName)
+}
@@ -26,10 +26,12 @@ import (
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -1963,3 +1965,191 @@ func TestHostRuleRegexAppRoot(t *testing.T) {
 
 	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
 }
+
+func TestHostruleClientCertificate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	hrname := objNameMap.GenerateName("samplehr-foo")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		isTLS:       true,
+		withSecret:  true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.ClientCertificate = &v1beta1.HostRuleClientCertificate{
+		CACertificate: v1beta1.HostRuleSecret{
+			Name: "thisisaviref-pki",
+			Type: v1beta1.HostRuleSecretTypeAviReference,
+		},
+		Mode:                  v1beta1.HostRuleClientCertificateModeRequest,
+		ForwardClientIdentity: true,
+	}
+	if _, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.VerifyMetadataHostRule(t, g, sniVSKey, "default/"+hrname, true)
+
+	// The application profile with the client certificate settings is attached to the SNI virtual service.
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].ClientCertAppProfile != nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	sniNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0]
+	appProfile := sniNode.ClientCertAppProfile
+	g.Expect(appProfile.Name).To(gomega.Equal(lib.GetClientAppProfileName(sniNode.Name)))
+	g.Expect(appProfile.PkiProfile).To(gomega.BeNil())
+	g.Expect(appProfile.GetPkiProfileName()).To(gomega.Equal("thisisaviref-pki"))
+	g.Expect(appProfile.ClientCertificateMode).To(gomega.Equal(lib.SSLClientCertificateModeRequest))
+	g.Expect(appProfile.ForwardClientIdentity).To(gomega.BeTrue())
+	g.Expect(*sniNode.ApplicationProfileRef).To(gomega.Equal("/api/applicationprofile?name=" + appProfile.Name))
+	g.Eventually(func() bool {
+		_, found := cache.SharedAviObjCache().AppProfileCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: appProfile.Name})
+		return found
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	g.Expect(appProfile.BaseProfile).To(gomega.Equal(utils.DEFAULT_L7_SECURE_APP_PROFILE))
+	appProfileCache, _ := cache.SharedAviObjCache().AppProfileCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: appProfile.Name})
+	appProfileCksum := appProfileCache.(*cache.AviAppProfileCache).CloudConfigCksum
+
+	// With a custom application profile, the client certificate settings are overlaid on a clone of it.
+	hrUpdate := hostrule.DeepCopy()
+	hrUpdate.Spec.VirtualHost.ApplicationProfile = "thisisaviref-appprof"
+	hrUpdate.ResourceVersion = "2"
+	if _, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 || nodes[0].SniNodes[0].ClientCertAppProfile == nil {
+			return ""
+		}
+		return nodes[0].SniNodes[0].ClientCertAppProfile.BaseProfile
+	}, 10*time.Second).Should(gomega.Equal("thisisaviref-appprof"))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	sniNode = aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0]
+	g.Expect(*sniNode.ApplicationProfileRef).To(gomega.Equal("/api/applicationprofile?name=" + appProfile.Name))
+	g.Eventually(func() uint32 {
+		appProfileCache, found := cache.SharedAviObjCache().AppProfileCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: appProfile.Name})
+		if !found {
+			return appProfileCksum
+		}
+		return appProfileCache.(*cache.AviAppProfileCache).CloudConfigCksum
+	}, 10*time.Second).ShouldNot(gomega.Equal(appProfileCksum))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].ClientCertAppProfile == nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Eventually(func() bool {
+		_, found := cache.SharedAviObjCache().AppProfileCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: appProfile.Name})
+		return found
+	}, 10*time.Second).Should(gomega.BeFalse())
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
+
+func TestHostruleClientCertificateCASecretUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	hrname := objNameMap.GenerateName("samplehr-foo")
+	secretName := objNameMap.GenerateName("my-secret")
+	caSecretName := objNameMap.GenerateName("my-ca-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		isTLS:       true,
+		withSecret:  true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            caSecretName,
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{utils.K8S_TLS_SECRET_CA_CERT: []byte("caCert")},
+	}
+	if _, err := KubeClient.CoreV1().Secrets("default").Create(context.TODO(), caSecret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Secret: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().SecretInformer.Lister().Secrets("default").Get(caSecretName)
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.ClientCertificate = &v1beta1.HostRuleClientCertificate{
+		CACertificate: v1beta1.HostRuleSecret{
+			Name: caSecretName,
+			Type: v1beta1.HostRuleSecretTypeSecretReference,
+		},
+	}
+	if _, err := v1beta1CRDClient.AkoV1beta1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	getPkiProfileCACert := func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 || nodes[0].SniNodes[0].ClientCertAppProfile == nil ||
+			nodes[0].SniNodes[0].ClientCertAppProfile.PkiProfile == nil {
+			return ""
+		}
+		return nodes[0].SniNodes[0].ClientCertAppProfile.PkiProfile.CACert
+	}
+	g.Eventually(getPkiProfileCACert, 10*time.Second).Should(gomega.Equal("caCert"))
+
+	// The update of the CA certificate in the Secret is applied to the PKI profile.
+	caSecret.Data[utils.K8S_TLS_SECRET_CA_CERT] = []byte("caCertUpdated")
+	caSecret.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Secrets("default").Update(context.TODO(), caSecret, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Secret: %v", err)
+	}
+	g.Eventually(getPkiProfileCACert, 10*time.Second).Should(gomega.Equal("caCertUpdated"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), caSecretName, metav1.DeleteOptions{})
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func TestIncrementalFullSync(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	lib.SetPrometheusRegistry()
+	reg := lib.RegisterPromMetrics()
+	lib.AKOControlConfig().SetAKOPrometheusFlag(true)
+	defer lib.AKOControlConfig().SetAKOPrometheusFlag(false)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	SetUpTestForIngress(t, svcName, modelName)
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "ingress-ifs",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.ResourceVersion = "1"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	// isVSSynced returns true if the virtual service of the model is in the cache with the checksum of the model.
+	vsKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--Shared-L7-0"}
+	isVSSynced := func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil || len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()) == 0 {
+			return false
+		}
+		vsNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0]
+		vsCache, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+		return found && vsCache.(*cache.AviVsCache).CloudConfigCksum == strconv.Itoa(int(vsNode.GetCheckSum()))
+	}
+	getFullSyncModels := func(result string) float64 {
+		families, err := reg.Gather()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		for _, family := range families {
+			if !strings.HasSuffix(family.GetName(), "_full_sync_models") {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				for _, labelPair := range metric.GetLabel() {
+					if labelPair.GetName() == "result" && labelPair.GetValue() == result {
+						return metric.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return -1
+	}
+
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Eventually(isVSSynced, 30*time.Second).Should(gomega.BeTrue())
+	getSyncedVersion := func() string {
+		_, resVer := objects.SharedFullSyncStateLister().ResourceVerStore.Get("Ingress/default/ingress-ifs")
+		if resVer == nil {
+			return ""
+		}
+		return resVer.(string)
+	}
+	g.Expect(getSyncedVersion()).To(gomega.HavePrefix("1/"))
+
+	// The unchanged model is not published again.
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Expect(getFullSyncModels("skipped")).To(gomega.BeNumerically(">=", 1))
+	rebuilt := getFullSyncModels("rebuilt")
+
+	// The model is published again, once its virtual service diverges from the cache.
+	vsCache, _ := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+	vsCache.(*cache.AviVsCache).CloudConfigCksum = "0"
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Expect(getFullSyncModels("rebuilt")).To(gomega.Equal(rebuilt + 1))
+	g.Eventually(isVSSynced, 30*time.Second).Should(gomega.BeTrue())
+
+	// The ingress is translated again, once its backend service changes without a change in the ingress.
+	svcObj, err := KubeClient.CoreV1().Services("default").Get(context.TODO(), svcName, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	svcObj.ResourceVersion = "100"
+	if _, err = KubeClient.CoreV1().Services("default").Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services("default").Get(svcName)
+		return err == nil && svcObj.ResourceVersion == "100"
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Expect(getSyncedVersion()).NotTo(gomega.ContainSubstring("Service/default/" + svcName + "=100"))
+	g.Expect(ctrl.FullSyncK8s(true)).To(gomega.Succeed())
+	g.Expect(getSyncedVersion()).To(gomega.ContainSubstring("Service/default/" + svcName + "=100"))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "ingress-ifs", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	TearDownTestForIngress(t, svcName, modelName)
+}
@@ -16,6 +16,7 @@ package ingresstests
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -26,6 +27,8 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -662,3 +665,753 @@ func TestIngressAnnotationAddDefaultCert(t *testing.T) {
 	VerifyIngressDeletion(t, g, aviModel, 0)
 	TearDownTestForIngress(t, svcName, modelName)
 }
+
+func TestSecureIngressWithNginxAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("ENABLE_NGINX_ANNOTATIONS", "true")
+	defer os.Setenv("ENABLE_NGINX_ANNOTATIONS", "false")
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("foo-with-nginx-annotations")
+	secretName := objNameMap.GenerateName("my-secret")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}).Ingress()
+	ingrFake.SetAnnotations(map[string]string{
+		lib.NginxRewriteTarget:        "/",
+		lib.NginxWhitelistSourceRange: "10.0.0.0/8, 192.168.1.1",
+		lib.NginxAffinity:             "cookie",
+		lib.NginxSessionCookieName:    "route",
+		lib.NginxSessionCookieMaxAge:  "3600",
+		lib.NginxBackendProtocol:      "HTTPS",
+		lib.NginxSSLRedirect:          "false",
+		lib.NginxProxyBodySize:        "8m",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	var nodes []*avinodes.AviVsNode
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return false
+		}
+		nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].SniNodes) == 1 && len(nodes[0].SniNodes[0].PoolRefs) == 1
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	sniNode := nodes[0].SniNodes[0]
+	pool := sniNode.PoolRefs[0]
+	g.Expect(pool.SniEnabled).To(gomega.BeTrue())
+	g.Expect(*pool.SslProfileRef).To(gomega.Equal("/api/sslprofile?name=" + lib.DefaultPoolSSLProfile))
+	g.Expect(pool.PersistenceProfile).NotTo(gomega.BeNil())
+	g.Expect(pool.PersistenceProfile.Name).To(gomega.Equal(lib.GetPoolPersistenceProfileName(pool.Name)))
+	g.Expect(pool.PersistenceProfile.PersistenceType).To(gomega.Equal(lib.PersistenceTypeHTTPCookie))
+	g.Expect(pool.PersistenceProfile.CookieName).To(gomega.Equal("route"))
+	g.Expect(pool.PersistenceProfile.Timeout).To(gomega.Equal(int32(60)))
+
+	var nginxPolicy *avinodes.AviHttpPolicySetNode
+	for _, httpPolicy := range sniNode.HttpPolicyRefs {
+		if httpPolicy.Name == lib.GetNginxHTTPPolicySetName(pool.Name) {
+			nginxPolicy = httpPolicy
+		}
+	}
+	g.Expect(nginxPolicy).NotTo(gomega.BeNil())
+	g.Expect(nginxPolicy.RequestRules).To(gomega.HaveLen(2))
+	denyRule := nginxPolicy.RequestRules[0]
+	g.Expect(denyRule.Match.HostHdr.Value).To(gomega.ConsistOf("foo.com"))
+	g.Expect(denyRule.Match.Path.MatchStr).To(gomega.ConsistOf("/foo"))
+	g.Expect(*denyRule.Match.ClientIP.MatchCriteria).To(gomega.Equal("IS_NOT_IN"))
+	g.Expect(denyRule.Match.ClientIP.Prefixes).To(gomega.HaveLen(2))
+	g.Expect(*denyRule.Match.ClientIP.Prefixes[1].IPAddr.Addr).To(gomega.Equal("192.168.1.1"))
+	g.Expect(*denyRule.Match.ClientIP.Prefixes[1].Mask).To(gomega.Equal(int32(32)))
+	g.Expect(*denyRule.SwitchingAction.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_403"))
+	rewriteRule := nginxPolicy.RequestRules[1]
+	g.Expect(*rewriteRule.SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + pool.Name))
+	g.Expect(*rewriteRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("/"))
+
+	// The request body size is set on the application profile of the child virtualservice.
+	g.Expect(sniNode.ClientCertAppProfile).NotTo(gomega.BeNil())
+	g.Expect(sniNode.ClientCertAppProfile.Name).To(gomega.Equal(lib.GetClientAppProfileName(sniNode.Name)))
+	g.Expect(sniNode.ClientCertAppProfile.BaseProfile).To(gomega.Equal(utils.DEFAULT_L7_SECURE_APP_PROFILE))
+	g.Expect(sniNode.ClientCertAppProfile.ClientCertificateMode).To(gomega.BeEmpty())
+	g.Expect(*sniNode.ClientCertAppProfile.ClientMaxBodySize).To(gomega.Equal(int64(8192)))
+	g.Expect(*sniNode.ApplicationProfileRef).To(gomega.Equal("/api/applicationprofile?name=" + sniNode.ClientCertAppProfile.Name))
+
+	// With ssl-redirect set to false, the host is served over HTTP as well, without a redirect to HTTPS.
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(1))
+	for _, httpPolicy := range nodes[0].HttpPolicyRefs {
+		for _, redirectPort := range httpPolicy.RedirectPorts {
+			g.Expect(redirectPort.Hosts).NotTo(gomega.ContainElement("foo.com"))
+		}
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the secret %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestIngressWithNginxAnnotationsUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("ENABLE_NGINX_ANNOTATIONS", "true")
+	defer os.Setenv("ENABLE_NGINX_ANNOTATIONS", "false")
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("foo-with-nginx-annotations")
+	cmName := objNameMap.GenerateName("custom-headers")
+	SetUpTestForIngress(t, svcName, modelName)
+
+	customHeaders := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: "default"},
+		Data:       map[string]string{"X-Frame-Options": "DENY", "X-Content-Type-Options": "nosniff"},
+	}
+	if _, err := KubeClient.CoreV1().ConfigMaps("default").Create(context.TODO(), customHeaders, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding ConfigMap: %v", err)
+	}
+	defer KubeClient.CoreV1().ConfigMaps("default").Delete(context.TODO(), cmName, metav1.DeleteOptions{})
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.SetAnnotations(map[string]string{
+		lib.NginxAffinity:         "cookie",
+		lib.NginxUpstreamVhost:    "internal.foo.com",
+		lib.NginxXForwardedPrefix: "/foo",
+		lib.NginxCustomHeaders:    cmName,
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	getNginxPolicy := func() (*avinodes.AviPoolNode, *avinodes.AviHttpPolicySetNode) {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return nil, nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil, nil
+		}
+		pool := nodes[0].PoolRefs[0]
+		for _, httpPolicy := range nodes[0].HttpPolicyRefs {
+			if httpPolicy.Name == lib.GetNginxHTTPPolicySetName(pool.Name) {
+				return pool, httpPolicy
+			}
+		}
+		return pool, nil
+	}
+	g.Eventually(func() bool {
+		_, nginxPolicy := getNginxPolicy()
+		return nginxPolicy != nil
+	}, 40*time.Second).Should(gomega.Equal(true))
+	pool, nginxPolicy := getNginxPolicy()
+	g.Expect(pool.SniEnabled).To(gomega.BeFalse())
+	g.Expect(pool.PersistenceProfile).To(gomega.BeNil())
+	g.Expect(*pool.ApplicationPersistenceProfileRef).To(gomega.Equal("/api/applicationpersistenceprofile?name=" + lib.SystemHTTPCookiePersistenceProfile))
+	g.Expect(nginxPolicy.RequestRules).To(gomega.HaveLen(1))
+	g.Expect(nginxPolicy.RequestRules[0].RewriteURLAction).To(gomega.BeNil())
+	g.Expect(nginxPolicy.RequestRules[0].HdrAction).To(gomega.HaveLen(2))
+	g.Expect(*nginxPolicy.RequestRules[0].HdrAction[0].Hdr.Name).To(gomega.Equal("Host"))
+	g.Expect(*nginxPolicy.RequestRules[0].HdrAction[0].Hdr.Value.Val).To(gomega.Equal("internal.foo.com"))
+	g.Expect(*nginxPolicy.RequestRules[0].HdrAction[1].Hdr.Name).To(gomega.Equal("X-Forwarded-Prefix"))
+	g.Expect(nginxPolicy.ResponseRules).To(gomega.HaveLen(1))
+	g.Expect(nginxPolicy.ResponseRules[0].Match.HostHdr.Value).To(gomega.ConsistOf("foo.com"))
+	g.Expect(nginxPolicy.ResponseRules[0].Match.Path.MatchStr).To(gomega.ConsistOf("/foo"))
+	g.Expect(nginxPolicy.ResponseRules[0].HdrAction).To(gomega.HaveLen(2))
+	g.Expect(*nginxPolicy.ResponseRules[0].HdrAction[0].Action).To(gomega.Equal("HTTP_REPLACE_HDR"))
+	g.Expect(*nginxPolicy.ResponseRules[0].HdrAction[0].Hdr.Name).To(gomega.Equal("X-Content-Type-Options"))
+	g.Expect(*nginxPolicy.ResponseRules[0].HdrAction[1].Hdr.Name).To(gomega.Equal("X-Frame-Options"))
+	g.Expect(*nginxPolicy.ResponseRules[0].HdrAction[1].Hdr.Value.Val).To(gomega.Equal("DENY"))
+
+	// Updating the ConfigMap updates the response headers of the Ingress.
+	customHeaders.Data = map[string]string{"X-Frame-Options": "SAMEORIGIN"}
+	customHeaders.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().ConfigMaps("default").Update(context.TODO(), customHeaders, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating ConfigMap: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, nginxPolicy := getNginxPolicy()
+		return nginxPolicy != nil && len(nginxPolicy.ResponseRules) == 1 && len(nginxPolicy.ResponseRules[0].HdrAction) == 1 &&
+			*nginxPolicy.ResponseRules[0].HdrAction[0].Hdr.Value.Val == "SAMEORIGIN"
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	// A ConfigMap in another namespace is not read for the custom headers.
+	ingrFake.Annotations[lib.NginxCustomHeaders] = "red/" + cmName
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, nginxPolicy := getNginxPolicy()
+		return nginxPolicy != nil && len(nginxPolicy.ResponseRules) == 0
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	// Removing the annotations removes the HTTP policy set and the persistence of the pool.
+	ingrFake.SetAnnotations(nil)
+	ingrFake.ResourceVersion = "3"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		pool, nginxPolicy := getNginxPolicy()
+		return pool != nil && nginxPolicy == nil && pool.ApplicationPersistenceProfileRef == nil
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestSecureIngressWithNginxAnnotationsOnRootPath(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("ENABLE_NGINX_ANNOTATIONS", "true")
+	defer os.Setenv("ENABLE_NGINX_ANNOTATIONS", "false")
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	rootIngName := objNameMap.GenerateName("foo-root")
+	fooIngName := objNameMap.GenerateName("foo-exact")
+	secretName := objNameMap.GenerateName("my-secret")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+
+	rootIngrFake := (integrationtest.FakeIngress{
+		Name:        rootIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}).Ingress()
+	rootIngrFake.SetAnnotations(map[string]string{
+		lib.NginxWhitelistSourceRange: "10.0.0.0/8",
+		lib.NginxRewriteTarget:        "/app",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), rootIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	fooIngrFake := (integrationtest.FakeIngress{
+		Name:        fooIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}).Ingress()
+	exact := networkingv1.PathTypeExact
+	fooIngrFake.Spec.Rules[0].HTTP.Paths[0].PathType = &exact
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), fooIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	rootPoolName := lib.GetSniPoolName(rootIngName, "default", "foo.com", "/", "", false)
+	getNginxPolicy := func() *avinodes.AviHttpPolicySetNode {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return nil
+		}
+		for _, httpPolicy := range nodes[0].SniNodes[0].HttpPolicyRefs {
+			if httpPolicy.Name == lib.GetNginxHTTPPolicySetName(rootPoolName) {
+				return httpPolicy
+			}
+		}
+		return nil
+	}
+
+	// The rules of the root path are not applied to the exact path of the other ingress, which precedes them.
+	g.Eventually(func() int {
+		nginxPolicy := getNginxPolicy()
+		if nginxPolicy == nil {
+			return 0
+		}
+		return len(nginxPolicy.RequestRules)
+	}, 40*time.Second).Should(gomega.Equal(3))
+	nginxPolicy := getNginxPolicy()
+	excludedRule := nginxPolicy.RequestRules[0]
+	g.Expect(excludedRule.Match.HostHdr.Value).To(gomega.ConsistOf("foo.com"))
+	g.Expect(*excludedRule.Match.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(excludedRule.Match.Path.MatchStr).To(gomega.ConsistOf("/foo"))
+	g.Expect(excludedRule.SwitchingAction).To(gomega.BeNil())
+	g.Expect(excludedRule.RewriteURLAction).To(gomega.BeNil())
+	denyRule := nginxPolicy.RequestRules[1]
+	g.Expect(*denyRule.Index).To(gomega.Equal(int32(1)))
+	g.Expect(*denyRule.Match.Path.MatchCriteria).To(gomega.Equal("BEGINS_WITH"))
+	g.Expect(denyRule.Match.Path.MatchStr).To(gomega.ConsistOf("/"))
+	g.Expect(*denyRule.SwitchingAction.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_403"))
+	rewriteRule := nginxPolicy.RequestRules[2]
+	g.Expect(*rewriteRule.SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + rootPoolName))
+	g.Expect(*rewriteRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("app"))
+
+	// Deleting the other ingress applies the rules of the root path to all the paths of the host.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), fooIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	g.Eventually(func() int {
+		nginxPolicy := getNginxPolicy()
+		if nginxPolicy == nil {
+			return 0
+		}
+		return len(nginxPolicy.RequestRules)
+	}, 40*time.Second).Should(gomega.Equal(2))
+	nginxPolicy = getNginxPolicy()
+	g.Expect(nginxPolicy.ExcludedPaths).To(gomega.BeEmpty())
+	g.Expect(*nginxPolicy.RequestRules[0].SwitchingAction.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_403"))
+	g.Expect(*nginxPolicy.RequestRules[0].Index).To(gomega.Equal(int32(0)))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), rootIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the secret %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestSecureIngressWithCanaryIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	ingName := objNameMap.GenerateName("foo-with-canary")
+	canaryIngName := objNameMap.GenerateName("foo-canary")
+	secretName := objNameMap.GenerateName("my-secret")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, "default", canarySvcName, false, false, "2.2.2")
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	canaryIngrFake := (integrationtest.FakeIngress{
+		Name:        canaryIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: canarySvcName,
+	}).Ingress()
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.CanaryAnnotation:       "true",
+		lib.CanaryWeightAnnotation: "20",
+		lib.CanaryHeaderAnnotation: "X-Canary",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), canaryIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	poolName := lib.GetSniPoolName(ingName, "default", "foo.com", "/foo", "", false)
+	canaryPoolName := lib.GetSniPoolName(ingName, "default", "foo.com", "/foo", "", false, canarySvcName)
+	var nodes []*avinodes.AviVsNode
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return 0
+		}
+		return len(nodes[0].SniNodes[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	sniNode := nodes[0].SniNodes[0]
+	g.Expect(sniNode.PoolRefs[0].Name).To(gomega.Equal(poolName))
+	g.Expect(sniNode.PoolRefs[1].Name).To(gomega.Equal(canaryPoolName))
+	g.Expect(sniNode.PoolRefs[1].Servers).To(gomega.HaveLen(1))
+	g.Expect(*sniNode.PoolRefs[1].Servers[0].Ip.Addr).To(gomega.Equal("2.2.2.1"))
+	g.Expect(sniNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(sniNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(2))
+	g.Expect(*sniNode.PoolGroupRefs[0].Members[0].PoolRef).To(gomega.Equal("/api/pool?name=" + poolName))
+	g.Expect(*sniNode.PoolGroupRefs[0].Members[0].Ratio).To(gomega.Equal(uint32(80)))
+	g.Expect(*sniNode.PoolGroupRefs[0].Members[1].PoolRef).To(gomega.Equal("/api/pool?name=" + canaryPoolName))
+	g.Expect(*sniNode.PoolGroupRefs[0].Members[1].Ratio).To(gomega.Equal(uint32(20)))
+
+	// The canary policy precedes the other policies of the SNI virtualservice.
+	canaryPolicy := sniNode.HttpPolicyRefs[0]
+	g.Expect(canaryPolicy.Name).To(gomega.Equal(lib.GetCanaryHTTPPolicySetName(canaryPoolName)))
+	g.Expect(canaryPolicy.RequestRules).To(gomega.HaveLen(2))
+	g.Expect(canaryPolicy.RequestRules[0].Match.HostHdr.Value).To(gomega.ConsistOf("foo.com"))
+	g.Expect(canaryPolicy.RequestRules[0].Match.Path.MatchStr).To(gomega.ConsistOf("/foo"))
+	g.Expect(*canaryPolicy.RequestRules[0].Match.Hdrs[0].Hdr).To(gomega.Equal("X-Canary"))
+	g.Expect(canaryPolicy.RequestRules[0].Match.Hdrs[0].Value).To(gomega.ConsistOf("always"))
+	g.Expect(*canaryPolicy.RequestRules[0].SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + canaryPoolName))
+	// The requests with the header set to never are switched to the pool of the ingress.
+	g.Expect(canaryPolicy.RequestRules[1].Match.Path.MatchStr).To(gomega.ConsistOf("/foo"))
+	g.Expect(*canaryPolicy.RequestRules[1].Match.Hdrs[0].Hdr).To(gomega.Equal("X-Canary"))
+	g.Expect(canaryPolicy.RequestRules[1].Match.Hdrs[0].Value).To(gomega.ConsistOf("never"))
+	g.Expect(*canaryPolicy.RequestRules[1].SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + poolName))
+
+	// Deleting the canary ingress removes the canary pool and policy, and restores the weight of the ingress.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), canaryIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].SniNodes[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(1))
+	sniNode = nodes[0].SniNodes[0]
+	g.Expect(sniNode.PoolRefs[0].Name).To(gomega.Equal(poolName))
+	g.Expect(sniNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+	g.Expect(*sniNode.PoolGroupRefs[0].Members[0].Ratio).To(gomega.Equal(uint32(100)))
+	for _, httpPolicy := range sniNode.HttpPolicyRefs {
+		g.Expect(httpPolicy.Name).NotTo(gomega.Equal(lib.GetCanaryHTTPPolicySetName(canaryPoolName)))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the secret %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPorEPS(t, "default", canarySvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestSecureIngressWithCanaryIngressAndLongerPath(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	ingName := objNameMap.GenerateName("foo-with-canary")
+	canaryIngName := objNameMap.GenerateName("foo-canary")
+	secretName := objNameMap.GenerateName("my-secret")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, "default", canarySvcName, false, false, "2.2.2")
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com", "foo.com"},
+		Paths:       []string{"/", "/api"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	canaryIngrFake := (integrationtest.FakeIngress{
+		Name:        canaryIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/"},
+		ServiceName: canarySvcName,
+	}).Ingress()
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.CanaryAnnotation:       "true",
+		lib.CanaryHeaderAnnotation: "X-Canary",
+		lib.CanaryCookieAnnotation: "canary",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), canaryIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	poolName := lib.GetSniPoolName(ingName, "default", "foo.com", "/", "", false)
+	canaryPoolName := lib.GetSniPoolName(ingName, "default", "foo.com", "/", "", false, canarySvcName)
+	var nodes []*avinodes.AviVsNode
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return 0
+		}
+		return len(nodes[0].SniNodes[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(3))
+
+	// The canary policy of the path / does not apply to the longer path /api of the host, and the
+	// requests with the header or the cookie set to never are switched to the pool of the ingress.
+	canaryPolicy := nodes[0].SniNodes[0].HttpPolicyRefs[0]
+	g.Expect(canaryPolicy.Name).To(gomega.Equal(lib.GetCanaryHTTPPolicySetName(canaryPoolName)))
+	g.Expect(canaryPolicy.RequestRules).To(gomega.HaveLen(5))
+	excludedRule := canaryPolicy.RequestRules[0]
+	g.Expect(excludedRule.Match.HostHdr.Value).To(gomega.ConsistOf("foo.com"))
+	g.Expect(excludedRule.Match.Path.MatchStr).To(gomega.ConsistOf("/api"))
+	g.Expect(excludedRule.SwitchingAction).To(gomega.BeNil())
+	expectedRules := []struct {
+		value string
+		pool  string
+	}{
+		{"always", canaryPoolName},
+		{"never", poolName},
+		{"always", canaryPoolName},
+		{"never", poolName},
+	}
+	for i, expected := range expectedRules {
+		rule := canaryPolicy.RequestRules[i+1]
+		g.Expect(*rule.Index).To(gomega.Equal(int32(i + 1)))
+		g.Expect(rule.Match.Path.MatchStr).To(gomega.ConsistOf("/"))
+		if i < 2 {
+			g.Expect(*rule.Match.Hdrs[0].Hdr).To(gomega.Equal("X-Canary"))
+			g.Expect(rule.Match.Hdrs[0].Value).To(gomega.ConsistOf(expected.value))
+		} else {
+			g.Expect(*rule.Match.Cookie.Name).To(gomega.Equal("canary"))
+			g.Expect(*rule.Match.Cookie.Value).To(gomega.Equal(expected.value))
+		}
+		g.Expect(*rule.SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + expected.pool))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), canaryIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the secret %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPorEPS(t, "default", canarySvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestIngressWithNginxCanaryWeight(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("ENABLE_NGINX_ANNOTATIONS", "true")
+	defer os.Setenv("ENABLE_NGINX_ANNOTATIONS", "false")
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	ingName := objNameMap.GenerateName("foo-with-canary")
+	canaryIngName := objNameMap.GenerateName("foo-canary")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, "default", canarySvcName, false, false, "2.2.2")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	canaryIngrFake := (integrationtest.FakeIngress{
+		Name:        canaryIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: canarySvcName,
+	}).Ingress()
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.NginxCanary:       "true",
+		lib.NginxCanaryWeight: "30",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), canaryIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	poolName := lib.GetL7PoolName("foo.com/foo", "default", ingName, "")
+	canaryPoolName := lib.GetL7PoolName("foo.com/foo", "default", ingName, "", canarySvcName)
+	getPoolRatios := func() map[string]uint32 {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		ratios := make(map[string]uint32)
+		for _, pool := range nodes[0].PoolRefs {
+			ratios[pool.Name] = pool.ServiceMetadata.PoolRatio
+		}
+		return ratios
+	}
+	g.Eventually(getPoolRatios, 40*time.Second).Should(gomega.Equal(map[string]uint32{poolName: 70, canaryPoolName: 30}))
+
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.NginxCanary:       "true",
+		lib.NginxCanaryWeight: "50",
+	})
+	canaryIngrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), canaryIngrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getPoolRatios, 40*time.Second).Should(gomega.Equal(map[string]uint32{poolName: 50, canaryPoolName: 50}))
+
+	// Deleting the canary ingress removes the canary pool, and restores the weight of the ingress.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), canaryIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	g.Eventually(getPoolRatios, 40*time.Second).Should(gomega.Equal(map[string]uint32{poolName: 100}))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPorEPS(t, "default", canarySvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestIngressWithNginxCanaryHeader(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("ENABLE_NGINX_ANNOTATIONS", "true")
+	defer os.Setenv("ENABLE_NGINX_ANNOTATIONS", "false")
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	ingName := objNameMap.GenerateName("foo-with-canary")
+	canaryIngName := objNameMap.GenerateName("foo-canary")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPorEPS(t, "default", canarySvcName, false, false, "2.2.2")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	canaryIngrFake := (integrationtest.FakeIngress{
+		Name:        canaryIngName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: canarySvcName,
+	}).Ingress()
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.NginxCanary:       "true",
+		lib.NginxCanaryWeight: "30",
+	})
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), canaryIngrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	poolName := lib.GetL7PoolName("foo.com/foo", "default", ingName, "")
+	canaryPoolName := lib.GetL7PoolName("foo.com/foo", "default", ingName, "", canarySvcName)
+	getPoolNames := func() []string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		var poolNames []string
+		for _, pool := range nodes[0].PoolRefs {
+			poolNames = append(poolNames, pool.Name)
+		}
+		return poolNames
+	}
+	g.Eventually(getPoolNames, 40*time.Second).Should(gomega.ConsistOf(poolName, canaryPoolName))
+
+	// The canary with a header is skipped for the insecure host of the shared virtualservice.
+	canaryIngrFake.SetAnnotations(map[string]string{
+		lib.NginxCanary:         "true",
+		lib.NginxCanaryWeight:   "30",
+		lib.NginxCanaryByHeader: "X-Canary",
+	})
+	canaryIngrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), canaryIngrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getPoolNames, 40*time.Second).Should(gomega.ConsistOf(poolName))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	for _, httpPolicy := range aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].HttpPolicyRefs {
+		g.Expect(httpPolicy.Name).NotTo(gomega.Equal(lib.GetCanaryHTTPPolicySetName(canaryPoolName)))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), canaryIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't Delete the Ingress %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPorEPS(t, "default", canarySvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/vmware/alb-sdk/go/models"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
@@ -100,6 +101,7 @@ func TestMain(m *testing.M) {
 		utils.NSInformer,
 		utils.NodeInformer,
 		utils.ConfigMapInformer,
+		utils.NginxConfigMapInformer,
 	}
 	if akoControlConfig.GetEndpointSlicesEnabled() {
 		registeredInformers = append(registeredInformers, utils.EndpointSlicesInformer)
@@ -3042,3 +3044,316 @@ func TestDualStackMultipleBackendService(t *testing.T) {
 
 	TearDownTestForIngress(t, svcName, modelName)
 }
+
+func getDefaultBackendPool(aviModel interface{}) *avinodes.AviPoolNode {
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) != 1 {
+		return nil
+	}
+	for _, pool := range nodes[0].PoolRefs {
+		if pool.Name == "cluster--Shared-L7-0--default-backend" {
+			return pool
+		}
+	}
+	return nil
+}
+
+func TestIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	defaultSvcName := objNameMap.GenerateName("avisvc-default")
+	ingName := objNameMap.GenerateName("foo-with-default-backend")
+	SetUpTestForIngress(t, svcName, modelName)
+	SetUpTestForIngress(t, defaultSvcName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: defaultSvcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) == 1 {
+				return nodes[0].DefaultPoolGroup
+			}
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal("cluster--Shared-L7-0--default-backend"))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(nodes[0].PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pg := range nodes[0].PoolGroupRefs {
+		// the default backend pool is not part of the shared poolgroup
+		g.Expect(pg.Members).To(gomega.HaveLen(1))
+		if pg.Name == "cluster--Shared-L7-0--default-backend" {
+			g.Expect(*pg.Members[0].PoolRef).To(gomega.Equal("/api/pool?name=cluster--Shared-L7-0--default-backend"))
+		} else {
+			g.Expect(*pg.Members[0].PoolRef).To(gomega.Equal("/api/pool?name=cluster--foo.com_foo-default-" + ingName))
+		}
+	}
+	pool := getDefaultBackendPool(aviModel)
+	g.Expect(pool).NotTo(gomega.BeNil())
+	g.Expect(pool.Port).To(gomega.Equal(int32(8080)))
+	g.Expect(pool.Servers).To(gomega.HaveLen(1))
+	g.Expect(pool.ServiceMetadata.IngressName).To(gomega.BeEmpty())
+
+	// Removing the default backend detaches it from the VS.
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].DefaultPoolGroup
+	}, 10*time.Second).Should(gomega.BeEmpty())
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolGroupRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, defaultSvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestSecureIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	defaultSvcName := objNameMap.GenerateName("avisvc-default")
+	ingName := objNameMap.GenerateName("foo-with-default-backend")
+	secretName := objNameMap.GenerateName("my-secret")
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	SetUpTestForIngress(t, svcName, modelName)
+	SetUpTestForIngress(t, defaultSvcName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:      ingName,
+		Namespace: "default",
+		DnsNames:  []string{"foo.com"},
+		Paths:     []string{"/foo"},
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: defaultSvcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	// The unmatched paths of the TLS host fall back to the default backend on the SNI child VS.
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) == 1 && len(nodes[0].SniNodes) == 1 {
+				return nodes[0].SniNodes[0].DefaultPoolGroup
+			}
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal("cluster--foo.com--default-backend"))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].DefaultPoolGroup).To(gomega.Equal("cluster--Shared-L7-0--default-backend"))
+	sniNode := nodes[0].SniNodes[0]
+	g.Expect(sniNode.PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(sniNode.PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pool := range sniNode.PoolRefs {
+		if pool.Name == "cluster--foo.com--default-backend" {
+			g.Expect(pool.Port).To(gomega.Equal(int32(8080)))
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+		} else {
+			g.Expect(pool.Name).To(gomega.Equal("cluster--default-foo.com_foo-" + ingName))
+		}
+	}
+
+	// Removing the default backend detaches it from the SNI child VS.
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0].DefaultPoolGroup
+	}, 10*time.Second).Should(gomega.BeEmpty())
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	sniNode = aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0]
+	g.Expect(sniNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(sniNode.PoolGroupRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), secretName, metav1.DeleteOptions{})
+	VerifySNIIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, defaultSvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestMultiIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName1 := objNameMap.GenerateName("foo-with-default-backend")
+	ingName2 := objNameMap.GenerateName("foo-with-default-backend")
+	SetUpTestForIngress(t, svcName, modelName)
+
+	defaultBackend := &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: svcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	ingrFake1 := (integrationtest.FakeIngress{
+		Name:        ingName1,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake1.Spec.DefaultBackend = defaultBackend
+	ingrFake1.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake1, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	ingrFake2 := (integrationtest.FakeIngress{
+		Name:        ingName2,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/bar"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake2.Spec.DefaultBackend = defaultBackend
+	ingrFake2.CreationTimestamp = metav1.NewTime(time.Now())
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake2, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	// The default backend of the oldest ingress is attached to the VS.
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if pool := getDefaultBackendPool(aviModel); pool != nil {
+				return pool.IngressName
+			}
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(ingName1))
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+	}, 10*time.Second).Should(gomega.Equal(3))
+
+	// The default backend of the next ingress takes over once the oldest one is deleted.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName1, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if pool := getDefaultBackendPool(aviModel); pool != nil {
+			return pool.IngressName
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(ingName2))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].DefaultPoolGroup).To(gomega.Equal("cluster--Shared-L7-0--default-backend"))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName2, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].DefaultPoolGroup).To(gomega.BeEmpty())
+
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestIngressWithServiceSessionAffinity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("foo-with-session-affinity")
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcObj := integrationtest.ConstructService("default", svcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false, make(map[string]string), "")
+	svcObj.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	if _, err := KubeClient.CoreV1().Services("default").Create(context.TODO(), svcObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	integrationtest.CreateEPorEPS(t, "default", svcName, false, false, "1.1.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() bool {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) == 1 && len(nodes[0].PoolRefs) == 1 {
+				return nodes[0].PoolRefs[0].PersistenceProfile != nil
+			}
+		}
+		return false
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0]
+	g.Expect(pool.PersistenceProfile.Name).To(gomega.Equal(lib.GetPoolPersistenceProfileName(pool.Name)))
+	g.Expect(pool.PersistenceProfile.PersistenceType).To(gomega.Equal(lib.PersistenceTypeClientIP))
+	// The default sessionAffinity timeout of 3 hours
+	g.Expect(pool.PersistenceProfile.Timeout).To(gomega.Equal(int32(180)))
+
+	// Removing the sessionAffinity of the Service removes the persistence profile from the pool.
+	svcObj = integrationtest.ConstructService("default", svcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false, make(map[string]string), "")
+	svcObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services("default").Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].PoolRefs) == 1 && nodes[0].PoolRefs[0].PersistenceProfile == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	TearDownTestForIngress(t, svcName, modelName)
+}
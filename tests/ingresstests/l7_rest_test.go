@@ -16,19 +16,25 @@ package ingresstests
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/introspect"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
+	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1198,3 +1204,104 @@ func TestProfilesAttachedToVS(t *testing.T) {
 
 	integrationtest.ResetMiddleware()
 }
+
+func TestIntrospectSecureIngressModelWithoutPrivateKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		isTLS:       true,
+		withSecret:  true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].SniNodes) == 1 && len(nodes[0].SniNodes[0].SSLKeyCertRefs) == 1
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	router := mux.NewRouter()
+	for _, operation := range (&introspect.IntrospectModel{}).ApiOperationMap(false, nil) {
+		router.HandleFunc(operation.Route, operation.Handler).Methods(operation.Method)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/models/"+modelName, nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+
+	// The certificate is dumped, but neither the raw nor the base64 encoded private key is.
+	body := recorder.Body.String()
+	g.Expect(body).To(gomega.ContainSubstring(`"Cert":`))
+	g.Expect(body).To(gomega.ContainSubstring(`"Key":null`))
+	g.Expect(body).NotTo(gomega.ContainSubstring("tlsKey"))
+	g.Expect(body).NotTo(gomega.ContainSubstring(base64.StdEncoding.EncodeToString([]byte("tlsKey"))))
+
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
+
+func TestDryRunSecureIngressWithoutPrivateKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("DRY_RUN", "true")
+	models.DryRun.InitModel()
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	ingTestObj := IngressTestObject{
+		ingressName: ingName,
+		dnsNames:    []string{"dryrun.com"},
+		isTLS:       true,
+		withSecret:  true,
+		secretName:  secretName,
+		serviceName: svcName,
+		modelNames:  []string{modelName},
+	}
+	ingTestObj.FillParams()
+	SetUpIngressForCacheSyncCheck(t, ingTestObj)
+
+	g.Eventually(func() bool {
+		plan := models.DryRun.GetPlan(modelName)
+		if plan == nil {
+			return false
+		}
+		for _, operation := range plan.Operations {
+			if operation.Model == "SSLKeyAndCertificate" {
+				return true
+			}
+		}
+		return false
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	router := mux.NewRouter()
+	for _, operation := range models.DryRun.ApiOperationMap(false, nil) {
+		router.HandleFunc(operation.Route, operation.Handler).Methods(operation.Method)
+	}
+	for _, uri := range []string{"/api/dryrun", "/api/dryrun/" + modelName} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, uri, nil))
+		g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+		body := recorder.Body.String()
+		g.Expect(body).To(gomega.ContainSubstring("tlsCert"))
+		g.Expect(body).NotTo(gomega.ContainSubstring("tlsKey"))
+	}
+	for _, operation := range models.DryRun.GetPlan(modelName).Operations {
+		if operation.Model == "SSLKeyAndCertificate" {
+			sslkeycert, err := json.Marshal(operation.Object)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			var sslkeycertFields map[string]interface{}
+			g.Expect(json.Unmarshal(sslkeycert, &sslkeycertFields)).To(gomega.Succeed())
+			g.Expect(sslkeycertFields).NotTo(gomega.HaveKey("key"))
+		}
+	}
+
+	os.Setenv("DRY_RUN", "false")
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func reviewHostRule(t *testing.T, operation admissionv1.Operation, hr integrationtest.FakeHostRule) *admissionv1.AdmissionResponse {
+	raw, err := json.Marshal(hr.HostRule())
+	if err != nil {
+		t.Fatalf("error in marshalling HostRule: %v", err)
+	}
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("review-" + hr.Name),
+			Kind:      metav1.GroupVersionKind{Group: "ako.vmware.com", Version: "v1beta1", Kind: lib.HostRule},
+			Name:      hr.Name,
+			Namespace: hr.Namespace,
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+	body, _ := json.Marshal(review)
+	recorder := httptest.NewRecorder()
+	k8s.ServeValidatingWebhook(recorder, httptest.NewRequest(http.MethodPost, lib.ValidatingWebhookPath, bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d from the validating webhook", recorder.Code)
+	}
+	response := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Response == nil {
+		t.Fatalf("invalid AdmissionReview response: %v", err)
+	}
+	if response.Response.UID != review.Request.UID {
+		t.Fatalf("expected the UID %s in the response, got %s", review.Request.UID, response.Response.UID)
+	}
+	return response.Response
+}
+
+func TestValidatingWebhookHostRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hrname := objNameMap.GenerateName("samplehr-webhook")
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "webhook.com",
+	}
+	response := reviewHostRule(t, admissionv1.Create, hostrule)
+	g.Expect(response.Allowed).To(gomega.BeTrue())
+
+	// GSLB FQDN same as the local FQDN is rejected.
+	hostrule.GslbFqdn = "webhook.com"
+	response = reviewHostRule(t, admissionv1.Update, hostrule)
+	g.Expect(response.Allowed).To(gomega.BeFalse())
+	g.Expect(response.Result.Message).To(gomega.ContainSubstring("GSLB"))
+
+	// The deletion is not validated.
+	response = reviewHostRule(t, admissionv1.Delete, hostrule)
+	g.Expect(response.Allowed).To(gomega.BeTrue())
+}
+
+func TestValidatingWebhookHostRuleDuplicateFqdn(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hrname := objNameMap.GenerateName("samplehr-webhook")
+	integrationtest.SetupHostRule(t, hrname, "webhook-dup.com", true)
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	// The FQDN claimed by another HostRule is rejected.
+	response := reviewHostRule(t, admissionv1.Create, integrationtest.FakeHostRule{
+		Name:      hrname + "-dup",
+		Namespace: "default",
+		Fqdn:      "webhook-dup.com",
+	})
+	g.Expect(response.Allowed).To(gomega.BeFalse())
+	g.Expect(response.Result.Message).To(gomega.ContainSubstring("duplicate fqdn"))
+
+	integrationtest.TearDownHostRuleWithNoVerify(t, g, hrname)
+}
+
+func TestValidatingWebhookCertificateReload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	certDir := t.TempDir()
+	certFile, keyFile := filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key")
+	writeCertificate := func(host string, modTime time.Time) {
+		cert, key := generateCertificate(t, host, time.Now().Add(24*time.Hour))
+		g.Expect(os.WriteFile(certFile, []byte(cert), 0600)).To(gomega.Succeed())
+		g.Expect(os.WriteFile(keyFile, []byte(key), 0600)).To(gomega.Succeed())
+		g.Expect(os.Chtimes(certFile, modTime, modTime)).To(gomega.Succeed())
+	}
+	webhookCert := k8s.NewWebhookCertificate(certFile, keyFile)
+	writeCertificate("webhook-1.com", time.Now().Add(-time.Hour))
+	cert, err := webhookCert.GetCertificate(nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	initialCert := cert.Certificate[0]
+
+	// The certificate is served until the file is modified, and is reloaded after.
+	cert, err = webhookCert.GetCertificate(nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cert.Certificate[0]).To(gomega.Equal(initialCert))
+	writeCertificate("webhook-2.com", time.Now())
+	cert, err = webhookCert.GetCertificate(nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cert.Certificate[0]).NotTo(gomega.Equal(initialCert))
+
+	// The last loaded certificate is served, when the files can not be loaded.
+	reloadedCert := cert.Certificate[0]
+	g.Expect(os.WriteFile(keyFile, []byte("invalid"), 0600)).To(gomega.Succeed())
+	g.Expect(os.Chtimes(certFile, time.Now().Add(time.Hour), time.Now().Add(time.Hour))).To(gomega.Succeed())
+	cert, err = webhookCert.GetCertificate(nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cert.Certificate[0]).To(gomega.Equal(reloadedCert))
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 
@@ -29,6 +30,7 @@ import (
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -279,3 +281,132 @@ func TestMultiPortL4SvcNodePort(t *testing.T) {
 
 	TearDownTestForSvcLBMultiport(t, g, svcName)
 }
+
+func createEPorEPSOnNode(t *testing.T, ns, name, address, nodeName string) {
+	if !lib.AKOControlConfig().GetEndpointSlicesEnabled() {
+		epExample := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: address, NodeName: &nodeName}},
+				Ports:     []corev1.EndpointPort{{Name: "foo0", Port: 8080, Protocol: "TCP"}},
+			}},
+		}
+		if _, err := KubeClient.CoreV1().Endpoints(ns).Create(context.TODO(), epExample, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in creating Endpoint: %v", err)
+		}
+		return
+	}
+	portName := "foo0"
+	port := int32(8080)
+	protocol := corev1.ProtocolTCP
+	epsExample := &discovery.EndpointSlice{
+		AddressType: discovery.AddressTypeIPv4,
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    map[string]string{discovery.LabelServiceName: name},
+		},
+		Endpoints: []discovery.Endpoint{{Addresses: []string{address}, NodeName: &nodeName}},
+		Ports:     []discovery.EndpointPort{{Name: &portName, Port: &port, Protocol: &protocol}},
+	}
+	if _, err := KubeClient.DiscoveryV1().EndpointSlices(ns).Create(context.TODO(), epsExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating EndpointSlice: %v", err)
+	}
+}
+
+func updateEPorEPSNode(t *testing.T, ns, name, nodeName string) {
+	if !lib.AKOControlConfig().GetEndpointSlicesEnabled() {
+		epObj, err := KubeClient.CoreV1().Endpoints(ns).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error in getting Endpoint: %v", err)
+		}
+		epObj.Subsets[0].Addresses[0].NodeName = &nodeName
+		epObj.ResourceVersion = "2"
+		if _, err = KubeClient.CoreV1().Endpoints(ns).Update(context.TODO(), epObj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating Endpoint: %v", err)
+		}
+		return
+	}
+	epsObj, err := KubeClient.DiscoveryV1().EndpointSlices(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting EndpointSlice: %v", err)
+	}
+	epsObj.Endpoints[0].NodeName = &nodeName
+	epsObj.ResourceVersion = "2"
+	if _, err = KubeClient.DiscoveryV1().EndpointSlices(ns).Update(context.TODO(), epsObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating EndpointSlice: %v", err)
+	}
+}
+
+// TestL4SvcNodePortWithExternalTrafficPolicyLocal tests that only the nodes with local endpoints are added to the pool,
+// and the healthCheckNodePort of the service is monitored.
+func TestL4SvcNodePortWithExternalTrafficPolicyLocal(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	SetNodePortMode()
+	defer SetClusterIPMode()
+	nodeIP1, nodeIP2 := "10.1.1.2", "10.1.1.3"
+	healthCheckNodePort := int32(32000)
+	CreateNode(t, "testNode1", nodeIP1)
+	defer DeleteNode(t, "testNode1")
+	CreateNode(t, "testNode2", nodeIP2)
+	defer DeleteNode(t, "testNode2")
+
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcObj := ConstructService(NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string), "")
+	svcObj.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+	svcObj.Spec.HealthCheckNodePort = healthCheckNodePort
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	createEPorEPSOnNode(t, NAMESPACE, svcName, "1.1.1.1", "testNode2")
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].PoolRefs) == 1 && len(nodes[0].PoolRefs[0].Servers) == 1
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(*nodes[0].PoolRefs[0].Servers[0].Ip.Addr).To(gomega.Equal(nodeIP2))
+	g.Expect(nodes[0].PoolRefs[0].HealthMonitor).NotTo(gomega.BeNil())
+	g.Expect(nodes[0].PoolRefs[0].HealthMonitor.MonitorPort).To(gomega.Equal(healthCheckNodePort))
+	g.Expect(nodes[0].PoolRefs[0].HealthMonitor.Type).To(gomega.Equal(lib.HealthMonitorTypeHTTP))
+
+	mcache := cache.SharedAviObjCache()
+	hmKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: lib.GetL4HealthMonitorName(svcName, NAMESPACE, "TCP", 8080)}
+	g.Eventually(func() bool {
+		_, found := mcache.HMCache.AviCacheGet(hmKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+	poolKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: nodes[0].PoolRefs[0].Name}
+	g.Eventually(func() string {
+		poolCache, found := mcache.PoolCache.AviCacheGet(poolKey)
+		if !found {
+			return ""
+		}
+		return poolCache.(*cache.AviPoolCache).HealthMonitorCollection.Name
+	}, 15*time.Second).Should(gomega.Equal(hmKey.Name))
+
+	// Move the endpoint to the other node, the pool server should follow it.
+	updateEPorEPSNode(t, NAMESPACE, svcName, "testNode1")
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 || len(nodes[0].PoolRefs[0].Servers) != 1 {
+			return ""
+		}
+		return *nodes[0].PoolRefs[0].Servers[0].Ip.Addr
+	}, 10*time.Second).Should(gomega.Equal(nodeIP1))
+
+	TearDownTestForSvcLB(t, g, svcName)
+	g.Eventually(func() bool {
+		_, found := mcache.HMCache.AviCacheGet(hmKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"sync"
@@ -27,16 +28,19 @@ import (
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/introspect"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
 	v1beta1crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/fake"
 
 	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
+	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1877,3 +1881,380 @@ func TestLBSvcWithExtDNSAndAutoFQDNAsFlat(t *testing.T) {
 	TearDownTestForSvcLBWithExtDNS(t, g)
 	os.Setenv("AUTO_L4_FQDN", "disable")
 }
+
+func TestAviSvcWithLoadBalancerSourceRanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+	objects.SharedAviGraphLister().Delete(modelName)
+
+	svcObj := ConstructService(NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string), "")
+	svcObj.Spec.LoadBalancerSourceRanges = []string{"10.10.0.0/16", "192.168.1.10/32"}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEPorEPS(t, NAMESPACE, svcName, false, false, "1.1.1")
+	PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) == 1 {
+				return len(nodes[0].NetworkSecurityPolicyRefs)
+			}
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].NetworkSecurityPolicyRefs[0].Name).To(gomega.Equal(vsName))
+	g.Expect(nodes[0].NetworkSecurityPolicyRefs[0].SourceRanges).To(gomega.Equal([]string{"10.10.0.0/16", "192.168.1.10/32"}))
+	g.Expect(*nodes[0].NetworkSecurityPolicyRef).To(gomega.Equal("/api/networksecuritypolicy?name=" + vsName))
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	nspKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	g.Eventually(func() bool {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		if !found {
+			return false
+		}
+		_, nspFound := mcache.NSPCache.AviCacheGet(nspKey)
+		return nspFound && len(vsCache.(*cache.AviVsCache).NetworkSecurityPolicyCollection) == 1
+	}, 15*time.Second).Should(gomega.BeTrue())
+	nspCache, _ := mcache.NSPCache.AviCacheGet(nspKey)
+	oldCksum := nspCache.(*cache.AviNetworkSecurityPolicyCache).CloudConfigCksum
+
+	// Invalid source ranges are skipped, while the valid ones are kept in sync.
+	svcObj.Spec.LoadBalancerSourceRanges = []string{"10.20.0.0/16", "10.30.0.0/33"}
+	svcObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() []string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].NetworkSecurityPolicyRefs) != 1 {
+			return nil
+		}
+		return nodes[0].NetworkSecurityPolicyRefs[0].SourceRanges
+	}, 10*time.Second).Should(gomega.Equal([]string{"10.20.0.0/16"}))
+	g.Eventually(func() uint32 {
+		if nspCache, found := mcache.NSPCache.AviCacheGet(nspKey); found {
+			return nspCache.(*cache.AviNetworkSecurityPolicyCache).CloudConfigCksum
+		}
+		return 0
+	}, 15*time.Second).ShouldNot(gomega.Equal(oldCksum))
+
+	// Removing the source ranges removes the network security policy.
+	svcObj.Spec.LoadBalancerSourceRanges = nil
+	svcObj.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].NetworkSecurityPolicyRefs) == 0 && nodes[0].NetworkSecurityPolicyRef == nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Eventually(func() bool {
+		_, found := mcache.NSPCache.AviCacheGet(nspKey)
+		return found
+	}, 15*time.Second).Should(gomega.BeFalse())
+
+	// The network security policy is deleted along with the Service.
+	svcObj.Spec.LoadBalancerSourceRanges = []string{"10.10.0.0/16"}
+	svcObj.ResourceVersion = "4"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.NSPCache.AviCacheGet(nspKey)
+		return found
+	}, 15*time.Second).Should(gomega.BeTrue())
+	TearDownTestForSvcLB(t, g, svcName)
+	g.Eventually(func() bool {
+		_, found := mcache.NSPCache.AviCacheGet(nspKey)
+		return found
+	}, 15*time.Second).Should(gomega.BeFalse())
+}
+
+// TestAviSvcWithSessionAffinity tests that a client IP persistence profile is attached to the pool
+// of a Service with sessionAffinity ClientIP, and is removed along with the sessionAffinity.
+func TestAviSvcWithSessionAffinity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	SetUpTestForSvcLB(t, svcName)
+
+	timeoutSeconds := int32(600)
+	svcObj := ConstructService(NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string), "")
+	svcObj.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	svcObj.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeoutSeconds},
+	}
+	svcObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && len(nodes[0].PoolRefs) == 1 && nodes[0].PoolRefs[0].PersistenceProfile != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0]
+	g.Expect(pool.PersistenceProfile.Name).To(gomega.Equal(lib.GetPoolPersistenceProfileName(pool.Name)))
+	g.Expect(pool.PersistenceProfile.PersistenceType).To(gomega.Equal(lib.PersistenceTypeClientIP))
+	g.Expect(pool.PersistenceProfile.Timeout).To(gomega.Equal(int32(10)))
+
+	mcache := cache.SharedAviObjCache()
+	persistenceKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: pool.PersistenceProfile.Name}
+	g.Eventually(func() bool {
+		_, found := mcache.AppPersistCache.AviCacheGet(persistenceKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+	poolKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: pool.Name}
+	g.Eventually(func() string {
+		poolCache, found := mcache.PoolCache.AviCacheGet(poolKey)
+		if !found {
+			return ""
+		}
+		return poolCache.(*cache.AviPoolCache).PersistenceProfileCollection.Name
+	}, 15*time.Second).Should(gomega.Equal(persistenceKey.Name))
+
+	// Removing the sessionAffinity deletes the persistence profile.
+	svcObj = ConstructService(NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string), "")
+	svcObj.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.AppPersistCache.AviCacheGet(persistenceKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0].PersistenceProfile).To(gomega.BeNil())
+
+	TearDownTestForSvcLB(t, g, svcName)
+}
+
+func TestAviSvcInDryRunMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("DRY_RUN", "true")
+	models.DryRun.InitModel()
+
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	objects.SharedAviGraphLister().Delete(modelName)
+	CreateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	CreateEPorEPS(t, NAMESPACE, svcName, false, false, "1.1.1")
+	PollForCompletion(t, modelName, 5)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+	g.Eventually(func() bool {
+		plan := models.DryRun.GetPlan(modelName)
+		if plan == nil {
+			return false
+		}
+		for _, operation := range plan.Operations {
+			if operation.Model == "VirtualService" && operation.Method == string(utils.RestPost) && operation.Name == vsName {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// The changes are not applied, hence the object caches are not updated.
+	mcache := cache.SharedAviObjCache()
+	_, found := mcache.VsCacheMeta.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName})
+	g.Expect(found).To(gomega.Equal(false))
+
+	// Without the service, there are no pending changes for the virtualservice.
+	objects.SharedAviGraphLister().Delete(modelName)
+	DelSVC(t, NAMESPACE, svcName)
+	DelEPorEPS(t, NAMESPACE, svcName)
+	g.Eventually(func() bool {
+		return models.DryRun.GetPlan(modelName) == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+}
+
+func TestAviSvcIntrospection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	SetUpTestForSvcLB(t, svcName)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName})
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	poolName := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0].Name
+
+	aviObjects := introspect.GetAviObjectsForK8sObject("service", NAMESPACE, svcName)
+	g.Expect(aviObjects).To(gomega.ContainElement(introspect.AviObjectRef{Model: modelName, Type: introspect.VirtualService, Tenant: AVINAMESPACE, Name: vsName}))
+	g.Expect(aviObjects).To(gomega.ContainElement(introspect.AviObjectRef{Model: modelName, Type: introspect.Pool, Tenant: AVINAMESPACE, Name: poolName}))
+	g.Expect(introspect.GetK8sObjectsForAviObject(introspect.Pool, AVINAMESPACE, poolName)).To(gomega.Equal([]introspect.K8sObjectRef{
+		{Kind: utils.Service, Namespace: NAMESPACE, Name: svcName},
+	}))
+
+	router := mux.NewRouter()
+	for _, operation := range (&introspect.IntrospectModel{}).ApiOperationMap(false, nil) {
+		router.HandleFunc(operation.Route, operation.Handler).Methods(operation.Method)
+	}
+	get := func(uri string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, uri, nil))
+		return recorder
+	}
+
+	response := get("/api/models/" + modelName)
+	g.Expect(response.Code).To(gomega.Equal(http.StatusOK))
+	var modelDump struct {
+		Name  string `json:"name"`
+		Nodes []struct {
+			Type string `json:"type"`
+		} `json:"nodes"`
+	}
+	g.Expect(json.Unmarshal(response.Body.Bytes(), &modelDump)).To(gomega.Succeed())
+	g.Expect(modelDump.Nodes).To(gomega.HaveLen(1))
+	g.Expect(modelDump.Nodes[0].Type).To(gomega.Equal("VirtualServiceNode"))
+
+	response = get("/api/cache/virtualservice/" + AVINAMESPACE + "/" + vsName)
+	g.Expect(response.Code).To(gomega.Equal(http.StatusOK))
+	var vsCache cache.AviVsCache
+	g.Expect(json.Unmarshal(response.Body.Bytes(), &vsCache)).To(gomega.Succeed())
+	g.Expect(vsCache.Name).To(gomega.Equal(vsName))
+	g.Expect(get("/api/cache/unknown/" + AVINAMESPACE + "/" + vsName).Code).To(gomega.Equal(http.StatusBadRequest))
+
+	TearDownTestForSvcLB(t, g, svcName)
+	g.Expect(get("/api/models/" + modelName).Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(introspect.GetAviObjectsForK8sObject("service", NAMESPACE, svcName)).To(gomega.BeEmpty())
+}
+
+func TestAviSvcPrometheusMetrics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	lib.SetPrometheusRegistry()
+	reg := lib.RegisterPromMetrics()
+	cache.RegisterCachePromMetrics()
+	lib.AKOControlConfig().SetAKOPrometheusFlag(true)
+	defer lib.AKOControlConfig().SetAKOPrometheusFlag(false)
+
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	SetUpTestForSvcLB(t, svcName)
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName})
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// getMetricValues returns the value of each metric of a family, keyed by the value of a label.
+	getMetricValues := func(name, label string) map[string]float64 {
+		values := make(map[string]float64)
+		families, err := reg.Gather()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		for _, family := range families {
+			if !strings.HasSuffix(family.GetName(), "_"+name) {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				for _, labelPair := range metric.GetLabel() {
+					if labelPair.GetName() != label {
+						continue
+					}
+					switch {
+					case metric.GetHistogram() != nil:
+						values[labelPair.GetValue()] += float64(metric.GetHistogram().GetSampleCount())
+					case metric.GetCounter() != nil:
+						values[labelPair.GetValue()] += metric.GetCounter().GetValue()
+					case metric.GetGauge() != nil:
+						values[labelPair.GetValue()] += metric.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return values
+	}
+	g.Eventually(func() float64 {
+		return getMetricValues("stage_duration_seconds", "stage")[lib.RestStage]
+	}, 10*time.Second).Should(gomega.BeNumerically(">", 0))
+	g.Expect(getMetricValues("stage_duration_seconds", "stage")[lib.GraphStage]).To(gomega.BeNumerically(">", 0))
+	g.Expect(getMetricValues("avi_api_responses_total", "object_type")["VirtualService"]).To(gomega.BeNumerically(">", 0))
+	g.Expect(getMetricValues("cache_objects", "object_type")["VirtualService"]).To(gomega.BeNumerically(">", 0))
+
+	TearDownTestForSvcLB(t, g, svcName)
+}
+
+func TestAviSvcConfigMapLiveUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := objNameMap.GenerateName(SINGLEPORTSVC)
+	modelName := MODEL_REDNS_PREFIX + svcName
+	SetUpTestForSvcLB(t, svcName)
+
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)}
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	resourceVersion := 0
+	updateConfigMap := func(data map[string]string) {
+		cm, err := KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.AviConfigMap, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error in getting configmap: %v", err)
+		}
+		resourceVersion++
+		cm.ResourceVersion = fmt.Sprintf("%d", resourceVersion)
+		cm.Data = data
+		if _, err = KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating configmap: %v", err)
+		}
+	}
+	getFQDNs := func() []string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil || len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()) == 0 {
+			return nil
+		}
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].VSVIPRefs[0].FQDNs
+	}
+	g.Expect(getFQDNs()).To(gomega.BeEmpty())
+
+	// The service is synced again with the FQDN, when autoFQDN is set, and the next full sync translates all the objects.
+	objects.SharedFullSyncStateLister().SetSynced()
+	updateConfigMap(map[string]string{lib.AutoFQDNConfig: "flat"})
+	g.Eventually(func() bool {
+		return objects.SharedFullSyncStateLister().IsSynced()
+	}, 10*time.Second).Should(gomega.BeFalse())
+	g.Eventually(func() []string {
+		return getFQDNs()
+	}, 10*time.Second).Should(gomega.HaveLen(1))
+	g.Expect(getFQDNs()[0]).To(gomega.HavePrefix(svcName + "-" + NAMESPACE + "."))
+
+	// The virtualservice is deleted when the namespace is blocked, and created again when it is unblocked.
+	updateConfigMap(map[string]string{lib.AutoFQDNConfig: "flat", lib.BlockedNSListConfig: `["` + NAMESPACE + `"]`})
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	updateConfigMap(map[string]string{lib.AutoFQDNConfig: "flat", lib.BlockedNSListConfig: `[]`})
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	updateConfigMap(nil)
+	g.Eventually(func() []string {
+		return getFQDNs()
+	}, 10*time.Second).Should(gomega.BeEmpty())
+	os.Setenv("AUTO_L4_FQDN", "disable")
+	TearDownTestForSvcLB(t, g, svcName)
+}
@@ -1491,6 +1491,10 @@ func NormalControllerServer(w http.ResponseWriter, r *http.Request, args ...stri
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte(`{"results": [], "count": 0}`))
 		}
+	} else if r.Method == "GET" && strings.Contains(url, "applicationprofile") && strings.Contains(r.URL.RawQuery, "System-Secure-HTTP") {
+		w.WriteHeader(http.StatusOK)
+		data, _ := os.ReadFile(fmt.Sprintf("%s/crd_mock.json", mockFilePath))
+		w.Write(data)
 	} else if r.Method == "GET" && strings.Contains(r.URL.RawQuery, "System-L4-Application") {
 		w.WriteHeader(http.StatusOK)
 		data, _ := os.ReadFile(fmt.Sprintf("%s/l4crd_mock.json", mockFilePath))
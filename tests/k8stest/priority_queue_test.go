@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+package k8stest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+func getPriorityQueueKeys(queue *utils.PriorityQueue, count int) []string {
+	var keys []string
+	for i := 0; i < count; i++ {
+		obj, shutdown := queue.Get()
+		if shutdown {
+			break
+		}
+		keys = append(keys, obj.(string))
+		queue.Done(obj)
+		queue.Forget(obj)
+	}
+	return keys
+}
+
+func TestPriorityQueueInteractiveKeyNotDelayedByBulkKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	queue := utils.NewPriorityQueue("avi-prioritytest")
+	defer queue.ShutDown()
+
+	for i := 0; i < 50; i++ {
+		queue.AddRateLimitedWithPriority(fmt.Sprintf("Ingress/default/bulk-%d", i), utils.PriorityBulk)
+	}
+	queue.AddRateLimited("Ingress/default/foo")
+	g.Eventually(queue.Len, 10*time.Second).Should(gomega.Equal(51))
+
+	g.Expect(getPriorityQueueKeys(queue, 1)).To(gomega.Equal([]string{"Ingress/default/foo"}))
+	g.Expect(queue.Len()).To(gomega.Equal(50))
+}
+
+func TestPriorityQueueFairScheduling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	queue := utils.NewPriorityQueue("avi-prioritytest")
+	defer queue.ShutDown()
+
+	for i := 0; i < 20; i++ {
+		queue.AddRateLimitedWithPriority(fmt.Sprintf("Ingress/default/bulk-%d", i), utils.PriorityBulk)
+		queue.AddRateLimited(fmt.Sprintf("Ingress/default/interactive-%d", i))
+	}
+	g.Eventually(queue.Len, 10*time.Second).Should(gomega.Equal(40))
+
+	// The bulk lane gets one key for every eight keys of the interactive lane.
+	bulkKeys := 0
+	for _, key := range getPriorityQueueKeys(queue, 18) {
+		if strings.HasPrefix(key, "Ingress/default/bulk-") {
+			bulkKeys++
+		}
+	}
+	g.Expect(bulkKeys).To(gomega.Equal(2))
+}
+
+func TestPriorityQueueKeyMovedToHigherPriorityLane(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	queue := utils.NewPriorityQueue("avi-prioritytest")
+	defer queue.ShutDown()
+
+	queue.AddRateLimitedWithPriority("Ingress/default/bulk", utils.PriorityBulk)
+	queue.AddRateLimitedWithPriority("Ingress/default/foo", utils.PriorityBulk)
+	g.Eventually(queue.Len, 10*time.Second).Should(gomega.Equal(2))
+
+	queue.AddRateLimitedWithPriority("Ingress/default/foo", utils.PriorityDelete)
+	// The key is added to the delete lane after the delay of the rate limiter.
+	time.Sleep(1 * time.Second)
+	g.Expect(queue.Len()).To(gomega.Equal(2))
+	g.Expect(getPriorityQueueKeys(queue, 1)).To(gomega.Equal([]string{"Ingress/default/foo"}))
+
+	// The key is processed once, though it was added to two lanes.
+	g.Expect(getPriorityQueueKeys(queue, 1)).To(gomega.Equal([]string{"Ingress/default/bulk"}))
+	g.Expect(queue.Len()).To(gomega.Equal(0))
+}